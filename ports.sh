@@ -6,12 +6,14 @@ trap 'kill $(jobs -p)' EXIT
 echo "Открываем туннели..."
 echo "   - Temporal UI: http://localhost:8080"
 echo "   - Services: :8081 (User), :8083 (Product), :8085 (Payment), :8091 (Order)"
+echo "   - Public API: http://localhost:8093/api/v1"
 
 kubectl port-forward svc/temporal-ui -n infrastructure 8080:8080 > /dev/null 2>&1 &
 kubectl port-forward svc/userservice -n application 8081:8081 > /dev/null 2>&1 &
 kubectl port-forward svc/productservice -n application 8083:8081 > /dev/null 2>&1 &
 kubectl port-forward svc/paymentservice -n application 8085:8081 > /dev/null 2>&1 &
 kubectl port-forward svc/orderservice -n application 8091:8081 > /dev/null 2>&1 &
+kubectl port-forward svc/gatewayservice -n application 8093:8082 > /dev/null 2>&1 &
 
 echo "Туннели активны. Нажми Ctrl+C, чтобы остановить всё."
 wait
\ No newline at end of file
@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.6
+// source: api/client/orderinternal/orderinternal.proto
+
+package orderinternal
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OrderInternalServiceClient is the client API for OrderInternalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrderInternalServiceClient interface {
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
+	FindOrder(ctx context.Context, in *FindOrderRequest, opts ...grpc.CallOption) (*FindOrderResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
+	// SetExchangeRate задает курс пересчета цен товаров в валюту заказа. Только для администраторов
+	SetExchangeRate(ctx context.Context, in *SetExchangeRateRequest, opts ...grpc.CallOption) (*SetExchangeRateResponse, error)
+	ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error)
+	// StorePromotion создает акцию с промокодом, если promotionID пустой, иначе меняет ее условия. Только для администраторов
+	StorePromotion(ctx context.Context, in *StorePromotionRequest, opts ...grpc.CallOption) (*StorePromotionResponse, error)
+	ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error)
+}
+
+type orderInternalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrderInternalServiceClient(cc grpc.ClientConnInterface) OrderInternalServiceClient {
+	return &orderInternalServiceClient{cc}
+}
+
+func (c *orderInternalServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error) {
+	out := new(CreateOrderResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/CreateOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) FindOrder(ctx context.Context, in *FindOrderRequest, opts ...grpc.CallOption) (*FindOrderResponse, error) {
+	out := new(FindOrderResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/FindOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) SetExchangeRate(ctx context.Context, in *SetExchangeRateRequest, opts ...grpc.CallOption) (*SetExchangeRateResponse, error) {
+	out := new(SetExchangeRateResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/SetExchangeRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error) {
+	out := new(ListExchangeRatesResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/ListExchangeRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) StorePromotion(ctx context.Context, in *StorePromotionRequest, opts ...grpc.CallOption) (*StorePromotionResponse, error) {
+	out := new(StorePromotionResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/StorePromotion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error) {
+	out := new(ListPromotionsResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/ListPromotions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OrderInternalServiceServer is the server API for OrderInternalService service.
+// All implementations must embed UnimplementedOrderInternalServiceServer
+// for forward compatibility
+type OrderInternalServiceServer interface {
+	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
+	FindOrder(context.Context, *FindOrderRequest) (*FindOrderResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
+	// SetExchangeRate задает курс пересчета цен товаров в валюту заказа. Только для администраторов
+	SetExchangeRate(context.Context, *SetExchangeRateRequest) (*SetExchangeRateResponse, error)
+	ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error)
+	// StorePromotion создает акцию с промокодом, если promotionID пустой, иначе меняет ее условия. Только для администраторов
+	StorePromotion(context.Context, *StorePromotionRequest) (*StorePromotionResponse, error)
+	ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error)
+	mustEmbedUnimplementedOrderInternalServiceServer()
+}
+
+// UnimplementedOrderInternalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedOrderInternalServiceServer struct {
+}
+
+func (UnimplementedOrderInternalServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) FindOrder(context.Context, *FindOrderRequest) (*FindOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindOrder not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) SetExchangeRate(context.Context, *SetExchangeRateRequest) (*SetExchangeRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExchangeRate not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExchangeRates not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) StorePromotion(context.Context, *StorePromotionRequest) (*StorePromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorePromotion not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromotions not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) mustEmbedUnimplementedOrderInternalServiceServer() {}
+
+// UnsafeOrderInternalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrderInternalServiceServer will
+// result in compilation errors.
+type UnsafeOrderInternalServiceServer interface {
+	mustEmbedUnimplementedOrderInternalServiceServer()
+}
+
+func RegisterOrderInternalServiceServer(s grpc.ServiceRegistrar, srv OrderInternalServiceServer) {
+	s.RegisterService(&OrderInternalService_ServiceDesc, srv)
+}
+
+func _OrderInternalService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/CreateOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_FindOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).FindOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/FindOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).FindOrder(ctx, req.(*FindOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_SetExchangeRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExchangeRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).SetExchangeRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/SetExchangeRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).SetExchangeRate(ctx, req.(*SetExchangeRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_ListExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).ListExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/ListExchangeRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).ListExchangeRates(ctx, req.(*ListExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_StorePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).StorePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/StorePromotion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).StorePromotion(ctx, req.(*StorePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_ListPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).ListPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/ListPromotions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).ListPromotions(ctx, req.(*ListPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OrderInternalService_ServiceDesc is the grpc.ServiceDesc for OrderInternalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OrderInternalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Order.OrderInternalService",
+	HandlerType: (*OrderInternalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateOrder",
+			Handler:    _OrderInternalService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "FindOrder",
+			Handler:    _OrderInternalService_FindOrder_Handler,
+		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _OrderInternalService_FindAuditLog_Handler,
+		},
+		{
+			MethodName: "SetExchangeRate",
+			Handler:    _OrderInternalService_SetExchangeRate_Handler,
+		},
+		{
+			MethodName: "ListExchangeRates",
+			Handler:    _OrderInternalService_ListExchangeRates_Handler,
+		},
+		{
+			MethodName: "StorePromotion",
+			Handler:    _OrderInternalService_StorePromotion_Handler,
+		},
+		{
+			MethodName: "ListPromotions",
+			Handler:    _OrderInternalService_ListPromotions_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/client/orderinternal/orderinternal.proto",
+}
@@ -0,0 +1,245 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.6
+// source: api/client/orderinternal/validate.proto
+
+package orderinternal
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Декларативные правила валидации полей запросов, проверяются в grpc middleware
+type FieldRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// строка не пустая, сообщение задано
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// непустая строка должна быть UUID
+	Uuid   bool   `protobuf:"varint,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	MinLen uint32 `protobuf:"varint,3,opt,name=minLen,proto3" json:"minLen,omitempty"`
+	MaxLen uint32 `protobuf:"varint,4,opt,name=maxLen,proto3" json:"maxLen,omitempty"`
+	// непустая строка должна быть email адресом
+	Email    bool   `protobuf:"varint,5,opt,name=email,proto3" json:"email,omitempty"`
+	Gt       *int64 `protobuf:"varint,6,opt,name=gt,proto3,oneof" json:"gt,omitempty"`
+	Gte      *int64 `protobuf:"varint,7,opt,name=gte,proto3,oneof" json:"gte,omitempty"`
+	MinItems uint32 `protobuf:"varint,8,opt,name=minItems,proto3" json:"minItems,omitempty"`
+}
+
+func (x *FieldRules) Reset() {
+	*x = FieldRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_client_orderinternal_validate_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldRules) ProtoMessage() {}
+
+func (x *FieldRules) ProtoReflect() protoreflect.Message {
+	mi := &file_api_client_orderinternal_validate_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldRules.ProtoReflect.Descriptor instead.
+func (*FieldRules) Descriptor() ([]byte, []int) {
+	return file_api_client_orderinternal_validate_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FieldRules) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *FieldRules) GetUuid() bool {
+	if x != nil {
+		return x.Uuid
+	}
+	return false
+}
+
+func (x *FieldRules) GetMinLen() uint32 {
+	if x != nil {
+		return x.MinLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMaxLen() uint32 {
+	if x != nil {
+		return x.MaxLen
+	}
+	return 0
+}
+
+func (x *FieldRules) GetEmail() bool {
+	if x != nil {
+		return x.Email
+	}
+	return false
+}
+
+func (x *FieldRules) GetGt() int64 {
+	if x != nil && x.Gt != nil {
+		return *x.Gt
+	}
+	return 0
+}
+
+func (x *FieldRules) GetGte() int64 {
+	if x != nil && x.Gte != nil {
+		return *x.Gte
+	}
+	return 0
+}
+
+func (x *FieldRules) GetMinItems() uint32 {
+	if x != nil {
+		return x.MinItems
+	}
+	return 0
+}
+
+var file_api_client_orderinternal_validate_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*FieldRules)(nil),
+		Field:         50003,
+		Name:          "Order.rules",
+		Tag:           "bytes,50003,opt,name=rules",
+		Filename:      "api/client/orderinternal/validate.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// optional Order.FieldRules rules = 50003;
+	E_Rules = &file_api_client_orderinternal_validate_proto_extTypes[0]
+)
+
+var File_api_client_orderinternal_validate_proto protoreflect.FileDescriptor
+
+var file_api_client_orderinternal_validate_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xd9, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78,
+	0x4c, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x4c, 0x65,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x13, 0x0a, 0x02, 0x67, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x02, 0x67, 0x74, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03,
+	0x67, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x03, 0x67, 0x74, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x42,
+	0x05, 0x0a, 0x03, 0x5f, 0x67, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x67, 0x74, 0x65, 0x3a, 0x48,
+	0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0xd3, 0x86, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x42, 0x12, 0x5a, 0x10, 0x2f, 0x2e, 0x3b, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_client_orderinternal_validate_proto_rawDescOnce sync.Once
+	file_api_client_orderinternal_validate_proto_rawDescData = file_api_client_orderinternal_validate_proto_rawDesc
+)
+
+func file_api_client_orderinternal_validate_proto_rawDescGZIP() []byte {
+	file_api_client_orderinternal_validate_proto_rawDescOnce.Do(func() {
+		file_api_client_orderinternal_validate_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_client_orderinternal_validate_proto_rawDescData)
+	})
+	return file_api_client_orderinternal_validate_proto_rawDescData
+}
+
+var file_api_client_orderinternal_validate_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_client_orderinternal_validate_proto_goTypes = []interface{}{
+	(*FieldRules)(nil),                // 0: Order.FieldRules
+	(*descriptorpb.FieldOptions)(nil), // 1: google.protobuf.FieldOptions
+}
+var file_api_client_orderinternal_validate_proto_depIdxs = []int32{
+	1, // 0: Order.rules:extendee -> google.protobuf.FieldOptions
+	0, // 1: Order.rules:type_name -> Order.FieldRules
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_client_orderinternal_validate_proto_init() }
+func file_api_client_orderinternal_validate_proto_init() {
+	if File_api_client_orderinternal_validate_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_client_orderinternal_validate_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_client_orderinternal_validate_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_client_orderinternal_validate_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_client_orderinternal_validate_proto_goTypes,
+		DependencyIndexes: file_api_client_orderinternal_validate_proto_depIdxs,
+		MessageInfos:      file_api_client_orderinternal_validate_proto_msgTypes,
+		ExtensionInfos:    file_api_client_orderinternal_validate_proto_extTypes,
+	}.Build()
+	File_api_client_orderinternal_validate_proto = out.File
+	file_api_client_orderinternal_validate_proto_rawDesc = nil
+	file_api_client_orderinternal_validate_proto_goTypes = nil
+	file_api_client_orderinternal_validate_proto_depIdxs = nil
+}
@@ -0,0 +1,889 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.6
+// source: api/client/productinternal/productinternal.proto
+
+package productinternal
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ProductInternalServiceClient is the client API for ProductInternalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProductInternalServiceClient interface {
+	StoreProduct(ctx context.Context, in *StoreProductRequest, opts ...grpc.CallOption) (*StoreProductResponse, error)
+	// FindProduct ищет по productID или по slug и возвращает и архивные товары
+	FindProduct(ctx context.Context, in *FindProductRequest, opts ...grpc.CallOption) (*FindProductResponse, error)
+	// ArchiveProduct снимает товар с продажи: он пропадает из списков и не заказывается
+	ArchiveProduct(ctx context.Context, in *ArchiveProductRequest, opts ...grpc.CallOption) (*ArchiveProductResponse, error)
+	UnarchiveProduct(ctx context.Context, in *UnarchiveProductRequest, opts ...grpc.CallOption) (*UnarchiveProductResponse, error)
+	// PurgeProduct окончательно удаляет архивный товар вместе с историей цен. Только для администраторов
+	PurgeProduct(ctx context.Context, in *PurgeProductRequest, opts ...grpc.CallOption) (*PurgeProductResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
+	// StoreCategory создает категорию без categoryID, иначе переименовывает или переносит ее
+	StoreCategory(ctx context.Context, in *StoreCategoryRequest, opts ...grpc.CallOption) (*StoreCategoryResponse, error)
+	// DeleteCategory удаляет только категорию без подкатегорий и товаров
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error)
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	// SetProductCategories заменяет весь список категорий товара
+	SetProductCategories(ctx context.Context, in *SetProductCategoriesRequest, opts ...grpc.CallOption) (*SetProductCategoriesResponse, error)
+	ListCategoryProducts(ctx context.Context, in *ListCategoryProductsRequest, opts ...grpc.CallOption) (*ListCategoryProductsResponse, error)
+	// SchedulePrice планирует цену товара или варианта, с effectiveUntil это распродажа
+	SchedulePrice(ctx context.Context, in *SchedulePriceRequest, opts ...grpc.CallOption) (*SchedulePriceResponse, error)
+	// CancelScheduledPrice отменяет только еще не примененную цену
+	CancelScheduledPrice(ctx context.Context, in *CancelScheduledPriceRequest, opts ...grpc.CallOption) (*CancelScheduledPriceResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	// AddImage добавляет в конец списка изображение, которое уже лежит по внешнему адресу
+	AddImage(ctx context.Context, in *AddImageRequest, opts ...grpc.CallOption) (*AddImageResponse, error)
+	// UploadImage принимает первым сообщением metadata, дальше содержимое файла частями.
+	// Поддерживаются JPEG, PNG и GIF, размеры определяются по содержимому
+	UploadImage(ctx context.Context, opts ...grpc.CallOption) (ProductInternalService_UploadImageClient, error)
+	// ReorderImages задает новый порядок, imageIDs должен содержать все изображения товара
+	ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error)
+	RemoveImage(ctx context.Context, in *RemoveImageRequest, opts ...grpc.CallOption) (*RemoveImageResponse, error)
+	// CreateReview принимает отзыв на модерацию, только если у автора есть оплаченный заказ с товаром
+	CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*CreateReviewResponse, error)
+	// ModerateReview одобряет или отклоняет отзыв, в рейтинге учитываются только одобренные
+	ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...grpc.CallOption) (*ModerateReviewResponse, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+}
+
+type productInternalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProductInternalServiceClient(cc grpc.ClientConnInterface) ProductInternalServiceClient {
+	return &productInternalServiceClient{cc}
+}
+
+func (c *productInternalServiceClient) StoreProduct(ctx context.Context, in *StoreProductRequest, opts ...grpc.CallOption) (*StoreProductResponse, error) {
+	out := new(StoreProductResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/StoreProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) FindProduct(ctx context.Context, in *FindProductRequest, opts ...grpc.CallOption) (*FindProductResponse, error) {
+	out := new(FindProductResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/FindProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) ArchiveProduct(ctx context.Context, in *ArchiveProductRequest, opts ...grpc.CallOption) (*ArchiveProductResponse, error) {
+	out := new(ArchiveProductResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ArchiveProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) UnarchiveProduct(ctx context.Context, in *UnarchiveProductRequest, opts ...grpc.CallOption) (*UnarchiveProductResponse, error) {
+	out := new(UnarchiveProductResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/UnarchiveProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) PurgeProduct(ctx context.Context, in *PurgeProductRequest, opts ...grpc.CallOption) (*PurgeProductResponse, error) {
+	out := new(PurgeProductResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/PurgeProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) StoreCategory(ctx context.Context, in *StoreCategoryRequest, opts ...grpc.CallOption) (*StoreCategoryResponse, error) {
+	out := new(StoreCategoryResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/StoreCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*DeleteCategoryResponse, error) {
+	out := new(DeleteCategoryResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/DeleteCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ListCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) SetProductCategories(ctx context.Context, in *SetProductCategoriesRequest, opts ...grpc.CallOption) (*SetProductCategoriesResponse, error) {
+	out := new(SetProductCategoriesResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/SetProductCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) ListCategoryProducts(ctx context.Context, in *ListCategoryProductsRequest, opts ...grpc.CallOption) (*ListCategoryProductsResponse, error) {
+	out := new(ListCategoryProductsResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ListCategoryProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) SchedulePrice(ctx context.Context, in *SchedulePriceRequest, opts ...grpc.CallOption) (*SchedulePriceResponse, error) {
+	out := new(SchedulePriceResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/SchedulePrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) CancelScheduledPrice(ctx context.Context, in *CancelScheduledPriceRequest, opts ...grpc.CallOption) (*CancelScheduledPriceResponse, error) {
+	out := new(CancelScheduledPriceResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/CancelScheduledPrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/GetPriceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) AddImage(ctx context.Context, in *AddImageRequest, opts ...grpc.CallOption) (*AddImageResponse, error) {
+	out := new(AddImageResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/AddImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) UploadImage(ctx context.Context, opts ...grpc.CallOption) (ProductInternalService_UploadImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductInternalService_ServiceDesc.Streams[0], "/Product.ProductInternalService/UploadImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productInternalServiceUploadImageClient{stream}
+	return x, nil
+}
+
+type ProductInternalService_UploadImageClient interface {
+	Send(*UploadImageRequest) error
+	CloseAndRecv() (*UploadImageResponse, error)
+	grpc.ClientStream
+}
+
+type productInternalServiceUploadImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *productInternalServiceUploadImageClient) Send(m *UploadImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *productInternalServiceUploadImageClient) CloseAndRecv() (*UploadImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productInternalServiceClient) ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error) {
+	out := new(ReorderImagesResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ReorderImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) RemoveImage(ctx context.Context, in *RemoveImageRequest, opts ...grpc.CallOption) (*RemoveImageResponse, error) {
+	out := new(RemoveImageResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/RemoveImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*CreateReviewResponse, error) {
+	out := new(CreateReviewResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/CreateReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) ModerateReview(ctx context.Context, in *ModerateReviewRequest, opts ...grpc.CallOption) (*ModerateReviewResponse, error) {
+	out := new(ModerateReviewResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ModerateReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productInternalServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, "/Product.ProductInternalService/ListReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProductInternalServiceServer is the server API for ProductInternalService service.
+// All implementations must embed UnimplementedProductInternalServiceServer
+// for forward compatibility
+type ProductInternalServiceServer interface {
+	StoreProduct(context.Context, *StoreProductRequest) (*StoreProductResponse, error)
+	// FindProduct ищет по productID или по slug и возвращает и архивные товары
+	FindProduct(context.Context, *FindProductRequest) (*FindProductResponse, error)
+	// ArchiveProduct снимает товар с продажи: он пропадает из списков и не заказывается
+	ArchiveProduct(context.Context, *ArchiveProductRequest) (*ArchiveProductResponse, error)
+	UnarchiveProduct(context.Context, *UnarchiveProductRequest) (*UnarchiveProductResponse, error)
+	// PurgeProduct окончательно удаляет архивный товар вместе с историей цен. Только для администраторов
+	PurgeProduct(context.Context, *PurgeProductRequest) (*PurgeProductResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
+	// StoreCategory создает категорию без categoryID, иначе переименовывает или переносит ее
+	StoreCategory(context.Context, *StoreCategoryRequest) (*StoreCategoryResponse, error)
+	// DeleteCategory удаляет только категорию без подкатегорий и товаров
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	// SetProductCategories заменяет весь список категорий товара
+	SetProductCategories(context.Context, *SetProductCategoriesRequest) (*SetProductCategoriesResponse, error)
+	ListCategoryProducts(context.Context, *ListCategoryProductsRequest) (*ListCategoryProductsResponse, error)
+	// SchedulePrice планирует цену товара или варианта, с effectiveUntil это распродажа
+	SchedulePrice(context.Context, *SchedulePriceRequest) (*SchedulePriceResponse, error)
+	// CancelScheduledPrice отменяет только еще не примененную цену
+	CancelScheduledPrice(context.Context, *CancelScheduledPriceRequest) (*CancelScheduledPriceResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	// AddImage добавляет в конец списка изображение, которое уже лежит по внешнему адресу
+	AddImage(context.Context, *AddImageRequest) (*AddImageResponse, error)
+	// UploadImage принимает первым сообщением metadata, дальше содержимое файла частями.
+	// Поддерживаются JPEG, PNG и GIF, размеры определяются по содержимому
+	UploadImage(ProductInternalService_UploadImageServer) error
+	// ReorderImages задает новый порядок, imageIDs должен содержать все изображения товара
+	ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error)
+	RemoveImage(context.Context, *RemoveImageRequest) (*RemoveImageResponse, error)
+	// CreateReview принимает отзыв на модерацию, только если у автора есть оплаченный заказ с товаром
+	CreateReview(context.Context, *CreateReviewRequest) (*CreateReviewResponse, error)
+	// ModerateReview одобряет или отклоняет отзыв, в рейтинге учитываются только одобренные
+	ModerateReview(context.Context, *ModerateReviewRequest) (*ModerateReviewResponse, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	mustEmbedUnimplementedProductInternalServiceServer()
+}
+
+// UnimplementedProductInternalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedProductInternalServiceServer struct {
+}
+
+func (UnimplementedProductInternalServiceServer) StoreProduct(context.Context, *StoreProductRequest) (*StoreProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreProduct not implemented")
+}
+func (UnimplementedProductInternalServiceServer) FindProduct(context.Context, *FindProductRequest) (*FindProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindProduct not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ArchiveProduct(context.Context, *ArchiveProductRequest) (*ArchiveProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveProduct not implemented")
+}
+func (UnimplementedProductInternalServiceServer) UnarchiveProduct(context.Context, *UnarchiveProductRequest) (*UnarchiveProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveProduct not implemented")
+}
+func (UnimplementedProductInternalServiceServer) PurgeProduct(context.Context, *PurgeProductRequest) (*PurgeProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeProduct not implemented")
+}
+func (UnimplementedProductInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
+func (UnimplementedProductInternalServiceServer) StoreCategory(context.Context, *StoreCategoryRequest) (*StoreCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreCategory not implemented")
+}
+func (UnimplementedProductInternalServiceServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*DeleteCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedProductInternalServiceServer) SetProductCategories(context.Context, *SetProductCategoriesRequest) (*SetProductCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductCategories not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ListCategoryProducts(context.Context, *ListCategoryProductsRequest) (*ListCategoryProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCategoryProducts not implemented")
+}
+func (UnimplementedProductInternalServiceServer) SchedulePrice(context.Context, *SchedulePriceRequest) (*SchedulePriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SchedulePrice not implemented")
+}
+func (UnimplementedProductInternalServiceServer) CancelScheduledPrice(context.Context, *CancelScheduledPriceRequest) (*CancelScheduledPriceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledPrice not implemented")
+}
+func (UnimplementedProductInternalServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedProductInternalServiceServer) AddImage(context.Context, *AddImageRequest) (*AddImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddImage not implemented")
+}
+func (UnimplementedProductInternalServiceServer) UploadImage(ProductInternalService_UploadImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderImages not implemented")
+}
+func (UnimplementedProductInternalServiceServer) RemoveImage(context.Context, *RemoveImageRequest) (*RemoveImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveImage not implemented")
+}
+func (UnimplementedProductInternalServiceServer) CreateReview(context.Context, *CreateReviewRequest) (*CreateReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReview not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ModerateReview(context.Context, *ModerateReviewRequest) (*ModerateReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModerateReview not implemented")
+}
+func (UnimplementedProductInternalServiceServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedProductInternalServiceServer) mustEmbedUnimplementedProductInternalServiceServer() {
+}
+
+// UnsafeProductInternalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProductInternalServiceServer will
+// result in compilation errors.
+type UnsafeProductInternalServiceServer interface {
+	mustEmbedUnimplementedProductInternalServiceServer()
+}
+
+func RegisterProductInternalServiceServer(s grpc.ServiceRegistrar, srv ProductInternalServiceServer) {
+	s.RegisterService(&ProductInternalService_ServiceDesc, srv)
+}
+
+func _ProductInternalService_StoreProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).StoreProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/StoreProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).StoreProduct(ctx, req.(*StoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_FindProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).FindProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/FindProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).FindProduct(ctx, req.(*FindProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_ArchiveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ArchiveProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ArchiveProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ArchiveProduct(ctx, req.(*ArchiveProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_UnarchiveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).UnarchiveProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/UnarchiveProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).UnarchiveProduct(ctx, req.(*UnarchiveProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_PurgeProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).PurgeProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/PurgeProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).PurgeProduct(ctx, req.(*PurgeProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_StoreCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).StoreCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/StoreCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).StoreCategory(ctx, req.(*StoreCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/DeleteCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ListCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_SetProductCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).SetProductCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/SetProductCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).SetProductCategories(ctx, req.(*SetProductCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_ListCategoryProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ListCategoryProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ListCategoryProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ListCategoryProducts(ctx, req.(*ListCategoryProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_SchedulePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchedulePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).SchedulePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/SchedulePrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).SchedulePrice(ctx, req.(*SchedulePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_CancelScheduledPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).CancelScheduledPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/CancelScheduledPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).CancelScheduledPrice(ctx, req.(*CancelScheduledPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/GetPriceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_AddImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).AddImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/AddImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).AddImage(ctx, req.(*AddImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_UploadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductInternalServiceServer).UploadImage(&productInternalServiceUploadImageServer{stream})
+}
+
+type ProductInternalService_UploadImageServer interface {
+	SendAndClose(*UploadImageResponse) error
+	Recv() (*UploadImageRequest, error)
+	grpc.ServerStream
+}
+
+type productInternalServiceUploadImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *productInternalServiceUploadImageServer) SendAndClose(m *UploadImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *productInternalServiceUploadImageServer) Recv() (*UploadImageRequest, error) {
+	m := new(UploadImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ProductInternalService_ReorderImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ReorderImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ReorderImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ReorderImages(ctx, req.(*ReorderImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_RemoveImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).RemoveImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/RemoveImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).RemoveImage(ctx, req.(*RemoveImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_CreateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).CreateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/CreateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).CreateReview(ctx, req.(*CreateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_ModerateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ModerateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ModerateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ModerateReview(ctx, req.(*ModerateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductInternalService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductInternalServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Product.ProductInternalService/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductInternalServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProductInternalService_ServiceDesc is the grpc.ServiceDesc for ProductInternalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProductInternalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "Product.ProductInternalService",
+	HandlerType: (*ProductInternalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StoreProduct",
+			Handler:    _ProductInternalService_StoreProduct_Handler,
+		},
+		{
+			MethodName: "FindProduct",
+			Handler:    _ProductInternalService_FindProduct_Handler,
+		},
+		{
+			MethodName: "ArchiveProduct",
+			Handler:    _ProductInternalService_ArchiveProduct_Handler,
+		},
+		{
+			MethodName: "UnarchiveProduct",
+			Handler:    _ProductInternalService_UnarchiveProduct_Handler,
+		},
+		{
+			MethodName: "PurgeProduct",
+			Handler:    _ProductInternalService_PurgeProduct_Handler,
+		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _ProductInternalService_FindAuditLog_Handler,
+		},
+		{
+			MethodName: "StoreCategory",
+			Handler:    _ProductInternalService_StoreCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _ProductInternalService_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _ProductInternalService_ListCategories_Handler,
+		},
+		{
+			MethodName: "SetProductCategories",
+			Handler:    _ProductInternalService_SetProductCategories_Handler,
+		},
+		{
+			MethodName: "ListCategoryProducts",
+			Handler:    _ProductInternalService_ListCategoryProducts_Handler,
+		},
+		{
+			MethodName: "SchedulePrice",
+			Handler:    _ProductInternalService_SchedulePrice_Handler,
+		},
+		{
+			MethodName: "CancelScheduledPrice",
+			Handler:    _ProductInternalService_CancelScheduledPrice_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _ProductInternalService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "AddImage",
+			Handler:    _ProductInternalService_AddImage_Handler,
+		},
+		{
+			MethodName: "ReorderImages",
+			Handler:    _ProductInternalService_ReorderImages_Handler,
+		},
+		{
+			MethodName: "RemoveImage",
+			Handler:    _ProductInternalService_RemoveImage_Handler,
+		},
+		{
+			MethodName: "CreateReview",
+			Handler:    _ProductInternalService_CreateReview_Handler,
+		},
+		{
+			MethodName: "ModerateReview",
+			Handler:    _ProductInternalService_ModerateReview_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _ProductInternalService_ListReviews_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadImage",
+			Handler:       _ProductInternalService_UploadImage_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "api/client/productinternal/productinternal.proto",
+}
@@ -0,0 +1,733 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.6
+// source: api/client/userinternal/userinternal.proto
+
+package userinternal
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// UserInternalServiceClient is the client API for UserInternalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserInternalServiceClient interface {
+	StoreUser(ctx context.Context, in *StoreUserRequest, opts ...grpc.CallOption) (*StoreUserResponse, error)
+	// PatchUser меняет только поля из updateMask и публикует одно событие user_updated на все изменение
+	PatchUser(ctx context.Context, in *PatchUserRequest, opts ...grpc.CallOption) (*PatchUserResponse, error)
+	FindUser(ctx context.Context, in *FindUserRequest, opts ...grpc.CallOption) (*FindUserResponse, error)
+	// FindUsers ищет пользователей одним запросом, не больше 100 за раз
+	FindUsers(ctx context.Context, in *FindUsersRequest, opts ...grpc.CallOption) (*FindUsersResponse, error)
+	// ChangeLogin публикует событие user_login_changed, прежний логин какое-то время остается занят за пользователем
+	ChangeLogin(ctx context.Context, in *ChangeLoginRequest, opts ...grpc.CallOption) (*ChangeLoginResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
+	ConfirmContact(ctx context.Context, in *ConfirmContactRequest, opts ...grpc.CallOption) (*ConfirmContactResponse, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	// ExportUserData собирает все, что хранится о пользователе во всех сервисах
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// EraseUser сразу окончательно удаляет пользователя и запускает удаление его данных в остальных сервисах
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error)
+	FindUserErasure(ctx context.Context, in *FindUserErasureRequest, opts ...grpc.CallOption) (*FindUserErasureResponse, error)
+	SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*SetPasswordResponse, error)
+	// Authenticate выдает access token и refresh token, после нескольких неудачных попыток вход временно блокируется
+	Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error)
+	// RefreshSession выдает новую пару токенов, переданный refresh token больше не действует
+	RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	// AssignRole и RevokeRole идемпотентны, роли вместе с правами уходят в событии user_updated
+	AssignRole(ctx context.Context, in *AssignRoleRequest, opts ...grpc.CallOption) (*AssignRoleResponse, error)
+	RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error)
+}
+
+type userInternalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserInternalServiceClient(cc grpc.ClientConnInterface) UserInternalServiceClient {
+	return &userInternalServiceClient{cc}
+}
+
+func (c *userInternalServiceClient) StoreUser(ctx context.Context, in *StoreUserRequest, opts ...grpc.CallOption) (*StoreUserResponse, error) {
+	out := new(StoreUserResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/StoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) PatchUser(ctx context.Context, in *PatchUserRequest, opts ...grpc.CallOption) (*PatchUserResponse, error) {
+	out := new(PatchUserResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/PatchUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) FindUser(ctx context.Context, in *FindUserRequest, opts ...grpc.CallOption) (*FindUserResponse, error) {
+	out := new(FindUserResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/FindUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) FindUsers(ctx context.Context, in *FindUsersRequest, opts ...grpc.CallOption) (*FindUsersResponse, error) {
+	out := new(FindUsersResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/FindUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) ChangeLogin(ctx context.Context, in *ChangeLoginRequest, opts ...grpc.CallOption) (*ChangeLoginResponse, error) {
+	out := new(ChangeLoginResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/ChangeLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) ConfirmContact(ctx context.Context, in *ConfirmContactRequest, opts ...grpc.CallOption) (*ConfirmContactResponse, error) {
+	out := new(ConfirmContactResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/ConfirmContact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*EraseUserResponse, error) {
+	out := new(EraseUserResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) FindUserErasure(ctx context.Context, in *FindUserErasureRequest, opts ...grpc.CallOption) (*FindUserErasureResponse, error) {
+	out := new(FindUserErasureResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/FindUserErasure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*SetPasswordResponse, error) {
+	out := new(SetPasswordResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/SetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) Authenticate(ctx context.Context, in *AuthenticateRequest, opts ...grpc.CallOption) (*AuthenticateResponse, error) {
+	out := new(AuthenticateResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/Authenticate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) RefreshSession(ctx context.Context, in *RefreshSessionRequest, opts ...grpc.CallOption) (*RefreshSessionResponse, error) {
+	out := new(RefreshSessionResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/RefreshSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) AssignRole(ctx context.Context, in *AssignRoleRequest, opts ...grpc.CallOption) (*AssignRoleResponse, error) {
+	out := new(AssignRoleResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/AssignRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userInternalServiceClient) RevokeRole(ctx context.Context, in *RevokeRoleRequest, opts ...grpc.CallOption) (*RevokeRoleResponse, error) {
+	out := new(RevokeRoleResponse)
+	err := c.cc.Invoke(ctx, "/User.UserInternalService/RevokeRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserInternalServiceServer is the server API for UserInternalService service.
+// All implementations must embed UnimplementedUserInternalServiceServer
+// for forward compatibility
+type UserInternalServiceServer interface {
+	StoreUser(context.Context, *StoreUserRequest) (*StoreUserResponse, error)
+	// PatchUser меняет только поля из updateMask и публикует одно событие user_updated на все изменение
+	PatchUser(context.Context, *PatchUserRequest) (*PatchUserResponse, error)
+	FindUser(context.Context, *FindUserRequest) (*FindUserResponse, error)
+	// FindUsers ищет пользователей одним запросом, не больше 100 за раз
+	FindUsers(context.Context, *FindUsersRequest) (*FindUsersResponse, error)
+	// ChangeLogin публикует событие user_login_changed, прежний логин какое-то время остается занят за пользователем
+	ChangeLogin(context.Context, *ChangeLoginRequest) (*ChangeLoginResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
+	ConfirmContact(context.Context, *ConfirmContactRequest) (*ConfirmContactResponse, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	// ExportUserData собирает все, что хранится о пользователе во всех сервисах
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// EraseUser сразу окончательно удаляет пользователя и запускает удаление его данных в остальных сервисах
+	EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error)
+	FindUserErasure(context.Context, *FindUserErasureRequest) (*FindUserErasureResponse, error)
+	SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error)
+	// Authenticate выдает access token и refresh token, после нескольких неудачных попыток вход временно блокируется
+	Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error)
+	// RefreshSession выдает новую пару токенов, переданный refresh token больше не действует
+	RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// AssignRole и RevokeRole идемпотентны, роли вместе с правами уходят в событии user_updated
+	AssignRole(context.Context, *AssignRoleRequest) (*AssignRoleResponse, error)
+	RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error)
+	mustEmbedUnimplementedUserInternalServiceServer()
+}
+
+// UnimplementedUserInternalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserInternalServiceServer struct {
+}
+
+func (UnimplementedUserInternalServiceServer) StoreUser(context.Context, *StoreUserRequest) (*StoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StoreUser not implemented")
+}
+func (UnimplementedUserInternalServiceServer) PatchUser(context.Context, *PatchUserRequest) (*PatchUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchUser not implemented")
+}
+func (UnimplementedUserInternalServiceServer) FindUser(context.Context, *FindUserRequest) (*FindUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindUser not implemented")
+}
+func (UnimplementedUserInternalServiceServer) FindUsers(context.Context, *FindUsersRequest) (*FindUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindUsers not implemented")
+}
+func (UnimplementedUserInternalServiceServer) ChangeLogin(context.Context, *ChangeLoginRequest) (*ChangeLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeLogin not implemented")
+}
+func (UnimplementedUserInternalServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
+func (UnimplementedUserInternalServiceServer) ConfirmContact(context.Context, *ConfirmContactRequest) (*ConfirmContactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmContact not implemented")
+}
+func (UnimplementedUserInternalServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedUserInternalServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedUserInternalServiceServer) EraseUser(context.Context, *EraseUserRequest) (*EraseUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (UnimplementedUserInternalServiceServer) FindUserErasure(context.Context, *FindUserErasureRequest) (*FindUserErasureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindUserErasure not implemented")
+}
+func (UnimplementedUserInternalServiceServer) SetPassword(context.Context, *SetPasswordRequest) (*SetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPassword not implemented")
+}
+func (UnimplementedUserInternalServiceServer) Authenticate(context.Context, *AuthenticateRequest) (*AuthenticateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authenticate not implemented")
+}
+func (UnimplementedUserInternalServiceServer) RefreshSession(context.Context, *RefreshSessionRequest) (*RefreshSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshSession not implemented")
+}
+func (UnimplementedUserInternalServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedUserInternalServiceServer) AssignRole(context.Context, *AssignRoleRequest) (*AssignRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignRole not implemented")
+}
+func (UnimplementedUserInternalServiceServer) RevokeRole(context.Context, *RevokeRoleRequest) (*RevokeRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRole not implemented")
+}
+func (UnimplementedUserInternalServiceServer) mustEmbedUnimplementedUserInternalServiceServer() {}
+
+// UnsafeUserInternalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserInternalServiceServer will
+// result in compilation errors.
+type UnsafeUserInternalServiceServer interface {
+	mustEmbedUnimplementedUserInternalServiceServer()
+}
+
+func RegisterUserInternalServiceServer(s grpc.ServiceRegistrar, srv UserInternalServiceServer) {
+	s.RegisterService(&UserInternalService_ServiceDesc, srv)
+}
+
+func _UserInternalService_StoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).StoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/StoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).StoreUser(ctx, req.(*StoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_PatchUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).PatchUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/PatchUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).PatchUser(ctx, req.(*PatchUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_FindUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).FindUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/FindUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).FindUser(ctx, req.(*FindUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_FindUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).FindUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/FindUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).FindUsers(ctx, req.(*FindUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_ChangeLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).ChangeLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/ChangeLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).ChangeLogin(ctx, req.(*ChangeLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_ConfirmContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).ConfirmContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/ConfirmContact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).ConfirmContact(ctx, req.(*ConfirmContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_FindUserErasure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindUserErasureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).FindUserErasure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/FindUserErasure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).FindUserErasure(ctx, req.(*FindUserErasureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_SetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).SetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/SetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).SetPassword(ctx, req.(*SetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_Authenticate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthenticateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).Authenticate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/Authenticate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).Authenticate(ctx, req.(*AuthenticateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_RefreshSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).RefreshSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/RefreshSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).RefreshSession(ctx, req.(*RefreshSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_AssignRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).AssignRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/AssignRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).AssignRole(ctx, req.(*AssignRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserInternalService_RevokeRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserInternalServiceServer).RevokeRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/User.UserInternalService/RevokeRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserInternalServiceServer).RevokeRole(ctx, req.(*RevokeRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserInternalService_ServiceDesc is the grpc.ServiceDesc for UserInternalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserInternalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "User.UserInternalService",
+	HandlerType: (*UserInternalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StoreUser",
+			Handler:    _UserInternalService_StoreUser_Handler,
+		},
+		{
+			MethodName: "PatchUser",
+			Handler:    _UserInternalService_PatchUser_Handler,
+		},
+		{
+			MethodName: "FindUser",
+			Handler:    _UserInternalService_FindUser_Handler,
+		},
+		{
+			MethodName: "FindUsers",
+			Handler:    _UserInternalService_FindUsers_Handler,
+		},
+		{
+			MethodName: "ChangeLogin",
+			Handler:    _UserInternalService_ChangeLogin_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserInternalService_ListUsers_Handler,
+		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _UserInternalService_FindAuditLog_Handler,
+		},
+		{
+			MethodName: "ConfirmContact",
+			Handler:    _UserInternalService_ConfirmContact_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserInternalService_RestoreUser_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _UserInternalService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _UserInternalService_EraseUser_Handler,
+		},
+		{
+			MethodName: "FindUserErasure",
+			Handler:    _UserInternalService_FindUserErasure_Handler,
+		},
+		{
+			MethodName: "SetPassword",
+			Handler:    _UserInternalService_SetPassword_Handler,
+		},
+		{
+			MethodName: "Authenticate",
+			Handler:    _UserInternalService_Authenticate_Handler,
+		},
+		{
+			MethodName: "RefreshSession",
+			Handler:    _UserInternalService_RefreshSession_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _UserInternalService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "AssignRole",
+			Handler:    _UserInternalService_AssignRole_Handler,
+		},
+		{
+			MethodName: "RevokeRole",
+			Handler:    _UserInternalService_RevokeRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/client/userinternal/userinternal.proto",
+}
@@ -0,0 +1,8 @@
+// Here placed all images used in this project for simple upgrade in future
+{
+  gobuilder: "golang:1.25.3",
+  golangcilint: "golangci/golangci-lint:v2.5.0",
+
+  // code generator
+  protoc: "namely/protoc:1.51_2",
+}
\ No newline at end of file
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+)
+
+func parseEnvs[T any]() (T, error) {
+	var c T
+	err := envconfig.Process(appID, &c)
+	return c, errors.WithStack(err)
+}
+
+type Service struct {
+	GracePeriod        time.Duration `envconfig:"GRACE_PERIOD" default:"15s"`
+	HTTPAddress        string        `envconfig:"HTTP_ADDRESS" default:":8082"`
+	MaxRequestBodySize int64         `envconfig:"MAX_REQUEST_BODY_SIZE" default:"1048576"`
+}
+
+type Auth struct {
+	TokenSecret string `envconfig:"TOKEN_SECRET" required:"true"`
+	TokenIssuer string `envconfig:"TOKEN_ISSUER" default:"user"`
+}
+
+type Clients struct {
+	UserServiceAddress         string        `envconfig:"USER_SERVICE_ADDRESS" required:"true"`
+	ProductServiceAddress      string        `envconfig:"PRODUCT_SERVICE_ADDRESS" required:"true"`
+	OrderServiceAddress        string        `envconfig:"ORDER_SERVICE_ADDRESS" required:"true"`
+	PaymentServiceAddress      string        `envconfig:"PAYMENT_SERVICE_ADDRESS" required:"true"`
+	NotificationServiceAddress string        `envconfig:"NOTIFICATION_SERVICE_ADDRESS" required:"true"`
+	RequestTimeout             time.Duration `envconfig:"REQUEST_TIMEOUT" default:"5s"`
+}
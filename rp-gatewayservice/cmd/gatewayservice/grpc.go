@@ -0,0 +1,18 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"gatewayservice/pkg/gateway/infrastructure/transport/middlewares"
+)
+
+func newGRPCClientConn(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(
+		address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(middlewares.NewGRPCCallerMiddleware(appID)),
+	)
+	return conn, errors.WithStack(err)
+}
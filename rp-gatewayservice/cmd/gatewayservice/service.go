@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"github.com/gorilla/mux"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+
+	"gatewayservice/api/client/notificationinternal"
+	"gatewayservice/api/client/orderinternal"
+	"gatewayservice/api/client/paymentinternal"
+	"gatewayservice/api/client/productinternal"
+	"gatewayservice/api/client/userinternal"
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+	"gatewayservice/pkg/gateway/infrastructure/transport"
+	"gatewayservice/pkg/gateway/infrastructure/transport/middlewares"
+)
+
+type serviceConfig struct {
+	Service Service `envconfig:"service"`
+	Auth    Auth    `envconfig:"auth" required:"true"`
+	Clients Clients `envconfig:"clients" required:"true"`
+}
+
+func service(logger logging.Logger) *cli.Command {
+	return &cli.Command{
+		Name: "service",
+		Action: func(c *cli.Context) error {
+			cnf, err := parseEnvs[serviceConfig]()
+			if err != nil {
+				return err
+			}
+
+			closer := libio.NewMultiCloser()
+			defer func() {
+				err = errors.Join(err, closer.Close())
+			}()
+
+			userConn, err := newGRPCClientConn(cnf.Clients.UserServiceAddress)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(userConn)
+			productConn, err := newGRPCClientConn(cnf.Clients.ProductServiceAddress)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(productConn)
+			orderConn, err := newGRPCClientConn(cnf.Clients.OrderServiceAddress)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(orderConn)
+			paymentConn, err := newGRPCClientConn(cnf.Clients.PaymentServiceAddress)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(paymentConn)
+			notificationConn, err := newGRPCClientConn(cnf.Clients.NotificationServiceAddress)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(notificationConn)
+
+			publicAPI := transport.NewPublicAPI(transport.Clients{
+				User:         userinternal.NewUserInternalServiceClient(userConn),
+				Product:      productinternal.NewProductInternalServiceClient(productConn),
+				Order:        orderinternal.NewOrderInternalServiceClient(orderConn),
+				Payment:      paymentinternal.NewPaymentInternalServiceClient(paymentConn),
+				Notification: notificationinternal.NewNotificationInternalServiceClient(notificationConn),
+			}, cnf.Clients.RequestTimeout)
+			tokenVerifier := auth.NewTokenVerifier([]byte(cnf.Auth.TokenSecret), cnf.Auth.TokenIssuer)
+
+			errGroup := errgroup.Group{}
+			errGroup.Go(func() error {
+				router := mux.NewRouter()
+				registerHealthcheck(router)
+				registerMetrics(router)
+
+				apiRouter := router.NewRoute().Subrouter()
+				apiRouter.Use(
+					middlewares.NewHTTPLoggingMiddleware(logger),
+					middlewares.NewBodyLimitMiddleware(cnf.Service.MaxRequestBodySize),
+				)
+				publicAPI.Register(apiRouter, middlewares.NewAuthMiddleware(tokenVerifier))
+
+				server := http.Server{
+					Addr:              cnf.Service.HTTPAddress,
+					Handler:           router,
+					ReadHeaderTimeout: 5 * time.Second,
+				}
+				graceCallback(c.Context, logger, cnf.Service.GracePeriod, server.Shutdown)
+				return server.ListenAndServe()
+			})
+
+			return errGroup.Wait()
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidToken = errors.New("invalid access token")
+
+type TokenVerifier interface {
+	Verify(rawToken string) (uuid.UUID, error)
+}
+
+func NewTokenVerifier(secret []byte, issuer string) TokenVerifier {
+	return &tokenVerifier{
+		secret: secret,
+		issuer: issuer,
+	}
+}
+
+type tokenVerifier struct {
+	secret []byte
+	issuer string
+}
+
+func (v *tokenVerifier) Verify(rawToken string) (uuid.UUID, error) {
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(
+		rawToken,
+		&claims,
+		func(_ *jwt.Token) (interface{}, error) {
+			return v.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return uuid.Nil, errors.Join(ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, errors.Join(ErrInvalidToken, err)
+	}
+	return userID, nil
+}
+
+type userIDKey struct{}
+
+func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+	return userID, ok
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	RequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gateway",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of public API requests",
+	}, []string{"method", "route", "status"})
+)
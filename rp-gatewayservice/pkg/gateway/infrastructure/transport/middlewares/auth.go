@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+	"gatewayservice/pkg/gateway/infrastructure/transport/response"
+)
+
+const bearerPrefix = "Bearer "
+
+func NewAuthMiddleware(verifier auth.TokenVerifier) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if !strings.HasPrefix(header, bearerPrefix) {
+				response.WriteError(w, response.ErrUnauthorized)
+				return
+			}
+
+			userID, err := verifier.Verify(strings.TrimPrefix(header, bearerPrefix))
+			if err != nil {
+				response.WriteError(w, response.ErrUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithUserID(r.Context(), userID)))
+		})
+	}
+}
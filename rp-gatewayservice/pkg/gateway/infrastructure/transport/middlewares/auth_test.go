@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+)
+
+const testIssuer = "userservice"
+
+var testSecret = []byte("secret")
+
+func signToken(t *testing.T, secret []byte, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	userID := uuid.New()
+	validClaims := func() jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			Subject:   userID.String(),
+			Issuer:    testIssuer,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}
+	}
+	handler := NewAuthMiddleware(auth.NewTokenVerifier(testSecret, testIssuer))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextUserID, ok := auth.UserIDFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, userID, contextUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	wrongIssuer := validClaims()
+	wrongIssuer.Issuer = "attacker"
+	noExpiration := validClaims()
+	noExpiration.ExpiresAt = nil
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{name: "valid", header: "Bearer " + signToken(t, testSecret, validClaims()), status: http.StatusNoContent},
+		{name: "missing_header", header: "", status: http.StatusUnauthorized},
+		{name: "not_bearer", header: "Basic " + signToken(t, testSecret, validClaims()), status: http.StatusUnauthorized},
+		{name: "malformed_token", header: "Bearer not-a-jwt", status: http.StatusUnauthorized},
+		{name: "expired", header: "Bearer " + signToken(t, testSecret, expired), status: http.StatusUnauthorized},
+		{name: "no_expiration", header: "Bearer " + signToken(t, testSecret, noExpiration), status: http.StatusUnauthorized},
+		{name: "wrong_issuer", header: "Bearer " + signToken(t, testSecret, wrongIssuer), status: http.StatusUnauthorized},
+		{name: "bad_signature", header: "Bearer " + signToken(t, []byte("other"), validClaims()), status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"gatewayservice/pkg/gateway/infrastructure/transport/response"
+)
+
+func NewBodyLimitMiddleware(maxBodySize int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBodySize {
+				response.WriteError(w, response.ErrRequestTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
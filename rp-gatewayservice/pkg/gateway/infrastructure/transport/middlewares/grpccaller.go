@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+)
+
+const (
+	userIDMetadataKey   = "x-user-id"
+	callerIDMetadataKey = "x-caller-id"
+)
+
+// NewGRPCCallerMiddleware передает внутренним сервисам идентичность вызывающего для лимитов запросов
+func NewGRPCCallerMiddleware(callerID string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, callerIDMetadataKey, callerID)
+		if userID, ok := auth.UserIDFromContext(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, userID.String())
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"github.com/gorilla/mux"
+
+	"gatewayservice/pkg/gateway/infrastructure/metrics"
+)
+
+func NewHTTPLoggingMiddleware(logger logging.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			route := r.URL.Path
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+			metrics.RequestDuration.WithLabelValues(r.Method, route, strconv.Itoa(recorder.status)).Observe(time.Since(start).Seconds())
+
+			l := logger.WithFields(logging.Fields{
+				"method":   r.Method,
+				"route":    route,
+				"status":   recorder.status,
+				"duration": time.Since(start).String(),
+			})
+			if recorder.status >= http.StatusInternalServerError {
+				l.Error(errors.New(http.StatusText(recorder.status)), "request failed")
+			} else {
+				l.Info("request finished")
+			}
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
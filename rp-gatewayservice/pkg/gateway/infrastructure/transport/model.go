@@ -0,0 +1,307 @@
+package transport
+
+import (
+	"gatewayservice/api/client/notificationinternal"
+	"gatewayservice/api/client/orderinternal"
+	"gatewayservice/api/client/productinternal"
+	"gatewayservice/api/client/userinternal"
+)
+
+type User struct {
+	UserID           string   `json:"user_id"`
+	Status           string   `json:"status"`
+	Login            string   `json:"login"`
+	Email            *string  `json:"email,omitempty"`
+	Telegram         *string  `json:"telegram,omitempty"`
+	EmailVerified    bool     `json:"email_verified"`
+	TelegramVerified bool     `json:"telegram_verified"`
+	Roles            []string `json:"roles,omitempty"`
+	Version          int64    `json:"version"`
+}
+
+// UpdateUserRequest пустая строка удаляет контакт, отсутствующее поле не меняет его.
+// Version - версия из последнего ответа с пользователем, при расхождении изменение отклоняется
+type UpdateUserRequest struct {
+	Email    *string `json:"email,omitempty"`
+	Telegram *string `json:"telegram,omitempty"`
+	Version  *int64  `json:"version,omitempty"`
+}
+
+// ConfirmContactRequest contact_type - email или telegram
+type ConfirmContactRequest struct {
+	ContactType string `json:"contact_type"`
+	Code        string `json:"code"`
+}
+
+type SetPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+type ChangeLoginRequest struct {
+	Login string `json:"login"`
+}
+
+type LoginRequest struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Session access_token передается в заголовке Authorization, refresh_token - только в /auth/refresh и /auth/logout
+type Session struct {
+	UserID                string `json:"user_id"`
+	AccessToken           string `json:"access_token"`
+	AccessTokenExpiresAt  int64  `json:"access_token_expires_at"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+}
+
+type Product struct {
+	ProductID     string    `json:"product_id"`
+	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
+	Price         int64     `json:"price"`
+	Currency      string    `json:"currency"`
+	Description   *string   `json:"description,omitempty"`
+	CategoryIDs   []string  `json:"category_ids"`
+	Variants      []Variant `json:"variants"`
+	Images        []Image   `json:"images"`
+	AverageRating float64   `json:"average_rating"`
+	ReviewCount   int32     `json:"review_count"`
+	Archived      bool      `json:"archived"`
+}
+
+type Image struct {
+	ImageID   string  `json:"image_id"`
+	URL       *string `json:"url,omitempty"`
+	ObjectKey *string `json:"object_key,omitempty"`
+	Width     int32   `json:"width"`
+	Height    int32   `json:"height"`
+	AltText   string  `json:"alt_text"`
+}
+
+type Variant struct {
+	VariantID  string            `json:"variant_id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	Price      int64             `json:"price"`
+	Stock      int64             `json:"stock"`
+}
+
+type ProductList struct {
+	Products []Product `json:"products"`
+}
+
+type Review struct {
+	ReviewID   string `json:"review_id"`
+	AuthorName string `json:"author_name"`
+	Rating     int32  `json:"rating"`
+	Text       string `json:"text"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+type ReviewList struct {
+	Reviews []Review `json:"reviews"`
+}
+
+type CreateReviewRequest struct {
+	Rating int32  `json:"rating"`
+	Text   string `json:"text"`
+}
+
+type CreateReviewResponse struct {
+	ReviewID string `json:"review_id"`
+}
+
+type Category struct {
+	CategoryID string  `json:"category_id"`
+	ParentID   *string `json:"parent_id,omitempty"`
+	Name       string  `json:"name"`
+}
+
+type CategoryList struct {
+	Categories []Category `json:"categories"`
+}
+
+type OrderItem struct {
+	ProductID        string  `json:"product_id"`
+	VariantID        *string `json:"variant_id,omitempty"`
+	SKU              string  `json:"sku,omitempty"`
+	Quantity         int32   `json:"quantity"`
+	Price            int64   `json:"price"`
+	OriginalPrice    int64   `json:"original_price"`
+	OriginalCurrency string  `json:"original_currency"`
+	ExchangeRate     int64   `json:"exchange_rate"`
+	Discount         int64   `json:"discount"`
+}
+
+type CreateOrderItem struct {
+	ProductID string  `json:"product_id"`
+	VariantID *string `json:"variant_id,omitempty"`
+	Quantity  int32   `json:"quantity"`
+}
+
+type CreateOrderRequest struct {
+	Currency  string            `json:"currency,omitempty"`
+	PromoCode string            `json:"promo_code,omitempty"`
+	Items     []CreateOrderItem `json:"items"`
+}
+
+type CreateOrderResponse struct {
+	OrderID string `json:"order_id"`
+}
+
+type Order struct {
+	OrderID       string      `json:"order_id"`
+	Items         []OrderItem `json:"items"`
+	Currency      string      `json:"currency"`
+	PromoCode     string      `json:"promo_code,omitempty"`
+	DiscountTotal int64       `json:"discount_total"`
+	TotalPrice    int64       `json:"total_price"`
+	Status        string      `json:"status"`
+	CreatedAt     int64       `json:"created_at"`
+}
+
+type Balance struct {
+	Currency string `json:"currency"`
+	Balance  int64  `json:"balance"`
+}
+
+type Notification struct {
+	NotificationID string `json:"notification_id"`
+	OrderID        string `json:"order_id"`
+	Message        string `json:"message"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type NotificationList struct {
+	Notifications []Notification `json:"notifications"`
+}
+
+func userFromProto(u *userinternal.User) User {
+	return User{
+		UserID:           u.UserID,
+		Status:           u.Status.String(),
+		Login:            u.Login,
+		Email:            u.Email,
+		Telegram:         u.Telegram,
+		EmailVerified:    u.EmailVerified,
+		TelegramVerified: u.TelegramVerified,
+		Roles:            u.Roles,
+		Version:          u.Version,
+	}
+}
+
+func sessionFromProto(s *userinternal.Session) Session {
+	return Session{
+		UserID:                s.UserID,
+		AccessToken:           s.AccessToken,
+		AccessTokenExpiresAt:  s.AccessTokenExpiresAt,
+		RefreshToken:          s.RefreshToken,
+		RefreshTokenExpiresAt: s.RefreshTokenExpiresAt,
+	}
+}
+
+func productFromProto(p *productinternal.Product) Product {
+	variants := make([]Variant, 0, len(p.Variants))
+	for _, v := range p.Variants {
+		attributes := v.Attributes
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		variants = append(variants, Variant{
+			VariantID:  v.VariantID,
+			SKU:        v.Sku,
+			Attributes: attributes,
+			Price:      v.Price,
+			Stock:      v.Stock,
+		})
+	}
+	images := make([]Image, 0, len(p.Images))
+	for _, i := range p.Images {
+		images = append(images, Image{
+			ImageID:   i.ImageID,
+			URL:       i.Url,
+			ObjectKey: i.ObjectKey,
+			Width:     i.Width,
+			Height:    i.Height,
+			AltText:   i.AltText,
+		})
+	}
+	return Product{
+		ProductID:     p.ProductID,
+		Name:          p.Name,
+		Slug:          p.Slug,
+		Price:         p.Price,
+		Currency:      p.Currency,
+		Description:   p.Description,
+		CategoryIDs:   p.CategoryIDs,
+		Variants:      variants,
+		Images:        images,
+		AverageRating: p.AverageRating,
+		ReviewCount:   p.ReviewCount,
+		Archived:      p.ArchivedAt != nil,
+	}
+}
+
+func reviewFromProto(r *productinternal.Review) Review {
+	return Review{
+		ReviewID:   r.ReviewID,
+		AuthorName: r.AuthorName,
+		Rating:     r.Rating,
+		Text:       r.Text,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func categoryFromProto(c *productinternal.Category) Category {
+	return Category{
+		CategoryID: c.CategoryID,
+		ParentID:   c.ParentID,
+		Name:       c.Name,
+	}
+}
+
+func orderFromProto(o *orderinternal.Order) Order {
+	items := make([]OrderItem, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = OrderItem{
+			ProductID:        item.ProductID,
+			VariantID:        item.VariantID,
+			SKU:              item.Sku,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+			OriginalPrice:    item.OriginalPrice,
+			OriginalCurrency: item.OriginalCurrency,
+			ExchangeRate:     item.ExchangeRate,
+			Discount:         item.Discount,
+		}
+	}
+	return Order{
+		OrderID:       o.OrderID,
+		Items:         items,
+		Currency:      o.Currency,
+		PromoCode:     o.PromoCode,
+		DiscountTotal: o.DiscountTotal,
+		TotalPrice:    o.TotalPrice,
+		Status:        o.Status.String(),
+		CreatedAt:     o.CreatedAt,
+	}
+}
+
+func notificationFromProto(n *notificationinternal.Notification) Notification {
+	return Notification{
+		NotificationID: n.NotificationID,
+		OrderID:        n.OrderID,
+		Message:        n.Message,
+		CreatedAt:      n.CreatedAt,
+	}
+}
+
+var contactTypes = map[string]userinternal.ContactType{
+	"email":    userinternal.ContactType_Email,
+	"telegram": userinternal.ContactType_Telegram,
+}
@@ -0,0 +1,550 @@
+package transport
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"gatewayservice/api/client/notificationinternal"
+	"gatewayservice/api/client/orderinternal"
+	"gatewayservice/api/client/paymentinternal"
+	"gatewayservice/api/client/productinternal"
+	"gatewayservice/api/client/userinternal"
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+	"gatewayservice/pkg/gateway/infrastructure/transport/response"
+)
+
+const APIPrefix = "/api/v1"
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+type PublicAPI interface {
+	// Register регистрирует маршруты, закрытые маршруты оборачиваются в authMiddleware
+	Register(router *mux.Router, authMiddleware mux.MiddlewareFunc)
+}
+
+type Clients struct {
+	User         userinternal.UserInternalServiceClient
+	Product      productinternal.ProductInternalServiceClient
+	Order        orderinternal.OrderInternalServiceClient
+	Payment      paymentinternal.PaymentInternalServiceClient
+	Notification notificationinternal.NotificationInternalServiceClient
+}
+
+func NewPublicAPI(clients Clients, requestTimeout time.Duration) PublicAPI {
+	return &publicAPI{
+		clients:        clients,
+		requestTimeout: requestTimeout,
+	}
+}
+
+type publicAPI struct {
+	clients        Clients
+	requestTimeout time.Duration
+}
+
+func (a *publicAPI) Register(router *mux.Router, authMiddleware mux.MiddlewareFunc) {
+	api := router.PathPrefix(APIPrefix).Subrouter()
+	api.HandleFunc("/openapi.yaml", a.openAPI).Methods(http.MethodGet)
+	api.HandleFunc("/products/by-slug/{slug}", a.findProductBySlug).Methods(http.MethodGet)
+	api.HandleFunc("/products/{productID}", a.findProduct).Methods(http.MethodGet)
+	api.HandleFunc("/products/{productID}/reviews", a.listProductReviews).Methods(http.MethodGet)
+	api.HandleFunc("/categories", a.listCategories).Methods(http.MethodGet)
+	api.HandleFunc("/categories/{categoryID}/products", a.listCategoryProducts).Methods(http.MethodGet)
+	api.HandleFunc("/auth/login", a.login).Methods(http.MethodPost)
+	api.HandleFunc("/auth/refresh", a.refreshSession).Methods(http.MethodPost)
+	api.HandleFunc("/auth/logout", a.logout).Methods(http.MethodPost)
+
+	protected := api.NewRoute().Subrouter()
+	protected.Use(authMiddleware)
+	protected.HandleFunc("/users/me", a.findCurrentUser).Methods(http.MethodGet)
+	protected.HandleFunc("/users/me", a.updateCurrentUser).Methods(http.MethodPatch)
+	protected.HandleFunc("/users/me/contacts/confirm", a.confirmContact).Methods(http.MethodPost)
+	protected.HandleFunc("/users/me/password", a.setPassword).Methods(http.MethodPut)
+	protected.HandleFunc("/users/me/login", a.changeLogin).Methods(http.MethodPut)
+	protected.HandleFunc("/products/{productID}/reviews", a.createReview).Methods(http.MethodPost)
+	protected.HandleFunc("/orders", a.createOrder).Methods(http.MethodPost)
+	protected.HandleFunc("/orders/{orderID}", a.findOrder).Methods(http.MethodGet)
+	protected.HandleFunc("/balance", a.findBalance).Methods(http.MethodGet)
+	protected.HandleFunc("/notifications", a.findNotifications).Methods(http.MethodGet)
+}
+
+func (a *publicAPI) openAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+func (a *publicAPI) findCurrentUser(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, userFromProto(user))
+}
+
+func (a *publicAPI) updateCurrentUser(w http.ResponseWriter, r *http.Request) {
+	var request UpdateUserRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	patch := &userinternal.PatchUserRequest{
+		UserID:          callerID(ctx).String(),
+		ExpectedVersion: request.Version,
+	}
+	if request.Email != nil {
+		patch.UpdateMask = append(patch.UpdateMask, "email")
+		patch.Email = emptyToNil(*request.Email)
+	}
+	if request.Telegram != nil {
+		patch.UpdateMask = append(patch.UpdateMask, "telegram")
+		patch.Telegram = emptyToNil(*request.Telegram)
+	}
+	if len(patch.UpdateMask) == 0 {
+		response.WriteError(w, response.BadRequest("nothing to update"))
+		return
+	}
+
+	resp, err := a.clients.User.PatchUser(ctx, patch)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, userFromProto(resp.User))
+}
+
+func (a *publicAPI) confirmContact(w http.ResponseWriter, r *http.Request) {
+	var request ConfirmContactRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	contactType, ok := contactTypes[request.ContactType]
+	if !ok {
+		response.WriteError(w, response.BadRequest("unknown contact_type"))
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	_, err := a.clients.User.ConfirmContact(ctx, &userinternal.ConfirmContactRequest{
+		UserID:      callerID(ctx).String(),
+		ContactType: contactType,
+		Code:        request.Code,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, userFromProto(user))
+}
+
+func (a *publicAPI) setPassword(w http.ResponseWriter, r *http.Request) {
+	var request SetPasswordRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	_, err := a.clients.User.SetPassword(ctx, &userinternal.SetPasswordRequest{
+		UserID:   callerID(ctx).String(),
+		Password: request.Password,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *publicAPI) changeLogin(w http.ResponseWriter, r *http.Request) {
+	var request ChangeLoginRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	_, err := a.clients.User.ChangeLogin(ctx, &userinternal.ChangeLoginRequest{
+		UserID: callerID(ctx).String(),
+		Login:  request.Login,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *publicAPI) login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.User.Authenticate(ctx, &userinternal.AuthenticateRequest{
+		Login:    request.Login,
+		Password: request.Password,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, sessionFromProto(resp.Session))
+}
+
+func (a *publicAPI) refreshSession(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokenRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.User.RefreshSession(ctx, &userinternal.RefreshSessionRequest{
+		RefreshToken: request.RefreshToken,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, sessionFromProto(resp.Session))
+}
+
+func (a *publicAPI) logout(w http.ResponseWriter, r *http.Request) {
+	var request RefreshTokenRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	_, err := a.clients.User.RevokeSession(ctx, &userinternal.RevokeSessionRequest{
+		RefreshToken: request.RefreshToken,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *publicAPI) findProduct(w http.ResponseWriter, r *http.Request) {
+	productID, err := pathUUID(r, "productID")
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	a.writeProduct(w, r, &productinternal.FindProductRequest{ProductID: productID.String()})
+}
+
+func (a *publicAPI) findProductBySlug(w http.ResponseWriter, r *http.Request) {
+	a.writeProduct(w, r, &productinternal.FindProductRequest{Slug: mux.Vars(r)["slug"]})
+}
+
+func (a *publicAPI) writeProduct(w http.ResponseWriter, r *http.Request, request *productinternal.FindProductRequest) {
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Product.FindProduct(ctx, request)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	if resp.Product == nil {
+		response.WriteError(w, response.ErrNotFound)
+		return
+	}
+	response.JSON(w, http.StatusOK, productFromProto(resp.Product))
+}
+
+func (a *publicAPI) listProductReviews(w http.ResponseWriter, r *http.Request) {
+	productID, err := pathUUID(r, "productID")
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	// Публично показываем только прошедшие модерацию отзывы
+	productIDStr := productID.String()
+	status := productinternal.ReviewStatus_REVIEW_APPROVED
+	resp, err := a.clients.Product.ListReviews(ctx, &productinternal.ListReviewsRequest{
+		ProductID: &productIDStr,
+		Status:    &status,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	reviews := make([]Review, 0, len(resp.Reviews))
+	for _, review := range resp.Reviews {
+		reviews = append(reviews, reviewFromProto(review))
+	}
+	response.JSON(w, http.StatusOK, ReviewList{Reviews: reviews})
+}
+
+func (a *publicAPI) createReview(w http.ResponseWriter, r *http.Request) {
+	productID, err := pathUUID(r, "productID")
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	var request CreateReviewRequest
+	if err = decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	user, err := a.currentUser(ctx)
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	resp, err := a.clients.Product.CreateReview(ctx, &productinternal.CreateReviewRequest{
+		ProductID:  productID.String(),
+		AuthorID:   user.UserID,
+		AuthorName: user.Login,
+		Rating:     request.Rating,
+		Text:       request.Text,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, CreateReviewResponse{ReviewID: resp.ReviewID})
+}
+
+func (a *publicAPI) listCategories(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Product.ListCategories(ctx, &productinternal.ListCategoriesRequest{})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	categories := make([]Category, 0, len(resp.Categories))
+	for _, category := range resp.Categories {
+		categories = append(categories, categoryFromProto(category))
+	}
+	response.JSON(w, http.StatusOK, CategoryList{Categories: categories})
+}
+
+func (a *publicAPI) listCategoryProducts(w http.ResponseWriter, r *http.Request) {
+	categoryID, err := pathUUID(r, "categoryID")
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	includeSubcategories := false
+	if value := r.URL.Query().Get("include_subcategories"); value != "" {
+		includeSubcategories, err = strconv.ParseBool(value)
+		if err != nil {
+			response.WriteError(w, response.BadRequest("invalid include_subcategories"))
+			return
+		}
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Product.ListCategoryProducts(ctx, &productinternal.ListCategoryProductsRequest{
+		CategoryID:           categoryID.String(),
+		IncludeSubcategories: includeSubcategories,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	products := make([]Product, 0, len(resp.Products))
+	for _, product := range resp.Products {
+		products = append(products, productFromProto(product))
+	}
+	response.JSON(w, http.StatusOK, ProductList{Products: products})
+}
+
+func (a *publicAPI) createOrder(w http.ResponseWriter, r *http.Request) {
+	var request CreateOrderRequest
+	if err := decodeJSON(r, &request); err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	items := make([]*orderinternal.OrderItem, len(request.Items))
+	for i, item := range request.Items {
+		items[i] = &orderinternal.OrderItem{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	resp, err := a.clients.Order.CreateOrder(ctx, &orderinternal.CreateOrderRequest{
+		UserID:    callerID(ctx).String(),
+		Currency:  request.Currency,
+		PromoCode: request.PromoCode,
+		Items:     items,
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, CreateOrderResponse{OrderID: resp.OrderID})
+}
+
+func (a *publicAPI) findOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := pathUUID(r, "orderID")
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Order.FindOrder(ctx, &orderinternal.FindOrderRequest{OrderID: orderID.String()})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	// Чужие заказы не показываем, даже сам факт их существования
+	if resp.Order == nil || resp.Order.UserID != callerID(ctx).String() {
+		response.WriteError(w, response.ErrNotFound)
+		return
+	}
+	response.JSON(w, http.StatusOK, orderFromProto(resp.Order))
+}
+
+func (a *publicAPI) findBalance(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Payment.FindUserBalance(ctx, &paymentinternal.FindUserBalanceRequest{UserID: callerID(ctx).String()})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+	if resp.Balance == nil {
+		response.WriteError(w, response.ErrNotFound)
+		return
+	}
+	response.JSON(w, http.StatusOK, Balance{Currency: resp.Balance.Currency, Balance: resp.Balance.Balance})
+}
+
+func (a *publicAPI) findNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := a.context(r)
+	defer cancel()
+
+	resp, err := a.clients.Notification.FindNotificationsForUser(ctx, &notificationinternal.FindNotificationsForUserRequest{
+		UserID: callerID(ctx).String(),
+	})
+	if err != nil {
+		response.WriteError(w, err)
+		return
+	}
+
+	notifications := make([]Notification, len(resp.Notifications))
+	for i, n := range resp.Notifications {
+		notifications[i] = notificationFromProto(n)
+	}
+	response.JSON(w, http.StatusOK, NotificationList{Notifications: notifications})
+}
+
+func (a *publicAPI) currentUser(ctx context.Context) (*userinternal.User, error) {
+	resp, err := a.clients.User.FindUser(ctx, &userinternal.FindUserRequest{UserID: callerID(ctx).String()})
+	if err != nil {
+		return nil, err
+	}
+	if resp.User == nil {
+		return nil, response.ErrNotFound
+	}
+	return resp.User, nil
+}
+
+func (a *publicAPI) context(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(r.Context(), a.requestTimeout)
+}
+
+// callerID возвращает пользователя из токена, маршрут без авторизации сюда не попадает
+func callerID(ctx context.Context) uuid.UUID {
+	userID, _ := auth.UserIDFromContext(ctx)
+	return userID
+}
+
+func pathUUID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(mux.Vars(r)[name])
+	if err != nil {
+		return uuid.Nil, response.BadRequest("invalid " + name)
+	}
+	return id, nil
+}
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(v)
+	if err == nil {
+		return nil
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return response.ErrRequestTooLarge
+	}
+	if errors.Is(err, io.EOF) {
+		return response.BadRequest("request body is empty")
+	}
+	return response.BadRequest("malformed request body: " + err.Error())
+}
+
+func emptyToNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,82 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gatewayservice/api/client/orderinternal"
+	"gatewayservice/pkg/gateway/infrastructure/auth"
+)
+
+// stubOrderClient отдает заказы из памяти, остальные методы клиента не используются
+type stubOrderClient struct {
+	orderinternal.OrderInternalServiceClient
+	orders map[string]*orderinternal.Order
+}
+
+func (c *stubOrderClient) FindOrder(_ context.Context, in *orderinternal.FindOrderRequest, _ ...grpc.CallOption) (*orderinternal.FindOrderResponse, error) {
+	order, ok := c.orders[in.OrderID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return &orderinternal.FindOrderResponse{Order: order}, nil
+}
+
+// withUser заменяет проверку токена, пользователь берется из заголовка
+func withUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := uuid.MustParse(r.Header.Get("X-Test-User"))
+		next.ServeHTTP(w, r.WithContext(auth.WithUserID(r.Context(), userID)))
+	})
+}
+
+func TestPublicAPI_FindOrder(t *testing.T) {
+	ownerID := uuid.New()
+	otherID := uuid.New()
+	orderID := uuid.New()
+	orders := &stubOrderClient{orders: map[string]*orderinternal.Order{
+		orderID.String(): {OrderID: orderID.String(), UserID: ownerID.String(), Currency: "RUB"},
+	}}
+	router := mux.NewRouter()
+	NewPublicAPI(Clients{Order: orders}, time.Second).Register(router, withUser)
+
+	findOrder := func(userID uuid.UUID, orderID string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, APIPrefix+"/orders/"+orderID, nil)
+		r.Header.Set("X-Test-User", userID.String())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		w := findOrder(ownerID, orderID.String())
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), orderID.String())
+	})
+
+	t.Run("other_user", func(t *testing.T) {
+		w := findOrder(otherID, orderID.String())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.NotContains(t, w.Body.String(), ownerID.String())
+	})
+
+	t.Run("missing_order", func(t *testing.T) {
+		w := findOrder(ownerID, uuid.NewString())
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid_id", func(t *testing.T) {
+		w := findOrder(ownerID, "not-a-uuid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
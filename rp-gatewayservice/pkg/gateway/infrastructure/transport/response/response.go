@@ -0,0 +1,117 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrUnauthorized    = NewError(http.StatusUnauthorized, "unauthenticated", "missing or invalid access token")
+	ErrNotFound        = NewError(http.StatusNotFound, "not_found", "resource not found")
+	ErrRequestTooLarge = NewError(http.StatusRequestEntityTooLarge, "request_too_large", "request body is too large")
+)
+
+// Error отдается клиенту в виде {"error": {"code": ..., "message": ...}}
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func NewError(status int, code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func BadRequest(message string) *Error {
+	return NewError(http.StatusBadRequest, "invalid_argument", message)
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func JSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func WriteError(w http.ResponseWriter, err error) {
+	e := fromError(err)
+	if retryAfter := retryDelay(err); retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+	}
+	body := errorBody{}
+	body.Error.Code = e.Code
+	body.Error.Message = e.Message
+	JSON(w, e.Status, body)
+}
+
+// retryDelay достает RetryInfo, который внутренние сервисы добавляют при превышении лимита запросов
+func retryDelay(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return 0
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+func fromError(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ErrRequestTooLarge
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return NewError(http.StatusInternalServerError, "internal", "internal error")
+	}
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return NewError(http.StatusBadRequest, "invalid_argument", st.Message())
+	case codes.NotFound:
+		return NewError(http.StatusNotFound, "not_found", st.Message())
+	case codes.AlreadyExists:
+		return NewError(http.StatusConflict, "already_exists", st.Message())
+	case codes.FailedPrecondition, codes.Aborted:
+		return NewError(http.StatusConflict, "failed_precondition", st.Message())
+	case codes.PermissionDenied:
+		return NewError(http.StatusForbidden, "permission_denied", st.Message())
+	case codes.Unauthenticated:
+		return ErrUnauthorized
+	case codes.ResourceExhausted:
+		return NewError(http.StatusTooManyRequests, "resource_exhausted", st.Message())
+	case codes.DeadlineExceeded, codes.Unavailable:
+		return NewError(http.StatusServiceUnavailable, "unavailable", "upstream service unavailable")
+	default:
+		return NewError(http.StatusInternalServerError, "internal", "internal error")
+	}
+}
@@ -0,0 +1,65 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{name: "invalid_argument", err: status.Error(codes.InvalidArgument, "bad"), status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "out_of_range", err: status.Error(codes.OutOfRange, "bad"), status: http.StatusBadRequest, code: "invalid_argument"},
+		{name: "not_found", err: status.Error(codes.NotFound, "missing"), status: http.StatusNotFound, code: "not_found"},
+		{name: "already_exists", err: status.Error(codes.AlreadyExists, "dup"), status: http.StatusConflict, code: "already_exists"},
+		{name: "failed_precondition", err: status.Error(codes.FailedPrecondition, "state"), status: http.StatusConflict, code: "failed_precondition"},
+		{name: "aborted", err: status.Error(codes.Aborted, "version"), status: http.StatusConflict, code: "failed_precondition"},
+		{name: "permission_denied", err: status.Error(codes.PermissionDenied, "denied"), status: http.StatusForbidden, code: "permission_denied"},
+		{name: "unauthenticated", err: status.Error(codes.Unauthenticated, "token"), status: http.StatusUnauthorized, code: "unauthenticated"},
+		{name: "resource_exhausted", err: status.Error(codes.ResourceExhausted, "limit"), status: http.StatusTooManyRequests, code: "resource_exhausted"},
+		{name: "deadline_exceeded", err: status.Error(codes.DeadlineExceeded, "slow"), status: http.StatusServiceUnavailable, code: "unavailable"},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), status: http.StatusServiceUnavailable, code: "unavailable"},
+		{name: "internal", err: status.Error(codes.Internal, "secret details"), status: http.StatusInternalServerError, code: "internal"},
+		{name: "not_grpc", err: errors.New("boom"), status: http.StatusInternalServerError, code: "internal"},
+		{name: "http_error", err: ErrNotFound, status: http.StatusNotFound, code: "not_found"},
+		{name: "max_bytes", err: &http.MaxBytesError{Limit: 1}, status: http.StatusRequestEntityTooLarge, code: "request_too_large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, tt.err)
+			assert.Equal(t, tt.status, w.Code)
+
+			var body errorBody
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.code, body.Error.Code)
+			assert.NotContains(t, body.Error.Message, "secret details")
+		})
+	}
+
+	t.Run("retry_after", func(t *testing.T) {
+		st, err := status.New(codes.ResourceExhausted, "limit").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)})
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		WriteError(w, st.Err())
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+}
@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type NotificationInternalServiceClient interface {
 	FindNotificationsForUser(ctx context.Context, in *FindNotificationsForUserRequest, opts ...grpc.CallOption) (*FindNotificationsForUserResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
 }
 
 type notificationInternalServiceClient struct {
@@ -42,11 +43,21 @@ func (c *notificationInternalServiceClient) FindNotificationsForUser(ctx context
 	return out, nil
 }
 
+func (c *notificationInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/Notification.NotificationInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationInternalServiceServer is the server API for NotificationInternalService service.
 // All implementations must embed UnimplementedNotificationInternalServiceServer
 // for forward compatibility
 type NotificationInternalServiceServer interface {
 	FindNotificationsForUser(context.Context, *FindNotificationsForUserRequest) (*FindNotificationsForUserResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
 	mustEmbedUnimplementedNotificationInternalServiceServer()
 }
 
@@ -57,6 +68,9 @@ type UnimplementedNotificationInternalServiceServer struct {
 func (UnimplementedNotificationInternalServiceServer) FindNotificationsForUser(context.Context, *FindNotificationsForUserRequest) (*FindNotificationsForUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindNotificationsForUser not implemented")
 }
+func (UnimplementedNotificationInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
 func (UnimplementedNotificationInternalServiceServer) mustEmbedUnimplementedNotificationInternalServiceServer() {
 }
 
@@ -89,6 +103,24 @@ func _NotificationInternalService_FindNotificationsForUser_Handler(srv interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Notification.NotificationInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationInternalService_ServiceDesc is the grpc.ServiceDesc for NotificationInternalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -100,6 +132,10 @@ var NotificationInternalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindNotificationsForUser",
 			Handler:    _NotificationInternalService_FindNotificationsForUser_Handler,
 		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _NotificationInternalService_FindAuditLog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/server/notificationinternal/notificationinternal.proto",
@@ -9,6 +9,7 @@ import (
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
 	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
 	"github.com/gorilla/mux"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sync/errgroup"
@@ -16,14 +17,18 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"notificationservice/api/server/notificationinternal"
+	"notificationservice/api/server/userdatainternal"
+	appservice "notificationservice/pkg/notification/application/service"
+	inframysql "notificationservice/pkg/notification/infrastructure/mysql"
 	"notificationservice/pkg/notification/infrastructure/mysql/query"
 	"notificationservice/pkg/notification/infrastructure/transport"
 	"notificationservice/pkg/notification/infrastructure/transport/middlewares"
 )
 
 type serviceConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
+	Service   Service   `envconfig:"service"`
+	RateLimit RateLimit `envconfig:"rate_limit"`
+	Database  Database  `envconfig:"database" required:"true"`
 }
 
 func service(logger logging.Logger) *cli.Command {
@@ -46,9 +51,22 @@ func service(logger logging.Logger) *cli.Command {
 				return err
 			}
 			closer.AddCloser(databaseConnector)
+			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
 
+			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
+			rateLimiter, err := newRateLimiter(cnf.RateLimit, libUoW)
+			if err != nil {
+				return err
+			}
+
+			notificationQueryService := query.NewNotificationQueryService(databaseConnector.TransactionalClient())
 			notificationAPI := transport.NewNotificationInternalAPI(
-				query.NewNotificationQueryService(databaseConnector.TransactionalClient()),
+				notificationQueryService,
+				query.NewAuditLogQueryService(databaseConnector.TransactionalClient()),
+			)
+			userDataInternalAPI := transport.NewUserDataInternalAPI(
+				notificationQueryService,
+				appservice.NewUserDataService(inframysql.NewUnitOfWork(libUoW)),
 			)
 
 			errGroup := errgroup.Group{}
@@ -58,9 +76,14 @@ func service(logger logging.Logger) *cli.Command {
 					return err
 				}
 				grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+					middlewares.NewGRPCErrorsMiddleware(),
 					middlewares.NewGRPCLoggingMiddleware(logger),
+					middlewares.NewGRPCRateLimitMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+					middlewares.NewGRPCValidationMiddleware(),
+					middlewares.NewGRPCAuditMiddleware(),
 				))
 				notificationinternal.RegisterNotificationInternalServiceServer(grpcServer, notificationAPI)
+				userdatainternal.RegisterUserDataInternalServiceServer(grpcServer, userDataInternalAPI)
 				reflection.Register(grpcServer)
 				graceCallback(c.Context, logger, cnf.Service.GracePeriod, func(_ context.Context) error {
 					grpcServer.GracefulStop()
@@ -48,6 +48,10 @@ func (m *StubNotifRepo) FindForUser(_ uuid.UUID) ([]domainmodel.Notification, er
 	return nil, nil
 }
 
+func (m *StubNotifRepo) DeleteForUser(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
 func TestNotificationService_CreateNotification(t *testing.T) {
 	provider := new(MockRepositoryProvider)
 	uow := new(MockUnitOfWork)
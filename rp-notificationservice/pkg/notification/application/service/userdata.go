@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService interface {
+	// EraseUserData удаляет уведомления пользователя
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserDataService(uow UnitOfWork) UserDataService {
+	return &userDataService{uow: uow}
+}
+
+type userDataService struct {
+	uow UnitOfWork
+}
+
+func (s *userDataService) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return provider.NotificationRepository(ctx).DeleteForUser(userID)
+	})
+}
@@ -23,4 +23,5 @@ type NotificationRepository interface {
 	NextID() (uuid.UUID, error)
 	Store(notification Notification) error
 	FindForUser(userID uuid.UUID) ([]Notification, error)
+	DeleteForUser(userID uuid.UUID) error
 }
@@ -32,6 +32,11 @@ func (m *MockNotificationRepository) FindForUser(userID uuid.UUID) ([]model.Noti
 	return args.Get(0).([]model.Notification), args.Error(1)
 }
 
+func (m *MockNotificationRepository) DeleteForUser(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
 func TestNotificationService_CreateNotification(t *testing.T) {
 	repo := new(MockNotificationRepository)
 	service := NewNotificationService(repo)
@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	appservice "notificationservice/pkg/notification/application/service"
+	"notificationservice/pkg/notification/infrastructure/audit"
 	"notificationservice/pkg/notification/infrastructure/metrics"
 )
 
@@ -56,6 +57,12 @@ func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err
 	l := c.logger.WithField("event_type", delivery.Type)
 	l.Info("processing event")
 
+	ctx = audit.WithInfo(ctx, audit.Info{
+		Actor:         audit.SystemActor,
+		Method:        "event/" + delivery.Type,
+		CorrelationID: delivery.CorrelationID,
+	})
+
 	var orderID, userID uuid.UUID
 	var message string
 
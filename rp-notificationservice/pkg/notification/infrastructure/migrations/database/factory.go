@@ -44,4 +44,6 @@ func NewDatabaseMigrator(
 
 var builderFunctions = []MigrationBuilderFunc{
 	NewVersion1722266009,
+	NewVersion1792400001,
+	NewVersion1792400002,
 }
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400001(client mysql.ClientContext) migrator.Migration {
+	return &version1792400001{
+		client: client,
+	}
+}
+
+type version1792400001 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400001) Version() int64 {
+	return 1792400001
+}
+
+func (v version1792400001) Description() string {
+	return "Create 'rate_limit_bucket' table"
+}
+
+func (v version1792400001) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE rate_limit_bucket
+		(
+		    bucket_key VARCHAR(255) NOT NULL,
+		    tokens     DOUBLE       NOT NULL,
+		    updated_at DATETIME(6)  NOT NULL,
+		    PRIMARY KEY (bucket_key),
+		    INDEX updated_at_idx (updated_at)
+		)
+		    ENGINE = InnoDB
+			CHARACTER SET = utf8mb4
+			COLLATE utf8mb4_unicode_ci;
+	`)
+	return errors.WithStack(err)
+}
@@ -16,6 +16,8 @@ import (
 const (
 	statusSuccess = "success"
 	statusError   = "error"
+
+	auditEntityNotification = "notification"
 )
 
 func NewNotificationRepository(ctx context.Context, client mysql.ClientContext) model.NotificationRepository {
@@ -48,7 +50,12 @@ func (r *notificationRepository) Store(notification model.Notification) (err err
 		`INSERT INTO notification (notification_id, order_id, user_id, message, created_at) VALUES (?, ?, ?, ?, ?)`,
 		notification.NotificationID, notification.OrderID, notification.UserID, notification.Message, notification.CreatedAt,
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// уведомления только создаются, предыдущего состояния нет
+	return appendAuditRecord[model.Notification](r.ctx, r.client, auditEntityNotification, notification.NotificationID.String(), nil, &notification)
 }
 
 func (r *notificationRepository) FindForUser(userID uuid.UUID) (_ []model.Notification, err error) {
@@ -68,3 +75,32 @@ func (r *notificationRepository) FindForUser(userID uuid.UUID) (_ []model.Notifi
 	}
 	return notifications, nil
 }
+
+func (r *notificationRepository) DeleteForUser(userID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "notification", status).Observe(time.Since(start).Seconds())
+	}()
+
+	notifications, err := r.FindForUser(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM notification WHERE user_id = ?`, userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, notification := range notifications {
+		err = appendAuditRecord[model.Notification](r.ctx, r.client, auditEntityNotification, notification.NotificationID.String(), &notification, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"notificationservice/pkg/notification/infrastructure/metrics"
+)
+
+const (
+	mysqlCleanupInterval = 10 * time.Minute
+	// bucket'ы, которые не трогали дольше этого времени, удаляются
+	mysqlBucketTTL = time.Hour
+)
+
+// NewMySQLLimiter хранит bucket'ы в таблице rate_limit_bucket, лимиты общие для всех реплик
+func NewMySQLLimiter(uow mysql.UnitOfWork) Limiter {
+	return &mysqlLimiter{
+		uow:       uow,
+		cleanupAt: time.Now().Add(mysqlCleanupInterval),
+	}
+}
+
+type mysqlLimiter struct {
+	uow mysql.UnitOfWork
+
+	mu        sync.Mutex
+	cleanupAt time.Time
+}
+
+func (l *mysqlLimiter) Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("take", "rate_limit_bucket", status).Observe(time.Since(start).Seconds())
+	}()
+
+	now := time.Now().UTC()
+	err = l.uow.ExecuteWithClientContext(ctx, func(client mysql.ClientContext) error {
+		b := newBucket(limit, now)
+		_, err2 := client.ExecContext(ctx,
+			`INSERT IGNORE INTO rate_limit_bucket (bucket_key, tokens, updated_at) VALUES (?, ?, ?)`,
+			key, b.tokens, b.updatedAt,
+		)
+		if err2 != nil {
+			return errors.WithStack(err2)
+		}
+
+		bucketData := struct {
+			Tokens    float64   `db:"tokens"`
+			UpdatedAt time.Time `db:"updated_at"`
+		}{}
+		err2 = client.GetContext(ctx, &bucketData, `SELECT tokens, updated_at FROM rate_limit_bucket WHERE bucket_key = ? FOR UPDATE`, key)
+		if err2 != nil {
+			return errors.WithStack(err2)
+		}
+
+		b = bucket{
+			tokens:    bucketData.Tokens,
+			updatedAt: bucketData.UpdatedAt,
+		}
+		allowed, retryAfter = b.take(limit, now)
+
+		_, err2 = client.ExecContext(ctx,
+			`UPDATE rate_limit_bucket SET tokens = ?, updated_at = ? WHERE bucket_key = ?`,
+			b.tokens, b.updatedAt, key,
+		)
+		return errors.WithStack(err2)
+	})
+	if err != nil {
+		return false, 0, err
+	}
+
+	l.cleanup(ctx, now)
+	return allowed, retryAfter, nil
+}
+
+func (l *mysqlLimiter) cleanup(ctx context.Context, now time.Time) {
+	l.mu.Lock()
+	if now.Before(l.cleanupAt) {
+		l.mu.Unlock()
+		return
+	}
+	l.cleanupAt = now.Add(mysqlCleanupInterval)
+	l.mu.Unlock()
+
+	// ошибка очистки не должна влиять на обработку запроса, попробуем в следующий раз
+	_ = l.uow.ExecuteWithClientContext(ctx, func(client mysql.ClientContext) error {
+		_, err := client.ExecContext(ctx, `DELETE FROM rate_limit_bucket WHERE updated_at < ?`, now.Add(-mysqlBucketTTL))
+		return err
+	})
+}
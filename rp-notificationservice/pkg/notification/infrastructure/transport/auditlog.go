@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"notificationservice/api/server/notificationinternal"
+	appmodel "notificationservice/pkg/notification/application/model"
+)
+
+func toAuditRecords(records []appmodel.AuditRecord) []*notificationinternal.AuditRecord {
+	result := make([]*notificationinternal.AuditRecord, len(records))
+	for i, record := range records {
+		result[i] = &notificationinternal.AuditRecord{
+			RecordID:      record.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt.Unix(),
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			Valid:         record.Valid,
+		}
+	}
+	return result
+}
@@ -3,30 +3,30 @@ package transport
 import (
 	"context"
 
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
-
 	"notificationservice/api/server/notificationinternal"
 	"notificationservice/pkg/notification/application/query"
 )
 
 func NewNotificationInternalAPI(
 	queryService query.NotificationQueryService,
+	auditLogQueryService query.AuditLogQueryService,
 ) notificationinternal.NotificationInternalServiceServer {
 	return &notificationInternalAPI{
-		queryService: queryService,
+		queryService:         queryService,
+		auditLogQueryService: auditLogQueryService,
 	}
 }
 
 type notificationInternalAPI struct {
-	queryService query.NotificationQueryService
+	queryService         query.NotificationQueryService
+	auditLogQueryService query.AuditLogQueryService
 	notificationinternal.UnimplementedNotificationInternalServiceServer
 }
 
 func (a *notificationInternalAPI) FindNotificationsForUser(ctx context.Context, request *notificationinternal.FindNotificationsForUserRequest) (*notificationinternal.FindNotificationsForUserResponse, error) {
-	userID, err := uuid.Parse(request.UserID)
+	userID, err := parseUUID("userID", request.UserID)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid user id")
+		return nil, err
 	}
 
 	notifications, err := a.queryService.FindForUser(ctx, userID)
@@ -49,3 +49,13 @@ func (a *notificationInternalAPI) FindNotificationsForUser(ctx context.Context,
 		Notifications: responseNotifications,
 	}, nil
 }
+
+func (a *notificationInternalAPI) FindAuditLog(ctx context.Context, request *notificationinternal.FindAuditLogRequest) (*notificationinternal.FindAuditLogResponse, error) {
+	records, err := a.auditLogQueryService.FindAuditLog(ctx, request.EntityType, request.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return &notificationinternal.FindAuditLogResponse{
+		Records: toAuditRecords(records),
+	}, nil
+}
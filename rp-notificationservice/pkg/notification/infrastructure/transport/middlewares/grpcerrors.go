@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"notificationservice/pkg/notification/domain/model"
+)
+
+const ErrorDomain = "notificationservice"
+
+type domainError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var domainErrors = []domainError{
+	{err: model.ErrNotificationNotFound, code: codes.NotFound, reason: "NOTIFICATION_NOT_FOUND"},
+}
+
+func NewGRPCErrorsMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(err)
+		}
+		return resp, nil
+	}
+}
+
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range domainErrors {
+		if errors.Is(err, e.err) {
+			return newStatusError(e.code, e.reason, e.err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		// детали внутренних ошибок наружу не отдаем, они есть в логах
+		return newStatusError(codes.Internal, "INTERNAL", "internal error")
+	}
+}
+
+func newStatusError(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"notificationservice/pkg/notification/domain/model"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{name: "notification_not_found", err: model.ErrNotificationNotFound, code: codes.NotFound, reason: "NOTIFICATION_NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// сервисы и репозитории оборачивают доменные ошибки, сопоставление должно видеть их через обертку
+			st := status.Convert(toStatusError(errors.Wrap(tt.err, "store")))
+			assert.Equal(t, tt.code, st.Code())
+			assert.Equal(t, tt.err.Error(), st.Message())
+			assertErrorInfo(t, st, tt.reason)
+		})
+	}
+}
+
+func TestToStatusError_AllDomainErrors(t *testing.T) {
+	reasons := make(map[string]bool)
+	for _, e := range domainErrors {
+		assert.False(t, reasons[e.reason], "duplicate reason %s", e.reason)
+		reasons[e.reason] = true
+
+		st := status.Convert(toStatusError(errors.WithStack(e.err)))
+		assert.Equal(t, e.code, st.Code(), e.reason)
+		assertErrorInfo(t, st, e.reason)
+	}
+}
+
+func TestToStatusError_NonDomainErrors(t *testing.T) {
+	t.Run("status_passthrough", func(t *testing.T) {
+		err := status.Error(codes.Unavailable, "downstream unavailable")
+		assert.Equal(t, err, toStatusError(err))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		st := status.Convert(toStatusError(errors.WithStack(context.Canceled)))
+		assert.Equal(t, codes.Canceled, st.Code())
+	})
+
+	t.Run("deadline_exceeded", func(t *testing.T) {
+		st := status.Convert(toStatusError(errors.WithStack(context.DeadlineExceeded)))
+		assert.Equal(t, codes.DeadlineExceeded, st.Code())
+	})
+
+	t.Run("internal_hides_details", func(t *testing.T) {
+		st := status.Convert(toStatusError(errors.New("dial tcp 10.0.0.1:3306: connection refused")))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.Equal(t, "internal error", st.Message())
+		assertErrorInfo(t, st, "INTERNAL")
+	})
+}
+
+func assertErrorInfo(t *testing.T, st *status.Status, reason string) {
+	t.Helper()
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, reason, info.Reason)
+	assert.Equal(t, ErrorDomain, info.Domain)
+}
@@ -0,0 +1,128 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"notificationservice/api/server/notificationinternal"
+)
+
+func TestValidateString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		rules *notificationinternal.FieldRules
+		want  []string
+	}{
+		{name: "required_empty", value: "", rules: &notificationinternal.FieldRules{Required: true}, want: []string{"is required"}},
+		{name: "required_set", value: "x", rules: &notificationinternal.FieldRules{Required: true}},
+		{name: "optional_empty_skips_rules", value: "", rules: &notificationinternal.FieldRules{Uuid: true, MinLen: 3}},
+		{name: "uuid_valid", value: "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11", rules: &notificationinternal.FieldRules{Uuid: true}},
+		{name: "uuid_invalid", value: "not-a-uuid", rules: &notificationinternal.FieldRules{Uuid: true}, want: []string{"must be a valid UUID"}},
+		{name: "min_len", value: "ab", rules: &notificationinternal.FieldRules{MinLen: 3}, want: []string{"must be at least 3 characters"}},
+		// длина считается в символах, а не в байтах
+		{name: "min_len_runes", value: "абв", rules: &notificationinternal.FieldRules{MinLen: 3, MaxLen: 3}},
+		{name: "max_len", value: "abcd", rules: &notificationinternal.FieldRules{MaxLen: 3}, want: []string{"must be at most 3 characters"}},
+		{name: "email_valid", value: "user@example.com", rules: &notificationinternal.FieldRules{Email: true}},
+		{name: "email_invalid", value: "user.example.com", rules: &notificationinternal.FieldRules{Email: true}, want: []string{"must be a valid email address"}},
+		{name: "email_display_name", value: "User <user@example.com>", rules: &notificationinternal.FieldRules{Email: true}, want: []string{"must be a valid email address"}},
+		{
+			name:  "several_violations",
+			value: "x",
+			rules: &notificationinternal.FieldRules{MinLen: 3, Uuid: true},
+			want:  []string{"must be at least 3 characters", "must be a valid UUID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateString(tt.value, tt.rules))
+		})
+	}
+}
+
+func TestValidateInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		rules *notificationinternal.FieldRules
+		want  []string
+	}{
+		{name: "gt_equal", value: 0, rules: &notificationinternal.FieldRules{Gt: proto.Int64(0)}, want: []string{"must be greater than 0"}},
+		{name: "gt_greater", value: 1, rules: &notificationinternal.FieldRules{Gt: proto.Int64(0)}},
+		{name: "gte_equal", value: 0, rules: &notificationinternal.FieldRules{Gte: proto.Int64(0)}},
+		{name: "gte_less", value: -1, rules: &notificationinternal.FieldRules{Gte: proto.Int64(0)}, want: []string{"must be greater than or equal to 0"}},
+		// нулевая граница задается явно, поэтому отсутствие правила и gt = 0 различаются
+		{name: "no_rules", value: -100, rules: &notificationinternal.FieldRules{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateInt(tt.value, tt.rules))
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	t.Run("required", func(t *testing.T) {
+		violations := validateMessage((&notificationinternal.FindNotificationsForUserRequest{}).ProtoReflect(), "")
+		assert.Equal(t, map[string]string{"userID": "is required"}, violationMap(violations))
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		request := &notificationinternal.FindNotificationsForUserRequest{UserID: "bad"}
+		violations := validateMessage(request.ProtoReflect(), "")
+		assert.Equal(t, map[string]string{"userID": "must be a valid UUID"}, violationMap(violations))
+	})
+
+	t.Run("required_strings", func(t *testing.T) {
+		violations := validateMessage((&notificationinternal.FindAuditLogRequest{}).ProtoReflect(), "")
+		assert.Equal(t, map[string]string{
+			"entityType": "is required",
+			"entityID":   "is required",
+		}, violationMap(violations))
+	})
+}
+
+func TestGRPCValidationMiddleware(t *testing.T) {
+	interceptor := NewGRPCValidationMiddleware()
+	handlerCalled := false
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		handlerCalled = true
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), &notificationinternal.FindNotificationsForUserRequest{UserID: "1"}, nil, handler)
+	assert.False(t, handlerCalled)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+	require.NotNil(t, badRequest)
+	assert.NotEmpty(t, badRequest.FieldViolations)
+
+	_, err = interceptor(context.Background(), &notificationinternal.FindNotificationsForUserRequest{UserID: "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11"}, nil, handler)
+	assert.NoError(t, err)
+	assert.True(t, handlerCalled)
+}
+
+func violationMap(violations []*errdetails.BadRequest_FieldViolation) map[string]string {
+	result := make(map[string]string, len(violations))
+	for _, v := range violations {
+		if previous, ok := result[v.Field]; ok {
+			result[v.Field] = previous + "; " + v.Description
+			continue
+		}
+		result[v.Field] = v.Description
+	}
+	return result
+}
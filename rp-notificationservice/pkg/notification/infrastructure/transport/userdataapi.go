@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"notificationservice/api/server/userdatainternal"
+	"notificationservice/pkg/notification/application/query"
+	"notificationservice/pkg/notification/application/service"
+)
+
+const userDataServiceName = "notificationservice"
+
+func NewUserDataInternalAPI(
+	queryService query.NotificationQueryService,
+	userDataService service.UserDataService,
+) userdatainternal.UserDataInternalServiceServer {
+	return &userDataInternalAPI{
+		queryService:    queryService,
+		userDataService: userDataService,
+	}
+}
+
+type userDataInternalAPI struct {
+	queryService    query.NotificationQueryService
+	userDataService service.UserDataService
+	userdatainternal.UnimplementedUserDataInternalServiceServer
+}
+
+type exportedNotification struct {
+	NotificationID string `json:"notification_id"`
+	OrderID        string `json:"order_id"`
+	Message        string `json:"message"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type exportedUserData struct {
+	Notifications []exportedNotification `json:"notifications"`
+}
+
+func (a *userDataInternalAPI) ExportUserData(ctx context.Context, request *userdatainternal.ExportUserDataRequest) (*userdatainternal.ExportUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	notifications, err := a.queryService.FindForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedUserData{
+		Notifications: make([]exportedNotification, len(notifications)),
+	}
+	for i, n := range notifications {
+		exported.Notifications[i] = exportedNotification{
+			NotificationID: n.NotificationID.String(),
+			OrderID:        n.OrderID.String(),
+			Message:        n.Message,
+			CreatedAt:      n.CreatedAt,
+		}
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &userdatainternal.ExportUserDataResponse{
+		Service: userDataServiceName,
+		Data:    string(data),
+	}, nil
+}
+
+func (a *userDataInternalAPI) EraseUserData(ctx context.Context, request *userdatainternal.EraseUserDataRequest) (*userdatainternal.EraseUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.userDataService.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &userdatainternal.EraseUserDataResponse{
+		Service:  userDataServiceName,
+		ErasedAt: time.Now().Unix(),
+	}, nil
+}
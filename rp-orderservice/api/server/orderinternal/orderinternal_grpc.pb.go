@@ -24,6 +24,13 @@ const _ = grpc.SupportPackageIsVersion7
 type OrderInternalServiceClient interface {
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
 	FindOrder(ctx context.Context, in *FindOrderRequest, opts ...grpc.CallOption) (*FindOrderResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
+	// SetExchangeRate задает курс пересчета цен товаров в валюту заказа. Только для администраторов
+	SetExchangeRate(ctx context.Context, in *SetExchangeRateRequest, opts ...grpc.CallOption) (*SetExchangeRateResponse, error)
+	ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error)
+	// StorePromotion создает акцию с промокодом, если promotionID пустой, иначе меняет ее условия. Только для администраторов
+	StorePromotion(ctx context.Context, in *StorePromotionRequest, opts ...grpc.CallOption) (*StorePromotionResponse, error)
+	ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error)
 }
 
 type orderInternalServiceClient struct {
@@ -52,12 +59,64 @@ func (c *orderInternalServiceClient) FindOrder(ctx context.Context, in *FindOrde
 	return out, nil
 }
 
+func (c *orderInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) SetExchangeRate(ctx context.Context, in *SetExchangeRateRequest, opts ...grpc.CallOption) (*SetExchangeRateResponse, error) {
+	out := new(SetExchangeRateResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/SetExchangeRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) ListExchangeRates(ctx context.Context, in *ListExchangeRatesRequest, opts ...grpc.CallOption) (*ListExchangeRatesResponse, error) {
+	out := new(ListExchangeRatesResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/ListExchangeRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) StorePromotion(ctx context.Context, in *StorePromotionRequest, opts ...grpc.CallOption) (*StorePromotionResponse, error) {
+	out := new(StorePromotionResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/StorePromotion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderInternalServiceClient) ListPromotions(ctx context.Context, in *ListPromotionsRequest, opts ...grpc.CallOption) (*ListPromotionsResponse, error) {
+	out := new(ListPromotionsResponse)
+	err := c.cc.Invoke(ctx, "/Order.OrderInternalService/ListPromotions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderInternalServiceServer is the server API for OrderInternalService service.
 // All implementations must embed UnimplementedOrderInternalServiceServer
 // for forward compatibility
 type OrderInternalServiceServer interface {
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 	FindOrder(context.Context, *FindOrderRequest) (*FindOrderResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
+	// SetExchangeRate задает курс пересчета цен товаров в валюту заказа. Только для администраторов
+	SetExchangeRate(context.Context, *SetExchangeRateRequest) (*SetExchangeRateResponse, error)
+	ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error)
+	// StorePromotion создает акцию с промокодом, если promotionID пустой, иначе меняет ее условия. Только для администраторов
+	StorePromotion(context.Context, *StorePromotionRequest) (*StorePromotionResponse, error)
+	ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error)
 	mustEmbedUnimplementedOrderInternalServiceServer()
 }
 
@@ -71,6 +130,21 @@ func (UnimplementedOrderInternalServiceServer) CreateOrder(context.Context, *Cre
 func (UnimplementedOrderInternalServiceServer) FindOrder(context.Context, *FindOrderRequest) (*FindOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindOrder not implemented")
 }
+func (UnimplementedOrderInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) SetExchangeRate(context.Context, *SetExchangeRateRequest) (*SetExchangeRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExchangeRate not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) ListExchangeRates(context.Context, *ListExchangeRatesRequest) (*ListExchangeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExchangeRates not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) StorePromotion(context.Context, *StorePromotionRequest) (*StorePromotionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StorePromotion not implemented")
+}
+func (UnimplementedOrderInternalServiceServer) ListPromotions(context.Context, *ListPromotionsRequest) (*ListPromotionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromotions not implemented")
+}
 func (UnimplementedOrderInternalServiceServer) mustEmbedUnimplementedOrderInternalServiceServer() {}
 
 // UnsafeOrderInternalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +194,96 @@ func _OrderInternalService_FindOrder_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_SetExchangeRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetExchangeRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).SetExchangeRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/SetExchangeRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).SetExchangeRate(ctx, req.(*SetExchangeRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_ListExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).ListExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/ListExchangeRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).ListExchangeRates(ctx, req.(*ListExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_StorePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).StorePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/StorePromotion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).StorePromotion(ctx, req.(*StorePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderInternalService_ListPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderInternalServiceServer).ListPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Order.OrderInternalService/ListPromotions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderInternalServiceServer).ListPromotions(ctx, req.(*ListPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderInternalService_ServiceDesc is the grpc.ServiceDesc for OrderInternalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +299,26 @@ var OrderInternalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindOrder",
 			Handler:    _OrderInternalService_FindOrder_Handler,
 		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _OrderInternalService_FindAuditLog_Handler,
+		},
+		{
+			MethodName: "SetExchangeRate",
+			Handler:    _OrderInternalService_SetExchangeRate_Handler,
+		},
+		{
+			MethodName: "ListExchangeRates",
+			Handler:    _OrderInternalService_ListExchangeRates_Handler,
+		},
+		{
+			MethodName: "StorePromotion",
+			Handler:    _OrderInternalService_StorePromotion_Handler,
+		},
+		{
+			MethodName: "ListPromotions",
+			Handler:    _OrderInternalService_ListPromotions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/server/orderinternal/orderinternal.proto",
@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.6
+// source: api/server/userdatainternal/userdatainternal.proto
+
+package userdatainternal
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// UserDataInternalServiceClient is the client API for UserDataInternalService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserDataInternalServiceClient interface {
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+}
+
+type userDataInternalServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserDataInternalServiceClient(cc grpc.ClientConnInterface) UserDataInternalServiceClient {
+	return &userDataInternalServiceClient{cc}
+}
+
+func (c *userDataInternalServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/UserData.UserDataInternalService/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userDataInternalServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, "/UserData.UserDataInternalService/EraseUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UserDataInternalServiceServer is the server API for UserDataInternalService service.
+// All implementations must embed UnimplementedUserDataInternalServiceServer
+// for forward compatibility
+type UserDataInternalServiceServer interface {
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	mustEmbedUnimplementedUserDataInternalServiceServer()
+}
+
+// UnimplementedUserDataInternalServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserDataInternalServiceServer struct {
+}
+
+func (UnimplementedUserDataInternalServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedUserDataInternalServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedUserDataInternalServiceServer) mustEmbedUnimplementedUserDataInternalServiceServer() {
+}
+
+// UnsafeUserDataInternalServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserDataInternalServiceServer will
+// result in compilation errors.
+type UnsafeUserDataInternalServiceServer interface {
+	mustEmbedUnimplementedUserDataInternalServiceServer()
+}
+
+func RegisterUserDataInternalServiceServer(s grpc.ServiceRegistrar, srv UserDataInternalServiceServer) {
+	s.RegisterService(&UserDataInternalService_ServiceDesc, srv)
+}
+
+func _UserDataInternalService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDataInternalServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserData.UserDataInternalService/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDataInternalServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserDataInternalService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserDataInternalServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/UserData.UserDataInternalService/EraseUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserDataInternalServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserDataInternalService_ServiceDesc is the grpc.ServiceDesc for UserDataInternalService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserDataInternalService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "UserData.UserDataInternalService",
+	HandlerType: (*UserDataInternalServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ExportUserData",
+			Handler:    _UserDataInternalService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _UserDataInternalService_EraseUserData_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/server/userdatainternal/userdatainternal.proto",
+}
@@ -19,6 +19,18 @@ type Service struct {
 	HTTPAddress string        `envconfig:"HTTP_ADDRESS" default:":8082"`
 }
 
+// RateLimit - лимиты в запросах в секунду, 0 - без ограничений.
+// METHOD_* и CALLER_* задаются списком вида "CreateOrder:5,FindOrder:50"
+type RateLimit struct {
+	Mode         string             `envconfig:"MODE" default:"memory"`
+	DefaultRate  float64            `envconfig:"DEFAULT_RATE" default:"0"`
+	DefaultBurst int                `envconfig:"DEFAULT_BURST" default:"0"`
+	MethodRates  map[string]float64 `envconfig:"METHOD_RATES"`
+	MethodBursts map[string]int     `envconfig:"METHOD_BURSTS"`
+	CallerRates  map[string]float64 `envconfig:"CALLER_RATES"`
+	CallerBursts map[string]int     `envconfig:"CALLER_BURSTS"`
+}
+
 type Database struct {
 	User                  string        `envconfig:"USER" required:"true"`
 	Password              string        `envconfig:"PASSWORD" required:"true"`
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"math"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"orderservice/pkg/order/infrastructure/ratelimit"
+	"orderservice/pkg/order/infrastructure/transport/middlewares"
+)
+
+const (
+	rateLimitModeMemory = "memory"
+	rateLimitModeMySQL  = "mysql"
+)
+
+func newRateLimiter(config RateLimit, uow mysql.UnitOfWork) (ratelimit.Limiter, error) {
+	switch config.Mode {
+	case rateLimitModeMemory:
+		return ratelimit.NewMemoryLimiter(), nil
+	case rateLimitModeMySQL:
+		return ratelimit.NewMySQLLimiter(uow), nil
+	default:
+		return nil, errors.Errorf("unknown rate limit mode %q", config.Mode)
+	}
+}
+
+func rateLimits(config RateLimit) middlewares.RateLimits {
+	limits := middlewares.RateLimits{
+		Default: newLimit(config.DefaultRate, config.DefaultBurst),
+		Methods: make(map[string]ratelimit.Limit, len(config.MethodRates)),
+		Callers: make(map[string]ratelimit.Limit, len(config.CallerRates)),
+	}
+	for method, rate := range config.MethodRates {
+		limits.Methods[method] = newLimit(rate, config.MethodBursts[method])
+	}
+	for caller, rate := range config.CallerRates {
+		limits.Callers[caller] = newLimit(rate, config.CallerBursts[caller])
+	}
+	return limits
+}
+
+// если burst не задан, разрешаем всплеск в размере секундного лимита
+func newLimit(rate float64, burst int) ratelimit.Limit {
+	if burst <= 0 {
+		burst = max(1, int(math.Ceil(rate)))
+	}
+	return ratelimit.Limit{
+		Rate:  rate,
+		Burst: burst,
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"orderservice/api/server/orderinternal"
+	"orderservice/api/server/userdatainternal"
 	appservice "orderservice/pkg/order/application/service"
 	"orderservice/pkg/order/infrastructure/integrationevent"
 	inframysql "orderservice/pkg/order/infrastructure/mysql"
@@ -27,8 +28,9 @@ import (
 )
 
 type serviceConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
+	Service   Service   `envconfig:"service"`
+	RateLimit RateLimit `envconfig:"rate_limit"`
+	Database  Database  `envconfig:"database" required:"true"`
 }
 
 func service(logger logging.Logger) *cli.Command {
@@ -57,11 +59,25 @@ func service(logger logging.Logger) *cli.Command {
 			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
 			uow := inframysql.NewUnitOfWork(libUoW)
 			luow := inframysql.NewLockableUnitOfWork(libLUow)
+			rateLimiter, err := newRateLimiter(cnf.RateLimit, libUoW)
+			if err != nil {
+				return err
+			}
 			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
 
 			orderInternalAPI := transport.NewOrderInternalAPI(
 				query.NewOrderQueryService(databaseConnector.TransactionalClient()),
+				query.NewAuditLogQueryService(databaseConnector.TransactionalClient()),
+				query.NewExchangeRateQueryService(databaseConnector.TransactionalClient()),
+				query.NewPromotionQueryService(databaseConnector.TransactionalClient()),
 				appservice.NewOrderService(uow, luow, eventDispatcher),
+				appservice.NewExchangeRateService(uow, eventDispatcher),
+				appservice.NewPromotionService(luow),
+			)
+			userAccessService := appservice.NewUserAccessService(uow, luow)
+			userDataInternalAPI := transport.NewUserDataInternalAPI(
+				query.NewUserDataQueryService(databaseConnector.TransactionalClient()),
+				appservice.NewUserDataService(uow),
 			)
 
 			errGroup := errgroup.Group{}
@@ -71,9 +87,15 @@ func service(logger logging.Logger) *cli.Command {
 					return err
 				}
 				grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+					middlewares.NewGRPCErrorsMiddleware(),
 					middlewares.NewGRPCLoggingMiddleware(logger),
+					middlewares.NewGRPCRateLimitMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+					middlewares.NewGRPCAuthorizationMiddleware(userAccessService, transport.MethodPermissions),
+					middlewares.NewGRPCValidationMiddleware(),
+					middlewares.NewGRPCAuditMiddleware(),
 				))
 				orderinternal.RegisterOrderInternalServiceServer(grpcServer, orderInternalAPI)
+				userdatainternal.RegisterUserDataInternalServiceServer(grpcServer, userDataInternalAPI)
 				reflection.Register(grpcServer)
 				graceCallback(c.Context, logger, cnf.Service.GracePeriod, func(_ context.Context) error {
 					grpcServer.GracefulStop()
@@ -3,20 +3,58 @@ package model
 import "github.com/google/uuid"
 
 type OrderItem struct {
-	ProductID uuid.UUID
-	Quantity  int
+	ProductID        uuid.UUID
+	VariantID        *uuid.UUID
+	SKU              string
+	Quantity         int
+	Price            int64
+	OriginalPrice    int64
+	OriginalCurrency string
+	ExchangeRate     int64
+	Discount         int64
 }
 
 type CreateOrder struct {
 	UserID uuid.UUID
-	Items  []OrderItem
+	// Currency - валюта заказа, пустая - DefaultCurrency
+	Currency  string
+	PromoCode string
+	Items     []OrderItem
 }
 
 type Order struct {
-	OrderID    uuid.UUID
-	UserID     uuid.UUID
-	Items      []OrderItem
-	TotalPrice int64
-	Status     int
-	CreatedAt  int64
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	Items         []OrderItem
+	Currency      string
+	PromoCode     string
+	DiscountTotal int64
+	TotalPrice    int64
+	Status        int
+	CreatedAt     int64
+}
+
+type ExchangeRate struct {
+	From      string
+	To        string
+	Rate      int64
+	UpdatedAt int64
+}
+
+type Promotion struct {
+	PromotionID   uuid.UUID
+	Code          string
+	Type          int
+	Value         int64
+	Currency      string
+	MinOrderValue int64
+	UsageLimit    int
+	PerUserLimit  int
+	UsedCount     int
+	ValidFrom     *int64
+	ValidUntil    *int64
+	ProductIDs    []uuid.UUID
+	CategoryIDs   []uuid.UUID
+	CreatedAt     int64
+	UpdatedAt     int64
 }
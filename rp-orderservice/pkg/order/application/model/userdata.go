@@ -0,0 +1,7 @@
+package model
+
+// UserData - все, что orderservice хранит о пользователе
+type UserData struct {
+	Login  *string
+	Orders []Order
+}
@@ -0,0 +1,11 @@
+package query
+
+import (
+	"context"
+
+	appmodel "orderservice/pkg/order/application/model"
+)
+
+type ExchangeRateQueryService interface {
+	ListExchangeRates(ctx context.Context) ([]appmodel.ExchangeRate, error)
+}
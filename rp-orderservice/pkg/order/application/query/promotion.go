@@ -0,0 +1,11 @@
+package query
+
+import (
+	"context"
+
+	appmodel "orderservice/pkg/order/application/model"
+)
+
+type PromotionQueryService interface {
+	ListPromotions(ctx context.Context) ([]appmodel.Promotion, error)
+}
@@ -0,0 +1,13 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	appmodel "orderservice/pkg/order/application/model"
+)
+
+type UserDataQueryService interface {
+	FindUserData(ctx context.Context, userID uuid.UUID) (appmodel.UserData, error)
+}
@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"orderservice/pkg/order/domain/model"
 )
 
 type DataSyncService interface {
 	SyncUser(ctx context.Context, user model.LocalUser) error
 	SyncProduct(ctx context.Context, product model.LocalProduct) error
+	SetProductArchived(ctx context.Context, productID uuid.UUID, archived bool) error
+	SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error
 }
 
 func NewDataSyncService(uow UnitOfWork) DataSyncService {
@@ -30,3 +34,15 @@ func (s *dataSyncService) SyncProduct(ctx context.Context, product model.LocalPr
 		return provider.LocalProductRepository(ctx).Store(product)
 	})
 }
+
+func (s *dataSyncService) SetProductArchived(ctx context.Context, productID uuid.UUID, archived bool) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return provider.LocalProductRepository(ctx).SetArchived(productID, archived)
+	})
+}
+
+func (s *dataSyncService) SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return provider.LocalProductRepository(ctx).SetCategories(productID, categoryIDs)
+	})
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+
+	"orderservice/pkg/order/domain/service"
+)
+
+type ExchangeRateService interface {
+	SetExchangeRate(ctx context.Context, from, to string, rate int64) error
+}
+
+func NewExchangeRateService(
+	uow UnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) ExchangeRateService {
+	return &exchangeRateService{
+		uow:             uow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type exchangeRateService struct {
+	uow             UnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *exchangeRateService) SetExchangeRate(ctx context.Context, from, to string, rate int64) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		domainService := service.NewExchangeRateService(provider.ExchangeRateRepository(ctx), &domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		})
+		return domainService.SetExchangeRate(from, to, rate)
+	})
+}
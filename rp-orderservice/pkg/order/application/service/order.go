@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
 	"github.com/google/uuid"
@@ -39,53 +40,57 @@ type orderService struct {
 
 func (s *orderService) CreateOrder(ctx context.Context, order appmodel.CreateOrder) (uuid.UUID, error) {
 	var orderID uuid.UUID
+	f := func(provider RepositoryProvider) error {
+		id, err := s.createOrder(ctx, provider, order)
+		orderID = id
+		return err
+	}
 
-	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
-		userRepo := provider.LocalUserRepository(ctx)
-		productRepo := provider.LocalProductRepository(ctx)
+	// Заказы с одним кодом создаются по очереди, иначе проверка лимита на пользователя допускает гонку
+	if code := model.NormalizePromoCode(order.PromoCode); code != "" {
+		return orderID, s.luow.Execute(ctx, []string{promotionLock(code)}, f)
+	}
+	return orderID, s.uow.Execute(ctx, f)
+}
 
-		if _, err := userRepo.Find(order.UserID); err != nil {
-			return errors.Wrap(model.ErrUserNotFound, err.Error())
-		}
+func (s *orderService) createOrder(ctx context.Context, provider RepositoryProvider, order appmodel.CreateOrder) (uuid.UUID, error) {
+	userRepo := provider.LocalUserRepository(ctx)
+	productRepo := provider.LocalProductRepository(ctx)
 
-		productIDs := make([]uuid.UUID, len(order.Items))
-		for i, item := range order.Items {
-			productIDs[i] = item.ProductID
-		}
+	if _, err := userRepo.Find(order.UserID); err != nil {
+		return uuid.Nil, errors.Wrap(model.ErrUserNotFound, err.Error())
+	}
 
-		products, err := productRepo.FindMany(productIDs)
-		if err != nil {
-			return err
-		}
-		if len(products) != len(order.Items) {
-			return model.ErrProductNotFound
+	var productIDs []uuid.UUID
+	for _, item := range order.Items {
+		if !slices.Contains(productIDs, item.ProductID) {
+			productIDs = append(productIDs, item.ProductID)
 		}
+	}
 
-		productMap := make(map[uuid.UUID]model.LocalProduct, len(products))
-		for _, p := range products {
-			productMap[p.ProductID] = p
-		}
+	products, err := productRepo.FindMany(productIDs)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(products) != len(productIDs) {
+		return uuid.Nil, model.ErrProductNotFound
+	}
 
-		domainItems := make([]model.OrderItem, len(order.Items))
-		for i, item := range order.Items {
-			product := productMap[item.ProductID]
-			domainItems[i] = model.OrderItem{
-				ProductID: item.ProductID,
-				Quantity:  item.Quantity,
-				Price:     product.Price,
-			}
-		}
+	productMap := make(map[uuid.UUID]model.LocalProduct, len(products))
+	for _, p := range products {
+		productMap[p.ProductID] = p
+	}
 
-		domainService := s.domainService(ctx, provider)
-		id, err := domainService.CreateOrder(order.UserID, domainItems)
+	domainItems := make([]model.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		domainItems[i], err = orderItem(productMap[item.ProductID], item)
 		if err != nil {
-			return err
+			return uuid.Nil, err
 		}
-		orderID = id
-		return nil
-	})
+	}
 
-	return orderID, err
+	domainService := s.domainService(ctx, provider)
+	return domainService.CreateOrder(order.UserID, order.Currency, order.PromoCode, domainItems)
 }
 
 func (s *orderService) HandlePaymentResult(ctx context.Context, orderID uuid.UUID, success bool) error {
@@ -100,7 +105,13 @@ func (s *orderService) HandlePaymentResult(ctx context.Context, orderID uuid.UUI
 }
 
 func (s *orderService) domainService(ctx context.Context, provider RepositoryProvider) service.OrderService {
-	return service.NewOrderService(provider.OrderRepository(ctx), s.domainEventDispatcher(ctx))
+	return service.NewOrderService(
+		provider.OrderRepository(ctx),
+		provider.ExchangeRateRepository(ctx),
+		provider.PromotionRepository(ctx),
+		provider.LocalProductRepository(ctx),
+		s.domainEventDispatcher(ctx),
+	)
 }
 
 func (s *orderService) domainEventDispatcher(ctx context.Context) domain.EventDispatcher {
@@ -110,8 +121,52 @@ func (s *orderService) domainEventDispatcher(ctx context.Context) domain.EventDi
 	}
 }
 
+// orderItem фиксирует цену в валюте товара и SKU на момент заказа. У товара с вариантами покупается конкретный вариант
+func orderItem(product model.LocalProduct, item appmodel.OrderItem) (model.OrderItem, error) {
+	if product.Archived {
+		return model.OrderItem{}, model.ErrProductArchived
+	}
+	if len(product.Variants) == 0 {
+		if item.VariantID != nil {
+			return model.OrderItem{}, model.ErrVariantNotFound
+		}
+		return model.OrderItem{
+			ProductID:        item.ProductID,
+			Quantity:         item.Quantity,
+			OriginalPrice:    product.Price,
+			OriginalCurrency: product.Currency,
+		}, nil
+	}
+
+	if item.VariantID == nil {
+		return model.OrderItem{}, model.ErrVariantRequired
+	}
+	i := slices.IndexFunc(product.Variants, func(v model.LocalVariant) bool {
+		return v.VariantID == *item.VariantID
+	})
+	if i < 0 {
+		return model.OrderItem{}, model.ErrVariantNotFound
+	}
+	variant := product.Variants[i]
+	return model.OrderItem{
+		ProductID:        item.ProductID,
+		VariantID:        &variant.VariantID,
+		SKU:              variant.SKU,
+		Quantity:         item.Quantity,
+		OriginalPrice:    variant.Price,
+		OriginalCurrency: product.Currency,
+	}, nil
+}
+
 const baseOrderLock = "order_"
 
 func orderLock(id uuid.UUID) string {
 	return fmt.Sprintf("%s%s", baseOrderLock, id.String())
 }
+
+const basePromotionLock = "promotion_"
+
+// promotionLock берется по нормализованному коду: и при создании заказа, и при изменении акции
+func promotionLock(code string) string {
+	return fmt.Sprintf("%s%s", basePromotionLock, code)
+}
@@ -33,6 +33,21 @@ func (m *MockRepositoryProvider) LocalProductRepository(ctx context.Context) dom
 	return args.Get(0).(domainmodel.LocalProductRepository)
 }
 
+func (m *MockRepositoryProvider) ExchangeRateRepository(ctx context.Context) domainmodel.ExchangeRateRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.ExchangeRateRepository)
+}
+
+func (m *MockRepositoryProvider) PromotionRepository(ctx context.Context) domainmodel.PromotionRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.PromotionRepository)
+}
+
+func (m *MockRepositoryProvider) UserAccessRepository(ctx context.Context) domainmodel.UserAccessRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.UserAccessRepository)
+}
+
 type MockLockableUnitOfWork struct {
 	mock.Mock
 }
@@ -56,6 +71,7 @@ type StubLocalUserRepo struct {
 }
 
 func (m *StubLocalUserRepo) Store(_ domainmodel.LocalUser) error { return nil }
+func (m *StubLocalUserRepo) Delete(_ uuid.UUID) error            { return nil }
 func (m *StubLocalUserRepo) Find(id uuid.UUID) (*domainmodel.LocalUser, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -70,6 +86,8 @@ type StubLocalProductRepo struct {
 
 func (m *StubLocalProductRepo) Store(_ domainmodel.LocalProduct) error              { return nil }
 func (m *StubLocalProductRepo) Find(_ uuid.UUID) (*domainmodel.LocalProduct, error) { return nil, nil }
+func (m *StubLocalProductRepo) SetArchived(_ uuid.UUID, _ bool) error               { return nil }
+func (m *StubLocalProductRepo) SetCategories(_ uuid.UUID, _ []uuid.UUID) error      { return nil }
 func (m *StubLocalProductRepo) FindMany(ids []uuid.UUID) ([]domainmodel.LocalProduct, error) {
 	args := m.Called(ids)
 	if args.Get(0) == nil {
@@ -78,6 +96,43 @@ func (m *StubLocalProductRepo) FindMany(ids []uuid.UUID) ([]domainmodel.LocalPro
 	return args.Get(0).([]domainmodel.LocalProduct), args.Error(1)
 }
 
+func (m *StubLocalProductRepo) ReserveStock(_ uuid.UUID, _ []domainmodel.OrderItem) error { return nil }
+func (m *StubLocalProductRepo) ReleaseStock(_ uuid.UUID) error                            { return nil }
+func (m *StubLocalProductRepo) CommitStock(_ uuid.UUID) error                             { return nil }
+
+type StubExchangeRateRepo struct {
+	mock.Mock
+}
+
+func (m *StubExchangeRateRepo) Store(_ domainmodel.ExchangeRate) error { return nil }
+func (m *StubExchangeRateRepo) Find(from, to string) (*domainmodel.ExchangeRate, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.ExchangeRate), args.Error(1)
+}
+
+type StubPromotionRepo struct {
+	mock.Mock
+}
+
+func (m *StubPromotionRepo) NextID() (uuid.UUID, error)          { return uuid.Nil, nil }
+func (m *StubPromotionRepo) Store(_ domainmodel.Promotion) error { return nil }
+func (m *StubPromotionRepo) Find(spec domainmodel.PromotionFindSpec) (*domainmodel.Promotion, error) {
+	args := m.Called(spec)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.Promotion), args.Error(1)
+}
+func (m *StubPromotionRepo) CountUserRedemptions(_, _ uuid.UUID) (int, error) { return 0, nil }
+func (m *StubPromotionRepo) Redeem(r domainmodel.PromotionRedemption) error {
+	args := m.Called(r)
+	return args.Error(0)
+}
+func (m *StubPromotionRepo) Release(_ uuid.UUID) error { return nil }
+
 type StubOrderRepo struct {
 	mock.Mock
 }
@@ -111,6 +166,8 @@ func TestOrderAppService_CreateOrder(t *testing.T) {
 		provider.On("LocalUserRepository", mock.Anything).Return(userRepo)
 		provider.On("LocalProductRepository", mock.Anything).Return(prodRepo)
 		provider.On("OrderRepository", mock.Anything).Return(orderRepo)
+		provider.On("ExchangeRateRepository", mock.Anything).Return(new(StubExchangeRateRepo))
+		provider.On("PromotionRepository", mock.Anything).Return(new(StubPromotionRepo))
 
 		userRepo.On("Find", userID).Return(&domainmodel.LocalUser{UserID: userID}, nil)
 
@@ -133,6 +190,136 @@ func TestOrderAppService_CreateOrder(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, orderID, id)
 	})
+
+	t.Run("promo_code_locks_promotion", func(t *testing.T) {
+		luow.On("Execute", mock.Anything, []string{"promotion_SPRING"}, mock.Anything).Return(nil).Once()
+		service := NewOrderService(uow, luow, &DummyDispatcher{})
+
+		_, err := service.CreateOrder(context.Background(), model.CreateOrder{
+			UserID:    userID,
+			PromoCode: " spring ",
+			Items:     []model.OrderItem{{ProductID: productID, Quantity: 1}},
+		})
+		assert.NoError(t, err)
+		luow.AssertExpectations(t)
+	})
+}
+
+func TestOrderAppService_CreateOrder_Variants(t *testing.T) {
+	userID := uuid.New()
+	productID := uuid.New()
+	sizeM := uuid.New()
+	sizeL := uuid.New()
+	product := domainmodel.LocalProduct{
+		ProductID: productID,
+		Price:     100,
+		Variants: []domainmodel.LocalVariant{
+			{VariantID: sizeM, SKU: "TSHIRT-M", Price: 150},
+			{VariantID: sizeL, SKU: "TSHIRT-L", Price: 170},
+		},
+	}
+
+	newService := func(orderRepo *StubOrderRepo) OrderService {
+		provider := new(MockRepositoryProvider)
+		userRepo := new(StubLocalUserRepo)
+		prodRepo := new(StubLocalProductRepo)
+		provider.On("LocalUserRepository", mock.Anything).Return(userRepo)
+		provider.On("LocalProductRepository", mock.Anything).Return(prodRepo)
+		provider.On("OrderRepository", mock.Anything).Return(orderRepo)
+		provider.On("ExchangeRateRepository", mock.Anything).Return(new(StubExchangeRateRepo))
+		provider.On("PromotionRepository", mock.Anything).Return(new(StubPromotionRepo))
+		userRepo.On("Find", userID).Return(&domainmodel.LocalUser{UserID: userID}, nil)
+		prodRepo.On("FindMany", []uuid.UUID{productID}).Return([]domainmodel.LocalProduct{product}, nil)
+		return NewOrderService(&MockUnitOfWork{provider: provider}, new(MockLockableUnitOfWork), &DummyDispatcher{})
+	}
+
+	t.Run("captures_variant_price_and_sku", func(t *testing.T) {
+		orderRepo := new(StubOrderRepo)
+		orderRepo.On("NextID").Return(uuid.New(), nil)
+		orderRepo.On("Store", mock.MatchedBy(func(o domainmodel.Order) bool {
+			return len(o.Items) == 2 &&
+				*o.Items[0].VariantID == sizeM && o.Items[0].SKU == "TSHIRT-M" && o.Items[0].Price == 150 &&
+				*o.Items[1].VariantID == sizeL && o.Items[1].Price == 170 &&
+				o.TotalPrice == 150*2+170
+		})).Return(nil)
+
+		_, err := newService(orderRepo).CreateOrder(context.Background(), model.CreateOrder{
+			UserID: userID,
+			Items: []model.OrderItem{
+				{ProductID: productID, VariantID: &sizeM, Quantity: 2},
+				{ProductID: productID, VariantID: &sizeL, Quantity: 1},
+			},
+		})
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("converts_to_order_currency", func(t *testing.T) {
+		rates := new(StubExchangeRateRepo)
+		rates.On("Find", domainmodel.DefaultCurrency, "USD").Return(&domainmodel.ExchangeRate{Rate: 10_800}, nil)
+		provider := new(MockRepositoryProvider)
+		userRepo := new(StubLocalUserRepo)
+		prodRepo := new(StubLocalProductRepo)
+		orderRepo := new(StubOrderRepo)
+		provider.On("LocalUserRepository", mock.Anything).Return(userRepo)
+		provider.On("LocalProductRepository", mock.Anything).Return(prodRepo)
+		provider.On("OrderRepository", mock.Anything).Return(orderRepo)
+		provider.On("ExchangeRateRepository", mock.Anything).Return(rates)
+		provider.On("PromotionRepository", mock.Anything).Return(new(StubPromotionRepo))
+		userRepo.On("Find", userID).Return(&domainmodel.LocalUser{UserID: userID}, nil)
+		prodRepo.On("FindMany", []uuid.UUID{productID}).Return([]domainmodel.LocalProduct{product}, nil)
+		orderRepo.On("NextID").Return(uuid.New(), nil)
+		orderRepo.On("Store", mock.MatchedBy(func(o domainmodel.Order) bool {
+			// 150 коп. по курсу 0.0108 - 1.62 цента, округляется до 2
+			return o.Currency == "USD" && o.Items[0].OriginalPrice == 150 &&
+				o.Items[0].OriginalCurrency == domainmodel.DefaultCurrency && o.Items[0].Price == 2 && o.TotalPrice == 2
+		})).Return(nil)
+		service := NewOrderService(&MockUnitOfWork{provider: provider}, new(MockLockableUnitOfWork), &DummyDispatcher{})
+
+		_, err := service.CreateOrder(context.Background(), model.CreateOrder{
+			UserID:   userID,
+			Currency: "USD",
+			Items:    []model.OrderItem{{ProductID: productID, VariantID: &sizeM, Quantity: 1}},
+		})
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("variant_required", func(t *testing.T) {
+		_, err := newService(new(StubOrderRepo)).CreateOrder(context.Background(), model.CreateOrder{
+			UserID: userID,
+			Items:  []model.OrderItem{{ProductID: productID, Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, domainmodel.ErrVariantRequired)
+	})
+
+	t.Run("archived_product", func(t *testing.T) {
+		provider := new(MockRepositoryProvider)
+		userRepo := new(StubLocalUserRepo)
+		prodRepo := new(StubLocalProductRepo)
+		provider.On("LocalUserRepository", mock.Anything).Return(userRepo)
+		provider.On("LocalProductRepository", mock.Anything).Return(prodRepo)
+		userRepo.On("Find", userID).Return(&domainmodel.LocalUser{UserID: userID}, nil)
+		archived := product
+		archived.Archived = true
+		prodRepo.On("FindMany", []uuid.UUID{productID}).Return([]domainmodel.LocalProduct{archived}, nil)
+		service := NewOrderService(&MockUnitOfWork{provider: provider}, new(MockLockableUnitOfWork), &DummyDispatcher{})
+
+		_, err := service.CreateOrder(context.Background(), model.CreateOrder{
+			UserID: userID,
+			Items:  []model.OrderItem{{ProductID: productID, VariantID: &sizeM, Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, domainmodel.ErrProductArchived)
+	})
+
+	t.Run("unknown_variant", func(t *testing.T) {
+		unknown := uuid.New()
+		_, err := newService(new(StubOrderRepo)).CreateOrder(context.Background(), model.CreateOrder{
+			UserID: userID,
+			Items:  []model.OrderItem{{ProductID: productID, VariantID: &unknown, Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, domainmodel.ErrVariantNotFound)
+	})
 }
 
 type DummyDispatcher struct{}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	appmodel "orderservice/pkg/order/application/model"
+	"orderservice/pkg/order/domain/model"
+	"orderservice/pkg/order/domain/service"
+)
+
+type PromotionService interface {
+	// StorePromotion создает акцию, если PromotionID пустой, иначе обновляет существующую
+	StorePromotion(ctx context.Context, promotion appmodel.Promotion) (uuid.UUID, error)
+}
+
+func NewPromotionService(luow LockableUnitOfWork) PromotionService {
+	return &promotionService{luow: luow}
+}
+
+type promotionService struct {
+	luow LockableUnitOfWork
+}
+
+func (s *promotionService) StorePromotion(ctx context.Context, promotion appmodel.Promotion) (uuid.UUID, error) {
+	domainPromotion := model.Promotion{
+		PromotionID:   promotion.PromotionID,
+		Code:          model.NormalizePromoCode(promotion.Code),
+		Type:          model.PromotionType(promotion.Type),
+		Value:         promotion.Value,
+		Currency:      promotion.Currency,
+		MinOrderValue: promotion.MinOrderValue,
+		UsageLimit:    promotion.UsageLimit,
+		PerUserLimit:  promotion.PerUserLimit,
+		ValidFrom:     unixTime(promotion.ValidFrom),
+		ValidUntil:    unixTime(promotion.ValidUntil),
+		ProductIDs:    promotion.ProductIDs,
+		CategoryIDs:   promotion.CategoryIDs,
+	}
+
+	promotionID := promotion.PromotionID
+	lockName := promotionLock(domainPromotion.Code)
+	err := s.luow.Execute(ctx, []string{lockName}, func(provider RepositoryProvider) error {
+		domainService := service.NewPromotionService(provider.PromotionRepository(ctx))
+		if promotionID != uuid.Nil {
+			return domainService.UpdatePromotion(domainPromotion)
+		}
+		id, err := domainService.CreatePromotion(domainPromotion)
+		promotionID = id
+		return err
+	})
+	return promotionID, err
+}
+
+func unixTime(t *int64) *time.Time {
+	if t == nil {
+		return nil
+	}
+	result := time.Unix(*t, 0)
+	return &result
+}
@@ -10,6 +10,9 @@ type RepositoryProvider interface {
 	OrderRepository(ctx context.Context) model.OrderRepository
 	LocalUserRepository(ctx context.Context) model.LocalUserRepository
 	LocalProductRepository(ctx context.Context) model.LocalProductRepository
+	ExchangeRateRepository(ctx context.Context) model.ExchangeRateRepository
+	PromotionRepository(ctx context.Context) model.PromotionRepository
+	UserAccessRepository(ctx context.Context) model.UserAccessRepository
 }
 
 type LockableUnitOfWork interface {
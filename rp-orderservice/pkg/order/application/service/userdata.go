@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService interface {
+	// EraseUserData удаляет персональные данные пользователя. Заказы остаются для учета,
+	// но после удаления local_user идентификатор в них уже не связан с человеком
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserDataService(uow UnitOfWork) UserDataService {
+	return &userDataService{uow: uow}
+}
+
+type userDataService struct {
+	uow UnitOfWork
+}
+
+func (s *userDataService) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return provider.LocalUserRepository(ctx).Delete(userID)
+	})
+}
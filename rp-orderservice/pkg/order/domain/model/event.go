@@ -7,11 +7,14 @@ import (
 )
 
 type OrderCreated struct {
-	OrderID    uuid.UUID
-	UserID     uuid.UUID
-	TotalPrice int64
-	Items      []OrderItem
-	CreatedAt  time.Time
+	OrderID       uuid.UUID
+	UserID        uuid.UUID
+	Currency      string
+	TotalPrice    int64
+	PromoCode     string
+	DiscountTotal int64
+	Items         []OrderItem
+	CreatedAt     time.Time
 }
 
 func (e OrderCreated) Type() string {
@@ -19,8 +22,12 @@ func (e OrderCreated) Type() string {
 }
 
 type OrderPaid struct {
-	OrderID uuid.UUID
-	PaidAt  time.Time
+	OrderID    uuid.UUID
+	UserID     uuid.UUID
+	Currency   string
+	TotalPrice int64
+	Items      []OrderItem
+	PaidAt     time.Time
 }
 
 func (e OrderPaid) Type() string {
@@ -29,6 +36,7 @@ func (e OrderPaid) Type() string {
 
 type OrderCancelled struct {
 	OrderID     uuid.UUID
+	UserID      uuid.UUID
 	Reason      string
 	CancelledAt time.Time
 }
@@ -36,3 +44,14 @@ type OrderCancelled struct {
 func (e OrderCancelled) Type() string {
 	return "order_cancelled"
 }
+
+type ExchangeRateUpdated struct {
+	From      string
+	To        string
+	Rate      int64
+	UpdatedAt time.Time
+}
+
+func (e ExchangeRateUpdated) Type() string {
+	return "exchange_rate_updated"
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
+var (
+	ErrExchangeRateNotFound = errors.New("exchange rate not found")
+	ErrInvalidExchangeRate  = errors.New("exchange rate must be positive")
+	ErrSameCurrency         = errors.New("exchange rate currencies must differ")
+	ErrPriceOverflow        = errors.New("converted price is out of range")
+)
+
+// RateScale - курс хранится целым числом: сколько единиц To стоит одна единица From, умноженное на RateScale
+const RateScale = 1_000_000
+
+// minorUnits - валюты ISO 4217, у которых в основной единице не 100 минимальных
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// MinorUnits возвращает число знаков после запятой у валюты: суммы хранятся в ее минимальных единицах
+func MinorUnits(currency string) int {
+	if units, ok := minorUnits[currency]; ok {
+		return units
+	}
+	return 2
+}
+
+// ExchangeRate - курс пересчета From в To. Обратный курс хранится отдельной записью
+type ExchangeRate struct {
+	From      string
+	To        string
+	Rate      int64
+	UpdatedAt time.Time
+}
+
+// Convert пересчитывает сумму в минимальных единицах From в минимальные единицы To, половина округляется от нуля
+func (r ExchangeRate) Convert(amount int64) (int64, error) {
+	return convert(amount, r.Rate, MinorUnits(r.From), MinorUnits(r.To))
+}
+
+// convert считает amount * rate / RateScale с поправкой на разницу в числе минимальных единиц валют
+func convert(amount, rate int64, fromUnits, toUnits int) (int64, error) {
+	product := new(big.Int).Mul(big.NewInt(amount), big.NewInt(rate))
+	product.Mul(product, pow10(toUnits))
+	scale := new(big.Int).Mul(big.NewInt(RateScale), pow10(fromUnits))
+	quotient, remainder := new(big.Int).QuoRem(product, scale, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(scale) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	if !quotient.IsInt64() {
+		return 0, ErrPriceOverflow
+	}
+	return quotient.Int64(), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+type ExchangeRateRepository interface {
+	Store(rate ExchangeRate) error
+	Find(from, to string) (*ExchangeRate, error)
+}
@@ -12,16 +12,42 @@ type LocalUser struct {
 type LocalUserRepository interface {
 	Store(user LocalUser) error
 	Find(userID uuid.UUID) (*LocalUser, error)
+	Delete(userID uuid.UUID) error
 }
 
 type LocalProduct struct {
 	ProductID uuid.UUID
 	Name      string
 	Price     int64
+	// Currency - валюта цены товара и его вариантов
+	Currency string
+	// Variants - SKU товара. Если они есть, заказать можно только конкретный вариант
+	Variants []LocalVariant
+	// Archived - товар снят с продажи и не заказывается
+	Archived bool
+	// CategoryIDs - категории, в которые товар добавлен напрямую
+	CategoryIDs []uuid.UUID
+}
+
+type LocalVariant struct {
+	VariantID uuid.UUID
+	SKU       string
+	Price     int64
+	// Stock - остаток в productservice, приходит с событиями товара. Find его не читает:
+	// доступный остаток с учетом резервов проверяет ReserveStock
+	Stock int64
 }
 
 type LocalProductRepository interface {
 	Store(product LocalProduct) error
 	Find(productID uuid.UUID) (*LocalProduct, error)
 	FindMany(productIDs []uuid.UUID) ([]LocalProduct, error)
+	SetArchived(productID uuid.UUID, archived bool) error
+	SetCategories(productID uuid.UUID, categoryIDs []uuid.UUID) error
+	// ReserveStock резервирует остаток вариантов под заказ, при нехватке возвращает ErrOutOfStock
+	ReserveStock(orderID uuid.UUID, items []OrderItem) error
+	// ReleaseStock снимает резерв отмененного заказа
+	ReleaseStock(orderID uuid.UUID) error
+	// CommitStock списывает резерв оплаченного заказа с остатка
+	CommitStock(orderID uuid.UUID) error
 }
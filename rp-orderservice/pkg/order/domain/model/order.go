@@ -10,10 +10,31 @@ import (
 var (
 	ErrOrderNotFound   = errors.New("order not found")
 	ErrProductNotFound = errors.New("product for order not found")
+	ErrProductArchived = errors.New("product for order is archived")
+	ErrVariantNotFound = errors.New("product variant for order not found")
+	ErrVariantRequired = errors.New("product variant for order must be specified")
+	ErrOutOfStock      = errors.New("not enough product variant in stock")
 	ErrUserNotFound    = errors.New("user for order not found")
 	ErrEmptyOrder      = errors.New("order must contain at least one item")
+	ErrInvalidCurrency = errors.New("invalid currency code")
 )
 
+// DefaultCurrency - валюта заказов, товаров и сумм, созданных до появления валют
+const DefaultCurrency = "RUB"
+
+// ValidCurrency проверяет, что code похож на код ISO 4217: три заглавные латинские буквы
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range []byte(code) {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
 type OrderStatus int
 
 const (
@@ -25,18 +46,32 @@ const (
 
 type OrderItem struct {
 	ProductID uuid.UUID
+	VariantID *uuid.UUID // Пустой, если у товара нет вариантов
+	SKU       string     // SKU варианта на момент заказа
 	Quantity  int
-	Price     int64 // Цена за единицу в копейках на момент заказа
+	Price     int64 // Цена за единицу в сотых долях валюты заказа на момент заказа
+	// OriginalPrice - цена за единицу в валюте товара, OriginalCurrency - валюта товара
+	OriginalPrice    int64
+	OriginalCurrency string
+	// ExchangeRate - курс OriginalCurrency к валюте заказа, умноженный на RateScale
+	ExchangeRate int64
+	// Discount - скидка на всю позицию в сотых долях валюты заказа
+	Discount int64
 }
 
 type Order struct {
 	OrderID    uuid.UUID
 	UserID     uuid.UUID
 	Items      []OrderItem
-	TotalPrice int64 // Общая цена в копейках
-	Status     OrderStatus
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	Currency   string
+	TotalPrice int64 // Общая цена в сотых долях Currency с учетом скидки
+	// PromotionID и PromoCode - примененный код, пустые если кода не было
+	PromotionID   *uuid.UUID
+	PromoCode     string
+	DiscountTotal int64
+	Status        OrderStatus
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 type OrderRepository interface {
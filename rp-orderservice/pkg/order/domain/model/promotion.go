@@ -0,0 +1,205 @@
+package model
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPromotionNotFound          = errors.New("promotion not found")
+	ErrPromotionCodeAlreadyUsed   = errors.New("promotion code already used by another promotion")
+	ErrInvalidPromotion           = errors.New("invalid promotion")
+	ErrPromotionNotActive         = errors.New("promotion is not active")
+	ErrPromotionUsageLimitReached = errors.New("promotion usage limit reached")
+	ErrPromotionUserLimitReached  = errors.New("promotion usage limit for user reached")
+	ErrPromotionMinOrderValue     = errors.New("order value is below promotion minimum")
+	ErrPromotionNotApplicable     = errors.New("promotion is not applicable to order")
+)
+
+type PromotionType int
+
+const (
+	// PromotionPercent - Value процентов от стоимости подходящих позиций
+	PromotionPercent PromotionType = iota
+	// PromotionFixed - Value в сотых долях Currency, но не больше стоимости подходящих позиций
+	PromotionFixed
+)
+
+type Promotion struct {
+	PromotionID uuid.UUID
+	Code        string // В верхнем регистре, см. NormalizePromoCode
+	Type        PromotionType
+	Value       int64
+	// Currency - валюта фиксированной скидки и MinOrderValue, код применяется только к заказам в этой валюте.
+	// Пустая - процентная скидка без минимальной суммы, подходит для любой валюты
+	Currency      string
+	MinOrderValue int64
+	// UsageLimit - сколько раз код можно применить всего, PerUserLimit - одним пользователем. 0 - без ограничения
+	UsageLimit   int
+	PerUserLimit int
+	// UsedCount меняют только Redeem и Release
+	UsedCount  int
+	ValidFrom  *time.Time
+	ValidUntil *time.Time
+	// ProductIDs и CategoryIDs ограничивают скидку позициями этих товаров или товаров из этих категорий.
+	// Если оба пустые, скидка на весь заказ
+	ProductIDs  []uuid.UUID
+	CategoryIDs []uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NormalizePromoCode - коды вводят руками, поэтому регистр и пробелы по краям не важны
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p Promotion) Validate() error {
+	if p.Code == "" || len(p.Code) > 64 {
+		return ErrInvalidPromotion
+	}
+	switch p.Type {
+	case PromotionPercent:
+		if p.Value < 1 || p.Value > 100 {
+			return ErrInvalidPromotion
+		}
+	case PromotionFixed:
+		if p.Value <= 0 || p.Currency == "" {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+	if p.MinOrderValue < 0 || (p.MinOrderValue > 0 && p.Currency == "") {
+		return ErrInvalidPromotion
+	}
+	if p.Currency != "" && !ValidCurrency(p.Currency) {
+		return ErrInvalidCurrency
+	}
+	if p.UsageLimit < 0 || p.PerUserLimit < 0 {
+		return ErrInvalidPromotion
+	}
+	if p.ValidFrom != nil && p.ValidUntil != nil && !p.ValidFrom.Before(*p.ValidUntil) {
+		return ErrInvalidPromotion
+	}
+	return nil
+}
+
+// Active - код действует в момент now. ValidUntil не входит в период действия
+func (p Promotion) Active(now time.Time) bool {
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+	return p.ValidUntil == nil || now.Before(*p.ValidUntil)
+}
+
+// Discounts считает скидку для каждой позиции заказа в валюте заказа. products нужны только для категорий.
+// Лимиты использования здесь не проверяются, для них нужны погашения из репозитория
+func (p Promotion) Discounts(currency string, items []OrderItem, products map[uuid.UUID]LocalProduct) ([]int64, error) {
+	if p.Currency != "" && p.Currency != currency {
+		return nil, ErrPromotionNotApplicable
+	}
+
+	var subtotal, eligibleTotal int64
+	lineTotals := make([]int64, len(items))
+	for i, item := range items {
+		lineTotal := item.Price * int64(item.Quantity)
+		subtotal += lineTotal
+		if p.appliesTo(item.ProductID, products[item.ProductID].CategoryIDs) {
+			lineTotals[i] = lineTotal
+			eligibleTotal += lineTotal
+		}
+	}
+	if subtotal < p.MinOrderValue {
+		return nil, ErrPromotionMinOrderValue
+	}
+	if eligibleTotal == 0 {
+		return nil, ErrPromotionNotApplicable
+	}
+
+	total := p.Value
+	if p.Type == PromotionPercent {
+		total = divRound(big.NewInt(eligibleTotal), big.NewInt(p.Value), big.NewInt(100))
+	}
+	return allocate(min(total, eligibleTotal), lineTotals, eligibleTotal), nil
+}
+
+func (p Promotion) appliesTo(productID uuid.UUID, categoryIDs []uuid.UUID) bool {
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	if slices.Contains(p.ProductIDs, productID) {
+		return true
+	}
+	return slices.ContainsFunc(categoryIDs, func(id uuid.UUID) bool {
+		return slices.Contains(p.CategoryIDs, id)
+	})
+}
+
+// allocate делит total пропорционально lineTotals методом наибольших остатков:
+// сумма долей равна total, и доля позиции не больше ее стоимости
+func allocate(total int64, lineTotals []int64, sum int64) []int64 {
+	result := make([]int64, len(lineTotals))
+	remainders := make([]*big.Int, len(lineTotals))
+	allocated := int64(0)
+	for i, lineTotal := range lineTotals {
+		quotient, remainder := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(total), big.NewInt(lineTotal)),
+			big.NewInt(sum),
+			new(big.Int),
+		)
+		result[i] = quotient.Int64()
+		remainders[i] = remainder
+		allocated += result[i]
+	}
+
+	order := make([]int, len(lineTotals))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return remainders[b].Cmp(remainders[a])
+	})
+	for _, i := range order[:total-allocated] {
+		result[i]++
+	}
+	return result
+}
+
+// divRound считает a*b/c, половина округляется вверх. Аргументы неотрицательные
+func divRound(a, b, c *big.Int) int64 {
+	product := new(big.Int).Mul(a, b)
+	product.Add(product, new(big.Int).Quo(c, big.NewInt(2)))
+	return product.Quo(product, c).Int64()
+}
+
+// PromotionRedemption - применение кода к заказу
+type PromotionRedemption struct {
+	PromotionID uuid.UUID
+	OrderID     uuid.UUID
+	UserID      uuid.UUID
+	RedeemedAt  time.Time
+}
+
+type PromotionFindSpec struct {
+	PromotionID *uuid.UUID
+	Code        *string
+}
+
+type PromotionRepository interface {
+	NextID() (uuid.UUID, error)
+	// Store не меняет UsedCount существующей акции
+	Store(promotion Promotion) error
+	Find(spec PromotionFindSpec) (*Promotion, error)
+	CountUserRedemptions(promotionID, userID uuid.UUID) (int, error)
+	// Redeem атомарно увеличивает UsedCount и сохраняет погашение.
+	// Если UsageLimit уже исчерпан, возвращает ErrPromotionUsageLimitReached
+	Redeem(redemption PromotionRedemption) error
+	// Release возвращает погашение отмененного заказа, если оно было
+	Release(orderID uuid.UUID) error
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"time"
+
+	"orderservice/pkg/common/domain"
+	"orderservice/pkg/order/domain/model"
+)
+
+type ExchangeRateService interface {
+	// SetExchangeRate задает курс from к to. Обратный курс не меняется
+	SetExchangeRate(from, to string, rate int64) error
+}
+
+func NewExchangeRateService(
+	exchangeRateRepo model.ExchangeRateRepository,
+	eventDispatcher domain.EventDispatcher,
+) ExchangeRateService {
+	return &exchangeRateService{
+		exchangeRateRepository: exchangeRateRepo,
+		eventDispatcher:        eventDispatcher,
+	}
+}
+
+type exchangeRateService struct {
+	exchangeRateRepository model.ExchangeRateRepository
+	eventDispatcher        domain.EventDispatcher
+}
+
+func (s *exchangeRateService) SetExchangeRate(from, to string, rate int64) error {
+	if !model.ValidCurrency(from) || !model.ValidCurrency(to) {
+		return model.ErrInvalidCurrency
+	}
+	if from == to {
+		return model.ErrSameCurrency
+	}
+	if rate <= 0 {
+		return model.ErrInvalidExchangeRate
+	}
+
+	currentTime := time.Now()
+	err := s.exchangeRateRepository.Store(model.ExchangeRate{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		UpdatedAt: currentTime,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.eventDispatcher.Dispatch(&model.ExchangeRateUpdated{
+		From:      from,
+		To:        to,
+		Rate:      rate,
+		UpdatedAt: currentTime,
+	})
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"orderservice/pkg/order/domain/model"
+)
+
+func TestExchangeRateService_SetExchangeRate(t *testing.T) {
+	rates := new(MockExchangeRateRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewExchangeRateService(rates, dispatcher)
+
+	t.Run("success", func(t *testing.T) {
+		rates.On("Store", mock.MatchedBy(func(r model.ExchangeRate) bool {
+			return r.From == "USD" && r.To == "RUB" && r.Rate == 92_500_000
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ExchangeRateUpdated) bool {
+			return e.From == "USD" && e.To == "RUB" && e.Rate == 92_500_000
+		})).Return(nil).Once()
+
+		err := service.SetExchangeRate("USD", "RUB", 92_500_000)
+		assert.NoError(t, err)
+		rates.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.ErrorIs(t, service.SetExchangeRate("USD", "rub", 1), model.ErrInvalidCurrency)
+		assert.ErrorIs(t, service.SetExchangeRate("USD", "USD", 1), model.ErrSameCurrency)
+		assert.ErrorIs(t, service.SetExchangeRate("USD", "RUB", 0), model.ErrInvalidExchangeRate)
+		rates.AssertNumberOfCalls(t, "Store", 1)
+	})
+}
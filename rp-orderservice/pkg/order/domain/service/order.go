@@ -1,6 +1,7 @@
 package service
 
 import (
+	"slices"
 	"time"
 
 	"orderservice/pkg/common/domain"
@@ -10,50 +11,102 @@ import (
 )
 
 type OrderService interface {
-	CreateOrder(userID uuid.UUID, items []model.OrderItem) (uuid.UUID, error)
+	// CreateOrder пересчитывает цены позиций из OriginalCurrency в валюту заказа и применяет promoCode, если он указан.
+	// Без currency заказ оформляется в DefaultCurrency
+	CreateOrder(userID uuid.UUID, currency, promoCode string, items []model.OrderItem) (uuid.UUID, error)
 	MarkAsPaid(orderID uuid.UUID) error
 	CancelOrder(orderID uuid.UUID, reason string) error
 }
 
 func NewOrderService(
 	orderRepo model.OrderRepository,
+	exchangeRateRepo model.ExchangeRateRepository,
+	promotionRepo model.PromotionRepository,
+	localProductRepo model.LocalProductRepository,
 	eventDispatcher domain.EventDispatcher,
 ) OrderService {
 	return &orderService{
-		orderRepository: orderRepo,
-		eventDispatcher: eventDispatcher,
+		orderRepository:        orderRepo,
+		exchangeRateRepository: exchangeRateRepo,
+		promotionRepository:    promotionRepo,
+		localProductRepository: localProductRepo,
+		eventDispatcher:        eventDispatcher,
 	}
 }
 
 type orderService struct {
-	orderRepository model.OrderRepository
-	eventDispatcher domain.EventDispatcher
+	orderRepository        model.OrderRepository
+	exchangeRateRepository model.ExchangeRateRepository
+	promotionRepository    model.PromotionRepository
+	localProductRepository model.LocalProductRepository
+	eventDispatcher        domain.EventDispatcher
 }
 
-func (s *orderService) CreateOrder(userID uuid.UUID, items []model.OrderItem) (uuid.UUID, error) {
+func (s *orderService) CreateOrder(userID uuid.UUID, currency, promoCode string, items []model.OrderItem) (uuid.UUID, error) {
 	if len(items) == 0 {
 		return uuid.Nil, model.ErrEmptyOrder
 	}
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	if !model.ValidCurrency(currency) {
+		return uuid.Nil, model.ErrInvalidCurrency
+	}
+
+	items, err := s.convertItems(currency, items)
+	if err != nil {
+		return uuid.Nil, err
+	}
 
 	orderID, err := s.orderRepository.NextID()
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	var totalPrice int64
+	currentTime := time.Now()
+	var promotion *model.Promotion
+	if promoCode != "" {
+		promotion, err = s.applyPromotion(userID, currency, promoCode, items, currentTime)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	// все цены и скидки уже в валюте заказа, поэтому сумма не смешивает валюты
+	var totalPrice, discountTotal int64
 	for _, item := range items {
-		totalPrice += item.Price * int64(item.Quantity)
+		totalPrice += item.Price*int64(item.Quantity) - item.Discount
+		discountTotal += item.Discount
 	}
 
-	currentTime := time.Now()
 	order := model.Order{
-		OrderID:    orderID,
-		UserID:     userID,
-		Items:      items,
-		TotalPrice: totalPrice,
-		Status:     model.StatusCreated,
-		CreatedAt:  currentTime,
-		UpdatedAt:  currentTime,
+		OrderID:       orderID,
+		UserID:        userID,
+		Items:         items,
+		Currency:      currency,
+		TotalPrice:    totalPrice,
+		DiscountTotal: discountTotal,
+		Status:        model.StatusCreated,
+		CreatedAt:     currentTime,
+		UpdatedAt:     currentTime,
+	}
+
+	if promotion != nil {
+		order.PromotionID = &promotion.PromotionID
+		order.PromoCode = promotion.Code
+		err = s.promotionRepository.Redeem(model.PromotionRedemption{
+			PromotionID: promotion.PromotionID,
+			OrderID:     orderID,
+			UserID:      userID,
+			RedeemedAt:  currentTime,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if err := s.localProductRepository.ReserveStock(orderID, items); err != nil {
+		return uuid.Nil, err
 	}
 
 	if err := s.orderRepository.Store(order); err != nil {
@@ -63,11 +116,14 @@ func (s *orderService) CreateOrder(userID uuid.UUID, items []model.OrderItem) (u
 	// орем, что заказ создан
 
 	return orderID, s.eventDispatcher.Dispatch(&model.OrderCreated{
-		OrderID:    orderID,
-		UserID:     userID,
-		TotalPrice: totalPrice,
-		Items:      items,
-		CreatedAt:  currentTime,
+		OrderID:       orderID,
+		UserID:        userID,
+		Currency:      currency,
+		TotalPrice:    totalPrice,
+		PromoCode:     order.PromoCode,
+		DiscountTotal: discountTotal,
+		Items:         items,
+		CreatedAt:     currentTime,
 	})
 }
 
@@ -88,9 +144,18 @@ func (s *orderService) MarkAsPaid(orderID uuid.UUID) error {
 		return err
 	}
 
+	// productservice спишет остаток по order_paid, до этого реплика уменьшает его сама
+	if err := s.localProductRepository.CommitStock(orderID); err != nil {
+		return err
+	}
+
 	return s.eventDispatcher.Dispatch(&model.OrderPaid{
-		OrderID: orderID,
-		PaidAt:  order.UpdatedAt,
+		OrderID:    orderID,
+		UserID:     order.UserID,
+		Currency:   order.Currency,
+		TotalPrice: order.TotalPrice,
+		Items:      order.Items,
+		PaidAt:     order.UpdatedAt,
 	})
 }
 
@@ -111,11 +176,113 @@ func (s *orderService) CancelOrder(orderID uuid.UUID, reason string) error {
 		return err
 	}
 
+	if err := s.localProductRepository.ReleaseStock(orderID); err != nil {
+		return err
+	}
+
+	// код из отмененного заказа можно применить снова
+	if order.PromotionID != nil {
+		if err := s.promotionRepository.Release(orderID); err != nil {
+			return err
+		}
+	}
+
 	// орем, что заказ отменен
 
 	return s.eventDispatcher.Dispatch(&model.OrderCancelled{
 		OrderID:     orderID,
+		UserID:      order.UserID,
 		Reason:      reason,
 		CancelledAt: order.UpdatedAt,
 	})
 }
+
+// applyPromotion проверяет код и записывает скидки в позиции. Погашение сохраняется вместе с заказом
+func (s *orderService) applyPromotion(
+	userID uuid.UUID,
+	currency, promoCode string,
+	items []model.OrderItem,
+	currentTime time.Time,
+) (*model.Promotion, error) {
+	code := model.NormalizePromoCode(promoCode)
+	promotion, err := s.promotionRepository.Find(model.PromotionFindSpec{Code: &code})
+	if err != nil {
+		return nil, err
+	}
+	if !promotion.Active(currentTime) {
+		return nil, model.ErrPromotionNotActive
+	}
+	if promotion.UsageLimit > 0 && promotion.UsedCount >= promotion.UsageLimit {
+		return nil, model.ErrPromotionUsageLimitReached
+	}
+	if promotion.PerUserLimit > 0 {
+		used, err := s.promotionRepository.CountUserRedemptions(promotion.PromotionID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= promotion.PerUserLimit {
+			return nil, model.ErrPromotionUserLimitReached
+		}
+	}
+
+	products := make(map[uuid.UUID]model.LocalProduct)
+	if len(promotion.CategoryIDs) > 0 {
+		var productIDs []uuid.UUID
+		for _, item := range items {
+			if !slices.Contains(productIDs, item.ProductID) {
+				productIDs = append(productIDs, item.ProductID)
+			}
+		}
+		found, err := s.localProductRepository.FindMany(productIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range found {
+			products[product.ProductID] = product
+		}
+	}
+
+	discounts, err := promotion.Discounts(currency, items, products)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Discount = discounts[i]
+	}
+	return promotion, nil
+}
+
+// convertItems фиксирует курс на момент заказа: позже курс может измениться, а заказ - нет
+func (s *orderService) convertItems(currency string, items []model.OrderItem) ([]model.OrderItem, error) {
+	rates := make(map[string]model.ExchangeRate)
+	result := make([]model.OrderItem, len(items))
+	for i, item := range items {
+		if item.OriginalCurrency == "" {
+			item.OriginalCurrency = model.DefaultCurrency
+		}
+		if item.OriginalCurrency == currency {
+			item.Price = item.OriginalPrice
+			item.ExchangeRate = model.RateScale
+			result[i] = item
+			continue
+		}
+
+		rate, ok := rates[item.OriginalCurrency]
+		if !ok {
+			found, err := s.exchangeRateRepository.Find(item.OriginalCurrency, currency)
+			if err != nil {
+				return nil, err
+			}
+			rate = *found
+			rates[item.OriginalCurrency] = rate
+		}
+		price, err := rate.Convert(item.OriginalPrice)
+		if err != nil {
+			return nil, err
+		}
+		item.Price = price
+		item.ExchangeRate = rate.Rate
+		result[i] = item
+	}
+	return result, nil
+}
@@ -33,6 +33,23 @@ func (m *MockOrderRepository) Find(orderID uuid.UUID) (*model.Order, error) {
 	return args.Get(0).(*model.Order), args.Error(1)
 }
 
+type MockExchangeRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockExchangeRateRepository) Store(rate model.ExchangeRate) error {
+	args := m.Called(rate)
+	return args.Error(0)
+}
+
+func (m *MockExchangeRateRepository) Find(from, to string) (*model.ExchangeRate, error) {
+	args := m.Called(from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ExchangeRate), args.Error(1)
+}
+
 type MockEventDispatcher struct {
 	mock.Mock
 }
@@ -45,48 +62,133 @@ func (m *MockEventDispatcher) Dispatch(event domain.Event) error {
 func TestOrderService_CreateOrder(t *testing.T) {
 	repo := new(MockOrderRepository)
 	dispatcher := new(MockEventDispatcher)
-	service := NewOrderService(repo, dispatcher)
+	rates := new(MockExchangeRateRepository)
+	products := new(MockLocalProductRepository)
+	service := NewOrderService(repo, rates, new(MockPromotionRepository), products, dispatcher)
 
 	userID := uuid.New()
 	productID := uuid.New()
 	orderID := uuid.New()
 
 	items := []model.OrderItem{
-		{ProductID: productID, Quantity: 2, Price: 100},
+		{ProductID: productID, Quantity: 2, OriginalPrice: 100, OriginalCurrency: "RUB"},
 	}
 
 	t.Run("success", func(t *testing.T) {
 		repo.On("NextID").Return(orderID, nil).Once()
+		products.On("ReserveStock", orderID, mock.Anything).Return(nil).Once()
 		repo.On("Store", mock.MatchedBy(func(o model.Order) bool {
-			return o.OrderID == orderID && o.UserID == userID && o.TotalPrice == 200
+			return o.OrderID == orderID && o.UserID == userID && o.TotalPrice == 200 && o.Currency == model.DefaultCurrency &&
+				o.Items[0].Price == 100 && o.Items[0].ExchangeRate == model.RateScale
 		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.OrderCreated) bool {
-			return e.OrderID == orderID && e.TotalPrice == 200
+			return e.OrderID == orderID && e.TotalPrice == 200 && e.Currency == model.DefaultCurrency
 		})).Return(nil).Once()
 
-		id, err := service.CreateOrder(userID, items)
+		id, err := service.CreateOrder(userID, "", "", items)
 		assert.NoError(t, err)
 		assert.Equal(t, orderID, id)
 		repo.AssertExpectations(t)
 		dispatcher.AssertExpectations(t)
 	})
 
+	t.Run("converts_to_order_currency", func(t *testing.T) {
+		mixed := []model.OrderItem{
+			{ProductID: productID, Quantity: 2, OriginalPrice: 1999, OriginalCurrency: "USD"},
+			{ProductID: uuid.New(), Quantity: 1, OriginalPrice: 500, OriginalCurrency: "EUR"},
+		}
+		rates.On("Find", "USD", "EUR").Return(&model.ExchangeRate{From: "USD", To: "EUR", Rate: 925_000}, nil).Once()
+		repo.On("NextID").Return(orderID, nil).Once()
+		products.On("ReserveStock", orderID, mock.Anything).Return(nil).Once()
+		repo.On("Store", mock.MatchedBy(func(o model.Order) bool {
+			// 19.99 USD * 0.925 = 18.49075 EUR
+			return o.Currency == "EUR" && o.TotalPrice == 1849*2+500 &&
+				o.Items[0].Price == 1849 && o.Items[0].OriginalPrice == 1999 && o.Items[0].ExchangeRate == 925_000 &&
+				o.Items[1].Price == 500 && o.Items[1].ExchangeRate == model.RateScale
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		_, err := service.CreateOrder(userID, "EUR", "", mixed)
+		assert.NoError(t, err)
+		rates.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("out_of_stock", func(t *testing.T) {
+		variantID := uuid.New()
+		withVariant := []model.OrderItem{
+			{ProductID: productID, VariantID: &variantID, Quantity: 3, OriginalPrice: 100, OriginalCurrency: "RUB"},
+		}
+		repo.On("NextID").Return(orderID, nil).Once()
+		products.On("ReserveStock", orderID, mock.MatchedBy(func(items []model.OrderItem) bool {
+			return len(items) == 1 && *items[0].VariantID == variantID && items[0].Quantity == 3
+		})).Return(model.ErrOutOfStock).Once()
+
+		_, err := service.CreateOrder(userID, "", "", withVariant)
+		assert.ErrorIs(t, err, model.ErrOutOfStock)
+		products.AssertExpectations(t)
+	})
+
+	t.Run("missing_exchange_rate", func(t *testing.T) {
+		rates.On("Find", "RUB", "USD").Return(nil, model.ErrExchangeRateNotFound).Once()
+
+		_, err := service.CreateOrder(userID, "USD", "", items)
+		assert.ErrorIs(t, err, model.ErrExchangeRateNotFound)
+	})
+
+	t.Run("invalid_currency", func(t *testing.T) {
+		_, err := service.CreateOrder(userID, "usd", "", items)
+		assert.ErrorIs(t, err, model.ErrInvalidCurrency)
+	})
+
 	t.Run("empty order", func(t *testing.T) {
-		_, err := service.CreateOrder(userID, []model.OrderItem{})
+		_, err := service.CreateOrder(userID, "", "", []model.OrderItem{})
 		assert.ErrorIs(t, err, model.ErrEmptyOrder)
 	})
 }
 
+func TestExchangeRate_Convert(t *testing.T) {
+	rate := model.ExchangeRate{From: "USD", To: "RUB", Rate: 92_505_000}
+
+	price, err := rate.Convert(100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9251), price)
+
+	price, err = rate.Convert(-100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-9251), price)
+
+	_, err = rate.Convert(1 << 62)
+	assert.ErrorIs(t, err, model.ErrPriceOverflow)
+
+	// у иены нет дробной части, у кувейтского динара три знака
+	price, err = model.ExchangeRate{From: "USD", To: "JPY", Rate: 150_250_000}.Convert(100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150), price)
+
+	price, err = model.ExchangeRate{From: "JPY", To: "USD", Rate: 6_655}.Convert(1000)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(666), price)
+
+	price, err = model.ExchangeRate{From: "USD", To: "KWD", Rate: 307_500}.Convert(1999)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6147), price)
+}
+
 func TestOrderService_MarkAsPaid(t *testing.T) {
 	repo := new(MockOrderRepository)
 	dispatcher := new(MockEventDispatcher)
-	service := NewOrderService(repo, dispatcher)
+	products := new(MockLocalProductRepository)
+	service := NewOrderService(repo, new(MockExchangeRateRepository), new(MockPromotionRepository), products, dispatcher)
 
 	orderID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
+		userID := uuid.New()
 		existingOrder := &model.Order{
 			OrderID: orderID,
+			UserID:  userID,
+			Items:   []model.OrderItem{{ProductID: uuid.New(), Quantity: 1, Price: 100}},
 			Status:  model.StatusCreated,
 		}
 
@@ -94,13 +196,15 @@ func TestOrderService_MarkAsPaid(t *testing.T) {
 		repo.On("Store", mock.MatchedBy(func(o model.Order) bool {
 			return o.OrderID == orderID && o.Status == model.StatusPaid
 		})).Return(nil).Once()
+		products.On("CommitStock", orderID).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.OrderPaid) bool {
-			return e.OrderID == orderID
+			return e.OrderID == orderID && e.UserID == userID && len(e.Items) == 1
 		})).Return(nil).Once()
 
 		err := service.MarkAsPaid(orderID)
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
+		products.AssertExpectations(t)
 		dispatcher.AssertExpectations(t)
 	})
 
@@ -120,7 +224,8 @@ func TestOrderService_MarkAsPaid(t *testing.T) {
 func TestOrderService_CancelOrder(t *testing.T) {
 	repo := new(MockOrderRepository)
 	dispatcher := new(MockEventDispatcher)
-	service := NewOrderService(repo, dispatcher)
+	products := new(MockLocalProductRepository)
+	service := NewOrderService(repo, new(MockExchangeRateRepository), new(MockPromotionRepository), products, dispatcher)
 
 	orderID := uuid.New()
 
@@ -134,6 +239,8 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		repo.On("Store", mock.MatchedBy(func(o model.Order) bool {
 			return o.OrderID == orderID && o.Status == model.StatusCancelled
 		})).Return(nil).Once()
+		// отмена возвращает зарезервированный остаток
+		products.On("ReleaseStock", orderID).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.OrderCancelled) bool {
 			return e.OrderID == orderID && e.Reason == "test"
 		})).Return(nil).Once()
@@ -141,6 +248,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		err := service.CancelOrder(orderID, "test")
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
+		products.AssertExpectations(t)
 	})
 
 	t.Run("cannot cancel paid order", func(t *testing.T) {
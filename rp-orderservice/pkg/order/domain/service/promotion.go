@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orderservice/pkg/order/domain/model"
+)
+
+type PromotionService interface {
+	CreatePromotion(promotion model.Promotion) (uuid.UUID, error)
+	// UpdatePromotion меняет условия акции, счетчик использований сохраняется
+	UpdatePromotion(promotion model.Promotion) error
+}
+
+func NewPromotionService(promotionRepo model.PromotionRepository) PromotionService {
+	return &promotionService{
+		promotionRepository: promotionRepo,
+	}
+}
+
+type promotionService struct {
+	promotionRepository model.PromotionRepository
+}
+
+func (s *promotionService) CreatePromotion(promotion model.Promotion) (uuid.UUID, error) {
+	promotion = normalizePromotion(promotion)
+	err := promotion.Validate()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	err = s.checkCodeUnique(uuid.Nil, promotion.Code)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	promotionID, err := s.promotionRepository.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	promotion.PromotionID = promotionID
+	promotion.UsedCount = 0
+	promotion.CreatedAt = currentTime
+	promotion.UpdatedAt = currentTime
+	return promotionID, s.promotionRepository.Store(promotion)
+}
+
+func (s *promotionService) UpdatePromotion(promotion model.Promotion) error {
+	promotion = normalizePromotion(promotion)
+	err := promotion.Validate()
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.promotionRepository.Find(model.PromotionFindSpec{PromotionID: &promotion.PromotionID})
+	if err != nil {
+		return err
+	}
+	if existing.Code != promotion.Code {
+		err = s.checkCodeUnique(promotion.PromotionID, promotion.Code)
+		if err != nil {
+			return err
+		}
+	}
+
+	promotion.UsedCount = existing.UsedCount
+	promotion.CreatedAt = existing.CreatedAt
+	promotion.UpdatedAt = time.Now()
+	return s.promotionRepository.Store(promotion)
+}
+
+// checkCodeUnique проверяет, что код не занят другой акцией, для новой акции promotionID пустой
+func (s *promotionService) checkCodeUnique(promotionID uuid.UUID, code string) error {
+	existing, err := s.promotionRepository.Find(model.PromotionFindSpec{Code: &code})
+	if err != nil && !errors.Is(err, model.ErrPromotionNotFound) {
+		return err
+	}
+	if existing != nil && (promotionID == uuid.Nil || existing.PromotionID != promotionID) {
+		return model.ErrPromotionCodeAlreadyUsed
+	}
+	return nil
+}
+
+func normalizePromotion(promotion model.Promotion) model.Promotion {
+	promotion.Code = model.NormalizePromoCode(promotion.Code)
+	promotion.ProductIDs = uniqueIDs(promotion.ProductIDs)
+	promotion.CategoryIDs = uniqueIDs(promotion.CategoryIDs)
+	return promotion
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	var result []uuid.UUID
+	for _, id := range ids {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
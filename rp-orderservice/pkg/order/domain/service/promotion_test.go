@@ -0,0 +1,295 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"orderservice/pkg/order/domain/model"
+)
+
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Store(promotion model.Promotion) error {
+	args := m.Called(promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Find(spec model.PromotionFindSpec) (*model.Promotion, error) {
+	args := m.Called(spec)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) CountUserRedemptions(promotionID, userID uuid.UUID) (int, error) {
+	args := m.Called(promotionID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Redeem(redemption model.PromotionRedemption) error {
+	args := m.Called(redemption)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Release(orderID uuid.UUID) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
+type MockLocalProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockLocalProductRepository) Store(_ model.LocalProduct) error               { return nil }
+func (m *MockLocalProductRepository) Find(_ uuid.UUID) (*model.LocalProduct, error)  { return nil, nil }
+func (m *MockLocalProductRepository) SetArchived(_ uuid.UUID, _ bool) error          { return nil }
+func (m *MockLocalProductRepository) SetCategories(_ uuid.UUID, _ []uuid.UUID) error { return nil }
+func (m *MockLocalProductRepository) FindMany(ids []uuid.UUID) ([]model.LocalProduct, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]model.LocalProduct), args.Error(1)
+}
+
+func (m *MockLocalProductRepository) ReserveStock(orderID uuid.UUID, items []model.OrderItem) error {
+	args := m.Called(orderID, items)
+	return args.Error(0)
+}
+
+func (m *MockLocalProductRepository) ReleaseStock(orderID uuid.UUID) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
+func (m *MockLocalProductRepository) CommitStock(orderID uuid.UUID) error {
+	args := m.Called(orderID)
+	return args.Error(0)
+}
+
+func TestOrderService_CreateOrder_Promotion(t *testing.T) {
+	userID := uuid.New()
+	orderID := uuid.New()
+	promotionID := uuid.New()
+	mug := uuid.New()
+	plate := uuid.New()
+	kitchen := uuid.New()
+	items := []model.OrderItem{
+		{ProductID: mug, Quantity: 2, OriginalPrice: 300},
+		{ProductID: plate, Quantity: 1, OriginalPrice: 1000},
+	}
+	code := "SPRING"
+
+	newService := func(promotion *model.Promotion) (OrderService, *MockOrderRepository, *MockPromotionRepository, *MockLocalProductRepository) {
+		repo := new(MockOrderRepository)
+		promotions := new(MockPromotionRepository)
+		products := new(MockLocalProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		repo.On("NextID").Return(orderID, nil)
+		promotions.On("Find", model.PromotionFindSpec{Code: &code}).Return(promotion, nil)
+		products.On("ReserveStock", orderID, mock.Anything).Return(nil)
+		dispatcher.On("Dispatch", mock.Anything).Return(nil)
+		return NewOrderService(repo, new(MockExchangeRateRepository), promotions, products, dispatcher), repo, promotions, products
+	}
+
+	t.Run("percent_for_category", func(t *testing.T) {
+		service, repo, promotions, products := newService(&model.Promotion{
+			PromotionID:  promotionID,
+			Code:         code,
+			Type:         model.PromotionPercent,
+			Value:        15,
+			PerUserLimit: 1,
+			CategoryIDs:  []uuid.UUID{kitchen},
+		})
+		products.On("FindMany", []uuid.UUID{mug, plate}).Return([]model.LocalProduct{
+			{ProductID: mug, CategoryIDs: []uuid.UUID{kitchen}},
+			{ProductID: plate},
+		}, nil)
+		promotions.On("CountUserRedemptions", promotionID, userID).Return(0, nil)
+		promotions.On("Redeem", mock.MatchedBy(func(r model.PromotionRedemption) bool {
+			return r.PromotionID == promotionID && r.OrderID == orderID && r.UserID == userID
+		})).Return(nil).Once()
+		repo.On("Store", mock.MatchedBy(func(o model.Order) bool {
+			// 15% от 600 - 90, тарелка вне категории
+			return o.PromoCode == code && *o.PromotionID == promotionID &&
+				o.Items[0].Discount == 90 && o.Items[1].Discount == 0 &&
+				o.DiscountTotal == 90 && o.TotalPrice == 1600-90
+		})).Return(nil).Once()
+
+		_, err := service.CreateOrder(userID, "", " spring ", items)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		promotions.AssertExpectations(t)
+	})
+
+	t.Run("user_limit_reached", func(t *testing.T) {
+		service, repo, promotions, _ := newService(&model.Promotion{
+			PromotionID:  promotionID,
+			Code:         code,
+			Type:         model.PromotionPercent,
+			Value:        10,
+			PerUserLimit: 1,
+		})
+		promotions.On("CountUserRedemptions", promotionID, userID).Return(1, nil)
+
+		_, err := service.CreateOrder(userID, "", code, items)
+		assert.ErrorIs(t, err, model.ErrPromotionUserLimitReached)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("usage_limit_reached", func(t *testing.T) {
+		service, _, _, _ := newService(&model.Promotion{
+			PromotionID: promotionID,
+			Code:        code,
+			Type:        model.PromotionPercent,
+			Value:       10,
+			UsageLimit:  5,
+			UsedCount:   5,
+		})
+
+		_, err := service.CreateOrder(userID, "", code, items)
+		assert.ErrorIs(t, err, model.ErrPromotionUsageLimitReached)
+	})
+
+	t.Run("redeem_race_lost", func(t *testing.T) {
+		service, repo, promotions, _ := newService(&model.Promotion{
+			PromotionID: promotionID,
+			Code:        code,
+			Type:        model.PromotionFixed,
+			Value:       500,
+			Currency:    model.DefaultCurrency,
+			UsageLimit:  1,
+		})
+		promotions.On("Redeem", mock.Anything).Return(model.ErrPromotionUsageLimitReached)
+
+		_, err := service.CreateOrder(userID, "", code, items)
+		assert.ErrorIs(t, err, model.ErrPromotionUsageLimitReached)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		validUntil := time.Now().Add(-time.Hour)
+		service, _, _, _ := newService(&model.Promotion{
+			PromotionID: promotionID,
+			Code:        code,
+			Type:        model.PromotionPercent,
+			Value:       10,
+			ValidUntil:  &validUntil,
+		})
+
+		_, err := service.CreateOrder(userID, "", code, items)
+		assert.ErrorIs(t, err, model.ErrPromotionNotActive)
+	})
+
+	t.Run("below_min_order_value", func(t *testing.T) {
+		service, _, _, _ := newService(&model.Promotion{
+			PromotionID:   promotionID,
+			Code:          code,
+			Type:          model.PromotionFixed,
+			Value:         100,
+			Currency:      model.DefaultCurrency,
+			MinOrderValue: 5000,
+		})
+
+		_, err := service.CreateOrder(userID, "", code, items)
+		assert.ErrorIs(t, err, model.ErrPromotionMinOrderValue)
+	})
+}
+
+func TestOrderService_CancelOrder_ReleasesPromotion(t *testing.T) {
+	repo := new(MockOrderRepository)
+	promotions := new(MockPromotionRepository)
+	dispatcher := new(MockEventDispatcher)
+	products := new(MockLocalProductRepository)
+	service := NewOrderService(repo, new(MockExchangeRateRepository), promotions, products, dispatcher)
+
+	orderID := uuid.New()
+	promotionID := uuid.New()
+	repo.On("Find", orderID).Return(&model.Order{OrderID: orderID, PromotionID: &promotionID, Status: model.StatusCreated}, nil)
+	repo.On("Store", mock.Anything).Return(nil)
+	promotions.On("Release", orderID).Return(nil).Once()
+	products.On("ReleaseStock", orderID).Return(nil)
+	dispatcher.On("Dispatch", mock.Anything).Return(nil)
+
+	err := service.CancelOrder(orderID, "Payment failed")
+	assert.NoError(t, err)
+	promotions.AssertExpectations(t)
+}
+
+func TestPromotion_Discounts(t *testing.T) {
+	items := []model.OrderItem{
+		{ProductID: uuid.New(), Quantity: 1, Price: 100},
+		{ProductID: uuid.New(), Quantity: 1, Price: 100},
+		{ProductID: uuid.New(), Quantity: 1, Price: 100},
+	}
+
+	t.Run("fixed_split_without_loss", func(t *testing.T) {
+		promotion := model.Promotion{Type: model.PromotionFixed, Value: 100, Currency: "RUB"}
+		discounts, err := promotion.Discounts("RUB", items, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{34, 33, 33}, discounts)
+	})
+
+	t.Run("fixed_capped_by_order", func(t *testing.T) {
+		promotion := model.Promotion{Type: model.PromotionFixed, Value: 1000, Currency: "RUB"}
+		discounts, err := promotion.Discounts("RUB", items, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{100, 100, 100}, discounts)
+	})
+
+	t.Run("product_scope", func(t *testing.T) {
+		promotion := model.Promotion{Type: model.PromotionPercent, Value: 50, ProductIDs: []uuid.UUID{items[1].ProductID}}
+		discounts, err := promotion.Discounts("USD", items, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{0, 50, 0}, discounts)
+	})
+
+	t.Run("other_currency", func(t *testing.T) {
+		promotion := model.Promotion{Type: model.PromotionFixed, Value: 100, Currency: "RUB"}
+		_, err := promotion.Discounts("USD", items, nil)
+		assert.ErrorIs(t, err, model.ErrPromotionNotApplicable)
+	})
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	promotions := new(MockPromotionRepository)
+	service := NewPromotionService(promotions)
+	promotionID := uuid.New()
+	code := "WELCOME"
+
+	t.Run("success", func(t *testing.T) {
+		promotions.On("Find", model.PromotionFindSpec{Code: &code}).Return(nil, model.ErrPromotionNotFound).Once()
+		promotions.On("NextID").Return(promotionID, nil).Once()
+		promotions.On("Store", mock.MatchedBy(func(p model.Promotion) bool {
+			return p.PromotionID == promotionID && p.Code == code && p.UsedCount == 0
+		})).Return(nil).Once()
+
+		id, err := service.CreatePromotion(model.Promotion{Code: "welcome", Type: model.PromotionPercent, Value: 10, UsedCount: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, promotionID, id)
+		promotions.AssertExpectations(t)
+	})
+
+	t.Run("code_already_used", func(t *testing.T) {
+		promotions.On("Find", model.PromotionFindSpec{Code: &code}).Return(&model.Promotion{PromotionID: uuid.New(), Code: code}, nil).Once()
+
+		_, err := service.CreatePromotion(model.Promotion{Code: code, Type: model.PromotionPercent, Value: 10})
+		assert.ErrorIs(t, err, model.ErrPromotionCodeAlreadyUsed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := service.CreatePromotion(model.Promotion{Code: code, Type: model.PromotionPercent, Value: 150})
+		assert.ErrorIs(t, err, model.ErrInvalidPromotion)
+		_, err = service.CreatePromotion(model.Promotion{Code: code, Type: model.PromotionFixed, Value: 100})
+		assert.ErrorIs(t, err, model.ErrInvalidPromotion)
+	})
+}
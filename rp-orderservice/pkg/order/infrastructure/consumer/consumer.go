@@ -14,14 +14,16 @@ import (
 	appservice "orderservice/pkg/order/application/service"
 	"orderservice/pkg/order/domain/model"
 	"orderservice/pkg/order/infrastructure/metrics"
+	inframysql "orderservice/pkg/order/infrastructure/mysql"
 )
 
 type EventConsumer struct {
-	conn            amqp.Connection
-	dataSyncService appservice.DataSyncService
-	logger          logging.Logger
-	ctx             context.Context
-	pool            mysql.ConnectionPool
+	conn              amqp.Connection
+	dataSyncService   appservice.DataSyncService
+	userAccessService appservice.UserAccessService
+	logger            logging.Logger
+	ctx               context.Context
+	pool              mysql.ConnectionPool
 }
 
 func NewEventConsumer(
@@ -31,13 +33,16 @@ func NewEventConsumer(
 	logger logging.Logger,
 ) (*EventConsumer, error) {
 	uow := &unitOfWorkForSync{pool: pool}
+	libUoW := mysql.NewUnitOfWork(pool, inframysql.NewRepositoryProvider)
+	luow := inframysql.NewLockableUnitOfWork(mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(pool)))
 
 	return &EventConsumer{
-		conn:            conn,
-		dataSyncService: appservice.NewDataSyncService(uow),
-		logger:          logger,
-		ctx:             ctx,
-		pool:            pool,
+		conn:              conn,
+		dataSyncService:   appservice.NewDataSyncService(uow),
+		userAccessService: appservice.NewUserAccessService(uow, luow),
+		logger:            logger,
+		ctx:               ctx,
+		pool:              pool,
 	}, nil
 }
 
@@ -59,7 +64,8 @@ func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err
 	l.Info("processing event")
 
 	switch delivery.Type {
-	case "user_created", "user_updated":
+	// user_updated логин не содержит, логин меняется только событием user_login_changed
+	case "user_created", "user_login_changed":
 		var event struct {
 			UserID string `json:"user_id"`
 			Login  string `json:"login"`
@@ -82,36 +88,94 @@ func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err
 			l.Error(storeErr, "failed to sync user")
 			return nil
 		}
+		if delivery.Type == "user_created" {
+			return c.syncUserAccess(ctx, l, delivery)
+		}
 		l.Info("user synced successfully")
 		return errors.New("user processed")
 
+	// права нужны для проверки доступа к методам, см. transport.MethodPermissions
+	case "user_updated", "user_deleted", "user_restored":
+		return c.syncUserAccess(ctx, l, delivery)
+
 	case "product_created", "product_updated":
+		var event productEvent
+		if err = json.Unmarshal(delivery.Body, &event); err != nil {
+			l.Error(err, "failed to unmarshal product event")
+			return nil
+		}
+		// productservice в product_updated присылает все поля товара, а не только измененные
+		if delivery.Type == "product_updated" {
+			event.productFields = event.UpdatedFields
+		}
+
+		product, parseErr := event.localProduct()
+		if parseErr != nil {
+			l.Error(parseErr, "invalid id in product event")
+			return nil
+		}
+
+		storeErr := c.dataSyncService.SyncProduct(ctx, product)
+		if storeErr != nil {
+			l.Error(storeErr, "failed to sync product")
+			return nil
+		}
+		l.Info("product synced successfully")
+		return errors.New("product processed")
+
+	case "product_archived", "product_unarchived":
 		var event struct {
 			ProductID string `json:"product_id"`
-			Name      string `json:"name"`
-			Price     int64  `json:"price"`
 		}
 		if err = json.Unmarshal(delivery.Body, &event); err != nil {
 			l.Error(err, "failed to unmarshal product event")
 			return nil
 		}
-
 		productID, parseErr := uuid.Parse(event.ProductID)
 		if parseErr != nil {
 			l.Error(parseErr, "invalid product id in product event")
 			return nil
 		}
 
-		storeErr := c.dataSyncService.SyncProduct(ctx, model.LocalProduct{
-			ProductID: productID,
-			Name:      event.Name,
-			Price:     event.Price,
-		})
+		storeErr := c.dataSyncService.SetProductArchived(ctx, productID, delivery.Type == "product_archived")
 		if storeErr != nil {
-			l.Error(storeErr, "failed to sync product")
+			l.Error(storeErr, "failed to sync product archive state")
 			return nil
 		}
-		l.Info("product synced successfully")
+		l.Info("product archive state synced successfully")
+		return errors.New("product processed")
+
+	// productservice присылает полный список категорий товара, он нужен для акций на категории
+	case "product_categories_changed":
+		var event struct {
+			ProductID   string   `json:"product_id"`
+			CategoryIDs []string `json:"category_ids"`
+		}
+		if err = json.Unmarshal(delivery.Body, &event); err != nil {
+			l.Error(err, "failed to unmarshal product categories event")
+			return nil
+		}
+		productID, parseErr := uuid.Parse(event.ProductID)
+		if parseErr != nil {
+			l.Error(parseErr, "invalid product id in product categories event")
+			return nil
+		}
+		categoryIDs := make([]uuid.UUID, 0, len(event.CategoryIDs))
+		for _, id := range event.CategoryIDs {
+			categoryID, parseErr := uuid.Parse(id)
+			if parseErr != nil {
+				l.Error(parseErr, "invalid category id in product categories event")
+				return nil
+			}
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+
+		storeErr := c.dataSyncService.SetProductCategories(ctx, productID, categoryIDs)
+		if storeErr != nil {
+			l.Error(storeErr, "failed to sync product categories")
+			return nil
+		}
+		l.Info("product categories synced successfully")
 		return errors.New("product processed")
 
 	default:
@@ -119,3 +183,65 @@ func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err
 		return nil
 	}
 }
+
+func (c *EventConsumer) syncUserAccess(ctx context.Context, l logging.Logger, delivery amqp.Delivery) error {
+	syncErr := syncUserAccess(ctx, c.userAccessService, delivery.Type, delivery.Body)
+	if errors.Is(syncErr, errInvalidEvent) {
+		l.Error(syncErr, "failed to parse user event")
+		return nil
+	}
+	if syncErr != nil {
+		l.Error(syncErr, "failed to sync user access")
+		return nil
+	}
+	l.Info("user access synced successfully")
+	return errors.New("user processed")
+}
+
+type productFields struct {
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Currency string `json:"currency"`
+	Variants []struct {
+		VariantID string `json:"variant_id"`
+		SKU       string `json:"sku"`
+		Price     int64  `json:"price"`
+		Stock     int64  `json:"stock"`
+	} `json:"variants"`
+}
+
+type productEvent struct {
+	ProductID string `json:"product_id"`
+	productFields
+	UpdatedFields productFields `json:"updated_fields"`
+}
+
+func (e productEvent) localProduct() (model.LocalProduct, error) {
+	productID, err := uuid.Parse(e.ProductID)
+	if err != nil {
+		return model.LocalProduct{}, err
+	}
+	product := model.LocalProduct{
+		ProductID: productID,
+		Name:      e.Name,
+		Price:     e.Price,
+		Currency:  e.Currency,
+	}
+	// события, отправленные до появления валют, валюту не содержат
+	if product.Currency == "" {
+		product.Currency = model.DefaultCurrency
+	}
+	for _, variant := range e.Variants {
+		variantID, err := uuid.Parse(variant.VariantID)
+		if err != nil {
+			return model.LocalProduct{}, err
+		}
+		product.Variants = append(product.Variants, model.LocalVariant{
+			VariantID: variantID,
+			SKU:       variant.SKU,
+			Price:     variant.Price,
+			Stock:     variant.Stock,
+		})
+	}
+	return product, nil
+}
@@ -0,0 +1,114 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appservice "orderservice/pkg/order/application/service"
+)
+
+// userStatusActive - статус Active в событиях userservice
+const userStatusActive = 1
+
+var errInvalidEvent = errors.New("invalid event")
+
+// syncUserAccess переносит права и статус пользователя из события userservice в локальную копию
+func syncUserAccess(ctx context.Context, userAccessService appservice.UserAccessService, eventType string, body []byte) error {
+	switch eventType {
+	case "user_created":
+		var event userCreated
+		userID, err := unmarshalUserEvent(body, &event, &event.UserID)
+		if err != nil {
+			return err
+		}
+		createdAt := time.Unix(event.CreatedAt, 0)
+		err = userAccessService.SyncUserPermissions(ctx, userID, event.Permissions, createdAt)
+		if err != nil {
+			return err
+		}
+		return userAccessService.SyncUserStatus(ctx, userID, event.Status == userStatusActive, createdAt)
+	case "user_updated":
+		var event userUpdated
+		userID, err := unmarshalUserEvent(body, &event, &event.UserID)
+		if err != nil {
+			return err
+		}
+		if event.UpdatedFields == nil {
+			return nil
+		}
+		updatedAt := time.Unix(event.UpdatedAt, 0)
+		if event.UpdatedFields.Permissions != nil {
+			err = userAccessService.SyncUserPermissions(ctx, userID, *event.UpdatedFields.Permissions, updatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		if event.UpdatedFields.Status != nil {
+			return userAccessService.SyncUserStatus(ctx, userID, *event.UpdatedFields.Status == userStatusActive, updatedAt)
+		}
+		return nil
+	case "user_deleted":
+		var event userDeleted
+		userID, err := unmarshalUserEvent(body, &event, &event.UserID)
+		if err != nil {
+			return err
+		}
+		if event.Hard {
+			return userAccessService.ForgetUser(ctx, userID)
+		}
+		return userAccessService.SyncUserStatus(ctx, userID, false, time.Unix(event.DeletedAt, 0))
+	case "user_restored":
+		var event userRestored
+		userID, err := unmarshalUserEvent(body, &event, &event.UserID)
+		if err != nil {
+			return err
+		}
+		return userAccessService.SyncUserStatus(ctx, userID, event.Status == userStatusActive, time.Unix(event.RestoredAt, 0))
+	default:
+		return nil
+	}
+}
+
+func unmarshalUserEvent(body []byte, event interface{}, rawUserID *string) (uuid.UUID, error) {
+	err := json.Unmarshal(body, event)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(errInvalidEvent, err.Error())
+	}
+	userID, err := uuid.Parse(*rawUserID)
+	if err != nil {
+		return uuid.Nil, errors.Wrap(errInvalidEvent, err.Error())
+	}
+	return userID, nil
+}
+
+type userCreated struct {
+	UserID      string   `json:"user_id"`
+	Status      int      `json:"status"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   int64    `json:"created_at"`
+}
+
+type userUpdated struct {
+	UserID        string `json:"user_id"`
+	UpdatedFields *struct {
+		Status      *int      `json:"status,omitempty"`
+		Permissions *[]string `json:"permissions,omitempty"`
+	} `json:"updated_fields,omitempty"`
+	UpdatedAt int64 `json:"updated_at,omitempty"`
+}
+
+type userDeleted struct {
+	UserID    string `json:"user_id"`
+	DeletedAt int64  `json:"deleted_at"`
+	Hard      bool   `json:"hard"`
+}
+
+type userRestored struct {
+	UserID     string `json:"user_id"`
+	Status     int    `json:"status"`
+	RestoredAt int64  `json:"restored_at"`
+}
@@ -19,52 +19,94 @@ type eventSerializer struct{}
 func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 	switch e := event.(type) {
 	case *model.OrderCreated:
-		items := make([]OrderItem, len(e.Items))
-		for i, item := range e.Items {
-			items[i] = OrderItem{
-				ProductID: item.ProductID.String(),
-				Quantity:  item.Quantity,
-				Price:     item.Price,
-			}
-		}
 		b, err := json.Marshal(OrderCreated{
-			OrderID:    e.OrderID.String(),
-			UserID:     e.UserID.String(),
-			TotalPrice: e.TotalPrice,
-			Items:      items,
-			CreatedAt:  e.CreatedAt.Unix(),
+			OrderID:       e.OrderID.String(),
+			UserID:        e.UserID.String(),
+			Currency:      e.Currency,
+			PromoCode:     e.PromoCode,
+			DiscountTotal: e.DiscountTotal,
+			TotalPrice:    e.TotalPrice,
+			Items:         orderItems(e.Items),
+			CreatedAt:     e.CreatedAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
 
 	case *model.OrderPaid:
 		b, err := json.Marshal(OrderPaid{
-			OrderID: e.OrderID.String(),
-			PaidAt:  e.PaidAt.Unix(),
+			OrderID:    e.OrderID.String(),
+			UserID:     e.UserID.String(),
+			Currency:   e.Currency,
+			TotalPrice: e.TotalPrice,
+			Items:      orderItems(e.Items),
+			PaidAt:     e.PaidAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
 
 	case *model.OrderCancelled:
 		b, err := json.Marshal(OrderCancelled{
 			OrderID:     e.OrderID.String(),
+			UserID:      e.UserID.String(),
 			Reason:      e.Reason,
 			CancelledAt: e.CancelledAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
 
+	case *model.ExchangeRateUpdated:
+		b, err := json.Marshal(ExchangeRateUpdated{
+			From:      e.From,
+			To:        e.To,
+			Rate:      e.Rate,
+			UpdatedAt: e.UpdatedAt.Unix(),
+		})
+		return string(b), errors.WithStack(err)
+
 	default:
 		return "", errors.Errorf("unknown event %q", event.Type())
 	}
 }
 
+func orderItems(orderItems []model.OrderItem) []OrderItem {
+	items := make([]OrderItem, len(orderItems))
+	for i, item := range orderItems {
+		items[i] = OrderItem{
+			ProductID:        item.ProductID.String(),
+			SKU:              item.SKU,
+			Quantity:         item.Quantity,
+			Price:            item.Price,
+			OriginalPrice:    item.OriginalPrice,
+			OriginalCurrency: item.OriginalCurrency,
+			ExchangeRate:     item.ExchangeRate,
+			Discount:         item.Discount,
+		}
+		if item.VariantID != nil {
+			variantID := item.VariantID.String()
+			items[i].VariantID = &variantID
+		}
+	}
+	return items
+}
+
 type OrderItem struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
-	Price     int64  `json:"price"`
+	ProductID string  `json:"product_id"`
+	VariantID *string `json:"variant_id,omitempty"`
+	SKU       string  `json:"sku,omitempty"`
+	Quantity  int     `json:"quantity"`
+	// Price - в валюте заказа, OriginalPrice - в валюте товара
+	Price            int64  `json:"price"`
+	OriginalPrice    int64  `json:"original_price"`
+	OriginalCurrency string `json:"original_currency"`
+	ExchangeRate     int64  `json:"exchange_rate"`
+	// Discount - скидка по промокоду на всю позицию, а не на единицу товара
+	Discount int64 `json:"discount"`
 }
 
 type OrderCreated struct {
-	OrderID    string      `json:"order_id"`
-	UserID     string      `json:"user_id"`
+	OrderID       string `json:"order_id"`
+	UserID        string `json:"user_id"`
+	Currency      string `json:"currency"`
+	PromoCode     string `json:"promo_code,omitempty"`
+	DiscountTotal int64  `json:"discount_total"`
+	// TotalPrice - с учетом скидки
 	TotalPrice int64       `json:"total_price"`
 	Items      []OrderItem `json:"items"`
 	CreatedAt  int64       `json:"created_at"`
@@ -72,11 +114,25 @@ type OrderCreated struct {
 
 type OrderPaid struct {
 	OrderID string `json:"order_id"`
-	PaidAt  int64  `json:"paid_at"`
+	// покупатель и состав заказа нужны productservice, чтобы разрешать отзывы только купившим
+	UserID     string      `json:"user_id"`
+	Currency   string      `json:"currency"`
+	TotalPrice int64       `json:"total_price"`
+	Items      []OrderItem `json:"items"`
+	PaidAt     int64       `json:"paid_at"`
 }
 
 type OrderCancelled struct {
 	OrderID     string `json:"order_id"`
+	UserID      string `json:"user_id"`
 	Reason      string `json:"reason"`
 	CancelledAt int64  `json:"cancelled_at"`
 }
+
+type ExchangeRateUpdated struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Rate - курс, умноженный на 1000000
+	Rate      int64 `json:"rate"`
+	UpdatedAt int64 `json:"updated_at"`
+}
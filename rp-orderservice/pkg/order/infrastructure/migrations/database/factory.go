@@ -46,4 +46,12 @@ var builderFunctions = []MigrationBuilderFunc{
 	NewVersion1722266006,
 	NewVersion1722266007,
 	NewVersion1722266008,
+	NewVersion1792400001,
+	NewVersion1792400002,
+	NewVersion1792400003,
+	NewVersion1792400004,
+	NewVersion1792400005,
+	NewVersion1792400006,
+	NewVersion1792400007,
+	NewVersion1792400008,
 }
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400003(client mysql.ClientContext) migrator.Migration {
+	return &version1792400003{
+		client: client,
+	}
+}
+
+type version1792400003 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400003) Version() int64 {
+	return 1792400003
+}
+
+func (v version1792400003) Description() string {
+	return "Create 'local_product_variant' table, add variant to 'order_item'"
+}
+
+func (v version1792400003) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE local_product_variant
+		(
+		    variant_id VARCHAR(64) NOT NULL,
+		    product_id VARCHAR(64) NOT NULL,
+		    sku        VARCHAR(64) NOT NULL,
+		    price      BIGINT      NOT NULL,
+		    PRIMARY KEY (variant_id),
+		    INDEX product_idx (product_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// пустой variant_id у товара без вариантов, NULL в первичный ключ не попадет
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE order_item
+		    ADD COLUMN variant_id VARCHAR(64) NOT NULL DEFAULT '' AFTER product_id,
+		    ADD COLUMN sku        VARCHAR(64) NOT NULL DEFAULT '' AFTER variant_id,
+		    DROP PRIMARY KEY,
+		    ADD PRIMARY KEY (order_id, product_id, variant_id)
+	`)
+	return errors.WithStack(err)
+}
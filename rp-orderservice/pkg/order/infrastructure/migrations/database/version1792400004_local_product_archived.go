@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400004(client mysql.ClientContext) migrator.Migration {
+	return &version1792400004{
+		client: client,
+	}
+}
+
+type version1792400004 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400004) Version() int64 {
+	return 1792400004
+}
+
+func (v version1792400004) Description() string {
+	return "Add 'archived' to 'local_product' table"
+}
+
+func (v version1792400004) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE local_product
+		    ADD COLUMN archived TINYINT(1) NOT NULL DEFAULT 0
+	`)
+	return errors.WithStack(err)
+}
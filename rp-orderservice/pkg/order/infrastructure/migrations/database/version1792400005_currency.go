@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400005(client mysql.ClientContext) migrator.Migration {
+	return &version1792400005{
+		client: client,
+	}
+}
+
+type version1792400005 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400005) Version() int64 {
+	return 1792400005
+}
+
+func (v version1792400005) Description() string {
+	return "Add currencies to orders and local products, create 'exchange_rate' table"
+}
+
+func (v version1792400005) Up(ctx context.Context) error {
+	// до появления валют все суммы были в рублях, курс позиций - 1
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE `+"`order`"+`
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB'
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE order_item
+		    ADD COLUMN original_price BIGINT NOT NULL DEFAULT 0,
+		    ADD COLUMN original_currency CHAR(3) NOT NULL DEFAULT 'RUB',
+		    ADD COLUMN exchange_rate BIGINT NOT NULL DEFAULT 1000000
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `UPDATE order_item SET original_price = price`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE local_product
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB'
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE exchange_rate
+		(
+		    from_currency CHAR(3)  NOT NULL,
+		    to_currency   CHAR(3)  NOT NULL,
+		    rate          BIGINT   NOT NULL,
+		    updated_at    DATETIME NOT NULL,
+		    PRIMARY KEY (from_currency, to_currency)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
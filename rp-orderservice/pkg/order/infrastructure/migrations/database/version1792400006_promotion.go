@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400006(client mysql.ClientContext) migrator.Migration {
+	return &version1792400006{
+		client: client,
+	}
+}
+
+type version1792400006 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400006) Version() int64 {
+	return 1792400006
+}
+
+func (v version1792400006) Description() string {
+	return "Create 'promotion' tables, add discounts to orders and categories to local products"
+}
+
+func (v version1792400006) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE promotion
+		(
+		    promotion_id    VARCHAR(64) NOT NULL,
+		    code            VARCHAR(64) NOT NULL,
+		    type            INT         NOT NULL,
+		    value           BIGINT      NOT NULL,
+		    currency        CHAR(3)     NOT NULL DEFAULT '',
+		    min_order_value BIGINT      NOT NULL DEFAULT 0,
+		    usage_limit     INT         NOT NULL DEFAULT 0,
+		    per_user_limit  INT         NOT NULL DEFAULT 0,
+		    used_count      INT         NOT NULL DEFAULT 0,
+		    valid_from      DATETIME    NULL,
+		    valid_until     DATETIME    NULL,
+		    created_at      DATETIME    NOT NULL,
+		    updated_at      DATETIME    NOT NULL,
+		    PRIMARY KEY (promotion_id),
+		    UNIQUE INDEX promotion_code_idx (code)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE promotion_product
+		(
+		    promotion_id VARCHAR(64) NOT NULL,
+		    product_id   VARCHAR(64) NOT NULL,
+		    PRIMARY KEY (promotion_id, product_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE promotion_category
+		(
+		    promotion_id VARCHAR(64) NOT NULL,
+		    category_id  VARCHAR(64) NOT NULL,
+		    PRIMARY KEY (promotion_id, category_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// у заказа не больше одного кода, поэтому погашение однозначно определяется заказом
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE promotion_redemption
+		(
+		    order_id     VARCHAR(64) NOT NULL,
+		    promotion_id VARCHAR(64) NOT NULL,
+		    user_id      VARCHAR(64) NOT NULL,
+		    redeemed_at  DATETIME    NOT NULL,
+		    PRIMARY KEY (order_id),
+		    INDEX promotion_redemption_user_idx (promotion_id, user_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE local_product_category
+		(
+		    product_id  VARCHAR(64) NOT NULL,
+		    category_id VARCHAR(64) NOT NULL,
+		    PRIMARY KEY (product_id, category_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE `+"`order`"+`
+		    ADD COLUMN promotion_id VARCHAR(64) NULL,
+		    ADD COLUMN promo_code VARCHAR(64) NOT NULL DEFAULT '',
+		    ADD COLUMN discount_total BIGINT NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE order_item
+		    ADD COLUMN discount BIGINT NOT NULL DEFAULT 0
+	`)
+	return errors.WithStack(err)
+}
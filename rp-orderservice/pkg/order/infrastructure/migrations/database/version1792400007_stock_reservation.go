@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400007(client mysql.ClientContext) migrator.Migration {
+	return &version1792400007{
+		client: client,
+	}
+}
+
+type version1792400007 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400007) Version() int64 {
+	return 1792400007
+}
+
+func (v version1792400007) Description() string {
+	return "Add stock to 'local_product_variant', create 'stock_reservation' table"
+}
+
+func (v version1792400007) Up(ctx context.Context) error {
+	// Остаток приходит с событиями товара. До первого события после миграции он неизвестен (NULL)
+	// и заказы не ограничивает, иначе все варианты оказались бы распроданы
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE local_product_variant
+		    ADD COLUMN stock BIGINT NULL,
+		    ADD COLUMN reserved BIGINT NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE stock_reservation
+		(
+		    order_id   VARCHAR(64) NOT NULL,
+		    variant_id VARCHAR(64) NOT NULL,
+		    quantity   BIGINT      NOT NULL,
+		    PRIMARY KEY (order_id, variant_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
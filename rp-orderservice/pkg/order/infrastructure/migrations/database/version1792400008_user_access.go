@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400008(client mysql.ClientContext) migrator.Migration {
+	return &version1792400008{
+		client: client,
+	}
+}
+
+type version1792400008 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400008) Version() int64 {
+	return 1792400008
+}
+
+func (v version1792400008) Description() string {
+	return "Create 'user_access' table"
+}
+
+func (v version1792400008) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE user_access
+		(
+		    user_id                VARCHAR(64)  NOT NULL,
+		    permissions            VARCHAR(255) NOT NULL DEFAULT '',
+		    permissions_updated_at DATETIME     NOT NULL,
+		    active                 TINYINT(1)   NOT NULL DEFAULT 0,
+		    status_updated_at      DATETIME     NOT NULL,
+		    PRIMARY KEY (user_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	appmodel "orderservice/pkg/order/application/model"
+	"orderservice/pkg/order/application/query"
+	"orderservice/pkg/order/infrastructure/metrics"
+)
+
+func NewExchangeRateQueryService(client mysql.ClientContext) query.ExchangeRateQueryService {
+	return &exchangeRateQueryService{
+		client: client,
+	}
+}
+
+type exchangeRateQueryService struct {
+	client mysql.ClientContext
+}
+
+func (s *exchangeRateQueryService) ListExchangeRates(ctx context.Context) (_ []appmodel.ExchangeRate, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "exchange_rate", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var ratesData []struct {
+		From      string    `db:"from_currency"`
+		To        string    `db:"to_currency"`
+		Rate      int64     `db:"rate"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+	err = s.client.SelectContext(ctx, &ratesData,
+		`SELECT from_currency, to_currency, rate, updated_at FROM exchange_rate ORDER BY from_currency, to_currency`)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rates := make([]appmodel.ExchangeRate, 0, len(ratesData))
+	for _, rate := range ratesData {
+		rates = append(rates, appmodel.ExchangeRate{
+			From:      rate.From,
+			To:        rate.To,
+			Rate:      rate.Rate,
+			UpdatedAt: rate.UpdatedAt.Unix(),
+		})
+	}
+	return rates, nil
+}
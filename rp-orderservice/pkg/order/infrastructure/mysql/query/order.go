@@ -36,14 +36,19 @@ func (s *orderQueryService) FindOrder(ctx context.Context, orderID uuid.UUID) (_
 	}()
 
 	orderData := struct {
-		OrderID    uuid.UUID `db:"order_id"`
-		UserID     uuid.UUID `db:"user_id"`
-		TotalPrice int64     `db:"total_price"`
-		Status     int       `db:"status"`
-		CreatedAt  time.Time `db:"created_at"`
+		OrderID       uuid.UUID `db:"order_id"`
+		UserID        uuid.UUID `db:"user_id"`
+		Currency      string    `db:"currency"`
+		PromoCode     string    `db:"promo_code"`
+		DiscountTotal int64     `db:"discount_total"`
+		TotalPrice    int64     `db:"total_price"`
+		Status        int       `db:"status"`
+		CreatedAt     time.Time `db:"created_at"`
 	}{}
 
-	err = s.client.GetContext(ctx, &orderData, "SELECT order_id, user_id, total_price, status, created_at FROM `order` WHERE order_id = ?", orderID)
+	err = s.client.GetContext(ctx, &orderData,
+		"SELECT order_id, user_id, currency, promo_code, discount_total, total_price, status, created_at FROM `order` WHERE order_id = ?",
+		orderID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.WithStack(model.ErrOrderNotFound)
@@ -52,28 +57,54 @@ func (s *orderQueryService) FindOrder(ctx context.Context, orderID uuid.UUID) (_
 	}
 
 	var itemsData []struct {
-		ProductID uuid.UUID `db:"product_id"`
-		Quantity  int       `db:"quantity"`
+		ProductID        uuid.UUID `db:"product_id"`
+		VariantID        string    `db:"variant_id"`
+		SKU              string    `db:"sku"`
+		Quantity         int       `db:"quantity"`
+		Price            int64     `db:"price"`
+		OriginalPrice    int64     `db:"original_price"`
+		OriginalCurrency string    `db:"original_currency"`
+		ExchangeRate     int64     `db:"exchange_rate"`
+		Discount         int64     `db:"discount"`
 	}
-	err = s.client.SelectContext(ctx, &itemsData, `SELECT product_id, quantity FROM order_item WHERE order_id = ?`, orderID)
+	err = s.client.SelectContext(ctx, &itemsData,
+		`SELECT product_id, variant_id, sku, quantity, price, original_price, original_currency, exchange_rate, discount FROM order_item WHERE order_id = ?`,
+		orderID)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	items := make([]appmodel.OrderItem, len(itemsData))
 	for i, itemData := range itemsData {
-		items[i] = appmodel.OrderItem{
-			ProductID: itemData.ProductID,
-			Quantity:  itemData.Quantity,
+		item := appmodel.OrderItem{
+			ProductID:        itemData.ProductID,
+			SKU:              itemData.SKU,
+			Quantity:         itemData.Quantity,
+			Price:            itemData.Price,
+			OriginalPrice:    itemData.OriginalPrice,
+			OriginalCurrency: itemData.OriginalCurrency,
+			ExchangeRate:     itemData.ExchangeRate,
+			Discount:         itemData.Discount,
 		}
+		if itemData.VariantID != "" {
+			variantID, err := uuid.Parse(itemData.VariantID)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			item.VariantID = &variantID
+		}
+		items[i] = item
 	}
 
 	return &appmodel.Order{
-		OrderID:    orderData.OrderID,
-		UserID:     orderData.UserID,
-		Items:      items,
-		TotalPrice: orderData.TotalPrice,
-		Status:     orderData.Status,
-		CreatedAt:  orderData.CreatedAt.Unix(),
+		OrderID:       orderData.OrderID,
+		UserID:        orderData.UserID,
+		Items:         items,
+		Currency:      orderData.Currency,
+		PromoCode:     orderData.PromoCode,
+		DiscountTotal: orderData.DiscountTotal,
+		TotalPrice:    orderData.TotalPrice,
+		Status:        orderData.Status,
+		CreatedAt:     orderData.CreatedAt.Unix(),
 	}, nil
 }
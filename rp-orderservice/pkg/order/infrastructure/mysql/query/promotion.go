@@ -0,0 +1,109 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "orderservice/pkg/order/application/model"
+	"orderservice/pkg/order/application/query"
+	"orderservice/pkg/order/infrastructure/metrics"
+)
+
+func NewPromotionQueryService(client mysql.ClientContext) query.PromotionQueryService {
+	return &promotionQueryService{
+		client: client,
+	}
+}
+
+type promotionQueryService struct {
+	client mysql.ClientContext
+}
+
+func (s *promotionQueryService) ListPromotions(ctx context.Context) (_ []appmodel.Promotion, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "promotion", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var promotionsData []struct {
+		PromotionID   uuid.UUID  `db:"promotion_id"`
+		Code          string     `db:"code"`
+		Type          int        `db:"type"`
+		Value         int64      `db:"value"`
+		Currency      string     `db:"currency"`
+		MinOrderValue int64      `db:"min_order_value"`
+		UsageLimit    int        `db:"usage_limit"`
+		PerUserLimit  int        `db:"per_user_limit"`
+		UsedCount     int        `db:"used_count"`
+		ValidFrom     *time.Time `db:"valid_from"`
+		ValidUntil    *time.Time `db:"valid_until"`
+		CreatedAt     time.Time  `db:"created_at"`
+		UpdatedAt     time.Time  `db:"updated_at"`
+	}
+	err = s.client.SelectContext(ctx, &promotionsData,
+		`SELECT promotion_id, code, type, value, currency, min_order_value, usage_limit, per_user_limit, used_count,
+		     valid_from, valid_until, created_at, updated_at FROM promotion ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var scopesData []struct {
+		PromotionID uuid.UUID `db:"promotion_id"`
+		ScopeID     uuid.UUID `db:"scope_id"`
+		IsCategory  bool      `db:"is_category"`
+	}
+	err = s.client.SelectContext(ctx, &scopesData,
+		`SELECT promotion_id, product_id AS scope_id, FALSE AS is_category FROM promotion_product
+		 UNION ALL
+		 SELECT promotion_id, category_id AS scope_id, TRUE AS is_category FROM promotion_category`)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	productIDs := make(map[uuid.UUID][]uuid.UUID)
+	categoryIDs := make(map[uuid.UUID][]uuid.UUID)
+	for _, scope := range scopesData {
+		if scope.IsCategory {
+			categoryIDs[scope.PromotionID] = append(categoryIDs[scope.PromotionID], scope.ScopeID)
+		} else {
+			productIDs[scope.PromotionID] = append(productIDs[scope.PromotionID], scope.ScopeID)
+		}
+	}
+
+	promotions := make([]appmodel.Promotion, 0, len(promotionsData))
+	for _, promotion := range promotionsData {
+		promotions = append(promotions, appmodel.Promotion{
+			PromotionID:   promotion.PromotionID,
+			Code:          promotion.Code,
+			Type:          promotion.Type,
+			Value:         promotion.Value,
+			Currency:      promotion.Currency,
+			MinOrderValue: promotion.MinOrderValue,
+			UsageLimit:    promotion.UsageLimit,
+			PerUserLimit:  promotion.PerUserLimit,
+			UsedCount:     promotion.UsedCount,
+			ValidFrom:     unixTime(promotion.ValidFrom),
+			ValidUntil:    unixTime(promotion.ValidUntil),
+			ProductIDs:    productIDs[promotion.PromotionID],
+			CategoryIDs:   categoryIDs[promotion.PromotionID],
+			CreatedAt:     promotion.CreatedAt.Unix(),
+			UpdatedAt:     promotion.UpdatedAt.Unix(),
+		})
+	}
+	return promotions, nil
+}
+
+func unixTime(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "orderservice/pkg/order/application/model"
+	"orderservice/pkg/order/application/query"
+	"orderservice/pkg/order/infrastructure/metrics"
+)
+
+func NewUserDataQueryService(client mysql.ClientContext) query.UserDataQueryService {
+	return &userDataQueryService{
+		client:            client,
+		orderQueryService: &orderQueryService{client: client},
+	}
+}
+
+type userDataQueryService struct {
+	client            mysql.ClientContext
+	orderQueryService *orderQueryService
+}
+
+func (s *userDataQueryService) FindUserData(ctx context.Context, userID uuid.UUID) (_ appmodel.UserData, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_query", "user_data", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var userData appmodel.UserData
+
+	var login string
+	err = s.client.GetContext(ctx, &login, `SELECT login FROM local_user WHERE user_id = ?`, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return appmodel.UserData{}, errors.WithStack(err)
+	}
+	if err == nil {
+		userData.Login = &login
+	}
+
+	var orderIDs []uuid.UUID
+	err = s.client.SelectContext(ctx, &orderIDs, "SELECT order_id FROM `order` WHERE user_id = ? ORDER BY created_at", userID)
+	if err != nil {
+		return appmodel.UserData{}, errors.WithStack(err)
+	}
+	for _, orderID := range orderIDs {
+		order, findErr := s.orderQueryService.FindOrder(ctx, orderID)
+		if findErr != nil {
+			return appmodel.UserData{}, findErr
+		}
+		userData.Orders = append(userData.Orders, *order)
+	}
+
+	return userData, nil
+}
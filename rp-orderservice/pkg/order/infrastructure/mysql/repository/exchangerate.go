@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"orderservice/pkg/order/domain/model"
+	"orderservice/pkg/order/infrastructure/metrics"
+)
+
+const auditEntityExchangeRate = "exchange_rate"
+
+func NewExchangeRateRepository(ctx context.Context, client mysql.ClientContext) model.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type exchangeRateRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *exchangeRateRepository) Store(rate model.ExchangeRate) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "exchange_rate", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := r.Find(rate.From, rate.To)
+	if err != nil && !errors.Is(err, model.ErrExchangeRateNotFound) {
+		return err
+	}
+
+	_, err = r.client.ExecContext(r.ctx,
+		`INSERT INTO exchange_rate (from_currency, to_currency, rate, updated_at) VALUES (?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE rate=VALUES(rate), updated_at=VALUES(updated_at)`,
+		rate.From, rate.To, rate.Rate, rate.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord(r.ctx, r.client, auditEntityExchangeRate, rate.From+"/"+rate.To, before, &rate)
+}
+
+func (r *exchangeRateRepository) Find(from, to string) (_ *model.ExchangeRate, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil && !errors.Is(err, model.ErrExchangeRateNotFound) {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "exchange_rate", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var rate sqlxExchangeRate
+	err = r.client.GetContext(r.ctx, &rate,
+		`SELECT from_currency, to_currency, rate, updated_at FROM exchange_rate WHERE from_currency = ? AND to_currency = ?`,
+		from, to)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrExchangeRateNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &model.ExchangeRate{
+		From:      rate.From,
+		To:        rate.To,
+		Rate:      rate.Rate,
+		UpdatedAt: rate.UpdatedAt,
+	}, nil
+}
+
+type sqlxExchangeRate struct {
+	From      string    `db:"from_currency"`
+	To        string    `db:"to_currency"`
+	Rate      int64     `db:"rate"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"slices"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
 	"github.com/google/uuid"
@@ -25,26 +26,68 @@ type localProductRepository struct {
 
 func (r *localProductRepository) Store(product model.LocalProduct) error {
 	_, err := r.client.ExecContext(r.ctx,
-		`INSERT INTO local_product (product_id, name, price) VALUES (?, ?, ?) 
-		 ON DUPLICATE KEY UPDATE name=VALUES(name), price=VALUES(price)`,
-		product.ProductID, product.Name, product.Price,
+		`INSERT INTO local_product (product_id, name, price, currency, archived) VALUES (?, ?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE name=VALUES(name), price=VALUES(price), currency=VALUES(currency)`,
+		product.ProductID, product.Name, product.Price, product.Currency, product.Archived,
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// события несут полный список вариантов, но резерв под неоплаченные заказы есть только в реплике,
+	// поэтому варианты обновляются на месте, а удаляются только пропавшие из события
+	var currentIDs []uuid.UUID
+	err = r.client.SelectContext(r.ctx, &currentIDs, `SELECT variant_id FROM local_product_variant WHERE product_id = ?`, product.ProductID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, variantID := range currentIDs {
+		if slices.ContainsFunc(product.Variants, func(v model.LocalVariant) bool { return v.VariantID == variantID }) {
+			continue
+		}
+		_, err = r.client.ExecContext(r.ctx, `DELETE FROM local_product_variant WHERE variant_id = ?`, variantID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	for _, variant := range product.Variants {
+		_, err = r.client.ExecContext(r.ctx,
+			`INSERT INTO local_product_variant (variant_id, product_id, sku, price, stock) VALUES (?, ?, ?, ?, ?)
+			 ON DUPLICATE KEY UPDATE sku=VALUES(sku), price=VALUES(price), stock=VALUES(stock)`,
+			variant.VariantID, product.ProductID, variant.SKU, variant.Price, variant.Stock,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
 }
 
 func (r *localProductRepository) Find(productID uuid.UUID) (*model.LocalProduct, error) {
 	var product sqlxProduct
-	err := r.client.GetContext(r.ctx, &product, `SELECT product_id, name, price FROM local_product WHERE product_id = ?`, productID)
+	err := r.client.GetContext(r.ctx, &product, `SELECT product_id, name, price, currency, archived FROM local_product WHERE product_id = ?`, productID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.WithStack(model.ErrProductNotFound)
 		}
 		return nil, errors.WithStack(err)
 	}
+	variants, err := r.findVariants(productID)
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs, err := r.findCategoryIDs(productID)
+	if err != nil {
+		return nil, err
+	}
 	return &model.LocalProduct{
-		ProductID: product.ProductID,
-		Name:      product.Name,
-		Price:     product.Price,
+		ProductID:   product.ProductID,
+		Name:        product.Name,
+		Price:       product.Price,
+		Currency:    product.Currency,
+		Variants:    variants,
+		CategoryIDs: categoryIDs,
+		Archived:    product.Archived,
 	}, nil
 }
 
@@ -58,7 +101,7 @@ func (r *localProductRepository) FindMany(productIDs []uuid.UUID) ([]model.Local
 	for _, productID := range productIDs {
 		var product sqlxProduct
 		err := r.client.GetContext(r.ctx, &product,
-			`SELECT product_id, name, price FROM local_product WHERE product_id = ?`,
+			`SELECT product_id, name, price, currency, archived FROM local_product WHERE product_id = ?`,
 			productID)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -66,18 +109,175 @@ func (r *localProductRepository) FindMany(productIDs []uuid.UUID) ([]model.Local
 			}
 			return nil, errors.WithStack(err)
 		}
+		variants, err := r.findVariants(productID)
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs, err := r.findCategoryIDs(productID)
+		if err != nil {
+			return nil, err
+		}
 		products = append(products, model.LocalProduct{
-			ProductID: product.ProductID,
-			Name:      product.Name,
-			Price:     product.Price,
+			ProductID:   product.ProductID,
+			Name:        product.Name,
+			Price:       product.Price,
+			Currency:    product.Currency,
+			Variants:    variants,
+			CategoryIDs: categoryIDs,
+			Archived:    product.Archived,
 		})
 	}
 
 	return products, nil
 }
 
+// SetArchived меняет только признак архива: product_updated его не несет и не должен сбрасывать
+func (r *localProductRepository) SetArchived(productID uuid.UUID, archived bool) error {
+	_, err := r.client.ExecContext(r.ctx, `UPDATE local_product SET archived = ? WHERE product_id = ?`, archived, productID)
+	return errors.WithStack(err)
+}
+
+// SetCategories заменяет категории товара: product_categories_changed несет полный список
+func (r *localProductRepository) SetCategories(productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	_, err := r.client.ExecContext(r.ctx, `DELETE FROM local_product_category WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, categoryID := range categoryIDs {
+		_, err = r.client.ExecContext(r.ctx,
+			`INSERT INTO local_product_category (product_id, category_id) VALUES (?, ?)`,
+			productID, categoryID,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (r *localProductRepository) ReserveStock(orderID uuid.UUID, items []model.OrderItem) error {
+	// один вариант может встретиться в заказе несколько раз, резерв на него один
+	var variantIDs []uuid.UUID
+	quantities := make(map[uuid.UUID]int64)
+	for _, item := range items {
+		if item.VariantID == nil {
+			continue
+		}
+		if _, ok := quantities[*item.VariantID]; !ok {
+			variantIDs = append(variantIDs, *item.VariantID)
+		}
+		quantities[*item.VariantID] += int64(item.Quantity)
+	}
+
+	for _, variantID := range variantIDs {
+		// Остаток проверяется в том же UPDATE, что и увеличивает резерв, поэтому параллельные заказы
+		// не могут зарезервировать больше остатка
+		result, err := r.client.ExecContext(r.ctx,
+			`UPDATE local_product_variant SET reserved = reserved + ?
+			 WHERE variant_id = ? AND (stock IS NULL OR stock - reserved >= ?)`,
+			quantities[variantID], variantID, quantities[variantID],
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if affected == 0 {
+			return errors.WithStack(model.ErrOutOfStock)
+		}
+
+		_, err = r.client.ExecContext(r.ctx,
+			`INSERT INTO stock_reservation (order_id, variant_id, quantity) VALUES (?, ?, ?)`,
+			orderID, variantID, quantities[variantID],
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func (r *localProductRepository) ReleaseStock(orderID uuid.UUID) error {
+	return r.removeReservations(orderID, false)
+}
+
+func (r *localProductRepository) CommitStock(orderID uuid.UUID) error {
+	return r.removeReservations(orderID, true)
+}
+
+// removeReservations удаляет резервы заказа, а при commit еще и списывает их с остатка.
+// Неизвестный остаток (NULL) так и остается неизвестным: GREATEST от NULL тоже NULL
+func (r *localProductRepository) removeReservations(orderID uuid.UUID, commit bool) error {
+	var reservations []struct {
+		VariantID uuid.UUID `db:"variant_id"`
+		Quantity  int64     `db:"quantity"`
+	}
+	err := r.client.SelectContext(r.ctx, &reservations,
+		`SELECT variant_id, quantity FROM stock_reservation WHERE order_id = ? FOR UPDATE`, orderID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, reservation := range reservations {
+		if commit {
+			_, err = r.client.ExecContext(r.ctx,
+				`UPDATE local_product_variant SET stock = GREATEST(stock - ?, 0), reserved = GREATEST(reserved - ?, 0)
+				 WHERE variant_id = ?`,
+				reservation.Quantity, reservation.Quantity, reservation.VariantID,
+			)
+		} else {
+			_, err = r.client.ExecContext(r.ctx,
+				`UPDATE local_product_variant SET reserved = GREATEST(reserved - ?, 0) WHERE variant_id = ?`,
+				reservation.Quantity, reservation.VariantID,
+			)
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM stock_reservation WHERE order_id = ?`, orderID)
+	return errors.WithStack(err)
+}
+
+func (r *localProductRepository) findCategoryIDs(productID uuid.UUID) ([]uuid.UUID, error) {
+	var categoryIDs []uuid.UUID
+	err := r.client.SelectContext(r.ctx, &categoryIDs,
+		`SELECT category_id FROM local_product_category WHERE product_id = ?`,
+		productID)
+	return categoryIDs, errors.WithStack(err)
+}
+
+func (r *localProductRepository) findVariants(productID uuid.UUID) ([]model.LocalVariant, error) {
+	var variantsData []struct {
+		VariantID uuid.UUID `db:"variant_id"`
+		SKU       string    `db:"sku"`
+		Price     int64     `db:"price"`
+	}
+	err := r.client.SelectContext(r.ctx, &variantsData,
+		`SELECT variant_id, sku, price FROM local_product_variant WHERE product_id = ?`,
+		productID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var variants []model.LocalVariant
+	for _, variant := range variantsData {
+		variants = append(variants, model.LocalVariant{
+			VariantID: variant.VariantID,
+			SKU:       variant.SKU,
+			Price:     variant.Price,
+		})
+	}
+	return variants, nil
+}
+
 type sqlxProduct struct {
 	ProductID uuid.UUID `db:"product_id"`
 	Name      string    `db:"name"`
 	Price     int64     `db:"price"`
+	Currency  string    `db:"currency"`
+	Archived  bool      `db:"archived"`
 }
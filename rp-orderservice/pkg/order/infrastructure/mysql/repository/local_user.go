@@ -46,6 +46,11 @@ func (r *localUserRepository) Find(userID uuid.UUID) (*model.LocalUser, error) {
 	}, nil
 }
 
+func (r *localUserRepository) Delete(userID uuid.UUID) error {
+	_, err := r.client.ExecContext(r.ctx, `DELETE FROM local_user WHERE user_id = ?`, userID)
+	return errors.WithStack(err)
+}
+
 type sqlxLocalUser struct {
 	UserID uuid.UUID `db:"user_id"`
 	Login  string    `db:"login"`
@@ -13,6 +13,8 @@ import (
 	"orderservice/pkg/order/infrastructure/metrics"
 )
 
+const auditEntityOrder = "order"
+
 func NewOrderRepository(ctx context.Context, client mysql.ClientContext) model.OrderRepository {
 	return &orderRepository{
 		ctx:    ctx,
@@ -39,10 +41,17 @@ func (r *orderRepository) Store(order model.Order) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("store", "order", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := r.Find(order.OrderID)
+	if err != nil && !errors.Is(err, model.ErrOrderNotFound) {
+		return err
+	}
+
 	_, err = r.client.ExecContext(r.ctx,
-		"INSERT INTO `order` (order_id, user_id, total_price, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?) "+
+		"INSERT INTO `order` (order_id, user_id, currency, promotion_id, promo_code, discount_total, total_price, status, created_at, updated_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) "+
 			"ON DUPLICATE KEY UPDATE total_price=VALUES(total_price), status=VALUES(status), updated_at=VALUES(updated_at)",
-		order.OrderID, order.UserID, order.TotalPrice, order.Status, order.CreatedAt, order.UpdatedAt,
+		order.OrderID, order.UserID, order.Currency, order.PromotionID, order.PromoCode, order.DiscountTotal,
+		order.TotalPrice, order.Status, order.CreatedAt, order.UpdatedAt,
 	)
 	if err != nil {
 		return errors.WithStack(err)
@@ -55,15 +64,17 @@ func (r *orderRepository) Store(order model.Order) (err error) {
 
 	for _, item := range order.Items {
 		_, err = r.client.ExecContext(r.ctx,
-			`INSERT INTO order_item (order_id, product_id, quantity, price) VALUES (?, ?, ?, ?)`,
-			order.OrderID, item.ProductID, item.Quantity, item.Price,
+			`INSERT INTO order_item (order_id, product_id, variant_id, sku, quantity, price, original_price, original_currency, exchange_rate, discount)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			order.OrderID, item.ProductID, toVariantKey(item.VariantID), item.SKU, item.Quantity, item.Price,
+			item.OriginalPrice, item.OriginalCurrency, item.ExchangeRate, item.Discount,
 		)
 		if err != nil {
 			return errors.WithStack(err)
 		}
 	}
 
-	return nil
+	return appendAuditRecord(r.ctx, r.client, auditEntityOrder, order.OrderID.String(), before, &order)
 }
 
 func (r *orderRepository) Find(orderID uuid.UUID) (_ *model.Order, err error) {
@@ -77,15 +88,21 @@ func (r *orderRepository) Find(orderID uuid.UUID) (_ *model.Order, err error) {
 	}()
 
 	orderData := struct {
-		OrderID    uuid.UUID `db:"order_id"`
-		UserID     uuid.UUID `db:"user_id"`
-		TotalPrice int64     `db:"total_price"`
-		Status     int       `db:"status"`
-		CreatedAt  time.Time `db:"created_at"`
-		UpdatedAt  time.Time `db:"updated_at"`
+		OrderID       uuid.UUID  `db:"order_id"`
+		UserID        uuid.UUID  `db:"user_id"`
+		Currency      string     `db:"currency"`
+		PromotionID   *uuid.UUID `db:"promotion_id"`
+		PromoCode     string     `db:"promo_code"`
+		DiscountTotal int64      `db:"discount_total"`
+		TotalPrice    int64      `db:"total_price"`
+		Status        int        `db:"status"`
+		CreatedAt     time.Time  `db:"created_at"`
+		UpdatedAt     time.Time  `db:"updated_at"`
 	}{}
 
-	err = r.client.GetContext(r.ctx, &orderData, "SELECT order_id, user_id, total_price, status, created_at, updated_at FROM `order` WHERE order_id = ?", orderID)
+	err = r.client.GetContext(r.ctx, &orderData,
+		"SELECT order_id, user_id, currency, promotion_id, promo_code, discount_total, total_price, status, created_at, updated_at FROM `order` WHERE order_id = ?",
+		orderID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.WithStack(model.ErrOrderNotFound)
@@ -94,31 +111,72 @@ func (r *orderRepository) Find(orderID uuid.UUID) (_ *model.Order, err error) {
 	}
 
 	var itemsData []struct {
-		ProductID uuid.UUID `db:"product_id"`
-		Quantity  int       `db:"quantity"`
-		Price     int64     `db:"price"`
+		ProductID        uuid.UUID `db:"product_id"`
+		VariantID        string    `db:"variant_id"`
+		SKU              string    `db:"sku"`
+		Quantity         int       `db:"quantity"`
+		Price            int64     `db:"price"`
+		OriginalPrice    int64     `db:"original_price"`
+		OriginalCurrency string    `db:"original_currency"`
+		ExchangeRate     int64     `db:"exchange_rate"`
+		Discount         int64     `db:"discount"`
 	}
-	err = r.client.SelectContext(r.ctx, &itemsData, `SELECT product_id, quantity, price FROM order_item WHERE order_id = ?`, orderID)
+	err = r.client.SelectContext(r.ctx, &itemsData,
+		`SELECT product_id, variant_id, sku, quantity, price, original_price, original_currency, exchange_rate, discount FROM order_item WHERE order_id = ?`,
+		orderID)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
 	items := make([]model.OrderItem, len(itemsData))
 	for i, itemData := range itemsData {
+		variantID, err := fromVariantKey(itemData.VariantID)
+		if err != nil {
+			return nil, err
+		}
 		items[i] = model.OrderItem{
-			ProductID: itemData.ProductID,
-			Quantity:  itemData.Quantity,
-			Price:     itemData.Price,
+			ProductID:        itemData.ProductID,
+			VariantID:        variantID,
+			SKU:              itemData.SKU,
+			Quantity:         itemData.Quantity,
+			Price:            itemData.Price,
+			OriginalPrice:    itemData.OriginalPrice,
+			OriginalCurrency: itemData.OriginalCurrency,
+			ExchangeRate:     itemData.ExchangeRate,
+			Discount:         itemData.Discount,
 		}
 	}
 
 	return &model.Order{
-		OrderID:    orderData.OrderID,
-		UserID:     orderData.UserID,
-		Items:      items,
-		TotalPrice: orderData.TotalPrice,
-		Status:     model.OrderStatus(orderData.Status),
-		CreatedAt:  orderData.CreatedAt,
-		UpdatedAt:  orderData.UpdatedAt,
+		OrderID:       orderData.OrderID,
+		UserID:        orderData.UserID,
+		Items:         items,
+		Currency:      orderData.Currency,
+		PromotionID:   orderData.PromotionID,
+		PromoCode:     orderData.PromoCode,
+		DiscountTotal: orderData.DiscountTotal,
+		TotalPrice:    orderData.TotalPrice,
+		Status:        model.OrderStatus(orderData.Status),
+		CreatedAt:     orderData.CreatedAt,
+		UpdatedAt:     orderData.UpdatedAt,
 	}, nil
 }
+
+// в order_item variant_id входит в первичный ключ, поэтому отсутствие варианта хранится пустой строкой
+func toVariantKey(variantID *uuid.UUID) string {
+	if variantID == nil {
+		return ""
+	}
+	return variantID.String()
+}
+
+func fromVariantKey(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	variantID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &variantID, nil
+}
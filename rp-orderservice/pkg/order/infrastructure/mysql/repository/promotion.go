@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"orderservice/pkg/order/domain/model"
+	"orderservice/pkg/order/infrastructure/metrics"
+)
+
+const auditEntityPromotion = "promotion"
+
+func NewPromotionRepository(ctx context.Context, client mysql.ClientContext) model.PromotionRepository {
+	return &promotionRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type promotionRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *promotionRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (r *promotionRepository) Store(promotion model.Promotion) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "promotion", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := r.Find(model.PromotionFindSpec{PromotionID: &promotion.PromotionID})
+	if err != nil && !errors.Is(err, model.ErrPromotionNotFound) {
+		return err
+	}
+
+	// used_count при обновлении не трогаем: его меняют только Redeem и Release
+	_, err = r.client.ExecContext(r.ctx,
+		`INSERT INTO promotion (promotion_id, code, type, value, currency, min_order_value, usage_limit, per_user_limit,
+		     used_count, valid_from, valid_until, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 ON DUPLICATE KEY UPDATE code=VALUES(code), type=VALUES(type), value=VALUES(value), currency=VALUES(currency),
+		     min_order_value=VALUES(min_order_value), usage_limit=VALUES(usage_limit), per_user_limit=VALUES(per_user_limit),
+		     valid_from=VALUES(valid_from), valid_until=VALUES(valid_until), updated_at=VALUES(updated_at)`,
+		promotion.PromotionID, promotion.Code, promotion.Type, promotion.Value, promotion.Currency, promotion.MinOrderValue,
+		promotion.UsageLimit, promotion.PerUserLimit, promotion.UsedCount, promotion.ValidFrom, promotion.ValidUntil,
+		promotion.CreatedAt, promotion.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM promotion_product WHERE promotion_id = ?`, promotion.PromotionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, productID := range promotion.ProductIDs {
+		_, err = r.client.ExecContext(r.ctx,
+			`INSERT INTO promotion_product (promotion_id, product_id) VALUES (?, ?)`,
+			promotion.PromotionID, productID,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM promotion_category WHERE promotion_id = ?`, promotion.PromotionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, categoryID := range promotion.CategoryIDs {
+		_, err = r.client.ExecContext(r.ctx,
+			`INSERT INTO promotion_category (promotion_id, category_id) VALUES (?, ?)`,
+			promotion.PromotionID, categoryID,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if before != nil {
+		promotion.UsedCount = before.UsedCount
+	}
+	return appendAuditRecord(r.ctx, r.client, auditEntityPromotion, promotion.PromotionID.String(), before, &promotion)
+}
+
+func (r *promotionRepository) Find(spec model.PromotionFindSpec) (_ *model.Promotion, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil && !errors.Is(err, model.ErrPromotionNotFound) {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "promotion", status).Observe(time.Since(start).Seconds())
+	}()
+
+	query, args := r.buildSpecArgs(spec)
+
+	var promotion sqlxPromotion
+	err = r.client.GetContext(
+		r.ctx,
+		&promotion,
+		`SELECT promotion_id, code, type, value, currency, min_order_value, usage_limit, per_user_limit, used_count,
+		     valid_from, valid_until, created_at, updated_at FROM promotion WHERE `+query,
+		args...,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrPromotionNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	var productIDs, categoryIDs []uuid.UUID
+	err = r.client.SelectContext(r.ctx, &productIDs,
+		`SELECT product_id FROM promotion_product WHERE promotion_id = ?`, promotion.PromotionID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = r.client.SelectContext(r.ctx, &categoryIDs,
+		`SELECT category_id FROM promotion_category WHERE promotion_id = ?`, promotion.PromotionID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Promotion{
+		PromotionID:   promotion.PromotionID,
+		Code:          promotion.Code,
+		Type:          model.PromotionType(promotion.Type),
+		Value:         promotion.Value,
+		Currency:      promotion.Currency,
+		MinOrderValue: promotion.MinOrderValue,
+		UsageLimit:    promotion.UsageLimit,
+		PerUserLimit:  promotion.PerUserLimit,
+		UsedCount:     promotion.UsedCount,
+		ValidFrom:     promotion.ValidFrom,
+		ValidUntil:    promotion.ValidUntil,
+		ProductIDs:    productIDs,
+		CategoryIDs:   categoryIDs,
+		CreatedAt:     promotion.CreatedAt,
+		UpdatedAt:     promotion.UpdatedAt,
+	}, nil
+}
+
+func (r *promotionRepository) CountUserRedemptions(promotionID, userID uuid.UUID) (count int, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("count", "promotion_redemption", status).Observe(time.Since(start).Seconds())
+	}()
+
+	err = r.client.GetContext(r.ctx, &count,
+		`SELECT COUNT(*) FROM promotion_redemption WHERE promotion_id = ? AND user_id = ?`,
+		promotionID, userID)
+	return count, errors.WithStack(err)
+}
+
+func (r *promotionRepository) Redeem(redemption model.PromotionRedemption) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil && !errors.Is(err, model.ErrPromotionUsageLimitReached) {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("redeem", "promotion", status).Observe(time.Since(start).Seconds())
+	}()
+
+	// Лимит проверяется в том же UPDATE, что и увеличивает счетчик, поэтому параллельные заказы
+	// не могут использовать код больше usage_limit раз
+	result, err := r.client.ExecContext(r.ctx,
+		`UPDATE promotion SET used_count = used_count + 1
+		 WHERE promotion_id = ? AND (usage_limit = 0 OR used_count < usage_limit)`,
+		redemption.PromotionID,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if affected == 0 {
+		return errors.WithStack(model.ErrPromotionUsageLimitReached)
+	}
+
+	_, err = r.client.ExecContext(r.ctx,
+		`INSERT INTO promotion_redemption (order_id, promotion_id, user_id, redeemed_at) VALUES (?, ?, ?, ?)`,
+		redemption.OrderID, redemption.PromotionID, redemption.UserID, redemption.RedeemedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *promotionRepository) Release(orderID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("release", "promotion", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var promotionID uuid.UUID
+	err = r.client.GetContext(r.ctx, &promotionID,
+		`SELECT promotion_id FROM promotion_redemption WHERE order_id = ? FOR UPDATE`, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM promotion_redemption WHERE order_id = ?`, orderID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = r.client.ExecContext(r.ctx,
+		`UPDATE promotion SET used_count = used_count - 1 WHERE promotion_id = ? AND used_count > 0`,
+		promotionID,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *promotionRepository) buildSpecArgs(spec model.PromotionFindSpec) (query string, args []interface{}) {
+	var parts []string
+	if spec.PromotionID != nil {
+		parts = append(parts, "promotion_id = ?")
+		args = append(args, *spec.PromotionID)
+	}
+	if spec.Code != nil {
+		parts = append(parts, "code = ?")
+		args = append(args, *spec.Code)
+	}
+	return strings.Join(parts, " AND "), args
+}
+
+type sqlxPromotion struct {
+	PromotionID   uuid.UUID  `db:"promotion_id"`
+	Code          string     `db:"code"`
+	Type          int        `db:"type"`
+	Value         int64      `db:"value"`
+	Currency      string     `db:"currency"`
+	MinOrderValue int64      `db:"min_order_value"`
+	UsageLimit    int        `db:"usage_limit"`
+	PerUserLimit  int        `db:"per_user_limit"`
+	UsedCount     int        `db:"used_count"`
+	ValidFrom     *time.Time `db:"valid_from"`
+	ValidUntil    *time.Time `db:"valid_until"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
@@ -29,3 +29,15 @@ func (r *repositoryProvider) LocalUserRepository(ctx context.Context) model.Loca
 func (r *repositoryProvider) LocalProductRepository(ctx context.Context) model.LocalProductRepository {
 	return repository.NewLocalProductRepository(ctx, r.client)
 }
+
+func (r *repositoryProvider) ExchangeRateRepository(ctx context.Context) model.ExchangeRateRepository {
+	return repository.NewExchangeRateRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) PromotionRepository(ctx context.Context) model.PromotionRepository {
+	return repository.NewPromotionRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) UserAccessRepository(ctx context.Context) model.UserAccessRepository {
+	return repository.NewUserAccessRepository(ctx, r.client)
+}
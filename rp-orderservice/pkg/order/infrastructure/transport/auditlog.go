@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"orderservice/api/server/orderinternal"
+	appmodel "orderservice/pkg/order/application/model"
+)
+
+func toAuditRecords(records []appmodel.AuditRecord) []*orderinternal.AuditRecord {
+	result := make([]*orderinternal.AuditRecord, len(records))
+	for i, record := range records {
+		result[i] = &orderinternal.AuditRecord{
+			RecordID:      record.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt.Unix(),
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			Valid:         record.Valid,
+		}
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package transport
+
+import (
+	"context"
+
+	"orderservice/api/server/orderinternal"
+)
+
+func (a *orderInternalAPI) SetExchangeRate(ctx context.Context, request *orderinternal.SetExchangeRateRequest) (*orderinternal.SetExchangeRateResponse, error) {
+	err := a.exchangeRateService.SetExchangeRate(ctx, request.From, request.To, request.Rate)
+	if err != nil {
+		return nil, err
+	}
+	return &orderinternal.SetExchangeRateResponse{}, nil
+}
+
+func (a *orderInternalAPI) ListExchangeRates(ctx context.Context, _ *orderinternal.ListExchangeRatesRequest) (*orderinternal.ListExchangeRatesResponse, error) {
+	rates, err := a.exchangeRateQueryService.ListExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*orderinternal.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, &orderinternal.ExchangeRate{
+			From:      rate.From,
+			To:        rate.To,
+			Rate:      rate.Rate,
+			UpdatedAt: rate.UpdatedAt,
+		})
+	}
+	return &orderinternal.ListExchangeRatesResponse{Rates: result}, nil
+}
@@ -3,52 +3,81 @@ package transport
 import (
 	"context"
 
-	"github.com/google/uuid"
-	"github.com/pkg/errors"
-
 	"orderservice/api/server/orderinternal"
 	appmodel "orderservice/pkg/order/application/model"
 	"orderservice/pkg/order/application/query"
 	"orderservice/pkg/order/application/service"
+	"orderservice/pkg/order/domain/model"
 )
 
+// MethodPermissions - права из userservice, нужные для вызова методов OrderInternalService.
+// Остальные методы доступны без прав
+var MethodPermissions = map[string]string{
+	"SetExchangeRate": string(model.PermissionManageExchangeRates),
+	"StorePromotion":  string(model.PermissionManagePromotions),
+}
+
 func NewOrderInternalAPI(
 	orderQueryService query.OrderQueryService,
+	auditLogQueryService query.AuditLogQueryService,
+	exchangeRateQueryService query.ExchangeRateQueryService,
+	promotionQueryService query.PromotionQueryService,
 	orderService service.OrderService,
+	exchangeRateService service.ExchangeRateService,
+	promotionService service.PromotionService,
 ) orderinternal.OrderInternalServiceServer {
 	return &orderInternalAPI{
-		orderQueryService: orderQueryService,
-		orderService:      orderService,
+		orderQueryService:        orderQueryService,
+		auditLogQueryService:     auditLogQueryService,
+		exchangeRateQueryService: exchangeRateQueryService,
+		promotionQueryService:    promotionQueryService,
+		orderService:             orderService,
+		exchangeRateService:      exchangeRateService,
+		promotionService:         promotionService,
 	}
 }
 
 type orderInternalAPI struct {
-	orderQueryService query.OrderQueryService
-	orderService      service.OrderService
+	orderQueryService        query.OrderQueryService
+	auditLogQueryService     query.AuditLogQueryService
+	exchangeRateQueryService query.ExchangeRateQueryService
+	promotionQueryService    query.PromotionQueryService
+	orderService             service.OrderService
+	exchangeRateService      service.ExchangeRateService
+	promotionService         service.PromotionService
 	orderinternal.UnimplementedOrderInternalServiceServer
 }
 
 func (a *orderInternalAPI) CreateOrder(ctx context.Context, request *orderinternal.CreateOrderRequest) (*orderinternal.CreateOrderResponse, error) {
-	userID, err := uuid.Parse(request.UserID)
+	userID, err := parseUUID("userID", request.UserID)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid user id")
+		return nil, err
 	}
 
 	items := make([]appmodel.OrderItem, len(request.Items))
 	for i, item := range request.Items {
-		productID, err := uuid.Parse(item.ProductID)
+		productID, err := parseUUID("items.productID", item.ProductID)
 		if err != nil {
-			return nil, errors.Wrapf(err, "invalid product id: %s", item.ProductID)
+			return nil, err
 		}
 		items[i] = appmodel.OrderItem{
 			ProductID: productID,
 			Quantity:  int(item.Quantity),
 		}
+		if item.VariantID != nil {
+			variantID, err := parseUUID("items.variantID", *item.VariantID)
+			if err != nil {
+				return nil, err
+			}
+			items[i].VariantID = &variantID
+		}
 	}
 
 	orderID, err := a.orderService.CreateOrder(ctx, appmodel.CreateOrder{
-		UserID: userID,
-		Items:  items,
+		UserID:    userID,
+		Currency:  request.Currency,
+		PromoCode: request.PromoCode,
+		Items:     items,
 	})
 	if err != nil {
 		return nil, err
@@ -58,9 +87,9 @@ func (a *orderInternalAPI) CreateOrder(ctx context.Context, request *orderintern
 }
 
 func (a *orderInternalAPI) FindOrder(ctx context.Context, request *orderinternal.FindOrderRequest) (*orderinternal.FindOrderResponse, error) {
-	orderID, err := uuid.Parse(request.OrderID)
+	orderID, err := parseUUID("orderID", request.OrderID)
 	if err != nil {
-		return nil, errors.Wrap(err, "invalid order id")
+		return nil, err
 	}
 
 	order, err := a.orderQueryService.FindOrder(ctx, orderID)
@@ -75,19 +104,42 @@ func (a *orderInternalAPI) FindOrder(ctx context.Context, request *orderinternal
 	items := make([]*orderinternal.OrderItem, len(order.Items))
 	for i, item := range order.Items {
 		items[i] = &orderinternal.OrderItem{
-			ProductID: item.ProductID.String(),
-			Quantity:  int32(item.Quantity), // nolint:gosec
+			ProductID:        item.ProductID.String(),
+			Quantity:         int32(item.Quantity), // nolint:gosec
+			Sku:              item.SKU,
+			Price:            item.Price,
+			OriginalPrice:    item.OriginalPrice,
+			OriginalCurrency: item.OriginalCurrency,
+			ExchangeRate:     item.ExchangeRate,
+			Discount:         item.Discount,
+		}
+		if item.VariantID != nil {
+			variantID := item.VariantID.String()
+			items[i].VariantID = &variantID
 		}
 	}
 
 	return &orderinternal.FindOrderResponse{
 		Order: &orderinternal.Order{
-			OrderID:    order.OrderID.String(),
-			UserID:     order.UserID.String(),
-			Items:      items,
-			TotalPrice: order.TotalPrice,
-			Status:     orderinternal.OrderStatus(order.Status), // nolint:gosec
-			CreatedAt:  order.CreatedAt,
+			OrderID:       order.OrderID.String(),
+			UserID:        order.UserID.String(),
+			Items:         items,
+			TotalPrice:    order.TotalPrice,
+			Status:        orderinternal.OrderStatus(order.Status), // nolint:gosec
+			CreatedAt:     order.CreatedAt,
+			Currency:      order.Currency,
+			PromoCode:     order.PromoCode,
+			DiscountTotal: order.DiscountTotal,
 		},
 	}, nil
 }
+
+func (a *orderInternalAPI) FindAuditLog(ctx context.Context, request *orderinternal.FindAuditLogRequest) (*orderinternal.FindAuditLogResponse, error) {
+	records, err := a.auditLogQueryService.FindAuditLog(ctx, request.EntityType, request.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return &orderinternal.FindAuditLogResponse{
+		Records: toAuditRecords(records),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"orderservice/pkg/order/domain/model"
+	"orderservice/pkg/order/infrastructure/transport/middlewares"
+)
+
+type stubAuthorizer map[uuid.UUID][]model.Permission
+
+func (a stubAuthorizer) Authorize(_ context.Context, userID uuid.UUID, permission string) error {
+	if slices.Contains(a[userID], model.Permission(permission)) {
+		return nil
+	}
+	return model.ErrPermissionDenied
+}
+
+func TestMethodPermissions_StorePromotion(t *testing.T) {
+	customer := uuid.New()
+	admin := uuid.New()
+	authorizer := stubAuthorizer{
+		admin: {model.PermissionManageExchangeRates, model.PermissionManagePromotions},
+	}
+	middleware := middlewares.NewGRPCAuthorizationMiddleware(authorizer, MethodPermissions)
+
+	call := func(userID uuid.UUID, method string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middlewares.UserIDMetadataKey, userID.String()))
+		_, err := middleware(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/Order.OrderInternalService/" + method},
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+		return err
+	}
+
+	// промокод со скидкой 100% может завести только администратор
+	assert.ErrorIs(t, call(customer, "StorePromotion"), model.ErrPermissionDenied)
+	assert.NoError(t, call(admin, "StorePromotion"))
+	assert.NoError(t, call(customer, "CreateOrder"))
+}
@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"context"
+	"net"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"orderservice/pkg/order/infrastructure/audit"
+)
+
+const CorrelationIDMetadataKey = "x-correlation-id"
+
+// NewGRPCAuditMiddleware кладет в контекст вызывающего, метод и correlation id для журнала аудита
+func NewGRPCAuditMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		correlationID := firstMetadataValue(md, CorrelationIDMetadataKey)
+		if correlationID == "" {
+			correlationID = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIDMetadataKey, correlationID))
+
+		ctx = audit.WithInfo(ctx, audit.Info{
+			Actor:         auditActor(ctx, md),
+			Method:        info.FullMethod,
+			CorrelationID: correlationID,
+		})
+		return handler(ctx, req)
+	}
+}
+
+func auditActor(ctx context.Context, md metadata.MD) string {
+	if userID := firstMetadataValue(md, UserIDMetadataKey); userID != "" {
+		return "user:" + userID
+	}
+	if callerID := firstMetadataValue(md, CallerIDMetadataKey); callerID != "" {
+		return "caller:" + callerID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "peer:" + host
+		}
+		return "peer:" + p.Addr.String()
+	}
+	return audit.SystemActor
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
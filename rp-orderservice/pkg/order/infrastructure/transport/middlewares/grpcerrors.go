@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"orderservice/pkg/order/domain/model"
+)
+
+const ErrorDomain = "orderservice"
+
+type domainError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var domainErrors = []domainError{
+	{err: model.ErrOrderNotFound, code: codes.NotFound, reason: "ORDER_NOT_FOUND"},
+	{err: model.ErrProductNotFound, code: codes.NotFound, reason: "PRODUCT_NOT_FOUND"},
+	{err: model.ErrProductArchived, code: codes.FailedPrecondition, reason: "PRODUCT_ARCHIVED"},
+	{err: model.ErrVariantNotFound, code: codes.NotFound, reason: "VARIANT_NOT_FOUND"},
+	{err: model.ErrVariantRequired, code: codes.InvalidArgument, reason: "VARIANT_REQUIRED"},
+	{err: model.ErrOutOfStock, code: codes.FailedPrecondition, reason: "OUT_OF_STOCK"},
+	{err: model.ErrUserNotFound, code: codes.NotFound, reason: "USER_NOT_FOUND"},
+	{err: model.ErrEmptyOrder, code: codes.InvalidArgument, reason: "EMPTY_ORDER"},
+	{err: model.ErrInvalidCurrency, code: codes.InvalidArgument, reason: "INVALID_CURRENCY"},
+	{err: model.ErrExchangeRateNotFound, code: codes.FailedPrecondition, reason: "EXCHANGE_RATE_NOT_FOUND"},
+	{err: model.ErrInvalidExchangeRate, code: codes.InvalidArgument, reason: "INVALID_EXCHANGE_RATE"},
+	{err: model.ErrSameCurrency, code: codes.InvalidArgument, reason: "SAME_CURRENCY"},
+	{err: model.ErrPriceOverflow, code: codes.InvalidArgument, reason: "PRICE_OVERFLOW"},
+	{err: model.ErrPromotionNotFound, code: codes.NotFound, reason: "PROMOTION_NOT_FOUND"},
+	{err: model.ErrPromotionCodeAlreadyUsed, code: codes.AlreadyExists, reason: "PROMOTION_CODE_ALREADY_USED"},
+	{err: model.ErrInvalidPromotion, code: codes.InvalidArgument, reason: "INVALID_PROMOTION"},
+	{err: model.ErrPromotionNotActive, code: codes.FailedPrecondition, reason: "PROMOTION_NOT_ACTIVE"},
+	{err: model.ErrPromotionUsageLimitReached, code: codes.FailedPrecondition, reason: "PROMOTION_USAGE_LIMIT_REACHED"},
+	{err: model.ErrPromotionUserLimitReached, code: codes.FailedPrecondition, reason: "PROMOTION_USER_LIMIT_REACHED"},
+	{err: model.ErrPromotionMinOrderValue, code: codes.FailedPrecondition, reason: "PROMOTION_MIN_ORDER_VALUE"},
+	{err: model.ErrPromotionNotApplicable, code: codes.FailedPrecondition, reason: "PROMOTION_NOT_APPLICABLE"},
+	{err: model.ErrPermissionDenied, code: codes.PermissionDenied, reason: "PERMISSION_DENIED"},
+}
+
+func NewGRPCErrorsMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(err)
+		}
+		return resp, nil
+	}
+}
+
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range domainErrors {
+		if errors.Is(err, e.err) {
+			return newStatusError(e.code, e.reason, e.err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		// детали внутренних ошибок наружу не отдаем, они есть в логах
+		return newStatusError(codes.Internal, "INTERNAL", "internal error")
+	}
+}
+
+func newStatusError(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
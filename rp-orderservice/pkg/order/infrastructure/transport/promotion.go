@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"orderservice/api/server/orderinternal"
+	appmodel "orderservice/pkg/order/application/model"
+)
+
+func (a *orderInternalAPI) StorePromotion(ctx context.Context, request *orderinternal.StorePromotionRequest) (*orderinternal.StorePromotionResponse, error) {
+	promotion := appmodel.Promotion{
+		Code:          request.Code,
+		Type:          int(request.Type),
+		Value:         request.Value,
+		Currency:      request.Currency,
+		MinOrderValue: request.MinOrderValue,
+		UsageLimit:    int(request.UsageLimit),
+		PerUserLimit:  int(request.PerUserLimit),
+		ValidFrom:     request.ValidFrom,
+		ValidUntil:    request.ValidUntil,
+	}
+	if request.PromotionID != nil {
+		promotionID, err := parseUUID("promotionID", *request.PromotionID)
+		if err != nil {
+			return nil, err
+		}
+		promotion.PromotionID = promotionID
+	}
+	var err error
+	promotion.ProductIDs, err = parseUUIDs("productIDs", request.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+	promotion.CategoryIDs, err = parseUUIDs("categoryIDs", request.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	promotionID, err := a.promotionService.StorePromotion(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+	return &orderinternal.StorePromotionResponse{PromotionID: promotionID.String()}, nil
+}
+
+func (a *orderInternalAPI) ListPromotions(ctx context.Context, _ *orderinternal.ListPromotionsRequest) (*orderinternal.ListPromotionsResponse, error) {
+	promotions, err := a.promotionQueryService.ListPromotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*orderinternal.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		result = append(result, &orderinternal.Promotion{
+			PromotionID:   promotion.PromotionID.String(),
+			Code:          promotion.Code,
+			Type:          orderinternal.PromotionType(promotion.Type), // nolint:gosec
+			Value:         promotion.Value,
+			Currency:      promotion.Currency,
+			MinOrderValue: promotion.MinOrderValue,
+			UsageLimit:    int32(promotion.UsageLimit),   // nolint:gosec
+			PerUserLimit:  int32(promotion.PerUserLimit), // nolint:gosec
+			UsedCount:     int32(promotion.UsedCount),    // nolint:gosec
+			ValidFrom:     promotion.ValidFrom,
+			ValidUntil:    promotion.ValidUntil,
+			ProductIDs:    uuidStrings(promotion.ProductIDs),
+			CategoryIDs:   uuidStrings(promotion.CategoryIDs),
+			CreatedAt:     promotion.CreatedAt,
+			UpdatedAt:     promotion.UpdatedAt,
+		})
+	}
+	return &orderinternal.ListPromotionsResponse{Promotions: result}, nil
+}
+
+func parseUUIDs(field string, values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := parseUUID(field, value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
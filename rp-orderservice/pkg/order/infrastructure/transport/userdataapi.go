@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"orderservice/api/server/orderinternal"
+	"orderservice/api/server/userdatainternal"
+	"orderservice/pkg/order/application/query"
+	"orderservice/pkg/order/application/service"
+)
+
+const userDataServiceName = "orderservice"
+
+func NewUserDataInternalAPI(
+	userDataQueryService query.UserDataQueryService,
+	userDataService service.UserDataService,
+) userdatainternal.UserDataInternalServiceServer {
+	return &userDataInternalAPI{
+		userDataQueryService: userDataQueryService,
+		userDataService:      userDataService,
+	}
+}
+
+type userDataInternalAPI struct {
+	userDataQueryService query.UserDataQueryService
+	userDataService      service.UserDataService
+	userdatainternal.UnimplementedUserDataInternalServiceServer
+}
+
+type exportedOrderItem struct {
+	ProductID string  `json:"product_id"`
+	VariantID *string `json:"variant_id,omitempty"`
+	SKU       string  `json:"sku,omitempty"`
+	Quantity  int     `json:"quantity"`
+	// Price - в валюте заказа, OriginalPrice - в валюте товара
+	Price            int64  `json:"price"`
+	OriginalPrice    int64  `json:"original_price"`
+	OriginalCurrency string `json:"original_currency"`
+	Discount         int64  `json:"discount"`
+}
+
+type exportedOrder struct {
+	OrderID       string              `json:"order_id"`
+	Items         []exportedOrderItem `json:"items"`
+	Currency      string              `json:"currency"`
+	PromoCode     string              `json:"promo_code,omitempty"`
+	DiscountTotal int64               `json:"discount_total"`
+	TotalPrice    int64               `json:"total_price"`
+	Status        string              `json:"status"`
+	CreatedAt     int64               `json:"created_at"`
+}
+
+type exportedUserData struct {
+	Login  *string         `json:"login,omitempty"`
+	Orders []exportedOrder `json:"orders"`
+}
+
+func (a *userDataInternalAPI) ExportUserData(ctx context.Context, request *userdatainternal.ExportUserDataRequest) (*userdatainternal.ExportUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userData, err := a.userDataQueryService.FindUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedUserData{
+		Login:  userData.Login,
+		Orders: make([]exportedOrder, len(userData.Orders)),
+	}
+	for i, order := range userData.Orders {
+		items := make([]exportedOrderItem, len(order.Items))
+		for j, item := range order.Items {
+			items[j] = exportedOrderItem{
+				ProductID:        item.ProductID.String(),
+				SKU:              item.SKU,
+				Quantity:         item.Quantity,
+				Price:            item.Price,
+				OriginalPrice:    item.OriginalPrice,
+				OriginalCurrency: item.OriginalCurrency,
+				Discount:         item.Discount,
+			}
+			if item.VariantID != nil {
+				variantID := item.VariantID.String()
+				items[j].VariantID = &variantID
+			}
+		}
+		exported.Orders[i] = exportedOrder{
+			OrderID:       order.OrderID.String(),
+			Items:         items,
+			Currency:      order.Currency,
+			PromoCode:     order.PromoCode,
+			DiscountTotal: order.DiscountTotal,
+			TotalPrice:    order.TotalPrice,
+			Status:        orderinternal.OrderStatus(order.Status).String(), // nolint:gosec
+			CreatedAt:     order.CreatedAt,
+		}
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &userdatainternal.ExportUserDataResponse{
+		Service: userDataServiceName,
+		Data:    string(data),
+	}, nil
+}
+
+func (a *userDataInternalAPI) EraseUserData(ctx context.Context, request *userdatainternal.EraseUserDataRequest) (*userdatainternal.EraseUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.userDataService.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &userdatainternal.EraseUserDataResponse{
+		Service:  userDataServiceName,
+		ErasedAt: time.Now().Unix(),
+	}, nil
+}
@@ -24,6 +24,7 @@ const _ = grpc.SupportPackageIsVersion7
 type PaymentInternalServiceClient interface {
 	StoreUserBalance(ctx context.Context, in *StoreUserBalanceRequest, opts ...grpc.CallOption) (*StoreUserBalanceResponse, error)
 	FindUserBalance(ctx context.Context, in *FindUserBalanceRequest, opts ...grpc.CallOption) (*FindUserBalanceResponse, error)
+	FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error)
 }
 
 type paymentInternalServiceClient struct {
@@ -52,12 +53,22 @@ func (c *paymentInternalServiceClient) FindUserBalance(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *paymentInternalServiceClient) FindAuditLog(ctx context.Context, in *FindAuditLogRequest, opts ...grpc.CallOption) (*FindAuditLogResponse, error) {
+	out := new(FindAuditLogResponse)
+	err := c.cc.Invoke(ctx, "/Payment.PaymentInternalService/FindAuditLog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentInternalServiceServer is the server API for PaymentInternalService service.
 // All implementations must embed UnimplementedPaymentInternalServiceServer
 // for forward compatibility
 type PaymentInternalServiceServer interface {
 	StoreUserBalance(context.Context, *StoreUserBalanceRequest) (*StoreUserBalanceResponse, error)
 	FindUserBalance(context.Context, *FindUserBalanceRequest) (*FindUserBalanceResponse, error)
+	FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error)
 	mustEmbedUnimplementedPaymentInternalServiceServer()
 }
 
@@ -71,6 +82,9 @@ func (UnimplementedPaymentInternalServiceServer) StoreUserBalance(context.Contex
 func (UnimplementedPaymentInternalServiceServer) FindUserBalance(context.Context, *FindUserBalanceRequest) (*FindUserBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindUserBalance not implemented")
 }
+func (UnimplementedPaymentInternalServiceServer) FindAuditLog(context.Context, *FindAuditLogRequest) (*FindAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindAuditLog not implemented")
+}
 func (UnimplementedPaymentInternalServiceServer) mustEmbedUnimplementedPaymentInternalServiceServer() {
 }
 
@@ -121,6 +135,24 @@ func _PaymentInternalService_FindUserBalance_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentInternalService_FindAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentInternalServiceServer).FindAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Payment.PaymentInternalService/FindAuditLog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentInternalServiceServer).FindAuditLog(ctx, req.(*FindAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentInternalService_ServiceDesc is the grpc.ServiceDesc for PaymentInternalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -136,6 +168,10 @@ var PaymentInternalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindUserBalance",
 			Handler:    _PaymentInternalService_FindUserBalance_Handler,
 		},
+		{
+			MethodName: "FindAuditLog",
+			Handler:    _PaymentInternalService_FindAuditLog_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/server/paymentinternal/paymentinternal.proto",
@@ -14,7 +14,10 @@ import (
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sync/errgroup"
 
+	appservice "paymentservice/pkg/payment/application/service"
+	"paymentservice/pkg/payment/infrastructure/consumer"
 	"paymentservice/pkg/payment/infrastructure/integrationevent"
+	inframysql "paymentservice/pkg/payment/infrastructure/mysql"
 )
 
 type messageHandlerConfig struct {
@@ -45,16 +48,43 @@ func messageHandler(logger logging.Logger) *cli.Command {
 			closer.AddCloser(databaseConnector)
 			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
 
+			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
+			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
+			uow := inframysql.NewUnitOfWork(libUoW)
+			luow := inframysql.NewLockableUnitOfWork(libLUow)
+
 			amqpConnection := newAMQPConnection(cnf.AMQP, logger)
+
+			// события пользователей нужны, чтобы проверять права на изменение балансов
+			queueConfig := &amqp.QueueConfig{
+				Name:    "payment_events",
+				Durable: true,
+			}
+			bindConfig := &amqp.BindConfig{
+				QueueName:    "payment_events",
+				ExchangeName: integrationevent.ExchangeName,
+				RoutingKeys:  []string{"user.*"},
+			}
+
 			amqpEventProducer := amqpConnection.Producer(
 				&amqp.ExchangeConfig{
 					Name:    integrationevent.ExchangeName,
 					Kind:    integrationevent.ExchangeKind,
 					Durable: true,
 				},
-				nil,
+				queueConfig,
+				bindConfig,
+			)
+
+			eventConsumer := consumer.NewEventConsumer(appservice.NewUserAccessService(uow, luow), logger)
+			amqpConnection.Consumer(
+				c.Context,
+				eventConsumer.Handler(),
+				queueConfig,
+				bindConfig,
 				nil,
 			)
+
 			err = amqpConnection.Start()
 			if err != nil {
 				return err
@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"paymentservice/api/server/paymentinternal"
+	"paymentservice/api/server/userdatainternal"
 	appservice "paymentservice/pkg/payment/application/service"
 	"paymentservice/pkg/payment/infrastructure/integrationevent"
 	inframysql "paymentservice/pkg/payment/infrastructure/mysql"
@@ -27,8 +28,9 @@ import (
 )
 
 type serviceConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
+	Service   Service   `envconfig:"service"`
+	RateLimit RateLimit `envconfig:"rate_limit"`
+	Database  Database  `envconfig:"database" required:"true"`
 }
 
 func service(logger logging.Logger) *cli.Command {
@@ -57,12 +59,22 @@ func service(logger logging.Logger) *cli.Command {
 			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
 			uow := inframysql.NewUnitOfWork(libUoW)
 			luow := inframysql.NewLockableUnitOfWork(libLUow)
+			rateLimiter, err := newRateLimiter(cnf.RateLimit, libUoW)
+			if err != nil {
+				return err
+			}
 			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
 
+			userAccessService := appservice.NewUserAccessService(uow, luow)
 			paymentInternalAPI := transport.NewPaymentInternalAPI(
 				query.NewAccountQueryService(databaseConnector.TransactionalClient()),
+				query.NewAuditLogQueryService(databaseConnector.TransactionalClient()),
 				appservice.NewAccountService(uow, luow, eventDispatcher),
 			)
+			userDataInternalAPI := transport.NewUserDataInternalAPI(
+				query.NewUserDataQueryService(databaseConnector.TransactionalClient()),
+				appservice.NewUserDataService(luow),
+			)
 
 			errGroup := errgroup.Group{}
 			errGroup.Go(func() error {
@@ -71,9 +83,15 @@ func service(logger logging.Logger) *cli.Command {
 					return err
 				}
 				grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+					middlewares.NewGRPCErrorsMiddleware(),
 					middlewares.NewGRPCLoggingMiddleware(logger),
+					middlewares.NewGRPCRateLimitMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+					middlewares.NewGRPCAuthorizationMiddleware(userAccessService, transport.MethodPermissions),
+					middlewares.NewGRPCValidationMiddleware(),
+					middlewares.NewGRPCAuditMiddleware(),
 				))
 				paymentinternal.RegisterPaymentInternalServiceServer(grpcServer, paymentInternalAPI)
+				userdatainternal.RegisterUserDataInternalServiceServer(grpcServer, userDataInternalAPI)
 				reflection.Register(grpcServer)
 				graceCallback(c.Context, logger, cnf.Service.GracePeriod, func(_ context.Context) error {
 					grpcServer.GracefulStop()
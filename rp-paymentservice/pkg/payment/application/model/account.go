@@ -3,6 +3,8 @@ package model
 import "github.com/google/uuid"
 
 type UserBalance struct {
-	UserID  uuid.UUID
-	Balance int64
+	UserID uuid.UUID
+	// Currency - валюта счета. Задается при создании счета, пустая - DefaultCurrency
+	Currency string
+	Balance  int64
 }
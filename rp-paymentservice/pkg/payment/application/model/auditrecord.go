@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type AuditRecord struct {
+	RecordID      int64
+	Actor         string
+	Method        string
+	EntityType    string
+	EntityID      string
+	Before        *string
+	After         *string
+	CorrelationID string
+	CreatedAt     time.Time
+	PrevHash      string
+	Hash          string
+	// Valid - хеш записи совпадает с пересчитанным по ее полям, а PrevHash - с хешем предыдущей записи журнала
+	Valid bool
+}
@@ -0,0 +1,7 @@
+package model
+
+type UserData struct {
+	Balance *UserBalance
+	// History - изменения счета из журнала аудита
+	History []AuditRecord
+}
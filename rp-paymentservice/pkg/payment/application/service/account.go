@@ -44,13 +44,13 @@ func (s *accountService) StoreUserBalance(ctx context.Context, balance appmodel.
 
 		_, err := provider.AccountRepository(ctx).Find(model.FindSpec{UserID: &balance.UserID})
 		if errors.Is(err, model.ErrAccountNotFound) {
-			return domainService.CreateAccount(balance.UserID, balance.Balance)
+			return domainService.CreateAccount(balance.UserID, balance.Currency, balance.Balance)
 		}
 		if err != nil {
 			return err
 		}
 
-		return domainService.UpdateBalance(balance.UserID, balance.Balance)
+		return domainService.UpdateBalance(balance.UserID, balance.Currency, balance.Balance)
 	})
 }
 
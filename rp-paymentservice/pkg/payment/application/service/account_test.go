@@ -22,6 +22,10 @@ func (m *MockRepositoryProvider) AccountRepository(ctx context.Context) domainmo
 	return m.Called(ctx).Get(0).(domainmodel.AccountRepository)
 }
 
+func (m *MockRepositoryProvider) UserAccessRepository(ctx context.Context) domainmodel.UserAccessRepository {
+	return m.Called(ctx).Get(0).(domainmodel.UserAccessRepository)
+}
+
 type MockLockableUnitOfWork struct {
 	mock.Mock
 }
@@ -52,6 +56,10 @@ func (m *StubAccountRepo) Find(s domainmodel.FindSpec) (*domainmodel.Account, er
 	return args.Get(0).(*domainmodel.Account), args.Error(1)
 }
 
+func (m *StubAccountRepo) Delete(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
 type DummyDispatcher struct{}
 
 func (d *DummyDispatcher) Dispatch(_ context.Context, _ outbox.Event) error {
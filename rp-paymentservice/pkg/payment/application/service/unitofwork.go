@@ -8,6 +8,7 @@ import (
 
 type RepositoryProvider interface {
 	AccountRepository(ctx context.Context) model.AccountRepository
+	UserAccessRepository(ctx context.Context) model.UserAccessRepository
 }
 
 type LockableUnitOfWork interface {
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService interface {
+	// EraseUserData удаляет счет пользователя. Журнал аудита остается как основание для учета платежей
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserDataService(luow LockableUnitOfWork) UserDataService {
+	return &userDataService{luow: luow}
+}
+
+type userDataService struct {
+	luow LockableUnitOfWork
+}
+
+func (s *userDataService) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{userBalanceLock(userID)}, func(provider RepositoryProvider) error {
+		return provider.AccountRepository(ctx).Delete(userID)
+	})
+}
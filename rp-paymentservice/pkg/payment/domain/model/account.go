@@ -10,11 +10,30 @@ import (
 var (
 	ErrAccountNotFound   = errors.New("account not found")
 	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidCurrency   = errors.New("invalid currency code")
+	ErrCurrencyMismatch  = errors.New("account currency cannot be changed")
 )
 
+// DefaultCurrency - валюта счетов, созданных до появления валют
+const DefaultCurrency = "RUB"
+
+// ValidCurrency проверяет, что code похож на код ISO 4217: три заглавные латинские буквы
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range []byte(code) {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
 type Account struct {
 	UserID    uuid.UUID
-	Balance   int64 // Баланс в копейках
+	Currency  string
+	Balance   int64 // Баланс в сотых долях Currency
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -27,4 +46,5 @@ type AccountRepository interface {
 	NextID(userID uuid.UUID) uuid.UUID
 	Store(account Account) error
 	Find(spec FindSpec) (*Account, error)
+	Delete(userID uuid.UUID) error
 }
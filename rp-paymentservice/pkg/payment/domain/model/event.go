@@ -8,6 +8,7 @@ import (
 
 type AccountCreated struct {
 	UserID    uuid.UUID
+	Currency  string
 	Balance   int64
 	CreatedAt time.Time
 }
@@ -18,6 +19,7 @@ func (a AccountCreated) Type() string {
 
 type AccountBalanceUpdated struct {
 	UserID    uuid.UUID
+	Currency  string
 	Balance   int64
 	UpdatedAt time.Time
 }
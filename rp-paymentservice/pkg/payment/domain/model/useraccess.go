@@ -0,0 +1,43 @@
+package model
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUserAccessNotFound = errors.New("user access not found")
+)
+
+// Permission - право из userservice. Роли в права раскрывает userservice, в событиях приходит итоговый список
+type Permission string
+
+const (
+	PermissionManageBalances Permission = "balances.manage"
+)
+
+// UserAccess - локальная копия прав и статуса пользователя из событий userservice.
+// Время изменения хранится отдельно для прав и для статуса: события приходят не по порядку,
+// и устаревшее событие не должно затереть более новое
+type UserAccess struct {
+	UserID               uuid.UUID
+	Permissions          []Permission
+	PermissionsUpdatedAt time.Time
+	Active               bool
+	StatusUpdatedAt      time.Time
+}
+
+// Can - права действуют, только пока пользователь активен
+func (a UserAccess) Can(permission Permission) bool {
+	return a.Active && slices.Contains(a.Permissions, permission)
+}
+
+type UserAccessRepository interface {
+	Store(access UserAccess) error
+	Find(userID uuid.UUID) (*UserAccess, error)
+	Delete(userID uuid.UUID) error
+}
@@ -11,8 +11,10 @@ import (
 )
 
 type AccountService interface {
-	CreateAccount(userID uuid.UUID, initialBalance int64) error
-	UpdateBalance(userID uuid.UUID, newBalance int64) error
+	// CreateAccount без currency создает счет в DefaultCurrency
+	CreateAccount(userID uuid.UUID, currency string, initialBalance int64) error
+	// UpdateBalance меняет баланс в валюте счета. Без currency валюта не проверяется
+	UpdateBalance(userID uuid.UUID, currency string, newBalance int64) error
 }
 
 func NewAccountService(
@@ -30,7 +32,14 @@ type accountService struct {
 	eventDispatcher   domain.EventDispatcher
 }
 
-func (s *accountService) CreateAccount(userID uuid.UUID, initialBalance int64) error {
+func (s *accountService) CreateAccount(userID uuid.UUID, currency string, initialBalance int64) error {
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	if !model.ValidCurrency(currency) {
+		return model.ErrInvalidCurrency
+	}
+
 	_, err := s.accountRepository.Find(model.FindSpec{UserID: &userID})
 	if err == nil {
 		// Аккаунт уже существует, ничего не делаем
@@ -43,6 +52,7 @@ func (s *accountService) CreateAccount(userID uuid.UUID, initialBalance int64) e
 	currentTime := time.Now()
 	account := model.Account{
 		UserID:    userID,
+		Currency:  currency,
 		Balance:   initialBalance,
 		CreatedAt: currentTime,
 		UpdatedAt: currentTime,
@@ -55,16 +65,21 @@ func (s *accountService) CreateAccount(userID uuid.UUID, initialBalance int64) e
 
 	return s.eventDispatcher.Dispatch(&model.AccountCreated{
 		UserID:    userID,
+		Currency:  currency,
 		Balance:   initialBalance,
 		CreatedAt: currentTime,
 	})
 }
 
-func (s *accountService) UpdateBalance(userID uuid.UUID, newBalance int64) error {
+func (s *accountService) UpdateBalance(userID uuid.UUID, currency string, newBalance int64) error {
 	account, err := s.accountRepository.Find(model.FindSpec{UserID: &userID})
 	if err != nil {
 		return err
 	}
+	// баланс нельзя переложить в другую валюту без пересчета, поэтому валюта счета не меняется
+	if currency != "" && currency != account.Currency {
+		return model.ErrCurrencyMismatch
+	}
 
 	if account.Balance == newBalance {
 		return nil
@@ -81,6 +96,7 @@ func (s *accountService) UpdateBalance(userID uuid.UUID, newBalance int64) error
 
 	return s.eventDispatcher.Dispatch(&model.AccountBalanceUpdated{
 		UserID:    userID,
+		Currency:  account.Currency,
 		Balance:   newBalance,
 		UpdatedAt: currentTime,
 	})
@@ -33,6 +33,11 @@ func (m *MockAccountRepository) Find(spec model.FindSpec) (*model.Account, error
 	return args.Get(0).(*model.Account), args.Error(1)
 }
 
+func (m *MockAccountRepository) Delete(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
 type MockEventDispatcher struct {
 	mock.Mock
 }
@@ -53,13 +58,13 @@ func TestAccountService_CreateAccount(t *testing.T) {
 	t.Run("success_new_account", func(t *testing.T) {
 		repo.On("Find", model.FindSpec{UserID: &userID}).Return(nil, model.ErrAccountNotFound).Once()
 		repo.On("Store", mock.MatchedBy(func(a model.Account) bool {
-			return a.UserID == userID && a.Balance == initialBalance
+			return a.UserID == userID && a.Balance == initialBalance && a.Currency == model.DefaultCurrency
 		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.AccountCreated) bool {
-			return e.UserID == userID && e.Balance == initialBalance
+			return e.UserID == userID && e.Balance == initialBalance && e.Currency == model.DefaultCurrency
 		})).Return(nil).Once()
 
-		err := service.CreateAccount(userID, initialBalance)
+		err := service.CreateAccount(userID, "", initialBalance)
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
@@ -67,10 +72,15 @@ func TestAccountService_CreateAccount(t *testing.T) {
 	t.Run("already_exists", func(t *testing.T) {
 		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.Account{UserID: userID}, nil).Once()
 
-		err := service.CreateAccount(userID, initialBalance)
+		err := service.CreateAccount(userID, "USD", initialBalance)
 		assert.NoError(t, err)
 		repo.AssertNotCalled(t, "Store")
 	})
+
+	t.Run("invalid_currency", func(t *testing.T) {
+		err := service.CreateAccount(userID, "rub", initialBalance)
+		assert.ErrorIs(t, err, model.ErrInvalidCurrency)
+	})
 }
 
 func TestAccountService_UpdateBalance(t *testing.T) {
@@ -81,26 +91,34 @@ func TestAccountService_UpdateBalance(t *testing.T) {
 	userID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		existing := &model.Account{UserID: userID, Balance: 100}
+		existing := &model.Account{UserID: userID, Currency: "USD", Balance: 100}
 		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
 		repo.On("Store", mock.MatchedBy(func(a model.Account) bool {
-			return a.UserID == userID && a.Balance == 200
+			return a.UserID == userID && a.Balance == 200 && a.Currency == "USD"
 		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.AccountBalanceUpdated) bool {
-			return e.UserID == userID && e.Balance == 200
+			return e.UserID == userID && e.Balance == 200 && e.Currency == "USD"
 		})).Return(nil).Once()
 
-		err := service.UpdateBalance(userID, 200)
+		err := service.UpdateBalance(userID, "", 200)
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("no_change", func(t *testing.T) {
-		existing := &model.Account{UserID: userID, Balance: 100}
+		existing := &model.Account{UserID: userID, Currency: model.DefaultCurrency, Balance: 100}
 		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
 
-		err := service.UpdateBalance(userID, 100)
+		err := service.UpdateBalance(userID, model.DefaultCurrency, 100)
 		assert.NoError(t, err)
 		repo.AssertNotCalled(t, "Store")
 	})
+
+	t.Run("currency_mismatch", func(t *testing.T) {
+		existing := &model.Account{UserID: userID, Currency: model.DefaultCurrency, Balance: 100}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+
+		err := service.UpdateBalance(userID, "EUR", 200)
+		assert.ErrorIs(t, err, model.ErrCurrencyMismatch)
+	})
 }
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"paymentservice/pkg/payment/domain/model"
+)
+
+type UserAccessService interface {
+	// Authorize возвращает ErrPermissionDenied, если пользователь неизвестен, не активен или у него нет права
+	Authorize(userID uuid.UUID, permission model.Permission) error
+	// SetPermissions и SetStatus пропускают изменение, если сохранено более позднее
+	SetPermissions(userID uuid.UUID, permissions []model.Permission, updatedAt time.Time) error
+	SetStatus(userID uuid.UUID, active bool, updatedAt time.Time) error
+	Forget(userID uuid.UUID) error
+}
+
+func NewUserAccessService(userAccessRepository model.UserAccessRepository) UserAccessService {
+	return &userAccessService{
+		userAccessRepository: userAccessRepository,
+	}
+}
+
+type userAccessService struct {
+	userAccessRepository model.UserAccessRepository
+}
+
+func (s *userAccessService) Authorize(userID uuid.UUID, permission model.Permission) error {
+	access, err := s.userAccessRepository.Find(userID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserAccessNotFound) {
+			return model.ErrPermissionDenied
+		}
+		return err
+	}
+	if !access.Can(permission) {
+		return model.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *userAccessService) SetPermissions(userID uuid.UUID, permissions []model.Permission, updatedAt time.Time) error {
+	access, err := s.find(userID)
+	if err != nil {
+		return err
+	}
+	if updatedAt.Before(access.PermissionsUpdatedAt) {
+		return nil
+	}
+	access.Permissions = permissions
+	access.PermissionsUpdatedAt = updatedAt
+	return s.userAccessRepository.Store(*access)
+}
+
+func (s *userAccessService) SetStatus(userID uuid.UUID, active bool, updatedAt time.Time) error {
+	access, err := s.find(userID)
+	if err != nil {
+		return err
+	}
+	if updatedAt.Before(access.StatusUpdatedAt) {
+		return nil
+	}
+	access.Active = active
+	access.StatusUpdatedAt = updatedAt
+	return s.userAccessRepository.Store(*access)
+}
+
+func (s *userAccessService) Forget(userID uuid.UUID) error {
+	return s.userAccessRepository.Delete(userID)
+}
+
+// find возвращает пустую запись для пользователя, о котором событий еще не было
+func (s *userAccessService) find(userID uuid.UUID) (*model.UserAccess, error) {
+	access, err := s.userAccessRepository.Find(userID)
+	if errors.Is(err, model.ErrUserAccessNotFound) {
+		return &model.UserAccess{UserID: userID}, nil
+	}
+	return access, err
+}
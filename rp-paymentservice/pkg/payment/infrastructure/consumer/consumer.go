@@ -0,0 +1,64 @@
+package consumer
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"github.com/pkg/errors"
+
+	appservice "paymentservice/pkg/payment/application/service"
+	"paymentservice/pkg/payment/infrastructure/metrics"
+)
+
+// errProcessed - amqp.Consumer подтверждает сообщение, только если обработчик вернул ошибку,
+// а на nil возвращает его в очередь. Поэтому успешная обработка и неисправимые сообщения возвращают ошибку
+var errProcessed = errors.New("event processed")
+
+type EventConsumer struct {
+	userAccessService appservice.UserAccessService
+	logger            logging.Logger
+}
+
+func NewEventConsumer(userAccessService appservice.UserAccessService, logger logging.Logger) *EventConsumer {
+	return &EventConsumer{
+		userAccessService: userAccessService,
+		logger:            logger,
+	}
+}
+
+func (c *EventConsumer) Handler() amqp.Handler {
+	return c.handle
+}
+
+func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if !errors.Is(err, errProcessed) {
+			status = "error"
+		}
+		metrics.EventDuration.WithLabelValues(delivery.Type, status).Observe(time.Since(start).Seconds())
+	}()
+
+	l := c.logger.WithField("event_type", delivery.Type)
+
+	switch delivery.Type {
+	case "user_created", "user_updated", "user_deleted", "user_restored":
+		syncErr := syncUserAccess(ctx, c.userAccessService, delivery.Type, delivery.Body)
+		if errors.Is(syncErr, errInvalidEvent) {
+			l.Error(syncErr, "failed to parse user event")
+			return errProcessed
+		}
+		if syncErr != nil {
+			// сбой базы временный, сообщение вернется в очередь
+			l.Error(syncErr, "failed to sync user access")
+			return nil
+		}
+		return errProcessed
+
+	default:
+		return errProcessed
+	}
+}
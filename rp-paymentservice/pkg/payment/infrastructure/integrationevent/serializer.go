@@ -20,6 +20,7 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 	case *model.AccountCreated:
 		b, err := json.Marshal(AccountCreated{
 			UserID:    e.UserID.String(),
+			Currency:  e.Currency,
 			Balance:   e.Balance,
 			CreatedAt: e.CreatedAt.Unix(),
 		})
@@ -27,6 +28,7 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 	case *model.AccountBalanceUpdated:
 		b, err := json.Marshal(AccountBalanceUpdated{
 			UserID:    e.UserID.String(),
+			Currency:  e.Currency,
 			Balance:   e.Balance,
 			UpdatedAt: e.UpdatedAt.Unix(),
 		})
@@ -38,12 +40,14 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 
 type AccountCreated struct {
 	UserID    string `json:"user_id"`
+	Currency  string `json:"currency"`
 	Balance   int64  `json:"balance"`
 	CreatedAt int64  `json:"created_at"`
 }
 
 type AccountBalanceUpdated struct {
 	UserID    string `json:"user_id"`
+	Currency  string `json:"currency"`
 	Balance   int64  `json:"balance"`
 	UpdatedAt int64  `json:"updated_at"`
 }
@@ -12,4 +12,11 @@ var (
 		Name:      "query_duration_seconds",
 		Help:      "Duration of database queries",
 	}, []string{"operation", "table", "status"})
+
+	EventDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "payment",
+		Subsystem: "event",
+		Name:      "processing_duration_seconds",
+		Help:      "Duration of event processing",
+	}, []string{"event_type", "status"})
 )
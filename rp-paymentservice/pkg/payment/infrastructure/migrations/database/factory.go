@@ -44,4 +44,8 @@ func NewDatabaseMigrator(
 
 var builderFunctions = []MigrationBuilderFunc{
 	NewVersion1722266005,
+	NewVersion1792400001,
+	NewVersion1792400002,
+	NewVersion1792400003,
+	NewVersion1792400004,
 }
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400003(client mysql.ClientContext) migrator.Migration {
+	return &version1792400003{
+		client: client,
+	}
+}
+
+type version1792400003 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400003) Version() int64 {
+	return 1792400003
+}
+
+func (v version1792400003) Description() string {
+	return "Add 'currency' to 'account' table"
+}
+
+func (v version1792400003) Up(ctx context.Context) error {
+	// до появления валют все счета были в рублях
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE account
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB'
+	`)
+	return errors.WithStack(err)
+}
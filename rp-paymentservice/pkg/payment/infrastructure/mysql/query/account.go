@@ -36,14 +36,15 @@ func (p *accountQueryService) FindUserBalance(ctx context.Context, userID uuid.U
 	}()
 
 	account := struct {
-		UserID  uuid.UUID `db:"user_id"`
-		Balance int64     `db:"balance"`
+		UserID   uuid.UUID `db:"user_id"`
+		Currency string    `db:"currency"`
+		Balance  int64     `db:"balance"`
 	}{}
 
 	err = p.client.GetContext(
 		ctx,
 		&account,
-		`SELECT user_id, balance FROM account WHERE user_id = ?`,
+		`SELECT user_id, currency, balance FROM account WHERE user_id = ?`,
 		userID,
 	)
 	if err != nil {
@@ -54,7 +55,8 @@ func (p *accountQueryService) FindUserBalance(ctx context.Context, userID uuid.U
 	}
 
 	return &appmodel.UserBalance{
-		UserID:  account.UserID,
-		Balance: account.Balance,
+		UserID:   account.UserID,
+		Currency: account.Currency,
+		Balance:  account.Balance,
 	}, nil
 }
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "paymentservice/pkg/payment/application/model"
+	"paymentservice/pkg/payment/application/query"
+	"paymentservice/pkg/payment/domain/model"
+)
+
+const auditEntityAccount = "account"
+
+func NewUserDataQueryService(client mysql.ClientContext) query.UserDataQueryService {
+	return &userDataQueryService{
+		accountQueryService:  &accountQueryService{client: client},
+		auditLogQueryService: &auditLogQueryService{client: client},
+	}
+}
+
+type userDataQueryService struct {
+	accountQueryService  *accountQueryService
+	auditLogQueryService *auditLogQueryService
+}
+
+func (s *userDataQueryService) FindUserData(ctx context.Context, userID uuid.UUID) (appmodel.UserData, error) {
+	balance, err := s.accountQueryService.FindUserBalance(ctx, userID)
+	if err != nil && !errors.Is(err, model.ErrAccountNotFound) {
+		return appmodel.UserData{}, err
+	}
+
+	history, err := s.auditLogQueryService.FindAuditLog(ctx, auditEntityAccount, userID.String())
+	if err != nil {
+		return appmodel.UserData{}, err
+	}
+
+	return appmodel.UserData{
+		Balance: balance,
+		History: history,
+	}, nil
+}
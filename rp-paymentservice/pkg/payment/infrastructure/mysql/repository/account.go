@@ -17,6 +17,8 @@ import (
 const (
 	statusSuccess = "success"
 	statusError   = "error"
+
+	auditEntityAccount = "account"
 )
 
 func NewAccountRepository(ctx context.Context, client mysql.ClientContext) model.AccountRepository {
@@ -45,19 +47,29 @@ func (p *accountRepository) Store(account model.Account) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("store", "account", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := p.Find(model.FindSpec{UserID: &account.UserID})
+	if err != nil && !errors.Is(err, model.ErrAccountNotFound) {
+		return err
+	}
+
 	_, err = p.client.ExecContext(p.ctx,
 		`
-	INSERT INTO account (user_id, balance, created_at, updated_at) VALUES (?, ?, ?, ?)
+	INSERT INTO account (user_id, currency, balance, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
 	ON DUPLICATE KEY UPDATE
 		balance=VALUES(balance),
 	    updated_at=VALUES(updated_at)
 	`,
 		account.UserID,
+		account.Currency,
 		account.Balance,
 		account.CreatedAt,
 		account.UpdatedAt,
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord(p.ctx, p.client, auditEntityAccount, account.UserID.String(), before, &account)
 }
 
 func (p *accountRepository) Find(spec model.FindSpec) (_ *model.Account, err error) {
@@ -72,6 +84,7 @@ func (p *accountRepository) Find(spec model.FindSpec) (_ *model.Account, err err
 
 	account := struct {
 		UserID    uuid.UUID `db:"user_id"`
+		Currency  string    `db:"currency"`
 		Balance   int64     `db:"balance"`
 		CreatedAt time.Time `db:"created_at"`
 		UpdatedAt time.Time `db:"updated_at"`
@@ -81,7 +94,7 @@ func (p *accountRepository) Find(spec model.FindSpec) (_ *model.Account, err err
 	err = p.client.GetContext(
 		p.ctx,
 		&account,
-		`SELECT user_id, balance, created_at, updated_at FROM account WHERE `+query,
+		`SELECT user_id, currency, balance, created_at, updated_at FROM account WHERE `+query,
 		args...,
 	)
 	if err != nil {
@@ -93,12 +106,39 @@ func (p *accountRepository) Find(spec model.FindSpec) (_ *model.Account, err err
 
 	return &model.Account{
 		UserID:    account.UserID,
+		Currency:  account.Currency,
 		Balance:   account.Balance,
 		CreatedAt: account.CreatedAt,
 		UpdatedAt: account.UpdatedAt,
 	}, nil
 }
 
+func (p *accountRepository) Delete(userID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "account", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := p.Find(model.FindSpec{UserID: &userID})
+	if err != nil {
+		if errors.Is(err, model.ErrAccountNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM account WHERE user_id = ?`, userID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord[model.Account](p.ctx, p.client, auditEntityAccount, userID.String(), before, nil)
+}
+
 func (p *accountRepository) buildSpecArgs(spec model.FindSpec) (query string, args []interface{}) {
 	var parts []string
 	if spec.UserID != nil {
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	"paymentservice/pkg/payment/infrastructure/audit"
+	"paymentservice/pkg/payment/infrastructure/metrics"
+)
+
+// appendAuditRecord дописывает запись в журнал аудита в той же транзакции, что и изменение сущности.
+// Голова цепочки блокируется до конца транзакции, так что записи выстраиваются строго друг за другом
+func appendAuditRecord[T any](ctx context.Context, client mysql.ClientContext, entityType, entityID string, before, after *T) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("append", "audit_log", status).Observe(time.Since(start).Seconds())
+	}()
+
+	beforeValue, err := auditValue(before)
+	if err != nil {
+		return err
+	}
+	afterValue, err := auditValue(after)
+	if err != nil {
+		return err
+	}
+
+	var prevHash string
+	err = client.GetContext(ctx, &prevHash, `SELECT hash FROM audit_log_head WHERE id = 1 FOR UPDATE`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	info := audit.InfoFromContext(ctx)
+	record := audit.Record{
+		Actor:         info.Actor,
+		Method:        info.Method,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Before:        beforeValue,
+		After:         afterValue,
+		CorrelationID: info.CorrelationID,
+		CreatedAt:     audit.Now(),
+		PrevHash:      prevHash,
+	}
+	hash := record.Hash()
+
+	_, err = client.ExecContext(ctx,
+		`
+	INSERT INTO audit_log (actor, method, entity_type, entity_id, before_value, after_value, correlation_id, created_at, prev_hash, hash)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.Actor,
+		record.Method,
+		record.EntityType,
+		record.EntityID,
+		toSQLNull(record.Before),
+		toSQLNull(record.After),
+		record.CorrelationID,
+		record.CreatedAt,
+		record.PrevHash,
+		hash,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = client.ExecContext(ctx, `UPDATE audit_log_head SET hash = ? WHERE id = 1`, hash)
+	return errors.WithStack(err)
+}
+
+func auditValue[T any](v *T) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return toPtr(string(data)), nil
+}
+
+func toPtr[T any](v T) *T {
+	return &v
+}
+
+func toSQLNull[T any](v *T) sql.Null[T] {
+	if v == nil {
+		return sql.Null[T]{}
+	}
+	return sql.Null[T]{
+		V:     *v,
+		Valid: true,
+	}
+}
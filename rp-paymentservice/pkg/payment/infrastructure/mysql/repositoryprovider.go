@@ -21,3 +21,7 @@ type repositoryProvider struct {
 func (r *repositoryProvider) AccountRepository(ctx context.Context) model.AccountRepository {
 	return repository.NewAccountRepository(ctx, r.client)
 }
+
+func (r *repositoryProvider) UserAccessRepository(ctx context.Context) model.UserAccessRepository {
+	return repository.NewUserAccessRepository(ctx, r.client)
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket_Take(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 2, Burst: 3}
+
+	type step struct {
+		after      time.Duration
+		allowed    bool
+		retryAfter time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst_then_reject",
+			steps: []step{
+				{allowed: true},
+				{allowed: true},
+				{allowed: true},
+				// при 2 токенах в секунду следующий появится через 500мс
+				{allowed: false, retryAfter: 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "refill_over_time",
+			steps: []step{
+				{allowed: true},
+				{allowed: true},
+				{allowed: true},
+				{after: 250 * time.Millisecond, allowed: false, retryAfter: 250 * time.Millisecond},
+				{after: 500 * time.Millisecond, allowed: true},
+				// дробная часть токена не теряется: осталось 0.5
+				{allowed: false, retryAfter: 250 * time.Millisecond},
+			},
+		},
+		{
+			name: "refill_capped_by_burst",
+			steps: []step{
+				{allowed: true},
+				// за час накопилось бы 7200 токенов, но bucket вмещает только Burst
+				{after: time.Hour, allowed: true},
+				{allowed: true},
+				{allowed: true},
+				{allowed: false, retryAfter: 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "clock_going_back_does_not_refill",
+			steps: []step{
+				{allowed: true},
+				{allowed: true},
+				{allowed: true},
+				{after: -time.Second, allowed: false, retryAfter: 500 * time.Millisecond},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			b := newBucket(limit, now)
+			for i, s := range tt.steps {
+				now = now.Add(s.after)
+				allowed, retryAfter := b.take(limit, now)
+				assert.Equal(t, s.allowed, allowed, "step %d", i)
+				assert.Equal(t, s.retryAfter, retryAfter, "step %d", i)
+			}
+		})
+	}
+}
+
+func TestBucket_Full(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 1, Burst: 2}
+
+	b := newBucket(limit, start)
+	assert.True(t, b.full(limit, start))
+
+	b.take(limit, start)
+	b.take(limit, start)
+	assert.False(t, b.full(limit, start.Add(time.Second)))
+	assert.True(t, b.full(limit, start.Add(2*time.Second)))
+}
+
+func TestMemoryLimiter_Take(t *testing.T) {
+	limiter := NewMemoryLimiter()
+	ctx := context.Background()
+	limit := Limit{Rate: 0.001, Burst: 1}
+
+	allowed, _, err := limiter.Take(ctx, "caller-1", limit)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, retryAfter, err := limiter.Take(ctx, "caller-1", limit)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Positive(t, retryAfter)
+
+	// у каждого ключа свой bucket
+	allowed, _, err = limiter.Take(ctx, "caller-2", limit)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"paymentservice/api/server/paymentinternal"
+	appmodel "paymentservice/pkg/payment/application/model"
+)
+
+func toAuditRecords(records []appmodel.AuditRecord) []*paymentinternal.AuditRecord {
+	result := make([]*paymentinternal.AuditRecord, len(records))
+	for i, record := range records {
+		result[i] = &paymentinternal.AuditRecord{
+			RecordID:      record.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt.Unix(),
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			Valid:         record.Valid,
+		}
+	}
+	return result
+}
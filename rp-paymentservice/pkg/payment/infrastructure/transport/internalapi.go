@@ -3,40 +3,48 @@ package transport
 import (
 	"context"
 
-	"github.com/google/uuid"
-
 	"paymentservice/api/server/paymentinternal"
 	appmodel "paymentservice/pkg/payment/application/model"
 	"paymentservice/pkg/payment/application/query"
 	"paymentservice/pkg/payment/application/service"
+	"paymentservice/pkg/payment/domain/model"
 )
 
+// MethodPermissions - права из userservice, нужные для вызова методов PaymentInternalService
+var MethodPermissions = map[string]string{
+	"StoreUserBalance": string(model.PermissionManageBalances),
+}
+
 func NewPaymentInternalAPI(
 	accountQueryService query.AccountQueryService,
+	auditLogQueryService query.AuditLogQueryService,
 	accountService service.AccountService,
 ) paymentinternal.PaymentInternalServiceServer {
 	return &paymentInternalAPI{
-		accountQueryService: accountQueryService,
-		accountService:      accountService,
+		accountQueryService:  accountQueryService,
+		auditLogQueryService: auditLogQueryService,
+		accountService:       accountService,
 	}
 }
 
 type paymentInternalAPI struct {
-	accountQueryService query.AccountQueryService
-	accountService      service.AccountService
+	accountQueryService  query.AccountQueryService
+	auditLogQueryService query.AuditLogQueryService
+	accountService       service.AccountService
 
 	paymentinternal.UnimplementedPaymentInternalServiceServer
 }
 
 func (p *paymentInternalAPI) StoreUserBalance(ctx context.Context, request *paymentinternal.StoreUserBalanceRequest) (*paymentinternal.StoreUserBalanceResponse, error) {
-	userID, err := uuid.Parse(request.Balance.UserID)
+	userID, err := parseUUID("balance.userID", request.Balance.UserID)
 	if err != nil {
 		return nil, err
 	}
 
 	err = p.accountService.StoreUserBalance(ctx, appmodel.UserBalance{
-		UserID:  userID,
-		Balance: request.Balance.Balance,
+		UserID:   userID,
+		Currency: request.Balance.Currency,
+		Balance:  request.Balance.Balance,
 	})
 	if err != nil {
 		return nil, err
@@ -48,7 +56,7 @@ func (p *paymentInternalAPI) StoreUserBalance(ctx context.Context, request *paym
 }
 
 func (p *paymentInternalAPI) FindUserBalance(ctx context.Context, request *paymentinternal.FindUserBalanceRequest) (*paymentinternal.FindUserBalanceResponse, error) {
-	userID, err := uuid.Parse(request.UserID)
+	userID, err := parseUUID("userID", request.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,8 +69,19 @@ func (p *paymentInternalAPI) FindUserBalance(ctx context.Context, request *payme
 	}
 	return &paymentinternal.FindUserBalanceResponse{
 		Balance: &paymentinternal.UserBalance{
-			UserID:  balance.UserID.String(),
-			Balance: balance.Balance,
+			UserID:   balance.UserID.String(),
+			Currency: balance.Currency,
+			Balance:  balance.Balance,
 		},
 	}, nil
 }
+
+func (p *paymentInternalAPI) FindAuditLog(ctx context.Context, request *paymentinternal.FindAuditLogRequest) (*paymentinternal.FindAuditLogResponse, error) {
+	records, err := p.auditLogQueryService.FindAuditLog(ctx, request.EntityType, request.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return &paymentinternal.FindAuditLogResponse{
+		Records: toAuditRecords(records),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"paymentservice/api/server/userdatainternal"
+	"paymentservice/pkg/payment/application/query"
+	"paymentservice/pkg/payment/application/service"
+)
+
+const userDataServiceName = "paymentservice"
+
+func NewUserDataInternalAPI(
+	userDataQueryService query.UserDataQueryService,
+	userDataService service.UserDataService,
+) userdatainternal.UserDataInternalServiceServer {
+	return &userDataInternalAPI{
+		userDataQueryService: userDataQueryService,
+		userDataService:      userDataService,
+	}
+}
+
+type userDataInternalAPI struct {
+	userDataQueryService query.UserDataQueryService
+	userDataService      service.UserDataService
+	userdatainternal.UnimplementedUserDataInternalServiceServer
+}
+
+type exportedAccountChange struct {
+	Method    string           `json:"method"`
+	Before    *json.RawMessage `json:"before,omitempty"`
+	After     *json.RawMessage `json:"after,omitempty"`
+	CreatedAt int64            `json:"created_at"`
+}
+
+type exportedUserData struct {
+	Currency string                  `json:"currency,omitempty"`
+	Balance  *int64                  `json:"balance,omitempty"`
+	History  []exportedAccountChange `json:"history"`
+}
+
+func (a *userDataInternalAPI) ExportUserData(ctx context.Context, request *userdatainternal.ExportUserDataRequest) (*userdatainternal.ExportUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userData, err := a.userDataQueryService.FindUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedUserData{
+		History: make([]exportedAccountChange, len(userData.History)),
+	}
+	if userData.Balance != nil {
+		exported.Currency = userData.Balance.Currency
+		exported.Balance = &userData.Balance.Balance
+	}
+	for i, record := range userData.History {
+		exported.History[i] = exportedAccountChange{
+			Method:    record.Method,
+			Before:    toRawJSON(record.Before),
+			After:     toRawJSON(record.After),
+			CreatedAt: record.CreatedAt.Unix(),
+		}
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &userdatainternal.ExportUserDataResponse{
+		Service: userDataServiceName,
+		Data:    string(data),
+	}, nil
+}
+
+func (a *userDataInternalAPI) EraseUserData(ctx context.Context, request *userdatainternal.EraseUserDataRequest) (*userdatainternal.EraseUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.userDataService.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &userdatainternal.EraseUserDataResponse{
+		Service:  userDataServiceName,
+		ErasedAt: time.Now().Unix(),
+	}, nil
+}
+
+func toRawJSON(value *string) *json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	raw := json.RawMessage(*value)
+	return &raw
+}
@@ -19,6 +19,18 @@ type Service struct {
 	HTTPAddress string        `envconfig:"HTTP_ADDRESS" default:":8082"`
 }
 
+// RateLimit - лимиты в запросах в секунду, 0 - без ограничений.
+// METHOD_* и CALLER_* задаются списком вида "StoreProduct:5,FindProduct:50"
+type RateLimit struct {
+	Mode         string             `envconfig:"MODE" default:"memory"`
+	DefaultRate  float64            `envconfig:"DEFAULT_RATE" default:"0"`
+	DefaultBurst int                `envconfig:"DEFAULT_BURST" default:"0"`
+	MethodRates  map[string]float64 `envconfig:"METHOD_RATES"`
+	MethodBursts map[string]int     `envconfig:"METHOD_BURSTS"`
+	CallerRates  map[string]float64 `envconfig:"CALLER_RATES"`
+	CallerBursts map[string]int     `envconfig:"CALLER_BURSTS"`
+}
+
 type Database struct {
 	User                  string        `envconfig:"USER" required:"true"`
 	Password              string        `envconfig:"PASSWORD" required:"true"`
@@ -35,3 +47,13 @@ type AMQP struct {
 	Host           string        `envconfig:"HOST" required:"true"`
 	ConnectTimeout time.Duration `envconfig:"CONNECT_TIMEOUT"`
 }
+
+type PriceScheduler struct {
+	Interval  time.Duration `envconfig:"INTERVAL" default:"1m"`
+	BatchSize int           `envconfig:"BATCH_SIZE" default:"100"`
+}
+
+type Media struct {
+	LocalPath    string `envconfig:"LOCAL_PATH" default:"media"`
+	MaxImageSize int64  `envconfig:"MAX_IMAGE_SIZE" default:"10485760"` // 10 МБ
+}
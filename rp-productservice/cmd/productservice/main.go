@@ -26,6 +26,8 @@ func main() {
 			migrate(logger),
 			messageHandler(logger),
 			service(logger),
+			importProducts(logger),
+			exportProducts(logger),
 		},
 	}
 
@@ -14,13 +14,18 @@ import (
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sync/errgroup"
 
+	appservice "productservice/pkg/product/application/service"
+	"productservice/pkg/product/infrastructure/consumer"
 	"productservice/pkg/product/infrastructure/integrationevent"
+	inframysql "productservice/pkg/product/infrastructure/mysql"
+	"productservice/pkg/product/infrastructure/scheduler"
 )
 
 type messageHandlerConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
-	AMQP     AMQP     `envconfig:"amqp" required:"true"`
+	Service        Service        `envconfig:"service"`
+	Database       Database       `envconfig:"database" required:"true"`
+	AMQP           AMQP           `envconfig:"amqp" required:"true"`
+	PriceScheduler PriceScheduler `envconfig:"price_scheduler"`
 }
 
 func messageHandler(logger logging.Logger) *cli.Command {
@@ -45,16 +50,52 @@ func messageHandler(logger logging.Logger) *cli.Command {
 			closer.AddCloser(databaseConnector)
 			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
 
+			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
+			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
+			uow := inframysql.NewUnitOfWork(libUoW)
+			luow := inframysql.NewLockableUnitOfWork(libLUow)
+
 			amqpConnection := newAMQPConnection(cnf.AMQP, logger)
+
+			// оплаченные заказы нужны, чтобы разрешать отзывы только купившим товар и списывать остаток,
+			// события пользователей - чтобы проверять права на управление товарами
+			queueConfig := &amqp.QueueConfig{
+				Name:    "product_events",
+				Durable: true,
+			}
+			bindConfig := &amqp.BindConfig{
+				QueueName:    "product_events",
+				ExchangeName: integrationevent.ExchangeName,
+				RoutingKeys:  []string{"order.order_paid", "user.*"},
+			}
+
 			amqpEventProducer := amqpConnection.Producer(
 				&amqp.ExchangeConfig{
 					Name:    integrationevent.ExchangeName,
 					Kind:    integrationevent.ExchangeKind,
 					Durable: true,
 				},
-				nil,
+				queueConfig,
+				bindConfig,
+			)
+
+			// списание остатка по оплаченному заказу рассылает product_updated, поэтому диспетчер нужен раньше потребителя
+			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+
+			eventConsumer := consumer.NewEventConsumer(
+				appservice.NewStockService(luow, eventDispatcher),
+				appservice.NewReviewService(uow, luow),
+				appservice.NewUserAccessService(uow, luow),
+				logger,
+			)
+			amqpConnection.Consumer(
+				c.Context,
+				eventConsumer.Handler(),
+				queueConfig,
+				bindConfig,
 				nil,
 			)
+
 			err = amqpConnection.Start()
 			if err != nil {
 				return err
@@ -70,10 +111,22 @@ func messageHandler(logger logging.Logger) *cli.Command {
 				Logger:         logger,
 			})
 
+			priceScheduler := scheduler.NewPriceScheduler(
+				scheduler.PriceSchedulerConfig{
+					Interval:  cnf.PriceScheduler.Interval,
+					BatchSize: cnf.PriceScheduler.BatchSize,
+				},
+				appservice.NewPriceService(uow, luow, eventDispatcher),
+				logger,
+			)
+
 			errGroup := errgroup.Group{}
 			errGroup.Go(func() error {
 				return outboxEventHandler.Start(c.Context)
 			})
+			errGroup.Go(func() error {
+				return priceScheduler.Start(c.Context)
+			})
 
 			errGroup.Go(func() error {
 				router := mux.NewRouter()
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+
+	appmodel "productservice/pkg/product/application/model"
+	appservice "productservice/pkg/product/application/service"
+	"productservice/pkg/product/infrastructure/audit"
+	"productservice/pkg/product/infrastructure/integrationevent"
+	inframysql "productservice/pkg/product/infrastructure/mysql"
+	"productservice/pkg/product/infrastructure/mysql/query"
+	"productservice/pkg/product/infrastructure/productfile"
+)
+
+const exportBatchSize = 500
+
+type productsConfig struct {
+	Database Database `envconfig:"database" required:"true"`
+}
+
+var (
+	formatFlag = &cli.StringFlag{
+		Name:  "format",
+		Value: string(productfile.FormatJSONL),
+		Usage: "csv (product_id, name, description, price; without variants) or jsonl",
+	}
+	fileFlag = &cli.StringFlag{
+		Name:    "file",
+		Aliases: []string{"f"},
+		Usage:   "path to the file, stdin/stdout if not set",
+	}
+)
+
+func importProducts(logger logging.Logger) *cli.Command {
+	return &cli.Command{
+		Name:  "import-products",
+		Usage: "create products without product_id and update products with it, rows breaking domain rules are skipped",
+		Flags: []cli.Flag{
+			formatFlag,
+			fileFlag,
+			&cli.IntFlag{
+				Name:  "chunk-size",
+				Value: 100,
+				Usage: "products per transaction",
+			},
+		},
+		Action: func(c *cli.Context) (err error) {
+			format, err := productfile.ParseFormat(c.String(formatFlag.Name))
+			if err != nil {
+				return err
+			}
+			cnf, err := parseEnvs[productsConfig]()
+			if err != nil {
+				return err
+			}
+
+			closer := libio.NewMultiCloser()
+			defer func() {
+				err = errors.Join(err, closer.Close())
+			}()
+
+			input := io.Reader(os.Stdin)
+			if path := c.String(fileFlag.Name); path != "" {
+				file, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				closer.AddCloser(file)
+				input = file
+			}
+			rows, err := productfile.Read(format, input)
+			if err != nil {
+				return err
+			}
+
+			databaseConnector, err := newDatabaseConnector(cnf.Database)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(databaseConnector)
+			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
+
+			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
+			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
+			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+			importService := appservice.NewProductImportService(inframysql.NewLockableUnitOfWork(libLUow), eventDispatcher)
+
+			var (
+				products []appmodel.Product
+				lines    []int
+				failures []productfile.Row
+			)
+			for _, row := range rows {
+				if row.Err != nil {
+					failures = append(failures, row)
+					continue
+				}
+				products = append(products, row.Product)
+				lines = append(lines, row.Line)
+			}
+
+			ctx := audit.WithInfo(c.Context, audit.Info{Actor: audit.SystemActor, Method: "import-products"})
+			results, importErr := importService.ImportProducts(ctx, products, c.Int("chunk-size"))
+			imported := 0
+			for i, result := range results {
+				if result.Err != nil {
+					failures = append(failures, productfile.Row{Line: lines[i], Err: result.Err})
+					continue
+				}
+				imported++
+			}
+
+			sort.Slice(failures, func(i, j int) bool { return failures[i].Line < failures[j].Line })
+			for _, failure := range failures {
+				_, _ = fmt.Fprintf(c.App.Writer, "line %d: %v\n", failure.Line, failure.Err)
+			}
+			_, _ = fmt.Fprintf(c.App.Writer, "imported %d of %d products, %d failed\n", imported, len(rows), len(failures))
+			logger.WithFields(logging.Fields{
+				"imported": imported,
+				"failed":   len(failures),
+				"total":    len(rows),
+			}).Info("products import finished")
+
+			if importErr != nil {
+				return importErr
+			}
+			if len(failures) > 0 {
+				return fmt.Errorf("%d products failed to import", len(failures))
+			}
+			return nil
+		},
+	}
+}
+
+func exportProducts(logger logging.Logger) *cli.Command {
+	return &cli.Command{
+		Name:  "export-products",
+		Usage: "write all products except archived in the import-products format",
+		Flags: []cli.Flag{
+			formatFlag,
+			fileFlag,
+			&cli.BoolFlag{
+				Name:  "without-ids",
+				Usage: "omit product and variant ids, importing such a file creates new products",
+			},
+		},
+		Action: func(c *cli.Context) (err error) {
+			format, err := productfile.ParseFormat(c.String(formatFlag.Name))
+			if err != nil {
+				return err
+			}
+			cnf, err := parseEnvs[productsConfig]()
+			if err != nil {
+				return err
+			}
+
+			closer := libio.NewMultiCloser()
+			defer func() {
+				err = errors.Join(err, closer.Close())
+			}()
+
+			output := io.Writer(os.Stdout)
+			if path := c.String(fileFlag.Name); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				closer.AddCloser(file)
+				output = file
+			}
+			writer, err := productfile.NewWriter(format, output, !c.Bool("without-ids"))
+			if err != nil {
+				return err
+			}
+
+			databaseConnector, err := newDatabaseConnector(cnf.Database)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(databaseConnector)
+			productQueryService := query.NewProductQueryService(databaseConnector.TransactionalClient())
+
+			exported := 0
+			afterProductID := uuid.Nil
+			for {
+				products, err := productQueryService.ListProducts(c.Context, afterProductID, exportBatchSize)
+				if err != nil {
+					return err
+				}
+				for _, product := range products {
+					err = writer.Write(product)
+					if err != nil {
+						return err
+					}
+				}
+				exported += len(products)
+				if len(products) < exportBatchSize {
+					break
+				}
+				afterProductID = products[len(products)-1].ProductID
+			}
+
+			err = writer.Flush()
+			if err != nil {
+				return err
+			}
+			logger.WithField("exported", exported).Info("products export finished")
+			return nil
+		},
+	}
+}
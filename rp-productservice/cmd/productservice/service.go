@@ -18,7 +18,9 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"productservice/api/server/productinternal"
+	"productservice/api/server/userdatainternal"
 	appservice "productservice/pkg/product/application/service"
+	"productservice/pkg/product/infrastructure/blobstore"
 	"productservice/pkg/product/infrastructure/integrationevent"
 	inframysql "productservice/pkg/product/infrastructure/mysql"
 	"productservice/pkg/product/infrastructure/mysql/query"
@@ -27,8 +29,10 @@ import (
 )
 
 type serviceConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
+	Service   Service   `envconfig:"service"`
+	RateLimit RateLimit `envconfig:"rate_limit"`
+	Database  Database  `envconfig:"database" required:"true"`
+	Media     Media     `envconfig:"media"`
 }
 
 func service(logger logging.Logger) *cli.Command {
@@ -57,11 +61,28 @@ func service(logger logging.Logger) *cli.Command {
 			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
 			uow := inframysql.NewUnitOfWork(libUoW)
 			luow := inframysql.NewLockableUnitOfWork(libLUow)
+			rateLimiter, err := newRateLimiter(cnf.RateLimit, libUoW)
+			if err != nil {
+				return err
+			}
 			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
+			blobStore, err := blobstore.NewLocalBlobStore(cnf.Media.LocalPath)
+			if err != nil {
+				return err
+			}
 
+			userAccessService := appservice.NewUserAccessService(uow, luow)
 			productInternalAPI := transport.NewProductInternalAPI(
 				query.NewProductQueryService(databaseConnector.TransactionalClient()),
-				appservice.NewProductService(uow, luow, eventDispatcher),
+				query.NewCategoryQueryService(databaseConnector.TransactionalClient()),
+				query.NewPriceQueryService(databaseConnector.TransactionalClient()),
+				query.NewAuditLogQueryService(databaseConnector.TransactionalClient()),
+				query.NewReviewQueryService(databaseConnector.TransactionalClient()),
+				appservice.NewProductService(uow, luow, eventDispatcher, blobStore),
+				appservice.NewCategoryService(luow, eventDispatcher),
+				appservice.NewPriceService(uow, luow, eventDispatcher),
+				appservice.NewImageService(luow, blobStore, cnf.Media.MaxImageSize),
+				appservice.NewReviewService(uow, luow),
 			)
 
 			errGroup := errgroup.Group{}
@@ -70,10 +91,29 @@ func service(logger logging.Logger) *cli.Command {
 				if err != nil {
 					return err
 				}
-				grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
-					middlewares.NewGRPCLoggingMiddleware(logger),
-				))
+				grpcServer := grpc.NewServer(
+					grpc.ChainUnaryInterceptor(
+						middlewares.NewGRPCErrorsMiddleware(),
+						middlewares.NewGRPCLoggingMiddleware(logger),
+						middlewares.NewGRPCRateLimitMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+						middlewares.NewGRPCAuthorizationMiddleware(userAccessService, transport.MethodPermissions),
+						middlewares.NewGRPCValidationMiddleware(),
+						middlewares.NewGRPCAuditMiddleware(),
+					),
+					grpc.ChainStreamInterceptor(
+						middlewares.NewGRPCErrorsStreamMiddleware(),
+						middlewares.NewGRPCLoggingStreamMiddleware(logger),
+						middlewares.NewGRPCRateLimitStreamMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+						middlewares.NewGRPCAuthorizationStreamMiddleware(userAccessService, transport.MethodPermissions),
+						middlewares.NewGRPCValidationStreamMiddleware(),
+						middlewares.NewGRPCAuditStreamMiddleware(),
+					),
+				)
 				productinternal.RegisterProductInternalServiceServer(grpcServer, productInternalAPI)
+				userdatainternal.RegisterUserDataInternalServiceServer(grpcServer, transport.NewUserDataInternalAPI(
+					query.NewReviewQueryService(databaseConnector.TransactionalClient()),
+					appservice.NewUserDataService(uow, luow),
+				))
 				reflection.Register(grpcServer)
 				graceCallback(c.Context, logger, cnf.Service.GracePeriod, func(_ context.Context) error {
 					grpcServer.GracefulStop()
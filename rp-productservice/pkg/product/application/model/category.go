@@ -0,0 +1,9 @@
+package model
+
+import "github.com/google/uuid"
+
+type Category struct {
+	CategoryID uuid.UUID
+	ParentID   *uuid.UUID
+	Name       string
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type Image struct {
+	ImageID     uuid.UUID
+	URL         *string
+	ObjectKey   *string
+	ContentType string
+	Size        int64
+	Width       int
+	Height      int
+	Position    int
+	AltText     string
+}
+
+// ExternalImage - изображение, которое уже лежит по внешнему адресу
+type ExternalImage struct {
+	ProductID uuid.UUID
+	URL       string
+	Width     int
+	Height    int
+	AltText   string
+}
+
+// ImageUpload - изображение, содержимое которого загружается в хранилище
+type ImageUpload struct {
+	ProductID uuid.UUID
+	AltText   string
+	Content   io.Reader
+}
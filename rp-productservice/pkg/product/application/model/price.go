@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PriceRecord struct {
+	VariantID *uuid.UUID
+	Price     int64
+	Currency  string
+	ChangedAt time.Time
+}
+
+type ScheduledPriceStatus int
+
+const (
+	ScheduledPricePending ScheduledPriceStatus = iota
+	ScheduledPriceActive
+	ScheduledPriceCompleted
+	ScheduledPriceCancelled
+)
+
+type ScheduledPrice struct {
+	ScheduledPriceID uuid.UUID
+	ProductID        uuid.UUID
+	VariantID        *uuid.UUID
+	Price            int64
+	Currency         string
+	PreviousPrice    *int64
+	EffectiveFrom    time.Time
+	EffectiveUntil   *time.Time
+	Status           ScheduledPriceStatus
+}
+
+type PriceHistory struct {
+	Records         []PriceRecord
+	ScheduledPrices []ScheduledPrice
+}
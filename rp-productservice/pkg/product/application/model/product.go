@@ -1,10 +1,48 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Product struct {
-	ProductID   uuid.UUID
-	Name        string
-	Price       int64
+	ProductID uuid.UUID
+	Name      string
+	Slug      string
+	Price     int64
+	// Currency - код ISO 4217 цен товара и вариантов. При сохранении пустой оставляет текущую валюту
+	Currency    string
 	Description *string
+	CategoryIDs []uuid.UUID
+	Variants    []Variant
+	ArchivedAt  *time.Time
+	Images      []Image
+	Rating      Rating
+}
+
+type Variant struct {
+	VariantID  uuid.UUID
+	SKU        string
+	Attributes map[string]string
+	Price      int64
+	Stock      int64
+}
+
+// StockDeduction - позиции оплаченного заказа, которые списываются с остатка вариантов
+type StockDeduction struct {
+	OrderID uuid.UUID
+	Items   []StockDeductionItem
+}
+
+type StockDeductionItem struct {
+	ProductID uuid.UUID
+	VariantID uuid.UUID
+	Quantity  int64
+}
+
+// ImportResult - итог импорта одного товара. Err - нарушение доменных правил, товар пропущен
+type ImportResult struct {
+	ProductID uuid.UUID
+	Err       error
 }
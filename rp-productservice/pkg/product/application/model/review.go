@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReviewStatus int
+
+const (
+	ReviewPending ReviewStatus = iota
+	ReviewApproved
+	ReviewRejected
+)
+
+type Review struct {
+	ReviewID   uuid.UUID
+	ProductID  uuid.UUID
+	AuthorID   uuid.UUID
+	AuthorName string
+	Rating     int
+	Text       string
+	Status     ReviewStatus
+	CreatedAt  time.Time
+}
+
+// Rating - средняя оценка и число одобренных отзывов товара
+type Rating struct {
+	Average float64
+	Count   int
+}
+
+// ReviewFilter - условия выборки отзывов, пустые поля не ограничивают выборку
+type ReviewFilter struct {
+	ProductID *uuid.UUID
+	AuthorID  *uuid.UUID
+	Status    *ReviewStatus
+	// Limit 0 - без ограничения
+	Limit int
+}
+
+// Purchase - оплаченный заказ, из которого берутся купленные товары
+type Purchase struct {
+	OrderID     uuid.UUID
+	UserID      uuid.UUID
+	ProductIDs  []uuid.UUID
+	PurchasedAt time.Time
+}
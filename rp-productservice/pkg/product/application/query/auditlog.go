@@ -0,0 +1,11 @@
+package query
+
+import (
+	"context"
+
+	appmodel "productservice/pkg/product/application/model"
+)
+
+type AuditLogQueryService interface {
+	FindAuditLog(ctx context.Context, entityType, entityID string) ([]appmodel.AuditRecord, error)
+}
@@ -0,0 +1,15 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+)
+
+type CategoryQueryService interface {
+	// ListCategories возвращает все дерево каталога списком, родитель указан в ParentID
+	ListCategories(ctx context.Context) ([]appmodel.Category, error)
+	ListCategoryProducts(ctx context.Context, categoryID uuid.UUID, includeSubcategories bool) ([]appmodel.Product, error)
+}
@@ -0,0 +1,14 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+)
+
+type PriceQueryService interface {
+	// GetPriceHistory возвращает прошедшие изменения цен товара и его вариантов и запланированные цены
+	GetPriceHistory(ctx context.Context, productID uuid.UUID) (*appmodel.PriceHistory, error)
+}
@@ -10,4 +10,7 @@ import (
 
 type ProductQueryService interface {
 	FindProduct(ctx context.Context, productID uuid.UUID) (*appmodel.Product, error)
+	FindProductBySlug(ctx context.Context, slug string) (*appmodel.Product, error)
+	// ListProducts возвращает неархивные товары по возрастанию ProductID, начиная после afterProductID
+	ListProducts(ctx context.Context, afterProductID uuid.UUID, limit int) ([]appmodel.Product, error)
 }
@@ -0,0 +1,12 @@
+package query
+
+import (
+	"context"
+
+	appmodel "productservice/pkg/product/application/model"
+)
+
+type ReviewQueryService interface {
+	// ListReviews возвращает отзывы от новых к старым
+	ListReviews(ctx context.Context, filter appmodel.ReviewFilter) ([]appmodel.Review, error)
+}
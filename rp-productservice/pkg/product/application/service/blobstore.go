@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore хранит загруженные файлы по ключу
+type BlobStore interface {
+	// Put сохраняет содержимое r целиком и возвращает его размер. При ошибке файл не остается в хранилище
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет файл. Отсутствие файла ошибкой не считается
+	Delete(ctx context.Context, key string) error
+}
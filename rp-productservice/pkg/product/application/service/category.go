@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/service"
+)
+
+type CategoryService interface {
+	StoreCategory(ctx context.Context, category appmodel.Category) (uuid.UUID, error)
+	DeleteCategory(ctx context.Context, categoryID uuid.UUID) error
+	SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error
+}
+
+func NewCategoryService(
+	luow LockableUnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) CategoryService {
+	return &categoryService{
+		luow:            luow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type categoryService struct {
+	luow            LockableUnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *categoryService) StoreCategory(ctx context.Context, category appmodel.Category) (uuid.UUID, error) {
+	categoryID := category.CategoryID
+	err := s.luow.Execute(ctx, []string{categoryTreeLock}, func(provider RepositoryProvider) error {
+		domainService := s.domainService(ctx, provider)
+		if category.CategoryID == uuid.Nil {
+			cID, err := domainService.CreateCategory(category.Name, category.ParentID)
+			if err != nil {
+				return err
+			}
+			categoryID = cID
+			return nil
+		}
+		return domainService.UpdateCategory(categoryID, category.Name, category.ParentID)
+	})
+	return categoryID, err
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, categoryID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{categoryTreeLock}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).DeleteCategory(categoryID)
+	})
+}
+
+func (s *categoryService) SetProductCategories(ctx context.Context, productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	// блокировка дерева не дает удалить категорию, пока в нее добавляется товар
+	lockNames := []string{productLock(productID), categoryTreeLock}
+	return s.luow.Execute(ctx, lockNames, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetProductCategories(productID, categoryIDs)
+	})
+}
+
+func (s *categoryService) domainService(ctx context.Context, provider RepositoryProvider) service.CategoryService {
+	return service.NewCategoryService(
+		provider.CategoryRepository(ctx),
+		provider.ProductRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
+
+// categoryTreeLock один на все дерево: иначе два встречных перемещения могут замкнуть цикл
+const categoryTreeLock = "category_tree"
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmodel "productservice/pkg/product/application/model"
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+type StubCategoryRepo struct {
+	mock.Mock
+}
+
+func (m *StubCategoryRepo) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *StubCategoryRepo) Store(c domainmodel.Category) error {
+	return m.Called(c).Error(0)
+}
+
+func (m *StubCategoryRepo) Find(categoryID uuid.UUID) (*domainmodel.Category, error) {
+	args := m.Called(categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.Category), args.Error(1)
+}
+
+func (m *StubCategoryRepo) InUse(categoryID uuid.UUID) (bool, error) {
+	args := m.Called(categoryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *StubCategoryRepo) Delete(categoryID uuid.UUID) error {
+	return m.Called(categoryID).Error(0)
+}
+
+func TestCategoryService_StoreCategory_Create(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	luow := new(MockLockableUnitOfWork)
+	categories := new(StubCategoryRepo)
+
+	service := NewCategoryService(luow, &DummyDispatcher{})
+
+	ctx := context.Background()
+	categoryID := uuid.New()
+
+	luow.On("Execute", ctx, []string{categoryTreeLock}).Return(provider)
+	provider.On("CategoryRepository", ctx).Return(categories)
+	provider.On("ProductRepository", ctx).Return(new(StubProductRepo))
+
+	categories.On("NextID").Return(categoryID, nil)
+	categories.On("Store", mock.MatchedBy(func(c domainmodel.Category) bool {
+		return c.CategoryID == categoryID && c.ParentID == nil && c.Name == "Books"
+	})).Return(nil)
+
+	id, err := service.StoreCategory(ctx, appmodel.Category{Name: "Books"})
+	assert.NoError(t, err)
+	assert.Equal(t, categoryID, id)
+}
+
+func TestCategoryService_SetProductCategories(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	luow := new(MockLockableUnitOfWork)
+	categories := new(StubCategoryRepo)
+	products := new(StubProductRepo)
+
+	service := NewCategoryService(luow, &DummyDispatcher{})
+
+	ctx := context.Background()
+	productID := uuid.New()
+	categoryID := uuid.New()
+
+	luow.On("Execute", ctx, []string{productLock(productID), categoryTreeLock}).Return(provider)
+	provider.On("CategoryRepository", ctx).Return(categories)
+	provider.On("ProductRepository", ctx).Return(products)
+
+	products.On("Find", domainmodel.FindSpec{ProductID: &productID}).Return(&domainmodel.Product{ProductID: productID}, nil)
+	categories.On("Find", categoryID).Return(&domainmodel.Category{CategoryID: categoryID}, nil)
+	products.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+		return len(p.CategoryIDs) == 1 && p.CategoryIDs[0] == categoryID
+	})).Return(nil)
+
+	err := service.SetProductCategories(ctx, productID, []uuid.UUID{categoryID})
+	assert.NoError(t, err)
+	products.AssertExpectations(t)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // регистрация декодера для image.DecodeConfig
+	_ "image/jpeg" // регистрация декодера для image.DecodeConfig
+	_ "image/png"  // регистрация декодера для image.DecodeConfig
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/domain/service"
+)
+
+// allowedImageTypes - типы, которые умеет определять http.DetectContentType и декодировать image
+var allowedImageTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// sniffLength - сколько байт нужно http.DetectContentType
+const sniffLength = 512
+
+type ImageService interface {
+	AddImage(ctx context.Context, image appmodel.ExternalImage) (uuid.UUID, error)
+	// UploadImage сохраняет содержимое в хранилище и добавляет изображение товару.
+	// Тип и размеры определяются по содержимому
+	UploadImage(ctx context.Context, upload appmodel.ImageUpload) (uuid.UUID, error)
+	ReorderImages(ctx context.Context, productID uuid.UUID, imageIDs []uuid.UUID) error
+	RemoveImage(ctx context.Context, productID, imageID uuid.UUID) error
+}
+
+func NewImageService(
+	luow LockableUnitOfWork,
+	blobStore BlobStore,
+	maxImageSize int64,
+) ImageService {
+	return &imageService{
+		luow:         luow,
+		blobStore:    blobStore,
+		maxImageSize: maxImageSize,
+	}
+}
+
+type imageService struct {
+	luow         LockableUnitOfWork
+	blobStore    BlobStore
+	maxImageSize int64
+}
+
+func (s *imageService) AddImage(ctx context.Context, image appmodel.ExternalImage) (uuid.UUID, error) {
+	return s.addImage(ctx, model.Image{
+		ProductID: image.ProductID,
+		URL:       &image.URL,
+		Width:     image.Width,
+		Height:    image.Height,
+		AltText:   image.AltText,
+	})
+}
+
+func (s *imageService) UploadImage(ctx context.Context, upload appmodel.ImageUpload) (uuid.UUID, error) {
+	content := bufio.NewReaderSize(upload.Content, sniffLength)
+	head, err := content.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return uuid.Nil, err
+	}
+	contentType := http.DetectContentType(head)
+	if !slices.Contains(allowedImageTypes, contentType) {
+		return uuid.Nil, model.ErrUnsupportedImageType
+	}
+
+	// файл пишется до транзакции: загрузка может идти долго, а блокировку товара держать незачем
+	objectKey := fmt.Sprintf("products/%s/%s", upload.ProductID, uuid.NewString())
+	size, err := s.blobStore.Put(ctx, objectKey, &sizeLimitReader{r: content, remaining: s.maxImageSize})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	imageID, err := s.storeUploadedImage(ctx, upload, objectKey, contentType, size)
+	if err != nil {
+		return uuid.Nil, errors.Join(err, s.blobStore.Delete(ctx, objectKey))
+	}
+	return imageID, nil
+}
+
+func (s *imageService) storeUploadedImage(
+	ctx context.Context,
+	upload appmodel.ImageUpload,
+	objectKey, contentType string,
+	size int64,
+) (uuid.UUID, error) {
+	config, err := s.decodeConfig(ctx, objectKey, contentType)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return s.addImage(ctx, model.Image{
+		ProductID:   upload.ProductID,
+		ObjectKey:   &objectKey,
+		ContentType: contentType,
+		Size:        size,
+		Width:       config.Width,
+		Height:      config.Height,
+		AltText:     upload.AltText,
+	})
+}
+
+// decodeConfig читает заголовок сохраненного файла. Файл, который не разбирается
+// или не совпадает по формату с сигнатурой, считается неподдерживаемым
+func (s *imageService) decodeConfig(ctx context.Context, objectKey, contentType string) (image.Config, error) {
+	r, err := s.blobStore.Open(ctx, objectKey)
+	if err != nil {
+		return image.Config{}, err
+	}
+	defer r.Close()
+
+	config, format, err := image.DecodeConfig(r)
+	if err != nil || "image/"+format != contentType {
+		return image.Config{}, model.ErrUnsupportedImageType
+	}
+	return config, nil
+}
+
+func (s *imageService) ReorderImages(ctx context.Context, productID uuid.UUID, imageIDs []uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ReorderImages(productID, imageIDs)
+	})
+}
+
+func (s *imageService) RemoveImage(ctx context.Context, productID, imageID uuid.UUID) error {
+	var removed *model.Image
+	err := s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		var err error
+		removed, err = s.domainService(ctx, provider).RemoveImage(productID, imageID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	// файл удаляется после коммита, чтобы при откате транзакции изображение не осталось без файла
+	if removed.ObjectKey != nil {
+		return s.blobStore.Delete(ctx, *removed.ObjectKey)
+	}
+	return nil
+}
+
+func (s *imageService) addImage(ctx context.Context, image model.Image) (uuid.UUID, error) {
+	var imageID uuid.UUID
+	err := s.luow.Execute(ctx, []string{productLock(image.ProductID)}, func(provider RepositoryProvider) error {
+		var err error
+		imageID, err = s.domainService(ctx, provider).AddImage(image)
+		return err
+	})
+	return imageID, err
+}
+
+func (s *imageService) domainService(ctx context.Context, provider RepositoryProvider) service.ImageService {
+	return service.NewImageService(provider.ProductRepository(ctx), provider.ImageRepository(ctx))
+}
+
+// sizeLimitReader возвращает model.ErrImageTooLarge, как только прочитано больше remaining байт
+type sizeLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (r *sizeLimitReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, model.ErrImageTooLarge
+	}
+	// читаем на байт больше лимита, чтобы отличить файл ровно в лимит от превышающего
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, model.ErrImageTooLarge
+	}
+	return n, err
+}
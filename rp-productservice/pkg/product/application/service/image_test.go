@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmodel "productservice/pkg/product/application/model"
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+type MemoryBlobStore struct {
+	blobs map[string][]byte
+}
+
+func (s *MemoryBlobStore) Put(_ context.Context, key string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	s.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+func (s *MemoryBlobStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(s.blobs[key])), nil
+}
+
+func (s *MemoryBlobStore) Delete(_ context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+type StubImageRepo struct {
+	mock.Mock
+}
+
+func (m *StubImageRepo) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *StubImageRepo) Store(i domainmodel.Image) error {
+	return m.Called(i).Error(0)
+}
+
+func (m *StubImageRepo) Find(imageID uuid.UUID) (*domainmodel.Image, error) {
+	args := m.Called(imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.Image), args.Error(1)
+}
+
+func (m *StubImageRepo) FindByProduct(productID uuid.UUID) ([]domainmodel.Image, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]domainmodel.Image), args.Error(1)
+}
+
+func (m *StubImageRepo) Delete(imageID uuid.UUID) error {
+	return m.Called(imageID).Error(0)
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestImageService_UploadImage(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+
+	t.Run("unsupported_type", func(t *testing.T) {
+		blobs := &MemoryBlobStore{blobs: map[string][]byte{}}
+		service := NewImageService(new(MockLockableUnitOfWork), blobs, 1<<20)
+
+		_, err := service.UploadImage(ctx, appmodel.ImageUpload{
+			ProductID: productID,
+			Content:   bytes.NewReader([]byte("<html><body>not an image</body></html>")),
+		})
+		assert.ErrorIs(t, err, domainmodel.ErrUnsupportedImageType)
+		assert.Empty(t, blobs.blobs)
+	})
+
+	t.Run("too_large", func(t *testing.T) {
+		content := encodePNG(t, 64, 64)
+		blobs := &MemoryBlobStore{blobs: map[string][]byte{}}
+		service := NewImageService(new(MockLockableUnitOfWork), blobs, int64(len(content)-1))
+
+		_, err := service.UploadImage(ctx, appmodel.ImageUpload{ProductID: productID, Content: bytes.NewReader(content)})
+		assert.ErrorIs(t, err, domainmodel.ErrImageTooLarge)
+		assert.Empty(t, blobs.blobs)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		content := encodePNG(t, 40, 30)
+		blobs := &MemoryBlobStore{blobs: map[string][]byte{}}
+		provider := new(MockRepositoryProvider)
+		luow := new(MockLockableUnitOfWork)
+		products := new(StubProductRepo)
+		images := new(StubImageRepo)
+		service := NewImageService(luow, blobs, int64(len(content)))
+		imageID := uuid.New()
+
+		luow.On("Execute", ctx, []string{productLock(productID)}).Return(provider)
+		provider.On("ProductRepository", ctx).Return(products)
+		provider.On("ImageRepository", ctx).Return(images)
+		products.On("Find", domainmodel.FindSpec{ProductID: &productID}).Return(&domainmodel.Product{ProductID: productID}, nil)
+		images.On("FindByProduct", productID).Return([]domainmodel.Image{}, nil)
+		images.On("NextID").Return(imageID, nil)
+		images.On("Store", mock.MatchedBy(func(i domainmodel.Image) bool {
+			return i.ContentType == "image/png" && i.Width == 40 && i.Height == 30 &&
+				i.Size == int64(len(content)) && i.ObjectKey != nil && i.AltText == "front"
+		})).Return(nil).Once()
+
+		id, err := service.UploadImage(ctx, appmodel.ImageUpload{
+			ProductID: productID,
+			AltText:   "front",
+			Content:   bytes.NewReader(content),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, imageID, id)
+		assert.Len(t, blobs.blobs, 1)
+		images.AssertExpectations(t)
+	})
+
+	t.Run("product_not_found_removes_blob", func(t *testing.T) {
+		blobs := &MemoryBlobStore{blobs: map[string][]byte{}}
+		provider := new(MockRepositoryProvider)
+		luow := new(MockLockableUnitOfWork)
+		products := new(StubProductRepo)
+		service := NewImageService(luow, blobs, 1<<20)
+
+		luow.On("Execute", ctx, mock.Anything).Return(provider)
+		provider.On("ProductRepository", ctx).Return(products)
+		provider.On("ImageRepository", ctx).Return(new(StubImageRepo))
+		products.On("Find", mock.Anything).Return(nil, domainmodel.ErrProductNotFound)
+
+		_, err := service.UploadImage(ctx, appmodel.ImageUpload{ProductID: productID, Content: bytes.NewReader(encodePNG(t, 8, 8))})
+		assert.ErrorIs(t, err, domainmodel.ErrProductNotFound)
+		assert.Empty(t, blobs.blobs)
+	})
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/domain/service"
+)
+
+type PriceService interface {
+	SchedulePrice(ctx context.Context, price appmodel.ScheduledPrice) (uuid.UUID, error)
+	CancelScheduledPrice(ctx context.Context, scheduledPriceID uuid.UUID) error
+	// ApplyDuePrices применяет не больше limit цен, срок которых наступил к now, и возвращает их число
+	ApplyDuePrices(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+func NewPriceService(
+	uow UnitOfWork,
+	luow LockableUnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) PriceService {
+	return &priceService{
+		uow:             uow,
+		luow:            luow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type priceService struct {
+	uow             UnitOfWork
+	luow            LockableUnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *priceService) SchedulePrice(ctx context.Context, price appmodel.ScheduledPrice) (uuid.UUID, error) {
+	var scheduledPriceID uuid.UUID
+	err := s.luow.Execute(ctx, []string{productLock(price.ProductID)}, func(provider RepositoryProvider) error {
+		id, err := s.domainService(ctx, provider).SchedulePrice(
+			price.ProductID,
+			price.VariantID,
+			price.Price,
+			price.EffectiveFrom,
+			price.EffectiveUntil,
+		)
+		scheduledPriceID = id
+		return err
+	})
+	return scheduledPriceID, err
+}
+
+func (s *priceService) CancelScheduledPrice(ctx context.Context, scheduledPriceID uuid.UUID) error {
+	productID, err := s.scheduledPriceProductID(ctx, scheduledPriceID)
+	if err != nil {
+		return err
+	}
+	// отмена под блокировкой товара, чтобы не разойтись с планировщиком, применяющим эту же цену
+	return s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).CancelScheduledPrice(scheduledPriceID)
+	})
+}
+
+func (s *priceService) ApplyDuePrices(ctx context.Context, now time.Time, limit int) (int, error) {
+	var duePrices []model.ScheduledPrice
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		var err error
+		duePrices, err = provider.ScheduledPriceRepository(ctx).FindDue(now, limit)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// одна сломанная цена не должна останавливать остальные
+	var errs []error
+	applied := 0
+	for _, duePrice := range duePrices {
+		err = s.luow.Execute(ctx, []string{productLock(duePrice.ProductID)}, func(provider RepositoryProvider) error {
+			return s.domainService(ctx, provider).ApplyScheduledPrice(duePrice.ScheduledPriceID, now)
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		applied++
+	}
+	return applied, errors.Join(errs...)
+}
+
+func (s *priceService) scheduledPriceProductID(ctx context.Context, scheduledPriceID uuid.UUID) (uuid.UUID, error) {
+	var productID uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		scheduledPrice, err := provider.ScheduledPriceRepository(ctx).Find(scheduledPriceID)
+		if err != nil {
+			return err
+		}
+		productID = scheduledPrice.ProductID
+		return nil
+	})
+	return productID, err
+}
+
+func (s *priceService) domainService(ctx context.Context, provider RepositoryProvider) service.PriceService {
+	return service.NewPriceService(
+		provider.ProductRepository(ctx),
+		provider.ScheduledPriceRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
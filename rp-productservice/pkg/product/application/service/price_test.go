@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+type MockUnitOfWork struct {
+	mock.Mock
+}
+
+func (m *MockUnitOfWork) Execute(ctx context.Context, f func(provider RepositoryProvider) error) error {
+	args := m.Called(ctx)
+	return f(args.Get(0).(RepositoryProvider))
+}
+
+type StubScheduledPriceRepo struct {
+	mock.Mock
+}
+
+func (m *StubScheduledPriceRepo) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *StubScheduledPriceRepo) Store(p domainmodel.ScheduledPrice) error {
+	return m.Called(p).Error(0)
+}
+
+func (m *StubScheduledPriceRepo) Find(scheduledPriceID uuid.UUID) (*domainmodel.ScheduledPrice, error) {
+	args := m.Called(scheduledPriceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.ScheduledPrice), args.Error(1)
+}
+
+func (m *StubScheduledPriceRepo) FindDue(at time.Time, limit int) ([]domainmodel.ScheduledPrice, error) {
+	args := m.Called(at, limit)
+	return args.Get(0).([]domainmodel.ScheduledPrice), args.Error(1)
+}
+
+func (m *StubScheduledPriceRepo) FindUnfinished(productID uuid.UUID) ([]domainmodel.ScheduledPrice, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]domainmodel.ScheduledPrice), args.Error(1)
+}
+
+func TestPriceService_ApplyDuePrices_ContinuesAfterFailure(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	uow := new(MockUnitOfWork)
+	luow := new(MockLockableUnitOfWork)
+	products := new(StubProductRepo)
+	prices := new(StubScheduledPriceRepo)
+
+	service := NewPriceService(uow, luow, &DummyDispatcher{})
+
+	ctx := context.Background()
+	now := time.Now()
+	brokenProductID := uuid.New()
+	productID := uuid.New()
+	broken := domainmodel.ScheduledPrice{
+		ScheduledPriceID: uuid.New(),
+		ProductID:        brokenProductID,
+		Price:            100,
+		EffectiveFrom:    now.Add(-time.Minute),
+	}
+	due := domainmodel.ScheduledPrice{
+		ScheduledPriceID: uuid.New(),
+		ProductID:        productID,
+		Price:            700,
+		EffectiveFrom:    now.Add(-time.Minute),
+	}
+
+	uow.On("Execute", ctx).Return(provider)
+	luow.On("Execute", ctx, []string{productLock(brokenProductID)}).Return(provider).Once()
+	luow.On("Execute", ctx, []string{productLock(productID)}).Return(provider).Once()
+	provider.On("ProductRepository", ctx).Return(products)
+	provider.On("ScheduledPriceRepository", ctx).Return(prices)
+
+	prices.On("FindDue", now, 10).Return([]domainmodel.ScheduledPrice{broken, due}, nil)
+	prices.On("Find", broken.ScheduledPriceID).Return(&broken, nil)
+	prices.On("Find", due.ScheduledPriceID).Return(&due, nil)
+	products.On("Find", domainmodel.FindSpec{ProductID: &brokenProductID}).Return(nil, errors.New("connection lost"))
+	products.On("Find", domainmodel.FindSpec{ProductID: &productID}).Return(&domainmodel.Product{ProductID: productID, Price: 1000}, nil)
+	prices.On("Store", mock.Anything).Return(nil)
+	products.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+		return p.ProductID == productID && p.Price == 700
+	})).Return(nil).Once()
+
+	applied, err := service.ApplyDuePrices(ctx, now, 10)
+	assert.Error(t, err)
+	assert.Equal(t, 1, applied)
+	luow.AssertExpectations(t)
+	products.AssertExpectations(t)
+}
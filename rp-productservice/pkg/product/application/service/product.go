@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
 	"github.com/google/uuid"
@@ -15,17 +17,22 @@ import (
 
 type ProductService interface {
 	StoreProduct(ctx context.Context, product appmodel.Product) (uuid.UUID, error)
+	ArchiveProduct(ctx context.Context, productID uuid.UUID) error
+	UnarchiveProduct(ctx context.Context, productID uuid.UUID) error
+	PurgeProduct(ctx context.Context, productID uuid.UUID) error
 }
 
 func NewProductService(
 	uow UnitOfWork,
 	luow LockableUnitOfWork,
 	eventDispatcher outbox.EventDispatcher[outbox.Event],
+	blobStore BlobStore,
 ) ProductService {
 	return &productService{
 		uow:             uow,
 		luow:            luow,
 		eventDispatcher: eventDispatcher,
+		blobStore:       blobStore,
 	}
 }
 
@@ -33,27 +40,23 @@ type productService struct {
 	uow             UnitOfWork
 	luow            LockableUnitOfWork
 	eventDispatcher outbox.EventDispatcher[outbox.Event]
+	blobStore       BlobStore
 }
 
 func (s *productService) StoreProduct(ctx context.Context, product appmodel.Product) (uuid.UUID, error) {
-	var lockNames []string
-	if product.ProductID != uuid.Nil {
-		lockNames = append(lockNames, productLock(product.ProductID))
-	} else {
-		lockNames = append(lockNames, productNameLock(product.Name))
-	}
+	variants := domainVariants(product.Variants)
 
 	productID := product.ProductID
-	err := s.luow.Execute(ctx, lockNames, func(provider RepositoryProvider) error {
+	err := s.luow.Execute(ctx, productLockNames(product), func(provider RepositoryProvider) error {
 		domainService := s.domainService(ctx, provider.ProductRepository(ctx))
 		if product.ProductID == uuid.Nil {
-			pID, err := domainService.CreateProduct(product.Name, product.Price, product.Description)
+			pID, err := domainService.CreateProduct(product.Name, product.Price, product.Currency, product.Description, variants)
 			if err != nil {
 				return err
 			}
 			productID = pID
 		} else {
-			err := domainService.UpdateProduct(productID, product.Name, product.Price, product.Description)
+			err := domainService.UpdateProduct(productID, product.Name, product.Price, product.Currency, product.Description, variants)
 			if err != nil {
 				return err
 			}
@@ -63,6 +66,42 @@ func (s *productService) StoreProduct(ctx context.Context, product appmodel.Prod
 	return productID, err
 }
 
+func (s *productService) ArchiveProduct(ctx context.Context, productID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.ProductRepository(ctx)).ArchiveProduct(productID)
+	})
+}
+
+func (s *productService) UnarchiveProduct(ctx context.Context, productID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.ProductRepository(ctx)).UnarchiveProduct(productID)
+	})
+}
+
+func (s *productService) PurgeProduct(ctx context.Context, productID uuid.UUID) error {
+	var images []model.Image
+	err := s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		var err error
+		images, err = provider.ImageRepository(ctx).FindByProduct(productID)
+		if err != nil {
+			return err
+		}
+		return s.domainService(ctx, provider.ProductRepository(ctx)).PurgeProduct(productID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// загруженные файлы удаляются после коммита, метаданные изображений удалены вместе с товаром
+	var errs []error
+	for _, image := range images {
+		if image.ObjectKey != nil {
+			errs = append(errs, s.blobStore.Delete(ctx, *image.ObjectKey))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (s *productService) domainService(ctx context.Context, repository model.ProductRepository) service.ProductService {
 	return service.NewProductService(repository, s.domainEventDispatcher(ctx))
 }
@@ -74,12 +113,44 @@ func (s *productService) domainEventDispatcher(ctx context.Context) domain.Event
 	}
 }
 
+func domainVariants(variants []appmodel.Variant) []model.Variant {
+	result := make([]model.Variant, 0, len(variants))
+	for _, variant := range variants {
+		result = append(result, model.Variant{
+			VariantID:  variant.VariantID,
+			SKU:        variant.SKU,
+			Attributes: variant.Attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+	return result
+}
+
 const baseProductLock = "product_"
 
 func productLock(id uuid.UUID) string {
 	return baseProductLock + id.String()
 }
 
+// productNameLock берется по нормализованному имени: "Phone" и "phone " конкурируют за одно имя
 func productNameLock(name string) string {
-	return fmt.Sprintf("%sname_%s", baseProductLock, name)
+	return fmt.Sprintf("%sname_%s", baseProductLock, model.NormalizeProductName(name))
+}
+
+// productSlugLock берется по основе slug: "Phone!" и "Phone?" разные имена, но конкурируют за slug "phone"
+func productSlugLock(name string) string {
+	return fmt.Sprintf("%sslug_%s", baseProductLock, model.ProductSlug(name))
+}
+
+// productLockNames возвращает отсортированные блокировки сохранения товара
+func productLockNames(product appmodel.Product) []string {
+	lockNames := []string{productSlugLock(product.Name)}
+	if product.ProductID != uuid.Nil {
+		lockNames = append(lockNames, productLock(product.ProductID))
+	} else {
+		lockNames = append(lockNames, productNameLock(product.Name))
+	}
+	slices.Sort(lockNames)
+	return lockNames
 }
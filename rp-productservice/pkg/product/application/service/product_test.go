@@ -22,6 +22,30 @@ func (m *MockRepositoryProvider) ProductRepository(ctx context.Context) domainmo
 	return m.Called(ctx).Get(0).(domainmodel.ProductRepository)
 }
 
+func (m *MockRepositoryProvider) CategoryRepository(ctx context.Context) domainmodel.CategoryRepository {
+	return m.Called(ctx).Get(0).(domainmodel.CategoryRepository)
+}
+
+func (m *MockRepositoryProvider) ScheduledPriceRepository(ctx context.Context) domainmodel.ScheduledPriceRepository {
+	return m.Called(ctx).Get(0).(domainmodel.ScheduledPriceRepository)
+}
+
+func (m *MockRepositoryProvider) ImageRepository(ctx context.Context) domainmodel.ImageRepository {
+	return m.Called(ctx).Get(0).(domainmodel.ImageRepository)
+}
+
+func (m *MockRepositoryProvider) ReviewRepository(ctx context.Context) domainmodel.ReviewRepository {
+	return m.Called(ctx).Get(0).(domainmodel.ReviewRepository)
+}
+
+func (m *MockRepositoryProvider) PurchaseRepository(ctx context.Context) domainmodel.PurchaseRepository {
+	return m.Called(ctx).Get(0).(domainmodel.PurchaseRepository)
+}
+
+func (m *MockRepositoryProvider) UserAccessRepository(ctx context.Context) domainmodel.UserAccessRepository {
+	return m.Called(ctx).Get(0).(domainmodel.UserAccessRepository)
+}
+
 type MockLockableUnitOfWork struct {
 	mock.Mock
 }
@@ -57,6 +81,11 @@ func (m *StubProductRepo) Delete(_ uuid.UUID) error {
 	return nil
 }
 
+func (m *StubProductRepo) StoreStockDeduction(orderID, productID uuid.UUID) (bool, error) {
+	args := m.Called(orderID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
 type DummyDispatcher struct{}
 
 func (d *DummyDispatcher) Dispatch(_ context.Context, _ outbox.Event) error {
@@ -68,7 +97,7 @@ func TestProductService_StoreProduct_Create(t *testing.T) {
 	luow := new(MockLockableUnitOfWork)
 	repo := new(StubProductRepo)
 
-	service := NewProductService(nil, luow, &DummyDispatcher{})
+	service := NewProductService(nil, luow, &DummyDispatcher{}, nil)
 
 	ctx := context.Background()
 	productID := uuid.New()
@@ -83,7 +112,10 @@ func TestProductService_StoreProduct_Create(t *testing.T) {
 	luow.On("Execute", ctx, mock.Anything).Return(provider)
 	provider.On("ProductRepository", ctx).Return(repo)
 
-	repo.On("Find", domainmodel.FindSpec{Name: &name}).Return(nil, domainmodel.ErrProductNotFound)
+	normalizedName := domainmodel.NormalizeProductName(name)
+	slug := domainmodel.ProductSlug(name)
+	repo.On("Find", domainmodel.FindSpec{NormalizedName: &normalizedName}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("Find", domainmodel.FindSpec{Slug: &slug}).Return(nil, domainmodel.ErrProductNotFound)
 	repo.On("NextID").Return(productID, nil)
 	repo.On("Store", mock.Anything).Return(nil)
 
@@ -97,7 +129,7 @@ func TestProductService_StoreProduct_Update(t *testing.T) {
 	luow := new(MockLockableUnitOfWork)
 	repo := new(StubProductRepo)
 
-	service := NewProductService(nil, luow, &DummyDispatcher{})
+	service := NewProductService(nil, luow, &DummyDispatcher{}, nil)
 
 	ctx := context.Background()
 	productID := uuid.New()
@@ -115,8 +147,11 @@ func TestProductService_StoreProduct_Update(t *testing.T) {
 
 	existing := &domainmodel.Product{ProductID: productID, Name: "Old Name", Price: 100}
 
+	normalizedName := domainmodel.NormalizeProductName(name)
+	slug := domainmodel.ProductSlug(name)
 	repo.On("Find", domainmodel.FindSpec{ProductID: &productID}).Return(existing, nil)
-	repo.On("Find", domainmodel.FindSpec{Name: &name}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("Find", domainmodel.FindSpec{NormalizedName: &normalizedName}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("Find", domainmodel.FindSpec{Slug: &slug}).Return(nil, domainmodel.ErrProductNotFound)
 	repo.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
 		return p.Name == name && p.Price == price
 	})).Return(nil)
@@ -125,3 +160,53 @@ func TestProductService_StoreProduct_Update(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, productID, id)
 }
+
+func TestProductService_StoreProduct_Variants(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	luow := new(MockLockableUnitOfWork)
+	repo := new(StubProductRepo)
+
+	service := NewProductService(nil, luow, &DummyDispatcher{}, nil)
+
+	ctx := context.Background()
+	productID := uuid.New()
+	variantID := uuid.New()
+	name := "T-Shirt"
+	sku := "TSHIRT-M"
+
+	luow.On("Execute", ctx, mock.Anything).Return(provider)
+	provider.On("ProductRepository", ctx).Return(repo)
+
+	normalizedName := domainmodel.NormalizeProductName(name)
+	slug := domainmodel.ProductSlug(name)
+	repo.On("Find", domainmodel.FindSpec{NormalizedName: &normalizedName}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("Find", domainmodel.FindSpec{Slug: &slug}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("Find", domainmodel.FindSpec{SKU: &sku}).Return(nil, domainmodel.ErrProductNotFound)
+	repo.On("NextID").Return(productID, nil).Once()
+	repo.On("NextID").Return(variantID, nil).Once()
+	repo.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+		return len(p.Variants) == 1 && p.Variants[0].VariantID == variantID &&
+			p.Variants[0].Attributes["size"] == "M" && p.Variants[0].Stock == 3
+	})).Return(nil)
+
+	id, err := service.StoreProduct(ctx, appmodel.Product{
+		Name:  name,
+		Price: 900,
+		Variants: []appmodel.Variant{
+			{SKU: sku, Attributes: map[string]string{"size": "M"}, Price: 1000, Stock: 3},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, productID, id)
+	repo.AssertExpectations(t)
+}
+
+func TestProductLockNames(t *testing.T) {
+	// разные имена с одной основой slug создаются по очереди, иначе оба товара получили бы один slug
+	assert.Equal(t,
+		[]string{productNameLock("Phone!"), productSlugLock("Phone!")},
+		productLockNames(appmodel.Product{Name: "Phone!"}),
+	)
+	assert.Equal(t, productSlugLock("Phone!"), productSlugLock("phone?"))
+	assert.NotEqual(t, productNameLock("Phone!"), productNameLock("phone?"))
+}
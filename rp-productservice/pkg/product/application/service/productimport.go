@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/domain/service"
+)
+
+type ProductImportService interface {
+	// ImportProducts сохраняет товары пачками по chunkSize, каждая пачка в своей транзакции.
+	// Товар без ProductID создается, с ProductID - обновляется. Если Variants == nil, варианты не меняются.
+	// Нарушения доменных правил возвращаются в результатах по товарам, error - сбой, прервавший импорт
+	ImportProducts(ctx context.Context, products []appmodel.Product, chunkSize int) ([]appmodel.ImportResult, error)
+}
+
+func NewProductImportService(
+	luow LockableUnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+) ProductImportService {
+	return &productImportService{
+		luow:            luow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type productImportService struct {
+	luow            LockableUnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+// rowErrors - ошибки, из-за которых пропускается один товар, а не вся пачка
+var rowErrors = []error{
+	model.ErrProductNotFound,
+	model.ErrProductNameAlreadyUsed,
+	model.ErrProductSlugAlreadyUsed,
+	model.ErrVariantNotFound,
+	model.ErrVariantSKUAlreadyUsed,
+	model.ErrNegativePrice,
+}
+
+func (s *productImportService) ImportProducts(
+	ctx context.Context,
+	products []appmodel.Product,
+	chunkSize int,
+) ([]appmodel.ImportResult, error) {
+	results := make([]appmodel.ImportResult, 0, len(products))
+	for chunk := range slices.Chunk(products, max(chunkSize, 1)) {
+		var chunkResults []appmodel.ImportResult
+		err := s.luow.Execute(ctx, importLockNames(chunk), func(provider RepositoryProvider) error {
+			chunkResults = make([]appmodel.ImportResult, 0, len(chunk))
+			repository := provider.ProductRepository(ctx)
+			domainService := service.NewProductService(repository, &domainEventDispatcher{
+				ctx:             ctx,
+				eventDispatcher: s.eventDispatcher,
+			})
+			for _, product := range chunk {
+				productID, err := importProduct(repository, domainService, product)
+				if err != nil && !slices.ContainsFunc(rowErrors, func(e error) bool { return errors.Is(err, e) }) {
+					return err
+				}
+				chunkResults = append(chunkResults, appmodel.ImportResult{ProductID: productID, Err: err})
+			}
+			return nil
+		})
+		if err != nil {
+			// пачка откатилась целиком, поэтому ее результаты не возвращаются
+			return results, err
+		}
+		results = append(results, chunkResults...)
+	}
+	return results, nil
+}
+
+func importProduct(repository model.ProductRepository, domainService service.ProductService, product appmodel.Product) (uuid.UUID, error) {
+	if product.ProductID == uuid.Nil {
+		return domainService.CreateProduct(product.Name, product.Price, product.Currency, product.Description, domainVariants(product.Variants))
+	}
+
+	variants := domainVariants(product.Variants)
+	if product.Variants == nil {
+		existing, err := repository.Find(model.FindSpec{ProductID: &product.ProductID})
+		if err != nil {
+			return product.ProductID, err
+		}
+		variants = existing.Variants
+	}
+	return product.ProductID, domainService.UpdateProduct(product.ProductID, product.Name, product.Price, product.Currency, product.Description, variants)
+}
+
+// importLockNames берет те же блокировки, что и StoreProduct, в одном порядке,
+// чтобы параллельные импорты не заблокировали друг друга
+func importLockNames(products []appmodel.Product) []string {
+	lockNames := make([]string, 0, 2*len(products))
+	for _, product := range products {
+		lockNames = append(lockNames, productLockNames(product)...)
+	}
+	slices.Sort(lockNames)
+	return slices.Compact(lockNames)
+}
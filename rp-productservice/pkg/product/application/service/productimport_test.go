@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmodel "productservice/pkg/product/application/model"
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+func TestProductImportService_ImportProducts(t *testing.T) {
+	ctx := context.Background()
+	existingID := uuid.New()
+	newID := uuid.New()
+	variant := domainmodel.Variant{VariantID: uuid.New(), SKU: "MUG-RED", Price: 300}
+	products := []appmodel.Product{
+		{Name: "Mug", Price: 300},
+		{Name: "Plate", Price: -1},
+		{ProductID: existingID, Name: "Cup", Price: 250},
+	}
+
+	t.Run("skips_invalid_rows", func(t *testing.T) {
+		provider := new(MockRepositoryProvider)
+		luow := new(MockLockableUnitOfWork)
+		repo := new(StubProductRepo)
+		service := NewProductImportService(luow, &DummyDispatcher{})
+
+		// кроме имени блокируется основа slug: разные имена могут претендовать на один slug
+		luow.On("Execute", ctx, []string{
+			productNameLock("Mug"), productNameLock("Plate"), productSlugLock("Mug"), productSlugLock("Plate"),
+		}).Return(provider).Once()
+		luow.On("Execute", ctx, []string{productLock(existingID), productSlugLock("Cup")}).Return(provider).Once()
+		provider.On("ProductRepository", ctx).Return(repo)
+
+		mug := "mug"
+		repo.On("Find", domainmodel.FindSpec{NormalizedName: &mug}).Return(nil, domainmodel.ErrProductNotFound)
+		repo.On("Find", domainmodel.FindSpec{Slug: &mug}).Return(nil, domainmodel.ErrProductNotFound)
+		repo.On("NextID").Return(newID, nil)
+		repo.On("Find", domainmodel.FindSpec{ProductID: &existingID}).Return(&domainmodel.Product{
+			ProductID: existingID,
+			Name:      "Cup",
+			Price:     200,
+			Variants:  []domainmodel.Variant{variant},
+		}, nil)
+		repo.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+			return p.ProductID == newID
+		})).Return(nil).Once()
+		// варианты не указаны в строке импорта и должны сохраниться
+		repo.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+			return p.ProductID == existingID && p.Price == 250 && len(p.Variants) == 1 && p.Variants[0].SKU == "MUG-RED"
+		})).Return(nil).Once()
+
+		results, err := service.ImportProducts(ctx, products, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []appmodel.ImportResult{
+			{ProductID: newID},
+			{Err: domainmodel.ErrNegativePrice},
+			{ProductID: existingID},
+		}, results)
+		luow.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stops_on_failure", func(t *testing.T) {
+		provider := new(MockRepositoryProvider)
+		luow := new(MockLockableUnitOfWork)
+		repo := new(StubProductRepo)
+		service := NewProductImportService(luow, &DummyDispatcher{})
+		connectionLost := errors.New("connection lost")
+
+		luow.On("Execute", ctx, mock.Anything).Return(provider)
+		provider.On("ProductRepository", ctx).Return(repo)
+
+		mug := "mug"
+		repo.On("Find", domainmodel.FindSpec{NormalizedName: &mug}).Return(nil, connectionLost)
+
+		results, err := service.ImportProducts(ctx, products, 2)
+		assert.ErrorIs(t, err, connectionLost)
+		assert.Empty(t, results)
+		luow.AssertNumberOfCalls(t, "Execute", 1)
+	})
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/domain/service"
+)
+
+type ReviewService interface {
+	CreateReview(ctx context.Context, review appmodel.Review) (uuid.UUID, error)
+	ModerateReview(ctx context.Context, reviewID uuid.UUID, status appmodel.ReviewStatus) error
+	// RecordPurchase запоминает товары оплаченного заказа, после чего покупатель может оставить на них отзыв
+	RecordPurchase(ctx context.Context, purchase appmodel.Purchase) error
+}
+
+func NewReviewService(uow UnitOfWork, luow LockableUnitOfWork) ReviewService {
+	return &reviewService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type reviewService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, review appmodel.Review) (uuid.UUID, error) {
+	var reviewID uuid.UUID
+	// блокировка товара не дает одному автору оставить два отзыва и сериализует пересчет рейтинга
+	err := s.luow.Execute(ctx, []string{productLock(review.ProductID)}, func(provider RepositoryProvider) error {
+		var err error
+		reviewID, err = reviewDomainService(ctx, provider).CreateReview(
+			review.ProductID,
+			review.AuthorID,
+			review.AuthorName,
+			review.Rating,
+			review.Text,
+		)
+		return err
+	})
+	return reviewID, err
+}
+
+func (s *reviewService) ModerateReview(ctx context.Context, reviewID uuid.UUID, status appmodel.ReviewStatus) error {
+	var productID uuid.UUID
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		review, err := provider.ReviewRepository(ctx).Find(reviewID)
+		if err != nil {
+			return err
+		}
+		productID = review.ProductID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+		return reviewDomainService(ctx, provider).ModerateReview(reviewID, model.ReviewStatus(status))
+	})
+}
+
+func (s *reviewService) RecordPurchase(ctx context.Context, purchase appmodel.Purchase) error {
+	// в заказе товар может встречаться несколько раз, например разными вариантами
+	var productIDs []uuid.UUID
+	for _, productID := range purchase.ProductIDs {
+		if !slices.Contains(productIDs, productID) {
+			productIDs = append(productIDs, productID)
+		}
+	}
+
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		domainService := reviewDomainService(ctx, provider)
+		for _, productID := range productIDs {
+			err := domainService.RecordPurchase(model.Purchase{
+				OrderID:     purchase.OrderID,
+				UserID:      purchase.UserID,
+				ProductID:   productID,
+				PurchasedAt: purchase.PurchasedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func reviewDomainService(ctx context.Context, provider RepositoryProvider) service.ReviewService {
+	return service.NewReviewService(
+		provider.ProductRepository(ctx),
+		provider.ReviewRepository(ctx),
+		provider.PurchaseRepository(ctx),
+	)
+}
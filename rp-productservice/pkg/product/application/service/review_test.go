@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmodel "productservice/pkg/product/application/model"
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+type StubPurchaseRepo struct {
+	mock.Mock
+}
+
+func (m *StubPurchaseRepo) Store(p domainmodel.Purchase) error {
+	return m.Called(p).Error(0)
+}
+
+func (m *StubPurchaseRepo) Exists(userID, productID uuid.UUID) (bool, error) {
+	args := m.Called(userID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *StubPurchaseRepo) DeleteByUser(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
+type StubReviewRepo struct {
+	mock.Mock
+}
+
+func (m *StubReviewRepo) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *StubReviewRepo) Store(r domainmodel.Review) error {
+	return m.Called(r).Error(0)
+}
+
+func (m *StubReviewRepo) Find(reviewID uuid.UUID) (*domainmodel.Review, error) {
+	args := m.Called(reviewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.Review), args.Error(1)
+}
+
+func (m *StubReviewRepo) FindByProductAndAuthor(productID, authorID uuid.UUID) (*domainmodel.Review, error) {
+	args := m.Called(productID, authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainmodel.Review), args.Error(1)
+}
+
+func (m *StubReviewRepo) FindByAuthor(authorID uuid.UUID) ([]domainmodel.Review, error) {
+	args := m.Called(authorID)
+	return args.Get(0).([]domainmodel.Review), args.Error(1)
+}
+
+func (m *StubReviewRepo) Delete(reviewID uuid.UUID) error {
+	return m.Called(reviewID).Error(0)
+}
+
+func (m *StubReviewRepo) ForgetAuthor(authorID uuid.UUID) error {
+	return m.Called(authorID).Error(0)
+}
+
+func TestReviewService_RecordPurchase_DeduplicatesProducts(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	uow := new(MockUnitOfWork)
+	purchases := new(StubPurchaseRepo)
+
+	service := NewReviewService(uow, new(MockLockableUnitOfWork))
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	userID := uuid.New()
+	mug, shirt := uuid.New(), uuid.New()
+
+	uow.On("Execute", ctx).Return(provider)
+	provider.On("ProductRepository", ctx).Return(new(StubProductRepo))
+	provider.On("ReviewRepository", ctx).Return(new(StubReviewRepo))
+	provider.On("PurchaseRepository", ctx).Return(purchases)
+	purchases.On("Store", mock.MatchedBy(func(p domainmodel.Purchase) bool {
+		return p.OrderID == orderID && p.UserID == userID && p.ProductID == mug
+	})).Return(nil).Once()
+	purchases.On("Store", mock.MatchedBy(func(p domainmodel.Purchase) bool {
+		return p.ProductID == shirt
+	})).Return(nil).Once()
+
+	err := service.RecordPurchase(ctx, appmodel.Purchase{
+		OrderID:     orderID,
+		UserID:      userID,
+		ProductIDs:  []uuid.UUID{mug, shirt, mug},
+		PurchasedAt: time.Now(),
+	})
+	assert.NoError(t, err)
+	purchases.AssertExpectations(t)
+}
+
+func TestUserDataService_EraseUserData_LocksReviewedProducts(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	uow := new(MockUnitOfWork)
+	luow := new(MockLockableUnitOfWork)
+	reviews := new(StubReviewRepo)
+	purchases := new(StubPurchaseRepo)
+
+	service := NewUserDataService(uow, luow)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	productID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	userReviews := []domainmodel.Review{
+		{ReviewID: first, ProductID: productID},
+		{ReviewID: second, ProductID: productID},
+	}
+
+	uow.On("Execute", ctx).Return(provider)
+	luow.On("Execute", ctx, []string{productLock(productID)}).Return(provider).Once()
+	provider.On("ProductRepository", ctx).Return(new(StubProductRepo))
+	provider.On("ReviewRepository", ctx).Return(reviews)
+	provider.On("PurchaseRepository", ctx).Return(purchases)
+	reviews.On("FindByAuthor", userID).Return(userReviews, nil)
+	reviews.On("Delete", first).Return(nil).Once()
+	reviews.On("Delete", second).Return(nil).Once()
+	reviews.On("ForgetAuthor", userID).Return(nil).Once()
+	purchases.On("DeleteByUser", userID).Return(nil).Once()
+
+	assert.NoError(t, service.EraseUserData(ctx, userID))
+	luow.AssertExpectations(t)
+	reviews.AssertExpectations(t)
+	purchases.AssertExpectations(t)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/domain/service"
+)
+
+type StockService interface {
+	// DeductStock списывает с остатка варианты оплаченного заказа, каждый товар под своей блокировкой
+	DeductStock(ctx context.Context, deduction appmodel.StockDeduction) error
+}
+
+func NewStockService(luow LockableUnitOfWork, eventDispatcher outbox.EventDispatcher[outbox.Event]) StockService {
+	return &stockService{
+		luow:            luow,
+		eventDispatcher: eventDispatcher,
+	}
+}
+
+type stockService struct {
+	luow            LockableUnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+}
+
+func (s *stockService) DeductStock(ctx context.Context, deduction appmodel.StockDeduction) error {
+	var productIDs []uuid.UUID
+	quantities := make(map[uuid.UUID]map[uuid.UUID]int64)
+	for _, item := range deduction.Items {
+		if _, ok := quantities[item.ProductID]; !ok {
+			productIDs = append(productIDs, item.ProductID)
+			quantities[item.ProductID] = make(map[uuid.UUID]int64)
+		}
+		quantities[item.ProductID][item.VariantID] += item.Quantity
+	}
+
+	for _, productID := range productIDs {
+		err := s.luow.Execute(ctx, []string{productLock(productID)}, func(provider RepositoryProvider) error {
+			domainService := service.NewProductService(provider.ProductRepository(ctx), &domainEventDispatcher{
+				ctx:             ctx,
+				eventDispatcher: s.eventDispatcher,
+			})
+			return domainService.DeductStock(deduction.OrderID, productID, quantities[productID])
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	appmodel "productservice/pkg/product/application/model"
+	domainmodel "productservice/pkg/product/domain/model"
+)
+
+func TestStockService_DeductStock(t *testing.T) {
+	ctx := context.Background()
+	provider := new(MockRepositoryProvider)
+	luow := new(MockLockableUnitOfWork)
+	repo := new(StubProductRepo)
+	service := NewStockService(luow, &DummyDispatcher{})
+
+	orderID := uuid.New()
+	shirt := uuid.New()
+	mug := uuid.New()
+	sizeM := uuid.New()
+	mugVariant := uuid.New()
+
+	// каждый товар списывается под своей блокировкой, позиции одного варианта складываются
+	luow.On("Execute", ctx, []string{productLock(shirt)}).Return(provider).Once()
+	luow.On("Execute", ctx, []string{productLock(mug)}).Return(provider).Once()
+	provider.On("ProductRepository", ctx).Return(repo)
+	repo.On("StoreStockDeduction", orderID, shirt).Return(true, nil).Once()
+	repo.On("StoreStockDeduction", orderID, mug).Return(false, nil).Once()
+	repo.On("Find", domainmodel.FindSpec{ProductID: &shirt}).Return(&domainmodel.Product{
+		ProductID: shirt,
+		Variants:  []domainmodel.Variant{{VariantID: sizeM, Stock: 10}},
+	}, nil).Once()
+	repo.On("Store", mock.MatchedBy(func(p domainmodel.Product) bool {
+		return p.ProductID == shirt && p.Variants[0].Stock == 7
+	})).Return(nil).Once()
+
+	err := service.DeductStock(ctx, appmodel.StockDeduction{
+		OrderID: orderID,
+		Items: []appmodel.StockDeductionItem{
+			{ProductID: shirt, VariantID: sizeM, Quantity: 1},
+			{ProductID: mug, VariantID: mugVariant, Quantity: 1},
+			{ProductID: shirt, VariantID: sizeM, Quantity: 2},
+		},
+	})
+	assert.NoError(t, err)
+	luow.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
@@ -8,6 +8,12 @@ import (
 
 type RepositoryProvider interface {
 	ProductRepository(ctx context.Context) model.ProductRepository
+	CategoryRepository(ctx context.Context) model.CategoryRepository
+	ScheduledPriceRepository(ctx context.Context) model.ScheduledPriceRepository
+	ImageRepository(ctx context.Context) model.ImageRepository
+	ReviewRepository(ctx context.Context) model.ReviewRepository
+	PurchaseRepository(ctx context.Context) model.PurchaseRepository
+	UserAccessRepository(ctx context.Context) model.UserAccessRepository
 }
 
 type LockableUnitOfWork interface {
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/domain/service"
+)
+
+// UserAccessService ведет локальную копию прав пользователей по событиям userservice
+// и отвечает на вопрос "может ли пользователь X сделать Y" без синхронного вызова
+type UserAccessService interface {
+	Authorize(ctx context.Context, userID uuid.UUID, permission string) error
+	SyncUserPermissions(ctx context.Context, userID uuid.UUID, permissions []string, updatedAt time.Time) error
+	SyncUserStatus(ctx context.Context, userID uuid.UUID, active bool, updatedAt time.Time) error
+	ForgetUser(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserAccessService(uow UnitOfWork, luow LockableUnitOfWork) UserAccessService {
+	return &userAccessService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type userAccessService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *userAccessService) Authorize(ctx context.Context, userID uuid.UUID, permission string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return userAccessDomainService(ctx, provider).Authorize(userID, model.Permission(permission))
+	})
+}
+
+func (s *userAccessService) SyncUserPermissions(ctx context.Context, userID uuid.UUID, permissions []string, updatedAt time.Time) error {
+	domainPermissions := make([]model.Permission, len(permissions))
+	for i, permission := range permissions {
+		domainPermissions[i] = model.Permission(permission)
+	}
+	return s.luow.Execute(ctx, []string{userAccessLock(userID)}, func(provider RepositoryProvider) error {
+		return userAccessDomainService(ctx, provider).SetPermissions(userID, domainPermissions, updatedAt)
+	})
+}
+
+func (s *userAccessService) SyncUserStatus(ctx context.Context, userID uuid.UUID, active bool, updatedAt time.Time) error {
+	return s.luow.Execute(ctx, []string{userAccessLock(userID)}, func(provider RepositoryProvider) error {
+		return userAccessDomainService(ctx, provider).SetStatus(userID, active, updatedAt)
+	})
+}
+
+func (s *userAccessService) ForgetUser(ctx context.Context, userID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{userAccessLock(userID)}, func(provider RepositoryProvider) error {
+		return userAccessDomainService(ctx, provider).Forget(userID)
+	})
+}
+
+func userAccessDomainService(ctx context.Context, provider RepositoryProvider) service.UserAccessService {
+	return service.NewUserAccessService(provider.UserAccessRepository(ctx))
+}
+
+const baseUserAccessLock = "user_access_"
+
+func userAccessLock(id uuid.UUID) string {
+	return baseUserAccessLock + id.String()
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type UserDataService interface {
+	// EraseUserData удаляет отзывы и покупки пользователя, рейтинги затронутых товаров пересчитываются
+	EraseUserData(ctx context.Context, userID uuid.UUID) error
+}
+
+func NewUserDataService(uow UnitOfWork, luow LockableUnitOfWork) UserDataService {
+	return &userDataService{
+		uow:  uow,
+		luow: luow,
+	}
+}
+
+type userDataService struct {
+	uow  UnitOfWork
+	luow LockableUnitOfWork
+}
+
+func (s *userDataService) EraseUserData(ctx context.Context, userID uuid.UUID) error {
+	var lockNames []string
+	err := s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		reviews, err := provider.ReviewRepository(ctx).FindByAuthor(userID)
+		if err != nil {
+			return err
+		}
+		for _, review := range reviews {
+			lockNames = append(lockNames, productLock(review.ProductID))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	erase := func(provider RepositoryProvider) error {
+		return reviewDomainService(ctx, provider).EraseAuthorData(userID)
+	}
+	if len(lockNames) == 0 {
+		// отзывов нет, рейтинги не меняются и блокировать нечего
+		return s.uow.Execute(ctx, erase)
+	}
+	slices.Sort(lockNames)
+	return s.luow.Execute(ctx, slices.Compact(lockNames), erase)
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryCycle    = errors.New("category cannot be moved under itself or its subcategory")
+	ErrCategoryNotEmpty = errors.New("category has subcategories or products")
+)
+
+// Category - узел дерева каталога, у корневых категорий ParentID пустой
+type Category struct {
+	CategoryID uuid.UUID
+	ParentID   *uuid.UUID
+	Name       string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CategoryRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(category Category) error
+	Find(categoryID uuid.UUID) (*Category, error)
+	// InUse сообщает, есть ли у категории подкатегории или товары
+	InUse(categoryID uuid.UUID) (bool, error)
+	Delete(categoryID uuid.UUID) error
+}
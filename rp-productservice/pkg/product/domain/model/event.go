@@ -11,6 +11,8 @@ type ProductCreated struct {
 	Name        string
 	Description *string
 	Price       int64
+	Currency    string
+	Variants    []Variant
 	CreatedAt   time.Time
 }
 
@@ -24,6 +26,8 @@ type ProductUpdated struct {
 		Name        *string
 		Description *string
 		Price       *int64
+		Currency    *string
+		Variants    *[]Variant
 	}
 	UpdatedAt time.Time
 }
@@ -32,11 +36,34 @@ func (p ProductUpdated) Type() string {
 	return "product_updated"
 }
 
-type ProductDeleted struct {
-	ProductID uuid.UUID
-	DeletedAt time.Time
+type ProductArchived struct {
+	ProductID  uuid.UUID
+	ArchivedAt time.Time
 }
 
-func (p ProductDeleted) Type() string {
-	return "product_deleted"
+func (p ProductArchived) Type() string {
+	return "product_archived"
+}
+
+type ProductUnarchived struct {
+	ProductID    uuid.UUID
+	UnarchivedAt time.Time
+}
+
+func (p ProductUnarchived) Type() string {
+	return "product_unarchived"
+}
+
+// ProductCategoriesChanged несет полный список категорий товара,
+// чтобы реплики могли фильтровать по категории без запроса в productservice
+type ProductCategoriesChanged struct {
+	ProductID          uuid.UUID
+	CategoryIDs        []uuid.UUID
+	AddedCategoryIDs   []uuid.UUID
+	RemovedCategoryIDs []uuid.UUID
+	ChangedAt          time.Time
+}
+
+func (p ProductCategoriesChanged) Type() string {
+	return "product_categories_changed"
 }
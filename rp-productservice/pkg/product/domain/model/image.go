@@ -0,0 +1,41 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrImageNotFound        = errors.New("image not found")
+	ErrImageSourceRequired  = errors.New("image must have either url or object key")
+	ErrImageOrderMismatch   = errors.New("image order must list every product image exactly once")
+	ErrUnsupportedImageType = errors.New("unsupported image content type")
+	ErrImageTooLarge        = errors.New("image is too large")
+)
+
+// Image - метаданные изображения товара. Само изображение лежит по URL или в хранилище по ObjectKey
+type Image struct {
+	ImageID     uuid.UUID
+	ProductID   uuid.UUID
+	URL         *string // Внешний адрес изображения
+	ObjectKey   *string // Ключ загруженного файла в хранилище
+	ContentType string
+	Size        int64 // Размер в байтах, 0 - неизвестен
+	Width       int
+	Height      int
+	Position    int // Порядок показа, начиная с 0
+	AltText     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type ImageRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(image Image) error
+	Find(imageID uuid.UUID) (*Image, error)
+	// FindByProduct возвращает изображения товара по возрастанию Position
+	FindByProduct(productID uuid.UUID) ([]Image, error)
+	Delete(imageID uuid.UUID) error
+}
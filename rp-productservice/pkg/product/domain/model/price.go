@@ -0,0 +1,87 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScheduledPriceNotFound   = errors.New("scheduled price not found")
+	ErrScheduledPriceNotPending = errors.New("scheduled price already applied or cancelled")
+	ErrInvalidPricePeriod       = errors.New("price period must end after it starts")
+	ErrScheduledPriceOverlaps   = errors.New("scheduled price overlaps another scheduled price or sale")
+)
+
+type ScheduledPriceStatus int
+
+const (
+	// ScheduledPricePending - цена еще не действует
+	ScheduledPricePending ScheduledPriceStatus = iota
+	// ScheduledPriceActive - цена применена и будет отменена в EffectiveUntil
+	ScheduledPriceActive
+	ScheduledPriceCompleted
+	ScheduledPriceCancelled
+)
+
+// ScheduledPrice - будущая цена товара или его варианта.
+// С EffectiveUntil это распродажа: по окончании возвращается PreviousPrice
+type ScheduledPrice struct {
+	ScheduledPriceID uuid.UUID
+	ProductID        uuid.UUID
+	VariantID        *uuid.UUID // Пустой - меняется цена самого товара
+	Price            int64
+	Currency         string // Валюта товара на момент планирования
+	PreviousPrice    *int64 // Цена до применения, заполняется при применении
+	EffectiveFrom    time.Time
+	EffectiveUntil   *time.Time
+	Status           ScheduledPriceStatus
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// DueAt возвращает момент, когда цену нужно применить или отменить. false - ждать больше нечего
+func (p ScheduledPrice) DueAt() (time.Time, bool) {
+	switch {
+	case p.Status == ScheduledPricePending:
+		return p.EffectiveFrom, true
+	case p.Status == ScheduledPriceActive && p.EffectiveUntil != nil:
+		return *p.EffectiveUntil, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Overlaps проверяет, что цены меняют одну и ту же цену в пересекающиеся периоды.
+// Распродажа занимает [EffectiveFrom, EffectiveUntil), цена без окончания - только момент EffectiveFrom
+func (p ScheduledPrice) Overlaps(other ScheduledPrice) bool {
+	if p.ProductID != other.ProductID || (p.VariantID == nil) != (other.VariantID == nil) ||
+		(p.VariantID != nil && *p.VariantID != *other.VariantID) {
+		return false
+	}
+	switch {
+	case p.EffectiveUntil == nil && other.EffectiveUntil == nil:
+		return p.EffectiveFrom.Equal(other.EffectiveFrom)
+	case p.EffectiveUntil == nil:
+		return other.covers(p.EffectiveFrom)
+	case other.EffectiveUntil == nil:
+		return p.covers(other.EffectiveFrom)
+	default:
+		return p.EffectiveFrom.Before(*other.EffectiveUntil) && other.EffectiveFrom.Before(*p.EffectiveUntil)
+	}
+}
+
+func (p ScheduledPrice) covers(at time.Time) bool {
+	return !at.Before(p.EffectiveFrom) && at.Before(*p.EffectiveUntil)
+}
+
+type ScheduledPriceRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(price ScheduledPrice) error
+	Find(scheduledPriceID uuid.UUID) (*ScheduledPrice, error)
+	// FindDue возвращает цены, которые к моменту at пора применить или отменить
+	FindDue(at time.Time, limit int) ([]ScheduledPrice, error)
+	// FindUnfinished возвращает еще не примененные цены и идущие распродажи товара и его вариантов
+	FindUnfinished(productID uuid.UUID) ([]ScheduledPrice, error)
+}
@@ -2,28 +2,64 @@ package model
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
 	ErrProductNotFound        = errors.New("product.go not found")
 	ErrProductNameAlreadyUsed = errors.New("product.go name already used")
+	ErrProductSlugAlreadyUsed = errors.New("product slug was taken by a concurrent change, retry")
+	ErrProductNameRequired    = errors.New("product name must not be blank")
+	ErrVariantNotFound        = errors.New("product variant not found")
+	ErrVariantSKUAlreadyUsed  = errors.New("product variant sku already used")
+	ErrProductNotArchived     = errors.New("product must be archived before purge")
+	ErrNegativePrice          = errors.New("product price must not be negative")
+	ErrInvalidCurrency        = errors.New("currency must be an ISO 4217 code")
 )
 
+// DefaultCurrency - валюта товаров, созданных до появления валют, и новых товаров без указанной валюты
+const DefaultCurrency = "RUB"
+
 type Product struct {
-	ProductID   uuid.UUID
-	Name        string
+	ProductID uuid.UUID
+	Name      string
+	// Slug - адрес товара для URL, строится из имени и уникален среди всех товаров
+	Slug        string
 	Description *string
-	Price       int64 // Цена в копейках
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Price       int64 // Цена в сотых долях валюты: копейках, центах
+	// Currency - код ISO 4217, в нем указаны цены товара и всех его вариантов
+	Currency string
+	// CategoryIDs - категории, в которые напрямую входит товар, отсортированы
+	CategoryIDs []uuid.UUID
+	// Variants - SKU товара в порядке показа. Без вариантов товар продается по Price
+	Variants []Variant
+	// ArchivedAt - товар снят с продажи: скрыт из списков и не заказывается, но доступен по ID
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type Variant struct {
+	VariantID  uuid.UUID
+	SKU        string            // Уникален среди всех товаров
+	Attributes map[string]string // Например, size: XL, color: red
+	Price      int64             // Цена в сотых долях валюты товара
+	Stock      int64
 }
 
 type FindSpec struct {
 	ProductID *uuid.UUID
-	Name      *string
+	// NormalizedName сравнивается с результатом NormalizeProductName от имени товара
+	NormalizedName *string
+	Slug           *string
+	// SKU ищет товар, у которого есть вариант с таким SKU
+	SKU *string
 }
 
 type ProductRepository interface {
@@ -31,4 +67,80 @@ type ProductRepository interface {
 	Store(product Product) error
 	Find(spec FindSpec) (*Product, error)
 	Delete(productID uuid.UUID) error
+	// StoreStockDeduction запоминает списание остатка товара по заказу, false - списание уже было
+	StoreStockDeduction(orderID, productID uuid.UUID) (bool, error)
+}
+
+// ValidCurrency проверяет только формат кода: три заглавные латинские буквы
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeProductName приводит имя к виду, по которому проверяется уникальность:
+// без пробелов по краям, с одиночными пробелами внутри, без учета регистра, в форме NFC
+func NormalizeProductName(name string) string {
+	name = strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+	return norm.NFC.String(cases.Fold().String(name))
+}
+
+// ProductSlug строит slug из имени: кириллица транслитерируется, диакритика отбрасывается,
+// остальные символы кроме букв и цифр заменяются дефисом. Уникальность slug обеспечивает вызывающий
+func ProductSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	write := func(s string) {
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		dash = false
+		b.WriteString(s)
+	}
+	for _, r := range NormalizeProductName(name) {
+		if t, ok := cyrillicTranslit[r]; ok {
+			if t != "" {
+				write(t)
+			}
+			continue
+		}
+		for _, d := range norm.NFKD.String(string(r)) {
+			switch {
+			case unicode.Is(unicode.Mn, d):
+			case unicode.IsLetter(d) || unicode.IsDigit(d):
+				write(string(unicode.ToLower(d)))
+			default:
+				dash = true
+			}
+		}
+	}
+
+	slug := []rune(b.String())
+	if len(slug) > maxSlugBaseLength {
+		slug = slug[:maxSlugBaseLength]
+	}
+	result := strings.Trim(string(slug), "-")
+	if result == "" {
+		return defaultSlug
+	}
+	return result
+}
+
+const (
+	// maxSlugBaseLength оставляет место под числовой суффикс в колонке на 255 символов
+	maxSlugBaseLength = 240
+	defaultSlug       = "product"
+)
+
+var cyrillicTranslit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
 }
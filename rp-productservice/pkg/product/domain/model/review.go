@@ -0,0 +1,69 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReviewAlreadyExists = errors.New("author has already reviewed this product")
+	ErrInvalidRating       = errors.New("rating must be between 1 and 5")
+	ErrProductNotPurchased = errors.New("author has no paid order with this product")
+	ErrInvalidReviewStatus = errors.New("review can only be approved or rejected")
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+type ReviewStatus int
+
+const (
+	// ReviewPending - отзыв ждет модерации и не учитывается в рейтинге
+	ReviewPending ReviewStatus = iota
+	ReviewApproved
+	ReviewRejected
+)
+
+type Review struct {
+	ReviewID   uuid.UUID
+	ProductID  uuid.UUID
+	AuthorID   uuid.UUID
+	AuthorName string
+	Rating     int
+	Text       string
+	Status     ReviewStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Purchase - товар из оплаченного заказа, дает покупателю право оставить отзыв
+type Purchase struct {
+	OrderID     uuid.UUID
+	UserID      uuid.UUID
+	ProductID   uuid.UUID
+	PurchasedAt time.Time
+}
+
+// ReviewRepository при сохранении и удалении пересчитывает рейтинг товара по одобренным отзывам
+type ReviewRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(review Review) error
+	Find(reviewID uuid.UUID) (*Review, error)
+	FindByProductAndAuthor(productID, authorID uuid.UUID) (*Review, error)
+	FindByAuthor(authorID uuid.UUID) ([]Review, error)
+	Delete(reviewID uuid.UUID) error
+	// ForgetAuthor удаляет ключ, которым в журнале аудита захешированы данные автора
+	ForgetAuthor(authorID uuid.UUID) error
+}
+
+type PurchaseRepository interface {
+	// Store не меняет уже записанную покупку, поэтому повтор события безопасен
+	Store(purchase Purchase) error
+	Exists(userID, productID uuid.UUID) (bool, error)
+	DeleteByUser(userID uuid.UUID) error
+}
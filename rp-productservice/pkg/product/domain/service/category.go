@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/pkg/common/domain"
+	"productservice/pkg/product/domain/model"
+)
+
+type CategoryService interface {
+	CreateCategory(name string, parentID *uuid.UUID) (uuid.UUID, error)
+	UpdateCategory(categoryID uuid.UUID, name string, parentID *uuid.UUID) error
+	DeleteCategory(categoryID uuid.UUID) error
+	SetProductCategories(productID uuid.UUID, categoryIDs []uuid.UUID) error
+}
+
+func NewCategoryService(
+	categoryRepository model.CategoryRepository,
+	productRepository model.ProductRepository,
+	eventDispatcher domain.EventDispatcher,
+) CategoryService {
+	return &categoryService{
+		categoryRepository: categoryRepository,
+		productRepository:  productRepository,
+		eventDispatcher:    eventDispatcher,
+	}
+}
+
+type categoryService struct {
+	categoryRepository model.CategoryRepository
+	productRepository  model.ProductRepository
+	eventDispatcher    domain.EventDispatcher
+}
+
+func (s *categoryService) CreateCategory(name string, parentID *uuid.UUID) (uuid.UUID, error) {
+	if parentID != nil {
+		_, err := s.categoryRepository.Find(*parentID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	categoryID, err := s.categoryRepository.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	return categoryID, s.categoryRepository.Store(model.Category{
+		CategoryID: categoryID,
+		ParentID:   parentID,
+		Name:       name,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	})
+}
+
+func (s *categoryService) UpdateCategory(categoryID uuid.UUID, name string, parentID *uuid.UUID) error {
+	category, err := s.categoryRepository.Find(categoryID)
+	if err != nil {
+		return err
+	}
+
+	if category.Name == name && equalID(category.ParentID, parentID) {
+		return nil
+	}
+
+	if !equalID(category.ParentID, parentID) && parentID != nil {
+		err = s.checkNotDescendant(categoryID, *parentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	category.Name = name
+	category.ParentID = parentID
+	category.UpdatedAt = time.Now()
+	return s.categoryRepository.Store(*category)
+}
+
+// DeleteCategory удаляет только пустую категорию: иначе пришлось бы молча менять категории товаров
+func (s *categoryService) DeleteCategory(categoryID uuid.UUID) error {
+	_, err := s.categoryRepository.Find(categoryID)
+	if err != nil {
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	inUse, err := s.categoryRepository.InUse(categoryID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return model.ErrCategoryNotEmpty
+	}
+
+	return s.categoryRepository.Delete(categoryID)
+}
+
+func (s *categoryService) SetProductCategories(productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		return err
+	}
+
+	categoryIDs = sortedIDs(categoryIDs)
+	for _, categoryID := range categoryIDs {
+		_, err = s.categoryRepository.Find(categoryID)
+		if err != nil {
+			return err
+		}
+	}
+
+	var added, removed []uuid.UUID
+	for _, categoryID := range categoryIDs {
+		if !slices.Contains(product.CategoryIDs, categoryID) {
+			added = append(added, categoryID)
+		}
+	}
+	for _, categoryID := range product.CategoryIDs {
+		if !slices.Contains(categoryIDs, categoryID) {
+			removed = append(removed, categoryID)
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	currentTime := time.Now()
+	product.CategoryIDs = categoryIDs
+	product.UpdatedAt = currentTime
+
+	err = s.productRepository.Store(*product)
+	if err != nil {
+		return err
+	}
+
+	return s.eventDispatcher.Dispatch(&model.ProductCategoriesChanged{
+		ProductID:          productID,
+		CategoryIDs:        categoryIDs,
+		AddedCategoryIDs:   added,
+		RemovedCategoryIDs: removed,
+		ChangedAt:          currentTime,
+	})
+}
+
+// checkNotDescendant поднимается от нового родителя к корню и не дает замкнуть дерево в цикл
+func (s *categoryService) checkNotDescendant(categoryID, parentID uuid.UUID) error {
+	currentID := &parentID
+	for currentID != nil {
+		if *currentID == categoryID {
+			return model.ErrCategoryCycle
+		}
+		parent, err := s.categoryRepository.Find(*currentID)
+		if err != nil {
+			return err
+		}
+		currentID = parent.ParentID
+	}
+	return nil
+}
+
+func equalID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sortedIDs(ids []uuid.UUID) []uuid.UUID {
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	slices.SortFunc(result, func(a, b uuid.UUID) int {
+		return slices.Compare(a[:], b[:])
+	})
+	return result
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Store(category model.Category) error {
+	return m.Called(category).Error(0)
+}
+
+func (m *MockCategoryRepository) Find(categoryID uuid.UUID) (*model.Category, error) {
+	args := m.Called(categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) InUse(categoryID uuid.UUID) (bool, error) {
+	args := m.Called(categoryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Delete(categoryID uuid.UUID) error {
+	return m.Called(categoryID).Error(0)
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	service := NewCategoryService(categories, new(MockProductRepository), new(MockEventDispatcher))
+
+	parentID := uuid.New()
+	categoryID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		categories.On("Find", parentID).Return(&model.Category{CategoryID: parentID}, nil).Once()
+		categories.On("NextID").Return(categoryID, nil).Once()
+		categories.On("Store", mock.MatchedBy(func(c model.Category) bool {
+			return c.CategoryID == categoryID && *c.ParentID == parentID && c.Name == "Phones"
+		})).Return(nil).Once()
+
+		id, err := service.CreateCategory("Phones", &parentID)
+		assert.NoError(t, err)
+		assert.Equal(t, categoryID, id)
+		categories.AssertExpectations(t)
+	})
+
+	t.Run("parent_not_found", func(t *testing.T) {
+		categories.On("Find", parentID).Return(nil, model.ErrCategoryNotFound).Once()
+
+		_, err := service.CreateCategory("Phones", &parentID)
+		assert.ErrorIs(t, err, model.ErrCategoryNotFound)
+	})
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	rootID := uuid.New()
+	childID := uuid.New()
+	grandchildID := uuid.New()
+	root := model.Category{CategoryID: rootID, Name: "Electronics"}
+	child := model.Category{CategoryID: childID, ParentID: &rootID, Name: "Phones"}
+	grandchild := model.Category{CategoryID: grandchildID, ParentID: &childID, Name: "Smartphones"}
+
+	t.Run("move_under_descendant", func(t *testing.T) {
+		categories := new(MockCategoryRepository)
+		service := NewCategoryService(categories, new(MockProductRepository), new(MockEventDispatcher))
+		categories.On("Find", rootID).Return(&root, nil)
+		categories.On("Find", childID).Return(&child, nil)
+		categories.On("Find", grandchildID).Return(&grandchild, nil)
+
+		err := service.UpdateCategory(rootID, root.Name, &grandchildID)
+		assert.ErrorIs(t, err, model.ErrCategoryCycle)
+
+		err = service.UpdateCategory(rootID, root.Name, &rootID)
+		assert.ErrorIs(t, err, model.ErrCategoryCycle)
+		categories.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("move_to_root", func(t *testing.T) {
+		categories := new(MockCategoryRepository)
+		service := NewCategoryService(categories, new(MockProductRepository), new(MockEventDispatcher))
+		stored := grandchild
+		categories.On("Find", grandchildID).Return(&stored, nil).Once()
+		categories.On("Store", mock.MatchedBy(func(c model.Category) bool {
+			return c.CategoryID == grandchildID && c.ParentID == nil
+		})).Return(nil).Once()
+
+		err := service.UpdateCategory(grandchildID, grandchild.Name, nil)
+		assert.NoError(t, err)
+		categories.AssertExpectations(t)
+	})
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	categories := new(MockCategoryRepository)
+	service := NewCategoryService(categories, new(MockProductRepository), new(MockEventDispatcher))
+	categoryID := uuid.New()
+
+	t.Run("not_empty", func(t *testing.T) {
+		categories.On("Find", categoryID).Return(&model.Category{CategoryID: categoryID}, nil).Once()
+		categories.On("InUse", categoryID).Return(true, nil).Once()
+
+		err := service.DeleteCategory(categoryID)
+		assert.ErrorIs(t, err, model.ErrCategoryNotEmpty)
+		categories.AssertNotCalled(t, "Delete", categoryID)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		categories.On("Find", categoryID).Return(&model.Category{CategoryID: categoryID}, nil).Once()
+		categories.On("InUse", categoryID).Return(false, nil).Once()
+		categories.On("Delete", categoryID).Return(nil).Once()
+
+		err := service.DeleteCategory(categoryID)
+		assert.NoError(t, err)
+		categories.AssertExpectations(t)
+	})
+}
+
+func TestCategoryService_SetProductCategories(t *testing.T) {
+	productID := uuid.New()
+	keptID := uuid.New()
+	removedID := uuid.New()
+	addedID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		categories := new(MockCategoryRepository)
+		products := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewCategoryService(categories, products, dispatcher)
+
+		product := &model.Product{ProductID: productID, CategoryIDs: sortedIDs([]uuid.UUID{keptID, removedID})}
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
+		categories.On("Find", keptID).Return(&model.Category{CategoryID: keptID}, nil).Once()
+		categories.On("Find", addedID).Return(&model.Category{CategoryID: addedID}, nil).Once()
+		products.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return assert.ObjectsAreEqual(sortedIDs([]uuid.UUID{keptID, addedID}), p.CategoryIDs)
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductCategoriesChanged) bool {
+			return e.ProductID == productID &&
+				assert.ObjectsAreEqual([]uuid.UUID{addedID}, e.AddedCategoryIDs) &&
+				assert.ObjectsAreEqual([]uuid.UUID{removedID}, e.RemovedCategoryIDs) &&
+				len(e.CategoryIDs) == 2
+		})).Return(nil).Once()
+
+		err := service.SetProductCategories(productID, []uuid.UUID{addedID, keptID, addedID})
+		assert.NoError(t, err)
+		products.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		categories := new(MockCategoryRepository)
+		products := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewCategoryService(categories, products, dispatcher)
+
+		product := &model.Product{ProductID: productID, CategoryIDs: []uuid.UUID{keptID}}
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
+		categories.On("Find", keptID).Return(&model.Category{CategoryID: keptID}, nil).Once()
+
+		err := service.SetProductCategories(productID, []uuid.UUID{keptID})
+		assert.NoError(t, err)
+		products.AssertNotCalled(t, "Store", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("unknown_category", func(t *testing.T) {
+		categories := new(MockCategoryRepository)
+		products := new(MockProductRepository)
+		service := NewCategoryService(categories, products, new(MockEventDispatcher))
+
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Once()
+		categories.On("Find", addedID).Return(nil, model.ErrCategoryNotFound).Once()
+
+		err := service.SetProductCategories(productID, []uuid.UUID{addedID})
+		assert.ErrorIs(t, err, model.ErrCategoryNotFound)
+	})
+}
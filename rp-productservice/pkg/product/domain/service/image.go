@@ -0,0 +1,135 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type ImageService interface {
+	// AddImage добавляет изображение в конец списка. ImageID, Position и даты назначаются сервисом
+	AddImage(image model.Image) (uuid.UUID, error)
+	// ReorderImages задает новый порядок. imageIDs должен содержать каждое изображение товара ровно один раз
+	ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID) error
+	// RemoveImage удаляет изображение и возвращает его, чтобы вызывающий мог удалить загруженный файл
+	RemoveImage(productID, imageID uuid.UUID) (*model.Image, error)
+}
+
+func NewImageService(
+	productRepository model.ProductRepository,
+	imageRepository model.ImageRepository,
+) ImageService {
+	return &imageService{
+		productRepository: productRepository,
+		imageRepository:   imageRepository,
+	}
+}
+
+type imageService struct {
+	productRepository model.ProductRepository
+	imageRepository   model.ImageRepository
+}
+
+func (s *imageService) AddImage(image model.Image) (uuid.UUID, error) {
+	if (image.URL == nil) == (image.ObjectKey == nil) {
+		return uuid.Nil, model.ErrImageSourceRequired
+	}
+
+	_, err := s.productRepository.Find(model.FindSpec{ProductID: &image.ProductID})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	images, err := s.imageRepository.FindByProduct(image.ProductID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	imageID, err := s.imageRepository.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	image.ImageID = imageID
+	image.Position = len(images)
+	image.CreatedAt = currentTime
+	image.UpdatedAt = currentTime
+	return imageID, s.imageRepository.Store(image)
+}
+
+func (s *imageService) ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID) error {
+	images, err := s.imageRepository.FindByProduct(productID)
+	if err != nil {
+		return err
+	}
+	if len(images) != len(imageIDs) {
+		return model.ErrImageOrderMismatch
+	}
+
+	positions := make(map[uuid.UUID]int, len(imageIDs))
+	for i, imageID := range imageIDs {
+		if _, ok := positions[imageID]; ok {
+			return model.ErrImageOrderMismatch
+		}
+		positions[imageID] = i
+	}
+	for _, image := range images {
+		if _, ok := positions[image.ImageID]; !ok {
+			return model.ErrImageOrderMismatch
+		}
+	}
+
+	return s.storePositions(images, func(_ int, image model.Image) int {
+		return positions[image.ImageID]
+	})
+}
+
+func (s *imageService) RemoveImage(productID, imageID uuid.UUID) (*model.Image, error) {
+	images, err := s.imageRepository.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(images, func(image model.Image) bool {
+		return image.ImageID == imageID
+	})
+	if i < 0 {
+		return nil, model.ErrImageNotFound
+	}
+	removed := images[i]
+
+	err = s.imageRepository.Delete(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// позиции остаются непрерывными, чтобы новое изображение всегда вставало в конец
+	images = slices.Delete(images, i, i+1)
+	err = s.storePositions(images, func(i int, _ model.Image) int {
+		return i
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &removed, nil
+}
+
+func (s *imageService) storePositions(images []model.Image, position func(i int, image model.Image) int) error {
+	currentTime := time.Now()
+	for i, image := range images {
+		newPosition := position(i, image)
+		if image.Position == newPosition {
+			continue
+		}
+		image.Position = newPosition
+		image.UpdatedAt = currentTime
+		err := s.imageRepository.Store(image)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type MockImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockImageRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockImageRepository) Store(image model.Image) error {
+	return m.Called(image).Error(0)
+}
+
+func (m *MockImageRepository) Find(imageID uuid.UUID) (*model.Image, error) {
+	args := m.Called(imageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Image), args.Error(1)
+}
+
+func (m *MockImageRepository) FindByProduct(productID uuid.UUID) ([]model.Image, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]model.Image), args.Error(1)
+}
+
+func (m *MockImageRepository) Delete(imageID uuid.UUID) error {
+	return m.Called(imageID).Error(0)
+}
+
+func TestImageService_AddImage(t *testing.T) {
+	productID := uuid.New()
+	url := "https://cdn.example.com/mug.png"
+
+	t.Run("no_source", func(t *testing.T) {
+		service := NewImageService(new(MockProductRepository), new(MockImageRepository))
+
+		_, err := service.AddImage(model.Image{ProductID: productID})
+		assert.ErrorIs(t, err, model.ErrImageSourceRequired)
+	})
+
+	t.Run("product_not_found", func(t *testing.T) {
+		products := new(MockProductRepository)
+		service := NewImageService(products, new(MockImageRepository))
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(nil, model.ErrProductNotFound).Once()
+
+		_, err := service.AddImage(model.Image{ProductID: productID, URL: &url})
+		assert.ErrorIs(t, err, model.ErrProductNotFound)
+	})
+
+	t.Run("appends_to_end", func(t *testing.T) {
+		products := new(MockProductRepository)
+		images := new(MockImageRepository)
+		service := NewImageService(products, images)
+		imageID := uuid.New()
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Once()
+		images.On("FindByProduct", productID).Return([]model.Image{{ImageID: uuid.New(), Position: 0}}, nil).Once()
+		images.On("NextID").Return(imageID, nil).Once()
+		images.On("Store", mock.MatchedBy(func(i model.Image) bool {
+			return i.ImageID == imageID && i.Position == 1 && *i.URL == url && i.AltText == "Mug"
+		})).Return(nil).Once()
+
+		id, err := service.AddImage(model.Image{ProductID: productID, URL: &url, AltText: "Mug"})
+		assert.NoError(t, err)
+		assert.Equal(t, imageID, id)
+		images.AssertExpectations(t)
+	})
+}
+
+func TestImageService_ReorderImages(t *testing.T) {
+	productID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	current := []model.Image{
+		{ImageID: first, ProductID: productID, Position: 0},
+		{ImageID: second, ProductID: productID, Position: 1},
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		for _, order := range [][]uuid.UUID{{first}, {first, first}, {first, uuid.New()}} {
+			images := new(MockImageRepository)
+			service := NewImageService(new(MockProductRepository), images)
+			images.On("FindByProduct", productID).Return(current, nil).Once()
+
+			err := service.ReorderImages(productID, order)
+			assert.ErrorIs(t, err, model.ErrImageOrderMismatch)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		images := new(MockImageRepository)
+		service := NewImageService(new(MockProductRepository), images)
+		images.On("FindByProduct", productID).Return(current, nil).Once()
+		images.On("Store", mock.MatchedBy(func(i model.Image) bool {
+			return i.ImageID == second && i.Position == 0
+		})).Return(nil).Once()
+		images.On("Store", mock.MatchedBy(func(i model.Image) bool {
+			return i.ImageID == first && i.Position == 1
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ReorderImages(productID, []uuid.UUID{second, first}))
+		images.AssertExpectations(t)
+	})
+}
+
+func TestImageService_RemoveImage(t *testing.T) {
+	productID := uuid.New()
+	first, second, third := uuid.New(), uuid.New(), uuid.New()
+	current := []model.Image{
+		{ImageID: first, Position: 0},
+		{ImageID: second, Position: 1},
+		{ImageID: third, Position: 2},
+	}
+
+	t.Run("not_found", func(t *testing.T) {
+		images := new(MockImageRepository)
+		service := NewImageService(new(MockProductRepository), images)
+		images.On("FindByProduct", productID).Return(current, nil).Once()
+
+		_, err := service.RemoveImage(productID, uuid.New())
+		assert.ErrorIs(t, err, model.ErrImageNotFound)
+	})
+
+	t.Run("repacks_positions", func(t *testing.T) {
+		images := new(MockImageRepository)
+		service := NewImageService(new(MockProductRepository), images)
+		images.On("FindByProduct", productID).Return(append([]model.Image(nil), current...), nil).Once()
+		images.On("Delete", second).Return(nil).Once()
+		images.On("Store", mock.MatchedBy(func(i model.Image) bool {
+			return i.ImageID == third && i.Position == 1
+		})).Return(nil).Once()
+
+		removed, err := service.RemoveImage(productID, second)
+		assert.NoError(t, err)
+		assert.Equal(t, second, removed.ImageID)
+		images.AssertExpectations(t)
+	})
+}
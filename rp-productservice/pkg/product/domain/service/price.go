@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/pkg/common/domain"
+	"productservice/pkg/product/domain/model"
+)
+
+type PriceService interface {
+	SchedulePrice(productID uuid.UUID, variantID *uuid.UUID, price int64, from time.Time, until *time.Time) (uuid.UUID, error)
+	CancelScheduledPrice(scheduledPriceID uuid.UUID) error
+	// ApplyScheduledPrice применяет цену или завершает распродажу, если к моменту now пора.
+	// Повторный вызов ничего не меняет, поэтому планировщиков может быть несколько
+	ApplyScheduledPrice(scheduledPriceID uuid.UUID, now time.Time) error
+}
+
+func NewPriceService(
+	productRepository model.ProductRepository,
+	scheduledPriceRepository model.ScheduledPriceRepository,
+	eventDispatcher domain.EventDispatcher,
+) PriceService {
+	return &priceService{
+		productRepository:        productRepository,
+		scheduledPriceRepository: scheduledPriceRepository,
+		eventDispatcher:          eventDispatcher,
+	}
+}
+
+type priceService struct {
+	productRepository        model.ProductRepository
+	scheduledPriceRepository model.ScheduledPriceRepository
+	eventDispatcher          domain.EventDispatcher
+}
+
+func (s *priceService) SchedulePrice(
+	productID uuid.UUID,
+	variantID *uuid.UUID,
+	price int64,
+	from time.Time,
+	until *time.Time,
+) (uuid.UUID, error) {
+	if until != nil && !until.After(from) {
+		return uuid.Nil, model.ErrInvalidPricePeriod
+	}
+
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if variantID != nil && variantIndex(*product, *variantID) < 0 {
+		return uuid.Nil, model.ErrVariantNotFound
+	}
+
+	scheduledPrice := model.ScheduledPrice{
+		ProductID:      productID,
+		VariantID:      variantID,
+		Price:          price,
+		Currency:       product.Currency,
+		EffectiveFrom:  from,
+		EffectiveUntil: until,
+		Status:         model.ScheduledPricePending,
+	}
+	// при пересечении окончание одной распродажи вернуло бы цену посреди другой
+	unfinished, err := s.scheduledPriceRepository.FindUnfinished(productID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if slices.ContainsFunc(unfinished, scheduledPrice.Overlaps) {
+		return uuid.Nil, model.ErrScheduledPriceOverlaps
+	}
+
+	scheduledPriceID, err := s.scheduledPriceRepository.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	scheduledPrice.ScheduledPriceID = scheduledPriceID
+	scheduledPrice.CreatedAt = currentTime
+	scheduledPrice.UpdatedAt = currentTime
+	return scheduledPriceID, s.scheduledPriceRepository.Store(scheduledPrice)
+}
+
+func (s *priceService) CancelScheduledPrice(scheduledPriceID uuid.UUID) error {
+	scheduledPrice, err := s.scheduledPriceRepository.Find(scheduledPriceID)
+	if err != nil {
+		return err
+	}
+	if scheduledPrice.Status == model.ScheduledPriceCancelled {
+		return nil
+	}
+	if scheduledPrice.Status != model.ScheduledPricePending {
+		return model.ErrScheduledPriceNotPending
+	}
+
+	scheduledPrice.Status = model.ScheduledPriceCancelled
+	scheduledPrice.UpdatedAt = time.Now()
+	return s.scheduledPriceRepository.Store(*scheduledPrice)
+}
+
+func (s *priceService) ApplyScheduledPrice(scheduledPriceID uuid.UUID, now time.Time) error {
+	scheduledPrice, err := s.scheduledPriceRepository.Find(scheduledPriceID)
+	if err != nil {
+		return err
+	}
+	dueAt, ok := scheduledPrice.DueAt()
+	if !ok || dueAt.After(now) {
+		return nil
+	}
+
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &scheduledPrice.ProductID})
+	if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+		return err
+	}
+	// товар или вариант удалили, менять цену больше нечему
+	if product == nil || (scheduledPrice.VariantID != nil && variantIndex(*product, *scheduledPrice.VariantID) < 0) {
+		return s.finishScheduledPrice(*scheduledPrice, model.ScheduledPriceCancelled, now)
+	}
+	// товар перевели в другую валюту: цена и сохраненная предыдущая цена в старой валюте,
+	// применять или возвращать их нельзя
+	if scheduledPrice.Currency != product.Currency {
+		return s.finishScheduledPrice(*scheduledPrice, model.ScheduledPriceCancelled, now)
+	}
+
+	currentPrice := priceOf(*product, scheduledPrice.VariantID)
+	newPrice := currentPrice
+	status := model.ScheduledPriceCompleted
+	switch scheduledPrice.Status {
+	case model.ScheduledPricePending:
+		scheduledPrice.PreviousPrice = &currentPrice
+		// распродажа закончилась раньше, чем до нее дошла очередь
+		if scheduledPrice.EffectiveUntil == nil || scheduledPrice.EffectiveUntil.After(now) {
+			newPrice = scheduledPrice.Price
+		}
+		if scheduledPrice.EffectiveUntil != nil && newPrice != currentPrice {
+			status = model.ScheduledPriceActive
+		}
+	case model.ScheduledPriceActive:
+		// цену за время распродажи поменяли вручную, возвращать старую нельзя
+		if currentPrice == scheduledPrice.Price {
+			newPrice = *scheduledPrice.PreviousPrice
+		}
+	}
+
+	err = s.finishScheduledPrice(*scheduledPrice, status, now)
+	if err != nil {
+		return err
+	}
+	if newPrice == currentPrice {
+		return nil
+	}
+
+	setPrice(product, scheduledPrice.VariantID, newPrice)
+	product.UpdatedAt = now
+	err = s.productRepository.Store(*product)
+	if err != nil {
+		return err
+	}
+	return s.eventDispatcher.Dispatch(productUpdated(*product))
+}
+
+func (s *priceService) finishScheduledPrice(scheduledPrice model.ScheduledPrice, status model.ScheduledPriceStatus, now time.Time) error {
+	scheduledPrice.Status = status
+	scheduledPrice.UpdatedAt = now
+	return s.scheduledPriceRepository.Store(scheduledPrice)
+}
+
+func variantIndex(product model.Product, variantID uuid.UUID) int {
+	return slices.IndexFunc(product.Variants, func(v model.Variant) bool {
+		return v.VariantID == variantID
+	})
+}
+
+func priceOf(product model.Product, variantID *uuid.UUID) int64 {
+	if variantID == nil {
+		return product.Price
+	}
+	return product.Variants[variantIndex(product, *variantID)].Price
+}
+
+func setPrice(product *model.Product, variantID *uuid.UUID, price int64) {
+	if variantID == nil {
+		product.Price = price
+		return
+	}
+	product.Variants[variantIndex(*product, *variantID)].Price = price
+}
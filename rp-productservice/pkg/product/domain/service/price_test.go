@@ -0,0 +1,319 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type MockScheduledPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduledPriceRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockScheduledPriceRepository) Store(price model.ScheduledPrice) error {
+	return m.Called(price).Error(0)
+}
+
+func (m *MockScheduledPriceRepository) Find(scheduledPriceID uuid.UUID) (*model.ScheduledPrice, error) {
+	args := m.Called(scheduledPriceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScheduledPrice), args.Error(1)
+}
+
+func (m *MockScheduledPriceRepository) FindDue(at time.Time, limit int) ([]model.ScheduledPrice, error) {
+	args := m.Called(at, limit)
+	return args.Get(0).([]model.ScheduledPrice), args.Error(1)
+}
+
+func (m *MockScheduledPriceRepository) FindUnfinished(productID uuid.UUID) ([]model.ScheduledPrice, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]model.ScheduledPrice), args.Error(1)
+}
+
+func TestPriceService_SchedulePrice(t *testing.T) {
+	productID := uuid.New()
+	from := time.Now().Add(time.Hour)
+
+	t.Run("invalid_period", func(t *testing.T) {
+		service := NewPriceService(new(MockProductRepository), new(MockScheduledPriceRepository), new(MockEventDispatcher))
+		until := from.Add(-time.Minute)
+
+		_, err := service.SchedulePrice(productID, nil, 500, from, &until)
+		assert.ErrorIs(t, err, model.ErrInvalidPricePeriod)
+	})
+
+	t.Run("unknown_variant", func(t *testing.T) {
+		products := new(MockProductRepository)
+		service := NewPriceService(products, new(MockScheduledPriceRepository), new(MockEventDispatcher))
+		variantID := uuid.New()
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Once()
+
+		_, err := service.SchedulePrice(productID, &variantID, 500, from, nil)
+		assert.ErrorIs(t, err, model.ErrVariantNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		products := new(MockProductRepository)
+		prices := new(MockScheduledPriceRepository)
+		service := NewPriceService(products, prices, new(MockEventDispatcher))
+		scheduledPriceID := uuid.New()
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID, Currency: "RUB"}, nil).Once()
+		prices.On("FindUnfinished", productID).Return([]model.ScheduledPrice{}, nil).Once()
+		prices.On("NextID").Return(scheduledPriceID, nil).Once()
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.ScheduledPriceID == scheduledPriceID && p.Price == 500 && p.Currency == "RUB" && p.Status == model.ScheduledPricePending
+		})).Return(nil).Once()
+
+		id, err := service.SchedulePrice(productID, nil, 500, from, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, scheduledPriceID, id)
+		prices.AssertExpectations(t)
+	})
+
+	t.Run("overlaps", func(t *testing.T) {
+		variantID := uuid.New()
+		saleUntil := from.Add(24 * time.Hour)
+		twoHoursLater := from.Add(2 * time.Hour)
+		afterSale := saleUntil.Add(time.Hour)
+		// идущая распродажа товара и запланированная смена цены варианта
+		unfinished := []model.ScheduledPrice{
+			{ProductID: productID, Price: 700, EffectiveFrom: from, EffectiveUntil: &saleUntil, Status: model.ScheduledPriceActive},
+			{ProductID: productID, VariantID: &variantID, Price: 900, EffectiveFrom: from, Status: model.ScheduledPricePending},
+		}
+		newService := func() (PriceService, *MockScheduledPriceRepository) {
+			products := new(MockProductRepository)
+			prices := new(MockScheduledPriceRepository)
+			products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{
+				ProductID: productID,
+				Variants:  []model.Variant{{VariantID: variantID}},
+			}, nil).Once()
+			prices.On("FindUnfinished", productID).Return(unfinished, nil).Once()
+			return NewPriceService(products, prices, new(MockEventDispatcher)), prices
+		}
+
+		tests := []struct {
+			name      string
+			variantID *uuid.UUID
+			from      time.Time
+			until     *time.Time
+			overlaps  bool
+		}{
+			{name: "sale_inside_sale", from: from.Add(time.Hour), until: &twoHoursLater, overlaps: true},
+			{name: "sale_around_sale", from: from.Add(-time.Hour), until: &afterSale, overlaps: true},
+			{name: "price_inside_sale", from: from.Add(time.Hour), overlaps: true},
+			{name: "same_moment_price", variantID: &variantID, from: from, overlaps: true},
+			{name: "sale_after_sale", from: saleUntil, until: &afterSale},
+			{name: "price_after_sale", from: saleUntil},
+			{name: "other_target", variantID: &variantID, from: from.Add(time.Hour), until: &twoHoursLater},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service, prices := newService()
+				if !tt.overlaps {
+					prices.On("NextID").Return(uuid.New(), nil).Once()
+					prices.On("Store", mock.Anything).Return(nil).Once()
+				}
+
+				_, err := service.SchedulePrice(productID, tt.variantID, 500, tt.from, tt.until)
+				if tt.overlaps {
+					assert.ErrorIs(t, err, model.ErrScheduledPriceOverlaps)
+					prices.AssertNotCalled(t, "Store", mock.Anything)
+				} else {
+					assert.NoError(t, err)
+					prices.AssertExpectations(t)
+				}
+			})
+		}
+	})
+}
+
+func TestPriceService_ApplyScheduledPrice(t *testing.T) {
+	productID := uuid.New()
+	scheduledPriceID := uuid.New()
+	now := time.Now()
+	until := now.Add(time.Hour)
+
+	newService := func(product *model.Product, scheduledPrice *model.ScheduledPrice) (PriceService, *MockProductRepository, *MockScheduledPriceRepository, *MockEventDispatcher) {
+		products := new(MockProductRepository)
+		prices := new(MockScheduledPriceRepository)
+		dispatcher := new(MockEventDispatcher)
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Maybe()
+		prices.On("Find", scheduledPriceID).Return(scheduledPrice, nil).Once()
+		return NewPriceService(products, prices, dispatcher), products, prices, dispatcher
+	}
+
+	t.Run("not_due_yet", func(t *testing.T) {
+		service, products, prices, _ := newService(nil, &model.ScheduledPrice{
+			ScheduledPriceID: scheduledPriceID,
+			ProductID:        productID,
+			EffectiveFrom:    now.Add(time.Minute),
+			Status:           model.ScheduledPricePending,
+		})
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		products.AssertNotCalled(t, "Find", mock.Anything)
+		prices.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("start_sale", func(t *testing.T) {
+		service, products, prices, dispatcher := newService(
+			&model.Product{ProductID: productID, Name: "Mug", Price: 1000},
+			&model.ScheduledPrice{
+				ScheduledPriceID: scheduledPriceID,
+				ProductID:        productID,
+				Price:            700,
+				EffectiveFrom:    now.Add(-time.Minute),
+				EffectiveUntil:   &until,
+				Status:           model.ScheduledPricePending,
+			},
+		)
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceActive && *p.PreviousPrice == 1000
+		})).Return(nil).Once()
+		products.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.Price == 700
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUpdated) bool {
+			return *e.UpdatedFields.Price == 700 && *e.UpdatedFields.Name == "Mug"
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		prices.AssertExpectations(t)
+		products.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("end_sale_on_variant", func(t *testing.T) {
+		variantID := uuid.New()
+		previousPrice := int64(1200)
+		ended := now.Add(-time.Second)
+		service, products, prices, dispatcher := newService(
+			&model.Product{ProductID: productID, Variants: []model.Variant{{VariantID: variantID, SKU: "MUG-RED", Price: 900}}},
+			&model.ScheduledPrice{
+				ScheduledPriceID: scheduledPriceID,
+				ProductID:        productID,
+				VariantID:        &variantID,
+				Price:            900,
+				PreviousPrice:    &previousPrice,
+				EffectiveFrom:    now.Add(-time.Hour),
+				EffectiveUntil:   &ended,
+				Status:           model.ScheduledPriceActive,
+			},
+		)
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceCompleted
+		})).Return(nil).Once()
+		products.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.Variants[0].Price == 1200
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		products.AssertExpectations(t)
+	})
+
+	t.Run("end_sale_after_manual_change", func(t *testing.T) {
+		previousPrice := int64(1000)
+		ended := now.Add(-time.Second)
+		service, products, prices, dispatcher := newService(
+			&model.Product{ProductID: productID, Price: 800},
+			&model.ScheduledPrice{
+				ScheduledPriceID: scheduledPriceID,
+				ProductID:        productID,
+				Price:            700,
+				PreviousPrice:    &previousPrice,
+				EffectiveFrom:    now.Add(-time.Hour),
+				EffectiveUntil:   &ended,
+				Status:           model.ScheduledPriceActive,
+			},
+		)
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceCompleted
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		products.AssertNotCalled(t, "Store", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("product_deleted", func(t *testing.T) {
+		products := new(MockProductRepository)
+		prices := new(MockScheduledPriceRepository)
+		service := NewPriceService(products, prices, new(MockEventDispatcher))
+		prices.On("Find", scheduledPriceID).Return(&model.ScheduledPrice{
+			ScheduledPriceID: scheduledPriceID,
+			ProductID:        productID,
+			EffectiveFrom:    now.Add(-time.Minute),
+			Status:           model.ScheduledPricePending,
+		}, nil).Once()
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(nil, model.ErrProductNotFound).Once()
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceCancelled
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		prices.AssertExpectations(t)
+	})
+
+	t.Run("currency_changed", func(t *testing.T) {
+		previousPrice := int64(1000)
+		service, products, prices, dispatcher := newService(
+			&model.Product{ProductID: productID, Price: 70, Currency: "USD"},
+			&model.ScheduledPrice{
+				ScheduledPriceID: scheduledPriceID,
+				ProductID:        productID,
+				Price:            700,
+				Currency:         "RUB",
+				PreviousPrice:    &previousPrice,
+				EffectiveFrom:    now.Add(-2 * time.Hour),
+				EffectiveUntil:   &now,
+				Status:           model.ScheduledPriceActive,
+			},
+		)
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceCancelled
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ApplyScheduledPrice(scheduledPriceID, now))
+		prices.AssertExpectations(t)
+		products.AssertNotCalled(t, "Store", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+}
+
+func TestPriceService_CancelScheduledPrice(t *testing.T) {
+	scheduledPriceID := uuid.New()
+
+	t.Run("already_applied", func(t *testing.T) {
+		prices := new(MockScheduledPriceRepository)
+		service := NewPriceService(new(MockProductRepository), prices, new(MockEventDispatcher))
+		prices.On("Find", scheduledPriceID).Return(&model.ScheduledPrice{Status: model.ScheduledPriceActive}, nil).Once()
+
+		err := service.CancelScheduledPrice(scheduledPriceID)
+		assert.ErrorIs(t, err, model.ErrScheduledPriceNotPending)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		prices := new(MockScheduledPriceRepository)
+		service := NewPriceService(new(MockProductRepository), prices, new(MockEventDispatcher))
+		prices.On("Find", scheduledPriceID).Return(&model.ScheduledPrice{ScheduledPriceID: scheduledPriceID}, nil).Once()
+		prices.On("Store", mock.MatchedBy(func(p model.ScheduledPrice) bool {
+			return p.Status == model.ScheduledPriceCancelled
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.CancelScheduledPrice(scheduledPriceID))
+		prices.AssertExpectations(t)
+	})
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,9 +15,18 @@ import (
 )
 
 type ProductService interface {
-	CreateProduct(name string, price int64, description *string) (uuid.UUID, error)
-	UpdateProduct(productID uuid.UUID, name string, price int64, description *string) error
-	DeleteProduct(productID uuid.UUID) error
+	// CreateProduct без currency создает товар в DefaultCurrency
+	CreateProduct(name string, price int64, currency string, description *string, variants []model.Variant) (uuid.UUID, error)
+	// UpdateProduct заменяет список вариантов: варианты без VariantID создаются, отсутствующие в списке удаляются.
+	// Без currency валюта товара не меняется
+	UpdateProduct(productID uuid.UUID, name string, price int64, currency string, description *string, variants []model.Variant) error
+	ArchiveProduct(productID uuid.UUID) error
+	UnarchiveProduct(productID uuid.UUID) error
+	// PurgeProduct окончательно удаляет товар. Удалить можно только архивный товар
+	PurgeProduct(productID uuid.UUID) error
+	// DeductStock списывает с остатка вариантов количества из оплаченного заказа, повторное списание
+	// по тому же заказу ничего не меняет. Остаток не уходит ниже нуля
+	DeductStock(orderID, productID uuid.UUID, quantities map[uuid.UUID]int64) error
 }
 
 func NewProductService(
@@ -32,13 +44,21 @@ type productService struct {
 	eventDispatcher   domain.EventDispatcher
 }
 
-func (s *productService) CreateProduct(name string, price int64, description *string) (uuid.UUID, error) {
-	_, err := s.productRepository.Find(model.FindSpec{Name: &name})
-	if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+func (s *productService) CreateProduct(name string, price int64, currency string, description *string, variants []model.Variant) (uuid.UUID, error) {
+	err := checkPrices(price, variants)
+	if err != nil {
 		return uuid.Nil, err
 	}
-	if err == nil {
-		return uuid.Nil, model.ErrProductNameAlreadyUsed
+	if currency == "" {
+		currency = model.DefaultCurrency
+	}
+	if !model.ValidCurrency(currency) {
+		return uuid.Nil, model.ErrInvalidCurrency
+	}
+
+	err = s.checkNameUnique(uuid.Nil, name)
+	if err != nil {
+		return uuid.Nil, err
 	}
 
 	productID, err := s.productRepository.NextID()
@@ -46,12 +66,25 @@ func (s *productService) CreateProduct(name string, price int64, description *st
 		return uuid.Nil, err
 	}
 
+	slug, err := s.uniqueSlug(productID, name)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	variants, err = s.prepareVariants(productID, nil, variants)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	currentTime := time.Now()
 	product := model.Product{
 		ProductID:   productID,
 		Name:        name,
+		Slug:        slug,
 		Description: description,
 		Price:       price,
+		Currency:    currency,
+		Variants:    variants,
 		CreatedAt:   currentTime,
 		UpdatedAt:   currentTime,
 	}
@@ -67,34 +100,61 @@ func (s *productService) CreateProduct(name string, price int64, description *st
 		Name:        name,
 		Description: description,
 		Price:       price,
+		Currency:    currency,
+		Variants:    variants,
 		CreatedAt:   currentTime,
 	})
 }
 
-func (s *productService) UpdateProduct(productID uuid.UUID, name string, price int64, description *string) error {
+func (s *productService) UpdateProduct(productID uuid.UUID, name string, price int64, currency string, description *string, variants []model.Variant) error {
+	err := checkPrices(price, variants)
+	if err != nil {
+		return err
+	}
+	if currency != "" && !model.ValidCurrency(currency) {
+		return model.ErrInvalidCurrency
+	}
+
 	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
 	if err != nil {
 		return err
 	}
+	if currency == "" {
+		currency = product.Currency
+	}
 
+	slug := product.Slug
 	if product.Name != name {
-		existing, err := s.productRepository.Find(model.FindSpec{Name: &name})
-		if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+		err = s.checkNameUnique(productID, name)
+		if err != nil {
 			return err
 		}
-		if existing != nil && existing.ProductID != productID {
-			return model.ErrProductNameAlreadyUsed
+		// slug меняется только вместе с его основой, иначе смена регистра сломала бы ссылки
+		if model.ProductSlug(product.Name) != model.ProductSlug(name) {
+			slug, err = s.uniqueSlug(productID, name)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	if product.Name == name && product.Price == price && reflect.DeepEqual(product.Description, description) {
+	variants, err = s.prepareVariants(productID, product.Variants, variants)
+	if err != nil {
+		return err
+	}
+
+	if product.Name == name && product.Price == price && product.Currency == currency &&
+		reflect.DeepEqual(product.Description, description) && equalVariants(product.Variants, variants) {
 		return nil
 	}
 
 	currentTime := time.Now()
 	product.Name = name
+	product.Slug = slug
 	product.Price = price
+	product.Currency = currency
 	product.Description = description
+	product.Variants = variants
 	product.UpdatedAt = currentTime
 
 	err = s.productRepository.Store(*product)
@@ -103,33 +163,207 @@ func (s *productService) UpdateProduct(productID uuid.UUID, name string, price i
 	}
 
 	// кричим, что продукт обновлен
-	return s.eventDispatcher.Dispatch(&model.ProductUpdated{
-		ProductID: productID,
-		UpdatedFields: struct {
-			Name        *string
-			Description *string
-			Price       *int64
-		}{Name: &name, Description: description, Price: &price},
-		UpdatedAt: currentTime,
+	return s.eventDispatcher.Dispatch(productUpdated(*product))
+}
+
+func (s *productService) ArchiveProduct(productID uuid.UUID) error {
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		return err
+	}
+	if product.ArchivedAt != nil {
+		return nil
+	}
+
+	currentTime := time.Now()
+	product.ArchivedAt = &currentTime
+	product.UpdatedAt = currentTime
+	err = s.productRepository.Store(*product)
+	if err != nil {
+		return err
+	}
+
+	return s.eventDispatcher.Dispatch(&model.ProductArchived{
+		ProductID:  productID,
+		ArchivedAt: currentTime,
 	})
 }
 
-func (s *productService) DeleteProduct(productID uuid.UUID) error {
-	_, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+func (s *productService) UnarchiveProduct(productID uuid.UUID) error {
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		return err
+	}
+	if product.ArchivedAt == nil {
+		return nil
+	}
+
+	currentTime := time.Now()
+	product.ArchivedAt = nil
+	product.UpdatedAt = currentTime
+	err = s.productRepository.Store(*product)
+	if err != nil {
+		return err
+	}
+
+	return s.eventDispatcher.Dispatch(&model.ProductUnarchived{
+		ProductID:    productID,
+		UnarchivedAt: currentTime,
+	})
+}
+
+func (s *productService) PurgeProduct(productID uuid.UUID) error {
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
 	if err != nil {
 		if errors.Is(err, model.ErrProductNotFound) {
 			return nil
 		}
 		return err
 	}
+	// реплики узнают о снятии с продажи из product_archived, отдельное событие для удаления им не нужно
+	if product.ArchivedAt == nil {
+		return model.ErrProductNotArchived
+	}
+
+	return s.productRepository.Delete(productID)
+}
 
-	err = s.productRepository.Delete(productID)
+func (s *productService) DeductStock(orderID, productID uuid.UUID, quantities map[uuid.UUID]int64) error {
+	deducted, err := s.productRepository.StoreStockDeduction(orderID, productID)
+	if err != nil || !deducted {
+		return err
+	}
+
+	product, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
 	if err != nil {
+		if errors.Is(err, model.ErrProductNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	changed := false
+	for i, variant := range product.Variants {
+		quantity, ok := quantities[variant.VariantID]
+		if !ok || quantity <= 0 || variant.Stock == 0 {
+			continue
+		}
+		product.Variants[i].Stock = max(variant.Stock-quantity, 0)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	product.UpdatedAt = time.Now()
+	err = s.productRepository.Store(*product)
+	if err != nil {
+		return err
+	}
+
+	// реплики получают новый остаток вместе с остальными полями товара
+	return s.eventDispatcher.Dispatch(productUpdated(*product))
+}
+
+// checkNameUnique проверяет, что нормализованное имя не занято другим товаром, для нового товара productID пустой
+func (s *productService) checkNameUnique(productID uuid.UUID, name string) error {
+	normalizedName := model.NormalizeProductName(name)
+	if normalizedName == "" {
+		return model.ErrProductNameRequired
+	}
+	existing, err := s.productRepository.Find(model.FindSpec{NormalizedName: &normalizedName})
+	if err != nil && !errors.Is(err, model.ErrProductNotFound) {
 		return err
 	}
+	if existing != nil && (productID == uuid.Nil || existing.ProductID != productID) {
+		return model.ErrProductNameAlreadyUsed
+	}
+	return nil
+}
 
-	return s.eventDispatcher.Dispatch(&model.ProductDeleted{
-		ProductID: productID,
-		DeletedAt: time.Now(),
+// uniqueSlug строит slug из имени, при совпадении с другим товаром добавляет числовой суффикс
+func (s *productService) uniqueSlug(productID uuid.UUID, name string) (string, error) {
+	base := model.ProductSlug(name)
+	slug := base
+	for i := 2; ; i++ {
+		existing, err := s.productRepository.Find(model.FindSpec{Slug: &slug})
+		if errors.Is(err, model.ErrProductNotFound) {
+			return slug, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if existing.ProductID == productID {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// prepareVariants выдает идентификаторы новым вариантам и проверяет, что SKU не заняты
+func (s *productService) prepareVariants(productID uuid.UUID, current, requested []model.Variant) ([]model.Variant, error) {
+	result := make([]model.Variant, 0, len(requested))
+	for _, variant := range requested {
+		if variant.VariantID == uuid.Nil {
+			variantID, err := s.productRepository.NextID()
+			if err != nil {
+				return nil, err
+			}
+			variant.VariantID = variantID
+		} else if !slices.ContainsFunc(current, func(v model.Variant) bool { return v.VariantID == variant.VariantID }) {
+			return nil, model.ErrVariantNotFound
+		}
+
+		if slices.ContainsFunc(result, func(v model.Variant) bool { return v.SKU == variant.SKU }) {
+			return nil, model.ErrVariantSKUAlreadyUsed
+		}
+		if !slices.ContainsFunc(current, func(v model.Variant) bool { return v.SKU == variant.SKU }) {
+			existing, err := s.productRepository.Find(model.FindSpec{SKU: &variant.SKU})
+			if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+				return nil, err
+			}
+			if existing != nil && existing.ProductID != productID {
+				return nil, model.ErrVariantSKUAlreadyUsed
+			}
+		}
+
+		result = append(result, variant)
+	}
+	return result, nil
+}
+
+func checkPrices(price int64, variants []model.Variant) error {
+	if price < 0 || slices.ContainsFunc(variants, func(v model.Variant) bool { return v.Price < 0 }) {
+		return model.ErrNegativePrice
+	}
+	return nil
+}
+
+// equalVariants сравнивает варианты вместе с атрибутами, nil и пустые атрибуты считаются равными
+func equalVariants(a, b []model.Variant) bool {
+	return slices.EqualFunc(a, b, func(x, y model.Variant) bool {
+		return x.VariantID == y.VariantID && x.SKU == y.SKU && x.Price == y.Price && x.Stock == y.Stock &&
+			maps.Equal(x.Attributes, y.Attributes)
 	})
 }
+
+// productUpdated передает все поля товара: реплики не хранят товар целиком и не могут собрать его из частей
+func productUpdated(product model.Product) *model.ProductUpdated {
+	return &model.ProductUpdated{
+		ProductID: product.ProductID,
+		UpdatedFields: struct {
+			Name        *string
+			Description *string
+			Price       *int64
+			Currency    *string
+			Variants    *[]model.Variant
+		}{
+			Name:        &product.Name,
+			Description: product.Description,
+			Price:       &product.Price,
+			Currency:    &product.Currency,
+			Variants:    &product.Variants,
+		},
+		UpdatedAt: product.UpdatedAt,
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,11 @@ func (m *MockProductRepository) Delete(productID uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockProductRepository) StoreStockDeduction(orderID, productID uuid.UUID) (bool, error) {
+	args := m.Called(orderID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
 type MockEventDispatcher struct {
 	mock.Mock
 }
@@ -53,31 +59,66 @@ func TestProductService_CreateProduct(t *testing.T) {
 	service := NewProductService(repo, dispatcher)
 
 	name := "Test Product"
+	normalizedName := "test product"
+	slug := "test-product"
 	price := int64(1000)
 	productID := uuid.New()
 
 	t.Run("success", func(t *testing.T) {
-		repo.On("Find", model.FindSpec{Name: &name}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedName}).Return(nil, model.ErrProductNotFound).Once()
 		repo.On("NextID").Return(productID, nil).Once()
+		repo.On("Find", model.FindSpec{Slug: &slug}).Return(nil, model.ErrProductNotFound).Once()
 		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
-			return p.Name == name && p.Price == price && p.ProductID == productID
+			return p.Name == name && p.Price == price && p.ProductID == productID && p.Slug == slug &&
+				p.Currency == model.DefaultCurrency
 		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductCreated) bool {
 			return e.ProductID == productID
 		})).Return(nil).Once()
 
-		id, err := service.CreateProduct(name, price, nil)
+		id, err := service.CreateProduct(name, price, "", nil, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, productID, id)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("name_conflict", func(t *testing.T) {
-		repo.On("Find", model.FindSpec{Name: &name}).Return(&model.Product{}, nil).Once()
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedName}).Return(&model.Product{}, nil).Once()
 
-		_, err := service.CreateProduct(name, price, nil)
+		_, err := service.CreateProduct("  TEST \t product ", price, "", nil, nil)
 		assert.ErrorIs(t, err, model.ErrProductNameAlreadyUsed)
 	})
+
+	t.Run("invalid_currency", func(t *testing.T) {
+		_, err := service.CreateProduct(name, price, "rub", nil, nil)
+		assert.ErrorIs(t, err, model.ErrInvalidCurrency)
+	})
+
+	t.Run("blank_name", func(t *testing.T) {
+		_, err := service.CreateProduct(" \t ", price, "", nil, nil)
+		assert.ErrorIs(t, err, model.ErrProductNameRequired)
+	})
+
+	t.Run("slug_taken", func(t *testing.T) {
+		nextSlug := "test-product-2"
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedName}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("NextID").Return(productID, nil).Once()
+		repo.On("Find", model.FindSpec{Slug: &slug}).Return(&model.Product{ProductID: uuid.New()}, nil).Once()
+		repo.On("Find", model.FindSpec{Slug: &nextSlug}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.Slug == nextSlug
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		_, err := service.CreateProduct(name, price, "", nil, nil)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("negative_variant_price", func(t *testing.T) {
+		_, err := service.CreateProduct(name, price, "", nil, []model.Variant{{SKU: "SKU-1", Price: -1}})
+		assert.ErrorIs(t, err, model.ErrNegativePrice)
+	})
 }
 
 func TestProductService_UpdateProduct(t *testing.T) {
@@ -88,19 +129,22 @@ func TestProductService_UpdateProduct(t *testing.T) {
 	productID := uuid.New()
 	oldName := "Old Name"
 	newName := "New Name"
+	normalizedNewName := "new name"
+	newSlug := "new-name"
 
 	t.Run("success", func(t *testing.T) {
-		existing := &model.Product{ProductID: productID, Name: oldName, Price: 100}
+		existing := &model.Product{ProductID: productID, Name: oldName, Slug: "old-name", Price: 100}
 		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(existing, nil).Once()
-		repo.On("Find", model.FindSpec{Name: &newName}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedNewName}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Find", model.FindSpec{Slug: &newSlug}).Return(nil, model.ErrProductNotFound).Once()
 		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
-			return p.Name == newName && p.Price == 200
+			return p.Name == newName && p.Price == 200 && p.Slug == newSlug
 		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUpdated) bool {
 			return e.ProductID == productID && *e.UpdatedFields.Name == newName
 		})).Return(nil).Once()
 
-		err := service.UpdateProduct(productID, newName, 200, nil)
+		err := service.UpdateProduct(productID, newName, 200, "", nil, nil)
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
@@ -110,28 +154,296 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		otherProduct := &model.Product{ProductID: uuid.New(), Name: newName}
 
 		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(existing, nil).Once()
-		repo.On("Find", model.FindSpec{Name: &newName}).Return(otherProduct, nil).Once()
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedNewName}).Return(otherProduct, nil).Once()
 
-		err := service.UpdateProduct(productID, newName, 200, nil)
+		err := service.UpdateProduct(productID, newName, 200, "", nil, nil)
 		assert.ErrorIs(t, err, model.ErrProductNameAlreadyUsed)
 	})
+
+	t.Run("currency_change", func(t *testing.T) {
+		existing := &model.Product{ProductID: productID, Name: oldName, Slug: "old-name", Price: 100, Currency: "RUB"}
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.Currency == "USD" && p.Price == 2
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUpdated) bool {
+			return *e.UpdatedFields.Currency == "USD"
+		})).Return(nil).Once()
+
+		err := service.UpdateProduct(productID, oldName, 2, "USD", nil, nil)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("case_change_keeps_slug", func(t *testing.T) {
+		existing := &model.Product{ProductID: productID, Name: oldName, Slug: "old-name", Price: 100}
+		normalizedOldName := "old name"
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(existing, nil).Once()
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedOldName}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.Name == "OLD NAME" && p.Slug == "old-name"
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		err := service.UpdateProduct(productID, "OLD NAME", 100, "", nil, nil)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
 }
 
-func TestProductService_DeleteProduct(t *testing.T) {
-	repo := new(MockProductRepository)
-	dispatcher := new(MockEventDispatcher)
-	service := NewProductService(repo, dispatcher)
+func TestProductSlug(t *testing.T) {
+	for name, slug := range map[string]string{
+		"  Smart   Phone X ": "smart-phone-x",
+		"Café Crème":         "cafe-creme",
+		"Чайник электрический": "chaynik-elektricheskiy",
+		"Объём 2,5 л":          "obem-2-5-l",
+		"!!!":                  "product",
+	} {
+		assert.Equal(t, slug, model.ProductSlug(name), name)
+	}
+	assert.Equal(t, model.NormalizeProductName("Phone"), model.NormalizeProductName(" PHONE  "))
+	assert.Equal(t, model.NormalizeProductName("Cafe\u0301"), model.NormalizeProductName("Café"))
+}
 
+func TestProductService_ArchiveProduct(t *testing.T) {
 	productID := uuid.New()
 
+	t.Run("archive", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.ArchivedAt != nil
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductArchived) bool {
+			return e.ProductID == productID
+		})).Return(nil).Once()
+
+		err := service.ArchiveProduct(productID)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("already_archived", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+		archivedAt := time.Now()
+
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID, ArchivedAt: &archivedAt}, nil).Once()
+
+		err := service.ArchiveProduct(productID)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("unarchive", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+		archivedAt := time.Now()
+
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID, ArchivedAt: &archivedAt}, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return p.ArchivedAt == nil
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUnarchived) bool {
+			return e.ProductID == productID
+		})).Return(nil).Once()
+
+		err := service.UnarchiveProduct(productID)
+		assert.NoError(t, err)
+		dispatcher.AssertExpectations(t)
+	})
+}
+
+func TestProductService_PurgeProduct(t *testing.T) {
+	productID := uuid.New()
+
+	t.Run("not_archived", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Once()
+
+		err := service.PurgeProduct(productID)
+		assert.ErrorIs(t, err, model.ErrProductNotArchived)
+		repo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
 	t.Run("success", func(t *testing.T) {
-		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{}, nil).Once()
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+		archivedAt := time.Now()
+
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID, ArchivedAt: &archivedAt}, nil).Once()
 		repo.On("Delete", productID).Return(nil).Once()
-		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductDeleted) bool {
-			return e.ProductID == productID
+
+		err := service.PurgeProduct(productID)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_DeductStock(t *testing.T) {
+	orderID := uuid.New()
+	productID := uuid.New()
+	sizeM := uuid.New()
+	sizeL := uuid.New()
+	newProduct := func() *model.Product {
+		return &model.Product{
+			ProductID: productID,
+			Name:      "T-Shirt",
+			Variants: []model.Variant{
+				{VariantID: sizeM, SKU: "TSHIRT-M", Stock: 5},
+				{VariantID: sizeL, SKU: "TSHIRT-L", Stock: 1},
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+
+		repo.On("StoreStockDeduction", orderID, productID).Return(true, nil).Once()
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(newProduct(), nil).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			// остаток не уходит ниже нуля, даже если productservice узнал о заказе позже изменения остатка
+			return p.Variants[0].Stock == 3 && p.Variants[1].Stock == 0
 		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUpdated) bool {
+			return e.ProductID == productID && (*e.UpdatedFields.Variants)[0].Stock == 3
+		})).Return(nil).Once()
+
+		err := service.DeductStock(orderID, productID, map[uuid.UUID]int64{sizeM: 2, sizeL: 3})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("already_deducted", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+
+		repo.On("StoreStockDeduction", orderID, productID).Return(false, nil).Once()
+
+		err := service.DeductStock(orderID, productID, map[uuid.UUID]int64{sizeM: 2})
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("purged_product", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+
+		repo.On("StoreStockDeduction", orderID, productID).Return(true, nil).Once()
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(nil, model.ErrProductNotFound).Once()
+
+		err := service.DeductStock(orderID, productID, map[uuid.UUID]int64{sizeM: 2})
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+}
+
+func TestProductService_Variants(t *testing.T) {
+	productID := uuid.New()
+	variantID := uuid.New()
+	newVariantID := uuid.New()
+	existing := model.Variant{VariantID: variantID, SKU: "TSHIRT-M", Attributes: map[string]string{"size": "M"}, Price: 1000, Stock: 5}
+
+	t.Run("create_with_variants", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+		name := "T-Shirt"
+		normalizedName := "t-shirt"
+		slug := "t-shirt"
+		sku := "TSHIRT-L"
+
+		repo.On("Find", model.FindSpec{NormalizedName: &normalizedName}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("NextID").Return(productID, nil).Once()
+		repo.On("Find", model.FindSpec{Slug: &slug}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("NextID").Return(newVariantID, nil).Once()
+		repo.On("Find", model.FindSpec{SKU: &sku}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return len(p.Variants) == 1 && p.Variants[0].VariantID == newVariantID && p.Variants[0].SKU == sku
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductCreated) bool {
+			return len(e.Variants) == 1 && e.Variants[0].VariantID == newVariantID
+		})).Return(nil).Once()
+
+		_, err := service.CreateProduct(name, 900, "", nil, []model.Variant{{SKU: sku, Price: 1200}})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("replace_variants", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+		sku := "TSHIRT-XL"
+
+		product := &model.Product{ProductID: productID, Name: "T-Shirt", Price: 900, Variants: []model.Variant{existing}}
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
+		repo.On("NextID").Return(newVariantID, nil).Once()
+		repo.On("Find", model.FindSpec{SKU: &sku}).Return(nil, model.ErrProductNotFound).Once()
+		repo.On("Store", mock.MatchedBy(func(p model.Product) bool {
+			return len(p.Variants) == 2 && p.Variants[0].Price == 1100 && p.Variants[1].VariantID == newVariantID
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.ProductUpdated) bool {
+			return e.UpdatedFields.Variants != nil && len(*e.UpdatedFields.Variants) == 2
+		})).Return(nil).Once()
+
+		changed := existing
+		changed.Price = 1100
+		err := service.UpdateProduct(productID, "T-Shirt", 900, "", nil, []model.Variant{changed, {SKU: sku, Price: 1300}})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("unknown_variant", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+
+		product := &model.Product{ProductID: productID, Name: "T-Shirt", Variants: []model.Variant{existing}}
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
+
+		err := service.UpdateProduct(productID, "T-Shirt", 900, "", nil, []model.Variant{{VariantID: uuid.New(), SKU: "OTHER"}})
+		assert.ErrorIs(t, err, model.ErrVariantNotFound)
+	})
+
+	t.Run("sku_used_by_other_product", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		service := NewProductService(repo, new(MockEventDispatcher))
+		sku := "MUG-1"
+
+		product := &model.Product{ProductID: productID, Name: "T-Shirt"}
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
+		repo.On("NextID").Return(newVariantID, nil).Once()
+		repo.On("Find", model.FindSpec{SKU: &sku}).Return(&model.Product{ProductID: uuid.New()}, nil).Once()
+
+		err := service.UpdateProduct(productID, "T-Shirt", 900, "", nil, []model.Variant{{SKU: sku}})
+		assert.ErrorIs(t, err, model.ErrVariantSKUAlreadyUsed)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		repo := new(MockProductRepository)
+		dispatcher := new(MockEventDispatcher)
+		service := NewProductService(repo, dispatcher)
+
+		product := &model.Product{ProductID: productID, Name: "T-Shirt", Price: 900, Variants: []model.Variant{existing}}
+		repo.On("Find", model.FindSpec{ProductID: &productID}).Return(product, nil).Once()
 
-		err := service.DeleteProduct(productID)
+		err := service.UpdateProduct(productID, "T-Shirt", 900, "", nil, []model.Variant{existing})
 		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
 	})
 }
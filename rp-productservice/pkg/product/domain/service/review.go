@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type ReviewService interface {
+	// CreateReview создает отзыв на модерации. Отзыв можно оставить один раз и только на купленный товар
+	CreateReview(productID, authorID uuid.UUID, authorName string, rating int, text string) (uuid.UUID, error)
+	// ModerateReview одобряет или отклоняет отзыв, в том числе уже промодерированный
+	ModerateReview(reviewID uuid.UUID, status model.ReviewStatus) error
+	RecordPurchase(purchase model.Purchase) error
+	// EraseAuthorData удаляет отзывы и покупки пользователя, рейтинги товаров пересчитываются
+	EraseAuthorData(authorID uuid.UUID) error
+}
+
+func NewReviewService(
+	productRepository model.ProductRepository,
+	reviewRepository model.ReviewRepository,
+	purchaseRepository model.PurchaseRepository,
+) ReviewService {
+	return &reviewService{
+		productRepository:  productRepository,
+		reviewRepository:   reviewRepository,
+		purchaseRepository: purchaseRepository,
+	}
+}
+
+type reviewService struct {
+	productRepository  model.ProductRepository
+	reviewRepository   model.ReviewRepository
+	purchaseRepository model.PurchaseRepository
+}
+
+func (s *reviewService) CreateReview(
+	productID, authorID uuid.UUID,
+	authorName string,
+	rating int,
+	text string,
+) (uuid.UUID, error) {
+	if rating < model.MinRating || rating > model.MaxRating {
+		return uuid.Nil, model.ErrInvalidRating
+	}
+
+	_, err := s.productRepository.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	purchased, err := s.purchaseRepository.Exists(authorID, productID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !purchased {
+		return uuid.Nil, model.ErrProductNotPurchased
+	}
+
+	_, err = s.reviewRepository.FindByProductAndAuthor(productID, authorID)
+	if err == nil {
+		return uuid.Nil, model.ErrReviewAlreadyExists
+	}
+	if !errors.Is(err, model.ErrReviewNotFound) {
+		return uuid.Nil, err
+	}
+
+	reviewID, err := s.reviewRepository.NextID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	currentTime := time.Now()
+	return reviewID, s.reviewRepository.Store(model.Review{
+		ReviewID:   reviewID,
+		ProductID:  productID,
+		AuthorID:   authorID,
+		AuthorName: authorName,
+		Rating:     rating,
+		Text:       text,
+		Status:     model.ReviewPending,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	})
+}
+
+func (s *reviewService) ModerateReview(reviewID uuid.UUID, status model.ReviewStatus) error {
+	if status != model.ReviewApproved && status != model.ReviewRejected {
+		return model.ErrInvalidReviewStatus
+	}
+
+	review, err := s.reviewRepository.Find(reviewID)
+	if err != nil {
+		return err
+	}
+	if review.Status == status {
+		return nil
+	}
+
+	review.Status = status
+	review.UpdatedAt = time.Now()
+	return s.reviewRepository.Store(*review)
+}
+
+func (s *reviewService) RecordPurchase(purchase model.Purchase) error {
+	return s.purchaseRepository.Store(purchase)
+}
+
+func (s *reviewService) EraseAuthorData(authorID uuid.UUID) error {
+	reviews, err := s.reviewRepository.FindByAuthor(authorID)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		err = s.reviewRepository.Delete(review.ReviewID)
+		if err != nil {
+			return err
+		}
+	}
+	err = s.reviewRepository.ForgetAuthor(authorID)
+	if err != nil {
+		return err
+	}
+	return s.purchaseRepository.DeleteByUser(authorID)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type MockReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockReviewRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockReviewRepository) Store(review model.Review) error {
+	return m.Called(review).Error(0)
+}
+
+func (m *MockReviewRepository) Find(reviewID uuid.UUID) (*model.Review, error) {
+	args := m.Called(reviewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Review), args.Error(1)
+}
+
+func (m *MockReviewRepository) FindByProductAndAuthor(productID, authorID uuid.UUID) (*model.Review, error) {
+	args := m.Called(productID, authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Review), args.Error(1)
+}
+
+func (m *MockReviewRepository) FindByAuthor(authorID uuid.UUID) ([]model.Review, error) {
+	args := m.Called(authorID)
+	return args.Get(0).([]model.Review), args.Error(1)
+}
+
+func (m *MockReviewRepository) Delete(reviewID uuid.UUID) error {
+	return m.Called(reviewID).Error(0)
+}
+
+func (m *MockReviewRepository) ForgetAuthor(authorID uuid.UUID) error {
+	return m.Called(authorID).Error(0)
+}
+
+type MockPurchaseRepository struct {
+	mock.Mock
+}
+
+func (m *MockPurchaseRepository) Store(purchase model.Purchase) error {
+	return m.Called(purchase).Error(0)
+}
+
+func (m *MockPurchaseRepository) Exists(userID, productID uuid.UUID) (bool, error) {
+	args := m.Called(userID, productID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPurchaseRepository) DeleteByUser(userID uuid.UUID) error {
+	return m.Called(userID).Error(0)
+}
+
+func TestReviewService_CreateReview(t *testing.T) {
+	productID := uuid.New()
+	authorID := uuid.New()
+
+	newService := func(purchased bool) (ReviewService, *MockReviewRepository) {
+		products := new(MockProductRepository)
+		reviews := new(MockReviewRepository)
+		purchases := new(MockPurchaseRepository)
+		products.On("Find", model.FindSpec{ProductID: &productID}).Return(&model.Product{ProductID: productID}, nil).Maybe()
+		purchases.On("Exists", authorID, productID).Return(purchased, nil).Maybe()
+		return NewReviewService(products, reviews, purchases), reviews
+	}
+
+	t.Run("invalid_rating", func(t *testing.T) {
+		for _, rating := range []int{0, 6} {
+			service, _ := newService(true)
+
+			_, err := service.CreateReview(productID, authorID, "Ann", rating, "")
+			assert.ErrorIs(t, err, model.ErrInvalidRating)
+		}
+	})
+
+	t.Run("not_purchased", func(t *testing.T) {
+		service, reviews := newService(false)
+
+		_, err := service.CreateReview(productID, authorID, "Ann", 5, "Great")
+		assert.ErrorIs(t, err, model.ErrProductNotPurchased)
+		reviews.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("already_reviewed", func(t *testing.T) {
+		service, reviews := newService(true)
+		reviews.On("FindByProductAndAuthor", productID, authorID).Return(&model.Review{}, nil).Once()
+
+		_, err := service.CreateReview(productID, authorID, "Ann", 5, "Great")
+		assert.ErrorIs(t, err, model.ErrReviewAlreadyExists)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		service, reviews := newService(true)
+		reviewID := uuid.New()
+		reviews.On("FindByProductAndAuthor", productID, authorID).Return(nil, model.ErrReviewNotFound).Once()
+		reviews.On("NextID").Return(reviewID, nil).Once()
+		reviews.On("Store", mock.MatchedBy(func(r model.Review) bool {
+			return r.ReviewID == reviewID && r.Rating == 4 && r.Status == model.ReviewPending && r.AuthorName == "Ann"
+		})).Return(nil).Once()
+
+		id, err := service.CreateReview(productID, authorID, "Ann", 4, "Good")
+		assert.NoError(t, err)
+		assert.Equal(t, reviewID, id)
+		reviews.AssertExpectations(t)
+	})
+}
+
+func TestReviewService_ModerateReview(t *testing.T) {
+	reviewID := uuid.New()
+
+	t.Run("invalid_status", func(t *testing.T) {
+		service := NewReviewService(new(MockProductRepository), new(MockReviewRepository), new(MockPurchaseRepository))
+
+		err := service.ModerateReview(reviewID, model.ReviewPending)
+		assert.ErrorIs(t, err, model.ErrInvalidReviewStatus)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		reviews := new(MockReviewRepository)
+		service := NewReviewService(new(MockProductRepository), reviews, new(MockPurchaseRepository))
+		reviews.On("Find", reviewID).Return(&model.Review{ReviewID: reviewID, Status: model.ReviewPending}, nil).Once()
+		reviews.On("Store", mock.MatchedBy(func(r model.Review) bool {
+			return r.Status == model.ReviewApproved
+		})).Return(nil).Once()
+
+		assert.NoError(t, service.ModerateReview(reviewID, model.ReviewApproved))
+		reviews.AssertExpectations(t)
+	})
+
+	t.Run("same_status", func(t *testing.T) {
+		reviews := new(MockReviewRepository)
+		service := NewReviewService(new(MockProductRepository), reviews, new(MockPurchaseRepository))
+		reviews.On("Find", reviewID).Return(&model.Review{ReviewID: reviewID, Status: model.ReviewRejected}, nil).Once()
+
+		assert.NoError(t, service.ModerateReview(reviewID, model.ReviewRejected))
+		reviews.AssertNotCalled(t, "Store", mock.Anything)
+	})
+}
+
+func TestReviewService_EraseAuthorData(t *testing.T) {
+	authorID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	reviews := new(MockReviewRepository)
+	purchases := new(MockPurchaseRepository)
+	service := NewReviewService(new(MockProductRepository), reviews, purchases)
+	reviews.On("FindByAuthor", authorID).Return([]model.Review{{ReviewID: first}, {ReviewID: second}}, nil).Once()
+	reviews.On("Delete", first).Return(nil).Once()
+	reviews.On("Delete", second).Return(nil).Once()
+	reviews.On("ForgetAuthor", authorID).Return(nil).Once()
+	purchases.On("DeleteByUser", authorID).Return(nil).Once()
+
+	assert.NoError(t, service.EraseAuthorData(authorID))
+	reviews.AssertExpectations(t)
+	purchases.AssertExpectations(t)
+}
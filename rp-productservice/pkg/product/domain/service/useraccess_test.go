@@ -0,0 +1,126 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"productservice/pkg/product/domain/model"
+)
+
+type MockUserAccessRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserAccessRepository) Store(access model.UserAccess) error {
+	args := m.Called(access)
+	return args.Error(0)
+}
+
+func (m *MockUserAccessRepository) Find(userID uuid.UUID) (*model.UserAccess, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UserAccess), args.Error(1)
+}
+
+func (m *MockUserAccessRepository) Delete(userID uuid.UUID) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func TestUserAccessService_Authorize(t *testing.T) {
+	userID := uuid.New()
+	permission := model.PermissionManageProducts
+
+	tests := []struct {
+		name   string
+		access *model.UserAccess
+		err    error
+	}{
+		{
+			name:   "granted",
+			access: &model.UserAccess{UserID: userID, Active: true, Permissions: []model.Permission{permission}},
+		},
+		{
+			name:   "no_permission",
+			access: &model.UserAccess{UserID: userID, Active: true, Permissions: []model.Permission{"other.manage"}},
+			err:    model.ErrPermissionDenied,
+		},
+		{
+			name:   "inactive",
+			access: &model.UserAccess{UserID: userID, Permissions: []model.Permission{permission}},
+			err:    model.ErrPermissionDenied,
+		},
+		{
+			name: "unknown_user",
+			err:  model.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUserAccessRepository)
+			if tt.access != nil {
+				repo.On("Find", userID).Return(tt.access, nil)
+			} else {
+				repo.On("Find", userID).Return(nil, model.ErrUserAccessNotFound)
+			}
+
+			err := NewUserAccessService(repo).Authorize(userID, permission)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserAccessService_OutOfOrderEvents(t *testing.T) {
+	userID := uuid.New()
+	revokedAt := time.Now()
+	grantedAt := revokedAt.Add(-time.Minute)
+
+	t.Run("stale_grant_ignored", func(t *testing.T) {
+		repo := new(MockUserAccessRepository)
+		repo.On("Find", userID).Return(&model.UserAccess{UserID: userID, Active: true, PermissionsUpdatedAt: revokedAt}, nil)
+
+		err := NewUserAccessService(repo).SetPermissions(userID, []model.Permission{model.PermissionManageProducts}, grantedAt)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "Store", mock.Anything)
+	})
+
+	t.Run("status_does_not_depend_on_permissions_time", func(t *testing.T) {
+		// статус старше прав, но сам статус еще не менялся: его нужно применить
+		repo := new(MockUserAccessRepository)
+		repo.On("Find", userID).Return(&model.UserAccess{UserID: userID, PermissionsUpdatedAt: revokedAt}, nil)
+		repo.On("Store", model.UserAccess{
+			UserID:               userID,
+			PermissionsUpdatedAt: revokedAt,
+			Active:               true,
+			StatusUpdatedAt:      grantedAt,
+		}).Return(nil).Once()
+
+		err := NewUserAccessService(repo).SetStatus(userID, true, grantedAt)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("first_event", func(t *testing.T) {
+		repo := new(MockUserAccessRepository)
+		repo.On("Find", userID).Return(nil, model.ErrUserAccessNotFound)
+		repo.On("Store", model.UserAccess{
+			UserID:               userID,
+			Permissions:          []model.Permission{model.PermissionManageProducts},
+			PermissionsUpdatedAt: grantedAt,
+		}).Return(nil).Once()
+
+		err := NewUserAccessService(repo).SetPermissions(userID, []model.Permission{model.PermissionManageProducts}, grantedAt)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,96 @@
+package blobstore
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/application/service"
+)
+
+var errInvalidKey = errors.New("invalid blob key")
+
+// NewLocalBlobStore хранит файлы в каталоге root, ключ - относительный путь внутри него
+func NewLocalBlobStore(root string) (service.BlobStore, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = os.MkdirAll(root, 0o755)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &localBlobStore{root: root}, nil
+}
+
+type localBlobStore struct {
+	root string
+}
+
+func (s *localBlobStore) Put(_ context.Context, key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	// пишем во временный файл рядом с целевым, чтобы недокачанный файл никогда не был виден по ключу
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	size, err := io.Copy(file, r)
+	if err != nil {
+		_ = file.Close()
+		return 0, errors.WithStack(err)
+	}
+	err = file.Close()
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return size, errors.WithStack(os.Rename(file.Name(), path))
+}
+
+func (s *localBlobStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return file, nil
+}
+
+func (s *localBlobStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// path не выпускает ключ за пределы root
+func (s *localBlobStore) path(key string) (string, error) {
+	path := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, s.root+string(filepath.Separator)) {
+		return "", errors.WithStack(errInvalidKey)
+	}
+	return path, nil
+}
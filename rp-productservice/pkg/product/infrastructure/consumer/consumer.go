@@ -0,0 +1,170 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/amqp"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+	appservice "productservice/pkg/product/application/service"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+// errProcessed - amqp.Consumer подтверждает сообщение, только если обработчик вернул ошибку,
+// а на nil возвращает его в очередь. Поэтому успешная обработка и неисправимые сообщения возвращают ошибку
+var errProcessed = errors.New("event processed")
+
+type EventConsumer struct {
+	stockService      appservice.StockService
+	reviewService     appservice.ReviewService
+	userAccessService appservice.UserAccessService
+	logger            logging.Logger
+}
+
+func NewEventConsumer(
+	stockService appservice.StockService,
+	reviewService appservice.ReviewService,
+	userAccessService appservice.UserAccessService,
+	logger logging.Logger,
+) *EventConsumer {
+	return &EventConsumer{
+		stockService:      stockService,
+		reviewService:     reviewService,
+		userAccessService: userAccessService,
+		logger:            logger,
+	}
+}
+
+func (c *EventConsumer) Handler() amqp.Handler {
+	return c.handle
+}
+
+func (c *EventConsumer) handle(ctx context.Context, delivery amqp.Delivery) (err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if !errors.Is(err, errProcessed) {
+			status = "error"
+		}
+		metrics.EventDuration.WithLabelValues(delivery.Type, status).Observe(time.Since(start).Seconds())
+	}()
+
+	l := c.logger.WithField("event_type", delivery.Type)
+
+	switch delivery.Type {
+	case "order_paid":
+		var event orderPaid
+		if err = json.Unmarshal(delivery.Body, &event); err != nil {
+			l.Error(err, "failed to unmarshal order event")
+			return errProcessed
+		}
+		purchase, parseErr := event.purchase()
+		if parseErr != nil {
+			l.Error(parseErr, "invalid id in order event")
+			return errProcessed
+		}
+		deduction, parseErr := event.stockDeduction()
+		if parseErr != nil {
+			l.Error(parseErr, "invalid id in order event")
+			return errProcessed
+		}
+
+		storeErr := c.reviewService.RecordPurchase(ctx, purchase)
+		if storeErr != nil {
+			// сбой базы временный, сообщение вернется в очередь
+			l.Error(storeErr, "failed to record purchase")
+			return nil
+		}
+		// повтор после сбоя безопасен: покупка и списание по заказу запоминаются и второй раз не применяются
+		storeErr = c.stockService.DeductStock(ctx, deduction)
+		if storeErr != nil {
+			l.Error(storeErr, "failed to deduct stock")
+			return nil
+		}
+		l.Info("purchase recorded successfully")
+		return errProcessed
+
+	case "user_created", "user_updated", "user_deleted", "user_restored":
+		syncErr := syncUserAccess(ctx, c.userAccessService, delivery.Type, delivery.Body)
+		if errors.Is(syncErr, errInvalidEvent) {
+			l.Error(syncErr, "failed to parse user event")
+			return errProcessed
+		}
+		if syncErr != nil {
+			l.Error(syncErr, "failed to sync user access")
+			return nil
+		}
+		return errProcessed
+
+	default:
+		return errProcessed
+	}
+}
+
+type orderPaid struct {
+	OrderID string `json:"order_id"`
+	UserID  string `json:"user_id"`
+	Items   []struct {
+		ProductID string  `json:"product_id"`
+		VariantID *string `json:"variant_id"`
+		Quantity  int64   `json:"quantity"`
+	} `json:"items"`
+	PaidAt int64 `json:"paid_at"`
+}
+
+func (e orderPaid) purchase() (appmodel.Purchase, error) {
+	orderID, err := uuid.Parse(e.OrderID)
+	if err != nil {
+		return appmodel.Purchase{}, err
+	}
+	userID, err := uuid.Parse(e.UserID)
+	if err != nil {
+		return appmodel.Purchase{}, err
+	}
+	purchase := appmodel.Purchase{
+		OrderID:     orderID,
+		UserID:      userID,
+		PurchasedAt: time.Unix(e.PaidAt, 0),
+	}
+	for _, item := range e.Items {
+		productID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return appmodel.Purchase{}, err
+		}
+		purchase.ProductIDs = append(purchase.ProductIDs, productID)
+	}
+	return purchase, nil
+}
+
+// stockDeduction берет только позиции с вариантами: остаток есть только у вариантов
+func (e orderPaid) stockDeduction() (appmodel.StockDeduction, error) {
+	orderID, err := uuid.Parse(e.OrderID)
+	if err != nil {
+		return appmodel.StockDeduction{}, err
+	}
+	deduction := appmodel.StockDeduction{OrderID: orderID}
+	for _, item := range e.Items {
+		if item.VariantID == nil {
+			continue
+		}
+		productID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			return appmodel.StockDeduction{}, err
+		}
+		variantID, err := uuid.Parse(*item.VariantID)
+		if err != nil {
+			return appmodel.StockDeduction{}, err
+		}
+		deduction.Items = append(deduction.Items, appmodel.StockDeductionItem{
+			ProductID: productID,
+			VariantID: variantID,
+			Quantity:  item.Quantity,
+		})
+	}
+	return deduction, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"productservice/pkg/product/domain/model"
@@ -23,6 +24,8 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 			Name:        e.Name,
 			Description: e.Description,
 			Price:       e.Price,
+			Currency:    e.Currency,
+			Variants:    toVariants(e.Variants),
 			CreatedAt:   e.CreatedAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
@@ -40,13 +43,35 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 		if e.UpdatedFields.Price != nil {
 			ie.UpdatedFields.Price = e.UpdatedFields.Price
 		}
+		if e.UpdatedFields.Currency != nil {
+			ie.UpdatedFields.Currency = e.UpdatedFields.Currency
+		}
+		if e.UpdatedFields.Variants != nil {
+			variants := toVariants(*e.UpdatedFields.Variants)
+			ie.UpdatedFields.Variants = &variants
+		}
 		b, err := json.Marshal(ie)
 		return string(b), errors.WithStack(err)
 
-	case *model.ProductDeleted:
-		b, err := json.Marshal(ProductDeleted{
-			ProductID: e.ProductID.String(),
-			DeletedAt: e.DeletedAt.Unix(),
+	case *model.ProductArchived:
+		b, err := json.Marshal(ProductArchived{
+			ProductID:  e.ProductID.String(),
+			ArchivedAt: e.ArchivedAt.Unix(),
+		})
+		return string(b), errors.WithStack(err)
+	case *model.ProductUnarchived:
+		b, err := json.Marshal(ProductUnarchived{
+			ProductID:    e.ProductID.String(),
+			UnarchivedAt: e.UnarchivedAt.Unix(),
+		})
+		return string(b), errors.WithStack(err)
+	case *model.ProductCategoriesChanged:
+		b, err := json.Marshal(ProductCategoriesChanged{
+			ProductID:          e.ProductID.String(),
+			CategoryIDs:        idsToStrings(e.CategoryIDs),
+			AddedCategoryIDs:   idsToStrings(e.AddedCategoryIDs),
+			RemovedCategoryIDs: idsToStrings(e.RemovedCategoryIDs),
+			ChangedAt:          e.ChangedAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
 	default:
@@ -55,24 +80,77 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 }
 
 type ProductCreated struct {
-	ProductID   string  `json:"product_id"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	Price       int64   `json:"price"`
-	CreatedAt   int64   `json:"created_at"`
+	ProductID   string    `json:"product_id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description,omitempty"`
+	Price       int64     `json:"price"`
+	Currency    string    `json:"currency"`
+	Variants    []Variant `json:"variants"`
+	CreatedAt   int64     `json:"created_at"`
+}
+
+type Variant struct {
+	VariantID  string            `json:"variant_id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	Price      int64             `json:"price"`
+	Stock      int64             `json:"stock"`
 }
 
 type ProductUpdated struct {
 	ProductID     string `json:"product_id"`
 	UpdatedFields struct {
-		Name        *string `json:"name,omitempty"`
-		Description *string `json:"description,omitempty"`
-		Price       *int64  `json:"price,omitempty"`
+		Name        *string    `json:"name,omitempty"`
+		Description *string    `json:"description,omitempty"`
+		Price       *int64     `json:"price,omitempty"`
+		Currency    *string    `json:"currency,omitempty"`
+		Variants    *[]Variant `json:"variants,omitempty"`
 	} `json:"updated_fields,omitempty"`
 	UpdatedAt int64 `json:"updated_at,omitempty"`
 }
 
-type ProductDeleted struct {
-	ProductID string `json:"product_id"`
-	DeletedAt int64  `json:"deleted_at"`
+type ProductArchived struct {
+	ProductID  string `json:"product_id"`
+	ArchivedAt int64  `json:"archived_at"`
+}
+
+type ProductUnarchived struct {
+	ProductID    string `json:"product_id"`
+	UnarchivedAt int64  `json:"unarchived_at"`
+}
+
+type ProductCategoriesChanged struct {
+	ProductID          string   `json:"product_id"`
+	CategoryIDs        []string `json:"category_ids"`
+	AddedCategoryIDs   []string `json:"added_category_ids"`
+	RemovedCategoryIDs []string `json:"removed_category_ids"`
+	ChangedAt          int64    `json:"changed_at"`
+}
+
+// idsToStrings всегда возвращает массив, пустой список категорий не должен превращаться в null
+func idsToStrings(ids []uuid.UUID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
+
+// toVariants всегда возвращает массив: пустой список вариантов означает, что их удалили
+func toVariants(variants []model.Variant) []Variant {
+	result := make([]Variant, 0, len(variants))
+	for _, variant := range variants {
+		attributes := variant.Attributes
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		result = append(result, Variant{
+			VariantID:  variant.VariantID.String(),
+			SKU:        variant.SKU,
+			Attributes: attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+	return result
 }
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400002(client mysql.ClientContext) migrator.Migration {
+	return &version1792400002{
+		client: client,
+	}
+}
+
+type version1792400002 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400002) Version() int64 {
+	return 1792400002
+}
+
+func (v version1792400002) Description() string {
+	return "Create 'audit_log' and 'audit_log_head' tables"
+}
+
+func (v version1792400002) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE audit_log
+		(
+		    record_id      BIGINT       NOT NULL AUTO_INCREMENT,
+		    actor          VARCHAR(255) NOT NULL,
+		    method         VARCHAR(255) NOT NULL,
+		    entity_type    VARCHAR(64)  NOT NULL,
+		    entity_id      VARCHAR(64)  NOT NULL,
+		    before_value   MEDIUMTEXT,
+		    after_value    MEDIUMTEXT,
+		    correlation_id VARCHAR(255) NOT NULL,
+		    created_at     DATETIME(6)  NOT NULL,
+		    prev_hash      CHAR(64)     NOT NULL,
+		    hash           CHAR(64)     NOT NULL,
+		    PRIMARY KEY (record_id),
+		    INDEX entity_idx (entity_type, entity_id, record_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// одна строка с хешем последней записи, через нее сериализуется запись в журнал
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE audit_log_head
+		(
+		    id   TINYINT  NOT NULL,
+		    hash CHAR(64) NOT NULL,
+		    PRIMARY KEY (id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `INSERT INTO audit_log_head (id, hash) VALUES (1, '')`)
+	return errors.WithStack(err)
+}
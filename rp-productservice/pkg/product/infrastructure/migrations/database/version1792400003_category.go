@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400003(client mysql.ClientContext) migrator.Migration {
+	return &version1792400003{
+		client: client,
+	}
+}
+
+type version1792400003 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400003) Version() int64 {
+	return 1792400003
+}
+
+func (v version1792400003) Description() string {
+	return "Create 'category' and 'product_category' tables"
+}
+
+func (v version1792400003) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE category
+		(
+		    category_id VARCHAR(64)  NOT NULL,
+		    parent_id   VARCHAR(64),
+		    name        VARCHAR(255) NOT NULL,
+		    created_at  DATETIME     NOT NULL,
+		    updated_at  DATETIME     NOT NULL,
+		    PRIMARY KEY (category_id),
+		    INDEX parent_idx (parent_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE product_category
+		(
+		    product_id  VARCHAR(64) NOT NULL,
+		    category_id VARCHAR(64) NOT NULL,
+		    PRIMARY KEY (product_id, category_id),
+		    INDEX category_idx (category_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400004(client mysql.ClientContext) migrator.Migration {
+	return &version1792400004{
+		client: client,
+	}
+}
+
+type version1792400004 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400004) Version() int64 {
+	return 1792400004
+}
+
+func (v version1792400004) Description() string {
+	return "Create 'product_variant' table"
+}
+
+func (v version1792400004) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE product_variant
+		(
+		    variant_id VARCHAR(64)  NOT NULL,
+		    product_id VARCHAR(64)  NOT NULL,
+		    position   INT          NOT NULL,
+		    sku        VARCHAR(64)  NOT NULL,
+		    attributes JSON         NOT NULL,
+		    price      BIGINT       NOT NULL,
+		    stock      BIGINT       NOT NULL,
+		    PRIMARY KEY (variant_id),
+		    UNIQUE INDEX sku_uidx (sku),
+		    INDEX product_idx (product_id, position)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
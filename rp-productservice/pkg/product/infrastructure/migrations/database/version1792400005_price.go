@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400005(client mysql.ClientContext) migrator.Migration {
+	return &version1792400005{
+		client: client,
+	}
+}
+
+type version1792400005 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400005) Version() int64 {
+	return 1792400005
+}
+
+func (v version1792400005) Description() string {
+	return "Create 'price_history' and 'scheduled_price' tables"
+}
+
+func (v version1792400005) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE price_history
+		(
+		    id         BIGINT      NOT NULL AUTO_INCREMENT,
+		    product_id VARCHAR(64) NOT NULL,
+		    variant_id VARCHAR(64),
+		    price      BIGINT      NOT NULL,
+		    changed_at DATETIME    NOT NULL,
+		    PRIMARY KEY (id),
+		    INDEX product_idx (product_id, changed_at)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// история начинается с текущих цен, иначе первая запись потеряет предыдущее значение
+	_, err = v.client.ExecContext(ctx, `
+		INSERT INTO price_history (product_id, variant_id, price, changed_at)
+		SELECT product_id, NULL, price, updated_at FROM product
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = v.client.ExecContext(ctx, `
+		INSERT INTO price_history (product_id, variant_id, price, changed_at)
+		SELECT pv.product_id, pv.variant_id, pv.price, p.updated_at
+		FROM product_variant pv
+		    INNER JOIN product p ON p.product_id = pv.product_id
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE scheduled_price
+		(
+		    scheduled_price_id VARCHAR(64) NOT NULL,
+		    product_id         VARCHAR(64) NOT NULL,
+		    variant_id         VARCHAR(64),
+		    price              BIGINT      NOT NULL,
+		    previous_price     BIGINT,
+		    effective_from     DATETIME    NOT NULL,
+		    effective_until    DATETIME,
+		    status             INT         NOT NULL,
+		    created_at         DATETIME    NOT NULL,
+		    updated_at         DATETIME    NOT NULL,
+		    PRIMARY KEY (scheduled_price_id),
+		    INDEX product_idx (product_id),
+		    INDEX status_from_idx (status, effective_from),
+		    INDEX status_until_idx (status, effective_until)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400006(client mysql.ClientContext) migrator.Migration {
+	return &version1792400006{
+		client: client,
+	}
+}
+
+type version1792400006 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400006) Version() int64 {
+	return 1792400006
+}
+
+func (v version1792400006) Description() string {
+	return "Add 'archived_at' to 'product' table"
+}
+
+func (v version1792400006) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE product
+		    ADD COLUMN archived_at DATETIME NULL AFTER price
+	`)
+	return errors.WithStack(err)
+}
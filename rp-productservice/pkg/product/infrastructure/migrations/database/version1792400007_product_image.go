@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400007(client mysql.ClientContext) migrator.Migration {
+	return &version1792400007{
+		client: client,
+	}
+}
+
+type version1792400007 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400007) Version() int64 {
+	return 1792400007
+}
+
+func (v version1792400007) Description() string {
+	return "Create 'product_image' table"
+}
+
+func (v version1792400007) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE product_image
+		(
+		    image_id     VARCHAR(64)   NOT NULL,
+		    product_id   VARCHAR(64)   NOT NULL,
+		    url          VARCHAR(2048),
+		    object_key   VARCHAR(255),
+		    content_type VARCHAR(255)  NOT NULL DEFAULT '',
+		    size         BIGINT        NOT NULL DEFAULT 0,
+		    width        INT           NOT NULL,
+		    height       INT           NOT NULL,
+		    position     INT           NOT NULL,
+		    alt_text     VARCHAR(1024) NOT NULL DEFAULT '',
+		    created_at   DATETIME      NOT NULL,
+		    updated_at   DATETIME      NOT NULL,
+		    PRIMARY KEY (image_id),
+		    INDEX product_idx (product_id, position)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
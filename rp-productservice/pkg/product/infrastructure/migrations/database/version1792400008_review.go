@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400008(client mysql.ClientContext) migrator.Migration {
+	return &version1792400008{
+		client: client,
+	}
+}
+
+type version1792400008 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400008) Version() int64 {
+	return 1792400008
+}
+
+func (v version1792400008) Description() string {
+	return "Create 'review', 'product_rating' and 'purchase' tables"
+}
+
+func (v version1792400008) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE review
+		(
+		    review_id   VARCHAR(64)  NOT NULL,
+		    product_id  VARCHAR(64)  NOT NULL,
+		    author_id   VARCHAR(64)  NOT NULL,
+		    author_name VARCHAR(255) NOT NULL DEFAULT '',
+		    rating      TINYINT      NOT NULL,
+		    text        TEXT         NOT NULL,
+		    status      INT          NOT NULL,
+		    created_at  DATETIME     NOT NULL,
+		    updated_at  DATETIME     NOT NULL,
+		    PRIMARY KEY (review_id),
+		    UNIQUE INDEX product_author_idx (product_id, author_id),
+		    INDEX author_idx (author_id),
+		    INDEX status_idx (status, created_at)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE product_rating
+		(
+		    product_id     VARCHAR(64) NOT NULL,
+		    average_rating DOUBLE      NOT NULL,
+		    review_count   INT         NOT NULL,
+		    PRIMARY KEY (product_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE purchase
+		(
+		    order_id     VARCHAR(64) NOT NULL,
+		    product_id   VARCHAR(64) NOT NULL,
+		    user_id      VARCHAR(64) NOT NULL,
+		    purchased_at DATETIME    NOT NULL,
+		    PRIMARY KEY (order_id, product_id),
+		    INDEX user_product_idx (user_id, product_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+)
+
+func NewVersion1792400009(client mysql.ClientContext) migrator.Migration {
+	return &version1792400009{
+		client: client,
+	}
+}
+
+type version1792400009 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400009) Version() int64 {
+	return 1792400009
+}
+
+func (v version1792400009) Description() string {
+	return "Add 'normalized_name' and 'slug' to 'product' table"
+}
+
+func (v version1792400009) Up(ctx context.Context) error {
+	// utf8mb4_bin: нормализацию делает сервис, collation не должна склеивать, например, "е" и "ё"
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE product
+		    ADD COLUMN normalized_name VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL AFTER name,
+		    ADD COLUMN slug            VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NULL AFTER normalized_name,
+		    DROP INDEX product_name_uidx
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = v.fillNormalizedNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE product
+		    MODIFY normalized_name VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+		    MODIFY slug            VARCHAR(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+		    ADD UNIQUE INDEX product_normalized_name_uidx (normalized_name),
+		    ADD UNIQUE INDEX product_slug_uidx (slug)
+	`)
+	return errors.WithStack(err)
+}
+
+// fillNormalizedNames заполняет новые колонки у существующих товаров. Товары, чьи имена совпали
+// только после нормализации, получают normalized_name с суффиксом из product_id, чтобы индекс построился.
+// Store пересчитывает normalized_name только при смене имени, поэтому такие товары сохраняются как раньше
+func (v version1792400009) fillNormalizedNames(ctx context.Context) error {
+	var products []struct {
+		ProductID uuid.UUID `db:"product_id"`
+		Name      string    `db:"name"`
+	}
+	err := v.client.SelectContext(ctx, &products, `SELECT product_id, name FROM product ORDER BY created_at, product_id`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	usedNames := make(map[string]struct{}, len(products))
+	usedSlugs := make(map[string]struct{}, len(products))
+	for _, product := range products {
+		normalizedName := model.NormalizeProductName(product.Name)
+		if _, ok := usedNames[normalizedName]; ok {
+			normalizedName = fmt.Sprintf("%s #%s", normalizedName, product.ProductID)
+		}
+		usedNames[normalizedName] = struct{}{}
+
+		base := model.ProductSlug(product.Name)
+		slug := base
+		for i := 2; ; i++ {
+			if _, ok := usedSlugs[slug]; !ok {
+				break
+			}
+			slug = fmt.Sprintf("%s-%d", base, i)
+		}
+		usedSlugs[slug] = struct{}{}
+
+		_, err = v.client.ExecContext(ctx,
+			`UPDATE product SET normalized_name = ?, slug = ? WHERE product_id = ?`,
+			normalizedName, slug, product.ProductID,
+		)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
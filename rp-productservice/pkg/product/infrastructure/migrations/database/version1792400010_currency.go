@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400010(client mysql.ClientContext) migrator.Migration {
+	return &version1792400010{
+		client: client,
+	}
+}
+
+type version1792400010 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400010) Version() int64 {
+	return 1792400010
+}
+
+func (v version1792400010) Description() string {
+	return "Add 'currency' to 'product' and 'price_history' tables"
+}
+
+func (v version1792400010) Up(ctx context.Context) error {
+	// до появления валют все цены были в рублях
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE product
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' AFTER price
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		ALTER TABLE price_history
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' AFTER price
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400012(client mysql.ClientContext) migrator.Migration {
+	return &version1792400012{
+		client: client,
+	}
+}
+
+type version1792400012 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400012) Version() int64 {
+	return 1792400012
+}
+
+func (v version1792400012) Description() string {
+	return "Create 'stock_deduction' table"
+}
+
+func (v version1792400012) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE stock_deduction
+		(
+		    order_id   VARCHAR(64) NOT NULL,
+		    product_id VARCHAR(64) NOT NULL,
+		    PRIMARY KEY (order_id, product_id),
+		    INDEX product_idx (product_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
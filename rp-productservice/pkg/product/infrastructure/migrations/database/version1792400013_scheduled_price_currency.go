@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400013(client mysql.ClientContext) migrator.Migration {
+	return &version1792400013{
+		client: client,
+	}
+}
+
+type version1792400013 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400013) Version() int64 {
+	return 1792400013
+}
+
+func (v version1792400013) Description() string {
+	return "Add 'currency' to 'scheduled_price' table"
+}
+
+func (v version1792400013) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE scheduled_price
+		    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB' AFTER price
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// уже запланированные цены считаем заданными в текущей валюте товара
+	_, err = v.client.ExecContext(ctx, `
+		UPDATE scheduled_price sp
+		    INNER JOIN product p ON p.product_id = sp.product_id
+		SET sp.currency = p.currency
+	`)
+	return errors.WithStack(err)
+}
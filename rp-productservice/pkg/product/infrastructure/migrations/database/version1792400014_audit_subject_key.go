@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400014(client mysql.ClientContext) migrator.Migration {
+	return &version1792400014{
+		client: client,
+	}
+}
+
+type version1792400014 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400014) Version() int64 {
+	return 1792400014
+}
+
+func (v version1792400014) Description() string {
+	return "Create 'audit_subject_key' table"
+}
+
+func (v version1792400014) Up(ctx context.Context) error {
+	// записи журнала, сделанные до появления ключей, остаются захешированными с идентификатором сущности
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE audit_subject_key
+		(
+		    subject_id 1792400014ARCHAR(64)   NOT NULL,
+		    secret_key 1792400014ARBINARY(32) NOT NULL,
+		    PRIMARY KEY (subject_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/application/query"
+	"productservice/pkg/product/infrastructure/audit"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewAuditLogQueryService(client mysql.ClientContext) query.AuditLogQueryService {
+	return &auditLogQueryService{
+		client: client,
+	}
+}
+
+type auditLogQueryService struct {
+	client mysql.ClientContext
+}
+
+func (s *auditLogQueryService) FindAuditLog(ctx context.Context, entityType, entityID string) (_ []appmodel.AuditRecord, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_query", "audit_log", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var recordsData []struct {
+		RecordID      int64            `db:"record_id"`
+		Actor         string           `db:"actor"`
+		Method        string           `db:"method"`
+		EntityType    string           `db:"entity_type"`
+		EntityID      string           `db:"entity_id"`
+		Before        sql.Null[string] `db:"before_value"`
+		After         sql.Null[string] `db:"after_value"`
+		CorrelationID string           `db:"correlation_id"`
+		CreatedAt     time.Time        `db:"created_at"`
+		PrevHash      string           `db:"prev_hash"`
+		Hash          string           `db:"hash"`
+		PrecedingHash sql.Null[string] `db:"preceding_hash"`
+	}
+	err = s.client.SelectContext(
+		ctx,
+		&recordsData,
+		`
+	SELECT
+		a.record_id, a.actor, a.method, a.entity_type, a.entity_id, a.before_value, a.after_value,
+		a.correlation_id, a.created_at, a.prev_hash, a.hash,
+		(SELECT p.hash FROM audit_log p WHERE p.record_id < a.record_id ORDER BY p.record_id DESC LIMIT 1) AS preceding_hash
+	FROM audit_log a
+	WHERE a.entity_type = ? AND a.entity_id = ?
+	ORDER BY a.record_id
+	`,
+		entityType,
+		entityID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	records := make([]appmodel.AuditRecord, len(recordsData))
+	for i, data := range recordsData {
+		record := audit.Record{
+			Actor:         data.Actor,
+			Method:        data.Method,
+			EntityType:    data.EntityType,
+			EntityID:      data.EntityID,
+			Before:        fromSQLNull(data.Before),
+			After:         fromSQLNull(data.After),
+			CorrelationID: data.CorrelationID,
+			CreatedAt:     data.CreatedAt,
+			PrevHash:      data.PrevHash,
+		}
+		records[i] = appmodel.AuditRecord{
+			RecordID:      data.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt,
+			PrevHash:      record.PrevHash,
+			Hash:          data.Hash,
+			Valid:         audit.Verify(record, data.Hash, data.PrecedingHash.V),
+		}
+	}
+	return records, nil
+}
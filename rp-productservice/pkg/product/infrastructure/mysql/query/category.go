@@ -0,0 +1,154 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/application/query"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewCategoryQueryService(client mysql.ClientContext) query.CategoryQueryService {
+	return &categoryQueryService{
+		client: client,
+	}
+}
+
+type categoryQueryService struct {
+	client mysql.ClientContext
+}
+
+func (c *categoryQueryService) ListCategories(ctx context.Context) (_ []appmodel.Category, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var categoriesData []struct {
+		CategoryID uuid.UUID           `db:"category_id"`
+		ParentID   sql.Null[uuid.UUID] `db:"parent_id"`
+		Name       string              `db:"name"`
+	}
+	err = c.client.SelectContext(ctx, &categoriesData, `SELECT category_id, parent_id, name FROM category ORDER BY name, category_id`)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	categories := make([]appmodel.Category, 0, len(categoriesData))
+	for _, category := range categoriesData {
+		categories = append(categories, appmodel.Category{
+			CategoryID: category.CategoryID,
+			ParentID:   fromSQLNull(category.ParentID),
+			Name:       category.Name,
+		})
+	}
+	return categories, nil
+}
+
+func (c *categoryQueryService) ListCategoryProducts(
+	ctx context.Context,
+	categoryID uuid.UUID,
+	includeSubcategories bool,
+) (_ []appmodel.Product, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil && !errors.Is(err, model.ErrCategoryNotFound) {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "product_category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var exists bool
+	err = c.client.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM category WHERE category_id = ?)`, categoryID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !exists {
+		return nil, errors.WithStack(model.ErrCategoryNotFound)
+	}
+
+	// без подкатегорий рекурсивная часть просто не выполняется
+	subtreeQuery := `
+	WITH RECURSIVE subtree (category_id) AS (
+	    SELECT category_id FROM category WHERE category_id = ?
+	    UNION ALL
+	    SELECT c.category_id FROM category c JOIN subtree s ON c.parent_id = s.category_id WHERE ?
+	)`
+
+	var productsData []struct {
+		ProductID   uuid.UUID        `db:"product_id"`
+		Name        string           `db:"name"`
+		Slug        string           `db:"slug"`
+		Description sql.Null[string] `db:"description"`
+		Price       int64            `db:"price"`
+		Currency    string           `db:"currency"`
+		CategoryIDs sql.Null[string] `db:"category_ids"`
+	}
+	err = c.client.SelectContext(
+		ctx,
+		&productsData,
+		subtreeQuery+`
+	SELECT p.product_id, p.name, p.slug, p.description, p.price, p.currency, `+categoryIDsColumn+`
+	FROM product p
+	WHERE p.archived_at IS NULL AND p.product_id IN (
+	    SELECT pc.product_id FROM product_category pc JOIN subtree s ON pc.category_id = s.category_id
+	)
+	ORDER BY p.name
+	`,
+		categoryID,
+		includeSubcategories,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(productsData))
+	for _, product := range productsData {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	variants, err := findVariants(ctx, c.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	images, err := findImages(ctx, c.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := findRatings(ctx, c.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]appmodel.Product, 0, len(productsData))
+	for _, product := range productsData {
+		categoryIDs, err := splitIDs(product.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, appmodel.Product{
+			ProductID:   product.ProductID,
+			Name:        product.Name,
+			Slug:        product.Slug,
+			Description: fromSQLNull(product.Description),
+			Price:       product.Price,
+			Currency:    product.Currency,
+			CategoryIDs: categoryIDs,
+			Variants:    variants[product.ProductID],
+			Images:      images[product.ProductID],
+			Rating:      ratings[product.ProductID],
+		})
+	}
+	return products, nil
+}
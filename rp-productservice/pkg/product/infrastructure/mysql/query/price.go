@@ -0,0 +1,114 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/application/query"
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewPriceQueryService(client mysql.ClientContext) query.PriceQueryService {
+	return &priceQueryService{
+		client: client,
+	}
+}
+
+type priceQueryService struct {
+	client mysql.ClientContext
+}
+
+func (p *priceQueryService) GetPriceHistory(ctx context.Context, productID uuid.UUID) (_ *appmodel.PriceHistory, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "price_history", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var exists bool
+	err = p.client.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM product WHERE product_id = ?)`, productID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !exists {
+		return nil, errors.WithStack(model.ErrProductNotFound)
+	}
+
+	var recordsData []struct {
+		VariantID sql.Null[uuid.UUID] `db:"variant_id"`
+		Price     int64               `db:"price"`
+		Currency  string              `db:"currency"`
+		ChangedAt time.Time           `db:"changed_at"`
+	}
+	err = p.client.SelectContext(
+		ctx,
+		&recordsData,
+		`SELECT variant_id, price, currency, changed_at FROM price_history WHERE product_id = ? ORDER BY changed_at, id`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var scheduledPricesData []struct {
+		ScheduledPriceID uuid.UUID           `db:"scheduled_price_id"`
+		VariantID        sql.Null[uuid.UUID] `db:"variant_id"`
+		Price            int64               `db:"price"`
+		Currency         string              `db:"currency"`
+		PreviousPrice    sql.Null[int64]     `db:"previous_price"`
+		EffectiveFrom    time.Time           `db:"effective_from"`
+		EffectiveUntil   sql.Null[time.Time] `db:"effective_until"`
+		Status           int                 `db:"status"`
+	}
+	err = p.client.SelectContext(
+		ctx,
+		&scheduledPricesData,
+		`
+	SELECT scheduled_price_id, variant_id, price, currency, previous_price, effective_from, effective_until, status
+	FROM scheduled_price
+	WHERE product_id = ?
+	ORDER BY effective_from, scheduled_price_id
+	`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	history := &appmodel.PriceHistory{
+		Records:         make([]appmodel.PriceRecord, 0, len(recordsData)),
+		ScheduledPrices: make([]appmodel.ScheduledPrice, 0, len(scheduledPricesData)),
+	}
+	for _, record := range recordsData {
+		history.Records = append(history.Records, appmodel.PriceRecord{
+			VariantID: fromSQLNull(record.VariantID),
+			Price:     record.Price,
+			Currency:  record.Currency,
+			ChangedAt: record.ChangedAt,
+		})
+	}
+	for _, scheduledPrice := range scheduledPricesData {
+		history.ScheduledPrices = append(history.ScheduledPrices, appmodel.ScheduledPrice{
+			ScheduledPriceID: scheduledPrice.ScheduledPriceID,
+			ProductID:        productID,
+			VariantID:        fromSQLNull(scheduledPrice.VariantID),
+			Price:            scheduledPrice.Price,
+			Currency:         scheduledPrice.Currency,
+			PreviousPrice:    fromSQLNull(scheduledPrice.PreviousPrice),
+			EffectiveFrom:    scheduledPrice.EffectiveFrom,
+			EffectiveUntil:   fromSQLNull(scheduledPrice.EffectiveUntil),
+			Status:           appmodel.ScheduledPriceStatus(scheduledPrice.Status),
+		})
+	}
+	return history, nil
+}
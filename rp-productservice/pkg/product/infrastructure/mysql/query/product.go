@@ -3,6 +3,8 @@ package query
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"strings"
 	"time"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
@@ -25,7 +27,15 @@ type productQueryService struct {
 	client mysql.ClientContext
 }
 
-func (p *productQueryService) FindProduct(ctx context.Context, productID uuid.UUID) (_ *appmodel.Product, err error) {
+func (p *productQueryService) FindProduct(ctx context.Context, productID uuid.UUID) (*appmodel.Product, error) {
+	return p.findProduct(ctx, "product_id = ?", productID)
+}
+
+func (p *productQueryService) FindProductBySlug(ctx context.Context, slug string) (*appmodel.Product, error) {
+	return p.findProduct(ctx, "slug = ?", slug)
+}
+
+func (p *productQueryService) findProduct(ctx context.Context, condition string, arg interface{}) (_ *appmodel.Product, err error) {
 	start := time.Now()
 	defer func() {
 		status := "success"
@@ -36,17 +46,21 @@ func (p *productQueryService) FindProduct(ctx context.Context, productID uuid.UU
 	}()
 
 	product := struct {
-		ProductID   uuid.UUID        `db:"product_id"`
-		Name        string           `db:"name"`
-		Description sql.Null[string] `db:"description"`
-		Price       int64            `db:"price"`
+		ProductID   uuid.UUID           `db:"product_id"`
+		Name        string              `db:"name"`
+		Slug        string              `db:"slug"`
+		Description sql.Null[string]    `db:"description"`
+		Price       int64               `db:"price"`
+		Currency    string              `db:"currency"`
+		CategoryIDs sql.Null[string]    `db:"category_ids"`
+		ArchivedAt  sql.Null[time.Time] `db:"archived_at"`
 	}{}
 
 	err = p.client.GetContext(
 		ctx,
 		&product,
-		`SELECT product_id, name, description, price FROM product WHERE product_id = ?`,
-		productID,
+		`SELECT product_id, name, slug, description, price, currency, archived_at, `+categoryIDsColumn+` FROM product p WHERE `+condition,
+		arg,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -55,14 +69,282 @@ func (p *productQueryService) FindProduct(ctx context.Context, productID uuid.UU
 		return nil, errors.WithStack(err)
 	}
 
+	categoryIDs, err := splitIDs(product.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := findVariants(ctx, p.client, []uuid.UUID{product.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	images, err := findImages(ctx, p.client, []uuid.UUID{product.ProductID})
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := findRatings(ctx, p.client, []uuid.UUID{product.ProductID})
+	if err != nil {
+		return nil, err
+	}
+
 	return &appmodel.Product{
 		ProductID:   product.ProductID,
 		Name:        product.Name,
+		Slug:        product.Slug,
 		Description: fromSQLNull(product.Description),
 		Price:       product.Price,
+		Currency:    product.Currency,
+		CategoryIDs: categoryIDs,
+		Variants:    variants[product.ProductID],
+		ArchivedAt:  fromSQLNull(product.ArchivedAt),
+		Images:      images[product.ProductID],
+		Rating:      ratings[product.ProductID],
 	}, nil
 }
 
+func (p *productQueryService) ListProducts(ctx context.Context, afterProductID uuid.UUID, limit int) (_ []appmodel.Product, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "product", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var productsData []struct {
+		ProductID   uuid.UUID        `db:"product_id"`
+		Name        string           `db:"name"`
+		Slug        string           `db:"slug"`
+		Description sql.Null[string] `db:"description"`
+		Price       int64            `db:"price"`
+		Currency    string           `db:"currency"`
+		CategoryIDs sql.Null[string] `db:"category_ids"`
+	}
+	err = p.client.SelectContext(
+		ctx,
+		&productsData,
+		`
+	SELECT product_id, name, slug, description, price, currency, `+categoryIDsColumn+`
+	FROM product p
+	WHERE archived_at IS NULL AND product_id > ?
+	ORDER BY product_id
+	LIMIT ?
+	`,
+		afterProductID,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(productsData))
+	for _, product := range productsData {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	variants, err := findVariants(ctx, p.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	images, err := findImages(ctx, p.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := findRatings(ctx, p.client, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]appmodel.Product, 0, len(productsData))
+	for _, product := range productsData {
+		categoryIDs, err := splitIDs(product.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, appmodel.Product{
+			ProductID:   product.ProductID,
+			Name:        product.Name,
+			Slug:        product.Slug,
+			Description: fromSQLNull(product.Description),
+			Price:       product.Price,
+			Currency:    product.Currency,
+			CategoryIDs: categoryIDs,
+			Variants:    variants[product.ProductID],
+			Images:      images[product.ProductID],
+			Rating:      ratings[product.ProductID],
+		})
+	}
+	return products, nil
+}
+
+// findVariants одним запросом загружает варианты нескольких товаров
+func findVariants(ctx context.Context, client mysql.ClientContext, productIDs []uuid.UUID) (map[uuid.UUID][]appmodel.Variant, error) {
+	result := make(map[uuid.UUID][]appmodel.Variant, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, 0, len(productIDs))
+	args := make([]interface{}, 0, len(productIDs))
+	for _, productID := range productIDs {
+		placeholders = append(placeholders, "?")
+		args = append(args, productID)
+	}
+
+	var variantsData []struct {
+		ProductID  uuid.UUID `db:"product_id"`
+		VariantID  uuid.UUID `db:"variant_id"`
+		SKU        string    `db:"sku"`
+		Attributes string    `db:"attributes"`
+		Price      int64     `db:"price"`
+		Stock      int64     `db:"stock"`
+	}
+	err := client.SelectContext(
+		ctx,
+		&variantsData,
+		`
+	SELECT product_id, variant_id, sku, attributes, price, stock FROM product_variant
+	WHERE product_id IN (`+strings.Join(placeholders, ", ")+`)
+	ORDER BY product_id, position
+	`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, variant := range variantsData {
+		var attributes map[string]string
+		err = json.Unmarshal([]byte(variant.Attributes), &attributes)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		result[variant.ProductID] = append(result[variant.ProductID], appmodel.Variant{
+			VariantID:  variant.VariantID,
+			SKU:        variant.SKU,
+			Attributes: attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+	return result, nil
+}
+
+// findImages одним запросом загружает изображения нескольких товаров
+func findImages(ctx context.Context, client mysql.ClientContext, productIDs []uuid.UUID) (map[uuid.UUID][]appmodel.Image, error) {
+	result := make(map[uuid.UUID][]appmodel.Image, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, 0, len(productIDs))
+	args := make([]interface{}, 0, len(productIDs))
+	for _, productID := range productIDs {
+		placeholders = append(placeholders, "?")
+		args = append(args, productID)
+	}
+
+	var imagesData []struct {
+		ProductID   uuid.UUID        `db:"product_id"`
+		ImageID     uuid.UUID        `db:"image_id"`
+		URL         sql.Null[string] `db:"url"`
+		ObjectKey   sql.Null[string] `db:"object_key"`
+		ContentType string           `db:"content_type"`
+		Size        int64            `db:"size"`
+		Width       int              `db:"width"`
+		Height      int              `db:"height"`
+		Position    int              `db:"position"`
+		AltText     string           `db:"alt_text"`
+	}
+	err := client.SelectContext(
+		ctx,
+		&imagesData,
+		`
+	SELECT product_id, image_id, url, object_key, content_type, size, width, height, position, alt_text
+	FROM product_image
+	WHERE product_id IN (`+strings.Join(placeholders, ", ")+`)
+	ORDER BY product_id, position
+	`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, image := range imagesData {
+		result[image.ProductID] = append(result[image.ProductID], appmodel.Image{
+			ImageID:     image.ImageID,
+			URL:         fromSQLNull(image.URL),
+			ObjectKey:   fromSQLNull(image.ObjectKey),
+			ContentType: image.ContentType,
+			Size:        image.Size,
+			Width:       image.Width,
+			Height:      image.Height,
+			Position:    image.Position,
+			AltText:     image.AltText,
+		})
+	}
+	return result, nil
+}
+
+// findRatings одним запросом загружает рейтинги нескольких товаров, у товара без отзывов рейтинг нулевой
+func findRatings(ctx context.Context, client mysql.ClientContext, productIDs []uuid.UUID) (map[uuid.UUID]appmodel.Rating, error) {
+	result := make(map[uuid.UUID]appmodel.Rating, len(productIDs))
+	if len(productIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, 0, len(productIDs))
+	args := make([]interface{}, 0, len(productIDs))
+	for _, productID := range productIDs {
+		placeholders = append(placeholders, "?")
+		args = append(args, productID)
+	}
+
+	var ratingsData []struct {
+		ProductID     uuid.UUID `db:"product_id"`
+		AverageRating float64   `db:"average_rating"`
+		ReviewCount   int       `db:"review_count"`
+	}
+	err := client.SelectContext(
+		ctx,
+		&ratingsData,
+		`SELECT product_id, average_rating, review_count FROM product_rating WHERE product_id IN (`+strings.Join(placeholders, ", ")+`)`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, rating := range ratingsData {
+		result[rating.ProductID] = appmodel.Rating{
+			Average: rating.AverageRating,
+			Count:   rating.ReviewCount,
+		}
+	}
+	return result, nil
+}
+
+// categoryIDsColumn собирает категории товара p в одну строку, чтобы не делать отдельный запрос на каждый товар
+const categoryIDsColumn = `(
+	SELECT GROUP_CONCAT(pc.category_id ORDER BY pc.category_id) FROM product_category pc WHERE pc.product_id = p.product_id
+) AS category_ids`
+
+func splitIDs(value sql.Null[string]) ([]uuid.UUID, error) {
+	if !value.Valid || value.V == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value.V, ",")
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func fromSQLNull[T any](v sql.Null[T]) *T {
 	if v.Valid {
 		return &v.V
@@ -0,0 +1,96 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/application/query"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewReviewQueryService(client mysql.ClientContext) query.ReviewQueryService {
+	return &reviewQueryService{
+		client: client,
+	}
+}
+
+type reviewQueryService struct {
+	client mysql.ClientContext
+}
+
+func (r *reviewQueryService) ListReviews(ctx context.Context, filter appmodel.ReviewFilter) (_ []appmodel.Review, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "review", status).Observe(time.Since(start).Seconds())
+	}()
+
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	if filter.ProductID != nil {
+		conditions = append(conditions, "product_id = ?")
+		args = append(args, *filter.ProductID)
+	}
+	if filter.AuthorID != nil {
+		conditions = append(conditions, "author_id = ?")
+		args = append(args, *filter.AuthorID)
+	}
+	if filter.Status != nil {
+		conditions = append(conditions, "status = ?")
+		args = append(args, *filter.Status)
+	}
+	limit := ""
+	if filter.Limit > 0 {
+		limit = "LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	var reviewsData []struct {
+		ReviewID   uuid.UUID `db:"review_id"`
+		ProductID  uuid.UUID `db:"product_id"`
+		AuthorID   uuid.UUID `db:"author_id"`
+		AuthorName string    `db:"author_name"`
+		Rating     int       `db:"rating"`
+		Text       string    `db:"text"`
+		Status     int       `db:"status"`
+		CreatedAt  time.Time `db:"created_at"`
+	}
+	err = r.client.SelectContext(
+		ctx,
+		&reviewsData,
+		`
+	SELECT review_id, product_id, author_id, author_name, rating, text, status, created_at
+	FROM review
+	WHERE `+strings.Join(conditions, " AND ")+`
+	ORDER BY created_at DESC, review_id DESC
+	`+limit,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	reviews := make([]appmodel.Review, 0, len(reviewsData))
+	for _, review := range reviewsData {
+		reviews = append(reviews, appmodel.Review{
+			ReviewID:   review.ReviewID,
+			ProductID:  review.ProductID,
+			AuthorID:   review.AuthorID,
+			AuthorName: review.AuthorName,
+			Rating:     review.Rating,
+			Text:       review.Text,
+			Status:     appmodel.ReviewStatus(review.Status),
+			CreatedAt:  review.CreatedAt,
+		})
+	}
+	return reviews, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+const auditEntityCategory = "category"
+
+func NewCategoryRepository(ctx context.Context, client mysql.ClientContext) model.CategoryRepository {
+	return &categoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type categoryRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (c *categoryRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (c *categoryRepository) Store(category model.Category) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := c.Find(category.CategoryID)
+	if err != nil && !errors.Is(err, model.ErrCategoryNotFound) {
+		return err
+	}
+
+	_, err = c.client.ExecContext(c.ctx,
+		`
+	INSERT INTO category (category_id, parent_id, name, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		parent_id=VALUES(parent_id),
+	    name=VALUES(name),
+	    updated_at=VALUES(updated_at)
+	`,
+		category.CategoryID,
+		toSQLNull(category.ParentID),
+		category.Name,
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord(c.ctx, c.client, auditEntityCategory, category.CategoryID.String(), before, &category)
+}
+
+func (c *categoryRepository) Find(categoryID uuid.UUID) (_ *model.Category, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrCategoryNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	category := struct {
+		CategoryID uuid.UUID           `db:"category_id"`
+		ParentID   sql.Null[uuid.UUID] `db:"parent_id"`
+		Name       string              `db:"name"`
+		CreatedAt  time.Time           `db:"created_at"`
+		UpdatedAt  time.Time           `db:"updated_at"`
+	}{}
+
+	err = c.client.GetContext(
+		c.ctx,
+		&category,
+		`SELECT category_id, parent_id, name, created_at, updated_at FROM category WHERE category_id = ?`,
+		categoryID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrCategoryNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Category{
+		CategoryID: category.CategoryID,
+		ParentID:   fromSQLNull(category.ParentID),
+		Name:       category.Name,
+		CreatedAt:  category.CreatedAt,
+		UpdatedAt:  category.UpdatedAt,
+	}, nil
+}
+
+func (c *categoryRepository) InUse(categoryID uuid.UUID) (_ bool, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("in_use", "category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var inUse bool
+	err = c.client.GetContext(
+		c.ctx,
+		&inUse,
+		`
+	SELECT EXISTS(SELECT 1 FROM category WHERE parent_id = ?)
+	    OR EXISTS(SELECT 1 FROM product_category WHERE category_id = ?)
+	`,
+		categoryID,
+		categoryID,
+	)
+	return inUse, errors.WithStack(err)
+}
+
+func (c *categoryRepository) Delete(categoryID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "category", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := c.Find(categoryID)
+	if err != nil {
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = c.client.ExecContext(c.ctx, `DELETE FROM category WHERE category_id = ?`, categoryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord[model.Category](c.ctx, c.client, auditEntityCategory, categoryID.String(), before, nil)
+}
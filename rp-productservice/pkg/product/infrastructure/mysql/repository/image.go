@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+const auditEntityImage = "product_image"
+
+func NewImageRepository(ctx context.Context, client mysql.ClientContext) model.ImageRepository {
+	return &imageRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type imageRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (i *imageRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (i *imageRepository) Store(image model.Image) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "product_image", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := i.Find(image.ImageID)
+	if err != nil && !errors.Is(err, model.ErrImageNotFound) {
+		return err
+	}
+
+	_, err = i.client.ExecContext(i.ctx,
+		`
+	INSERT INTO product_image (
+		image_id, product_id, url, object_key, content_type, size,
+		width, height, position, alt_text, created_at, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		position=VALUES(position),
+	    alt_text=VALUES(alt_text),
+	    updated_at=VALUES(updated_at)
+	`,
+		image.ImageID,
+		image.ProductID,
+		toSQLNull(image.URL),
+		toSQLNull(image.ObjectKey),
+		image.ContentType,
+		image.Size,
+		image.Width,
+		image.Height,
+		image.Position,
+		image.AltText,
+		image.CreatedAt,
+		image.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord(i.ctx, i.client, auditEntityImage, image.ImageID.String(), before, &image)
+}
+
+func (i *imageRepository) Find(imageID uuid.UUID) (_ *model.Image, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrImageNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "product_image", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var image sqlxImage
+	err = i.client.GetContext(i.ctx, &image, `SELECT `+imageColumns+` FROM product_image WHERE image_id = ?`, imageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrImageNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	result := image.toModel()
+	return &result, nil
+}
+
+func (i *imageRepository) FindByProduct(productID uuid.UUID) (_ []model.Image, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_by_product", "product_image", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var images []sqlxImage
+	err = i.client.SelectContext(
+		i.ctx,
+		&images,
+		`SELECT `+imageColumns+` FROM product_image WHERE product_id = ? ORDER BY position`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]model.Image, 0, len(images))
+	for _, image := range images {
+		result = append(result, image.toModel())
+	}
+	return result, nil
+}
+
+func (i *imageRepository) Delete(imageID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "product_image", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := i.Find(imageID)
+	if err != nil {
+		if errors.Is(err, model.ErrImageNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = i.client.ExecContext(i.ctx, `DELETE FROM product_image WHERE image_id = ?`, imageID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord[model.Image](i.ctx, i.client, auditEntityImage, imageID.String(), before, nil)
+}
+
+const imageColumns = `image_id, product_id, url, object_key, content_type, size,
+	width, height, position, alt_text, created_at, updated_at`
+
+type sqlxImage struct {
+	ImageID     uuid.UUID        `db:"image_id"`
+	ProductID   uuid.UUID        `db:"product_id"`
+	URL         sql.Null[string] `db:"url"`
+	ObjectKey   sql.Null[string] `db:"object_key"`
+	ContentType string           `db:"content_type"`
+	Size        int64            `db:"size"`
+	Width       int              `db:"width"`
+	Height      int              `db:"height"`
+	Position    int              `db:"position"`
+	AltText     string           `db:"alt_text"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at"`
+}
+
+func (i sqlxImage) toModel() model.Image {
+	return model.Image{
+		ImageID:     i.ImageID,
+		ProductID:   i.ProductID,
+		URL:         fromSQLNull(i.URL),
+		ObjectKey:   fromSQLNull(i.ObjectKey),
+		ContentType: i.ContentType,
+		Size:        i.Size,
+		Width:       i.Width,
+		Height:      i.Height,
+		Position:    i.Position,
+		AltText:     i.AltText,
+		CreatedAt:   i.CreatedAt,
+		UpdatedAt:   i.UpdatedAt,
+	}
+}
@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"slices"
 	"strings"
 	"time"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	gomysql "github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
@@ -17,6 +20,12 @@ import (
 const (
 	statusSuccess = "success"
 	statusError   = "error"
+
+	auditEntityProduct = "product"
+
+	errDuplicateEntry       = 1062
+	productNormalizedNameUK = "product_normalized_name_uidx"
+	productSlugUK           = "product_slug_uidx"
 )
 
 func NewProductRepository(ctx context.Context, client mysql.ClientContext) model.ProductRepository {
@@ -45,23 +54,76 @@ func (p *productRepository) Store(product model.Product) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("store", "product", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := p.Find(model.FindSpec{ProductID: &product.ProductID})
+	if err != nil && !errors.Is(err, model.ErrProductNotFound) {
+		return err
+	}
+
+	// normalized_name пересчитывается, только когда меняется имя, и присваивается раньше name, чтобы сравнить
+	// со старым: у дубликатов, переживших миграцию 1792400009, он с суффиксом и совпал бы с чужим
 	_, err = p.client.ExecContext(p.ctx,
 		`
-	INSERT INTO product (product_id, name, description, price, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)
+	INSERT INTO product (product_id, name, normalized_name, slug, description, price, currency, archived_at, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	ON DUPLICATE KEY UPDATE
+		normalized_name=IF(BINARY name = BINARY VALUES(name), normalized_name, VALUES(normalized_name)),
 		name=VALUES(name),
+		slug=VALUES(slug),
 	    description=VALUES(description),
 	    price=VALUES(price),
+	    currency=VALUES(currency),
+	    archived_at=VALUES(archived_at),
 	    updated_at=VALUES(updated_at)
 	`,
 		product.ProductID,
 		product.Name,
+		model.NormalizeProductName(product.Name),
+		product.Slug,
 		toSQLNull(product.Description),
 		product.Price,
+		product.Currency,
+		toSQLNull(product.ArchivedAt),
 		product.CreatedAt,
 		product.UpdatedAt,
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		// проверка имени в домене не защищает от параллельного переименования, уникальный индекс защищает
+		var mysqlErr *gomysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errDuplicateEntry {
+			switch {
+			case strings.Contains(mysqlErr.Message, productNormalizedNameUK):
+				return errors.WithStack(model.ErrProductNameAlreadyUsed)
+			// блокировка по основе slug не защищает от суффикса: "Phone" может получить "phone-2",
+			// пока параллельно создается "Phone 2"
+			case strings.Contains(mysqlErr.Message, productSlugUK):
+				return errors.WithStack(model.ErrProductSlugAlreadyUsed)
+			}
+		}
+		return errors.WithStack(err)
+	}
+
+	var beforeCategoryIDs []uuid.UUID
+	if before != nil {
+		beforeCategoryIDs = before.CategoryIDs
+	}
+	if !slices.Equal(beforeCategoryIDs, product.CategoryIDs) {
+		err = p.storeCategories(product.ProductID, product.CategoryIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.storeVariants(product.ProductID, product.Variants)
+	if err != nil {
+		return err
+	}
+
+	err = p.appendPriceHistory(before, product)
+	if err != nil {
+		return err
+	}
+
+	return appendAuditRecord(p.ctx, p.client, auditEntityProduct, product.ProductID.String(), before, &product)
 }
 
 func (p *productRepository) Find(spec model.FindSpec) (_ *model.Product, err error) {
@@ -75,19 +137,22 @@ func (p *productRepository) Find(spec model.FindSpec) (_ *model.Product, err err
 	}()
 
 	product := struct {
-		ProductID   uuid.UUID        `db:"product_id"`
-		Name        string           `db:"name"`
-		Description sql.Null[string] `db:"description"`
-		Price       int64            `db:"price"`
-		CreatedAt   time.Time        `db:"created_at"`
-		UpdatedAt   time.Time        `db:"updated_at"`
+		ProductID   uuid.UUID           `db:"product_id"`
+		Name        string              `db:"name"`
+		Slug        string              `db:"slug"`
+		Description sql.Null[string]    `db:"description"`
+		Price       int64               `db:"price"`
+		Currency    string              `db:"currency"`
+		ArchivedAt  sql.Null[time.Time] `db:"archived_at"`
+		CreatedAt   time.Time           `db:"created_at"`
+		UpdatedAt   time.Time           `db:"updated_at"`
 	}{}
 	query, args := p.buildSpecArgs(spec)
 
 	err = p.client.GetContext(
 		p.ctx,
 		&product,
-		`SELECT product_id, name, description, price, created_at, updated_at FROM product WHERE `+query,
+		`SELECT product_id, name, slug, description, price, currency, archived_at, created_at, updated_at FROM product WHERE `+query,
 		args...,
 	)
 	if err != nil {
@@ -97,11 +162,32 @@ func (p *productRepository) Find(spec model.FindSpec) (_ *model.Product, err err
 		return nil, errors.WithStack(err)
 	}
 
+	var categoryIDs []uuid.UUID
+	err = p.client.SelectContext(
+		p.ctx,
+		&categoryIDs,
+		`SELECT category_id FROM product_category WHERE product_id = ? ORDER BY category_id`,
+		product.ProductID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	variants, err := p.findVariants(product.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &model.Product{
 		ProductID:   product.ProductID,
 		Name:        product.Name,
+		Slug:        product.Slug,
 		Description: fromSQLNull(product.Description),
 		Price:       product.Price,
+		Currency:    product.Currency,
+		CategoryIDs: categoryIDs,
+		Variants:    variants,
+		ArchivedAt:  fromSQLNull(product.ArchivedAt),
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 	}, nil
@@ -117,19 +203,237 @@ func (p *productRepository) Delete(productID uuid.UUID) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("delete", "product", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := p.Find(model.FindSpec{ProductID: &productID})
+	if err != nil {
+		if errors.Is(err, model.ErrProductNotFound) {
+			return nil
+		}
+		return err
+	}
+
 	_, err = p.client.ExecContext(p.ctx, `DELETE FROM product WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM product_category WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM product_variant WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM price_history WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM scheduled_price WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM product_image WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM review WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM product_rating WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM purchase WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM stock_deduction WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord[model.Product](p.ctx, p.client, auditEntityProduct, productID.String(), before, nil)
+}
+
+func (p *productRepository) StoreStockDeduction(orderID, productID uuid.UUID) (_ bool, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store_stock_deduction", "product", status).Observe(time.Since(start).Seconds())
+	}()
+
+	// order_paid может прийти повторно, списание по заказу выполняется только при первой вставке
+	result, err := p.client.ExecContext(p.ctx,
+		`INSERT IGNORE INTO stock_deduction (order_id, product_id) VALUES (?, ?)`,
+		orderID, productID,
+	)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return affected > 0, nil
+}
+
+// storeCategories целиком заменяет членство товара в категориях
+func (p *productRepository) storeCategories(productID uuid.UUID, categoryIDs []uuid.UUID) error {
+	_, err := p.client.ExecContext(p.ctx, `DELETE FROM product_category WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(categoryIDs))
+	args := make([]interface{}, 0, len(categoryIDs)*2)
+	for _, categoryID := range categoryIDs {
+		placeholders = append(placeholders, "(?, ?)")
+		args = append(args, productID, categoryID)
+	}
+	_, err = p.client.ExecContext(p.ctx,
+		`INSERT INTO product_category (product_id, category_id) VALUES `+strings.Join(placeholders, ", "),
+		args...,
+	)
 	return errors.WithStack(err)
 }
 
+// storeVariants целиком заменяет варианты товара, порядок сохраняется в position
+func (p *productRepository) storeVariants(productID uuid.UUID, variants []model.Variant) error {
+	_, err := p.client.ExecContext(p.ctx, `DELETE FROM product_variant WHERE product_id = ?`, productID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(variants))
+	args := make([]interface{}, 0, len(variants)*7)
+	for i, variant := range variants {
+		if variant.Attributes == nil {
+			variant.Attributes = map[string]string{}
+		}
+		attributes, err := json.Marshal(variant.Attributes)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, variant.VariantID, productID, i, variant.SKU, string(attributes), variant.Price, variant.Stock)
+	}
+	_, err = p.client.ExecContext(p.ctx,
+		`INSERT INTO product_variant (variant_id, product_id, position, sku, attributes, price, stock) VALUES `+
+			strings.Join(placeholders, ", "),
+		args...,
+	)
+	return errors.WithStack(err)
+}
+
+// appendPriceHistory записывает цены товара и вариантов, которые отличаются от сохраненных ранее.
+// При смене валюты меняются все цены, поэтому записываются все
+func (p *productRepository) appendPriceHistory(before *model.Product, product model.Product) error {
+	if before != nil && before.Currency != product.Currency {
+		before = nil
+	}
+	beforePrices := map[uuid.UUID]int64{}
+	if before != nil {
+		for _, variant := range before.Variants {
+			beforePrices[variant.VariantID] = variant.Price
+		}
+	}
+
+	var placeholders []string
+	var args []interface{}
+	if before == nil || before.Price != product.Price {
+		placeholders = append(placeholders, "(?, NULL, ?, ?, ?)")
+		args = append(args, product.ProductID, product.Price, product.Currency, product.UpdatedAt)
+	}
+	for _, variant := range product.Variants {
+		price, ok := beforePrices[variant.VariantID]
+		if ok && price == variant.Price {
+			continue
+		}
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+		args = append(args, product.ProductID, variant.VariantID, variant.Price, product.Currency, product.UpdatedAt)
+	}
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	_, err := p.client.ExecContext(p.ctx,
+		`INSERT INTO price_history (product_id, variant_id, price, currency, changed_at) VALUES `+strings.Join(placeholders, ", "),
+		args...,
+	)
+	return errors.WithStack(err)
+}
+
+func (p *productRepository) findVariants(productID uuid.UUID) ([]model.Variant, error) {
+	var variantsData []struct {
+		VariantID  uuid.UUID `db:"variant_id"`
+		SKU        string    `db:"sku"`
+		Attributes string    `db:"attributes"`
+		Price      int64     `db:"price"`
+		Stock      int64     `db:"stock"`
+	}
+	err := p.client.SelectContext(
+		p.ctx,
+		&variantsData,
+		`SELECT variant_id, sku, attributes, price, stock FROM product_variant WHERE product_id = ? ORDER BY position`,
+		productID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var variants []model.Variant
+	for _, variant := range variantsData {
+		var attributes map[string]string
+		err = json.Unmarshal([]byte(variant.Attributes), &attributes)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		variants = append(variants, model.Variant{
+			VariantID:  variant.VariantID,
+			SKU:        variant.SKU,
+			Attributes: attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+	return variants, nil
+}
+
 func (p *productRepository) buildSpecArgs(spec model.FindSpec) (query string, args []interface{}) {
 	var parts []string
 	if spec.ProductID != nil {
 		parts = append(parts, "product_id = ?")
 		args = append(args, *spec.ProductID)
 	}
-	if spec.Name != nil {
-		parts = append(parts, "name = ?")
-		args = append(args, *spec.Name)
+	if spec.NormalizedName != nil {
+		parts = append(parts, "normalized_name = ?")
+		args = append(args, *spec.NormalizedName)
+	}
+	if spec.Slug != nil {
+		parts = append(parts, "slug = ?")
+		args = append(args, *spec.Slug)
+	}
+	if spec.SKU != nil {
+		parts = append(parts, "product_id = (SELECT product_id FROM product_variant WHERE sku = ?)")
+		args = append(args, *spec.SKU)
 	}
 	return strings.Join(parts, " AND "), args
 }
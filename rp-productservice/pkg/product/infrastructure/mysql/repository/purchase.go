@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewPurchaseRepository(ctx context.Context, client mysql.ClientContext) model.PurchaseRepository {
+	return &purchaseRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type purchaseRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (p *purchaseRepository) Store(purchase model.Purchase) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "purchase", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = p.client.ExecContext(p.ctx,
+		`INSERT IGNORE INTO purchase (order_id, product_id, user_id, purchased_at) VALUES (?, ?, ?, ?)`,
+		purchase.OrderID,
+		purchase.ProductID,
+		purchase.UserID,
+		purchase.PurchasedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (p *purchaseRepository) Exists(userID, productID uuid.UUID) (_ bool, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("exists", "purchase", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var exists bool
+	err = p.client.GetContext(
+		p.ctx,
+		&exists,
+		`SELECT EXISTS(SELECT 1 FROM purchase WHERE user_id = ? AND product_id = ?)`,
+		userID,
+		productID,
+	)
+	return exists, errors.WithStack(err)
+}
+
+func (p *purchaseRepository) DeleteByUser(userID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete_by_user", "purchase", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = p.client.ExecContext(p.ctx, `DELETE FROM purchase WHERE user_id = ?`, userID)
+	return errors.WithStack(err)
+}
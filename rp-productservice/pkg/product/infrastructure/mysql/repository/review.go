@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/audit"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+const auditEntityReview = "review"
+
+func NewReviewRepository(ctx context.Context, client mysql.ClientContext) model.ReviewRepository {
+	return &reviewRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type reviewRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *reviewRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (r *reviewRepository) Store(review model.Review) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "review", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := r.Find(review.ReviewID)
+	if err != nil && !errors.Is(err, model.ErrReviewNotFound) {
+		return err
+	}
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO review (
+		review_id, product_id, author_id, author_name, rating, text, status, created_at, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		rating=VALUES(rating),
+	    text=VALUES(text),
+	    status=VALUES(status),
+	    updated_at=VALUES(updated_at)
+	`,
+		review.ReviewID,
+		review.ProductID,
+		review.AuthorID,
+		review.AuthorName,
+		review.Rating,
+		review.Text,
+		review.Status,
+		review.CreatedAt,
+		review.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = r.updateRating(review.ProductID)
+	if err != nil {
+		return err
+	}
+
+	key, err := auditSubjectKey(r.ctx, r.client, review.AuthorID.String())
+	if err != nil {
+		return err
+	}
+	return appendAuditRecord(r.ctx, r.client, auditEntityReview, review.ReviewID.String(), toAuditReview(before, key), toAuditReview(&review, key))
+}
+
+func (r *reviewRepository) Find(reviewID uuid.UUID) (*model.Review, error) {
+	return r.find("find", `WHERE review_id = ?`, reviewID)
+}
+
+func (r *reviewRepository) FindByProductAndAuthor(productID, authorID uuid.UUID) (*model.Review, error) {
+	return r.find("find_by_product_and_author", `WHERE product_id = ? AND author_id = ?`, productID, authorID)
+}
+
+func (r *reviewRepository) FindByAuthor(authorID uuid.UUID) (_ []model.Review, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_by_author", "review", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var reviews []sqlxReview
+	err = r.client.SelectContext(r.ctx, &reviews, `SELECT `+reviewColumns+` FROM review WHERE author_id = ?`, authorID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]model.Review, 0, len(reviews))
+	for _, review := range reviews {
+		result = append(result, review.toModel())
+	}
+	return result, nil
+}
+
+func (r *reviewRepository) Delete(reviewID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "review", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := r.Find(reviewID)
+	if err != nil {
+		if errors.Is(err, model.ErrReviewNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM review WHERE review_id = ?`, reviewID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = r.updateRating(before.ProductID)
+	if err != nil {
+		return err
+	}
+
+	key, err := auditSubjectKey(r.ctx, r.client, before.AuthorID.String())
+	if err != nil {
+		return err
+	}
+	return appendAuditRecord(r.ctx, r.client, auditEntityReview, reviewID.String(), toAuditReview(before, key), nil)
+}
+
+func (r *reviewRepository) ForgetAuthor(authorID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "audit_subject_key", status).Observe(time.Since(start).Seconds())
+	}()
+
+	return forgetAuditSubject(r.ctx, r.client, authorID.String())
+}
+
+// auditReview - отзыв в журнале аудита: имя автора и текст записываются хешем на ключе автора,
+// который удаляется вместе с данными автора
+type auditReview struct {
+	ReviewID   uuid.UUID
+	ProductID  uuid.UUID
+	AuthorID   uuid.UUID
+	AuthorName string
+	Rating     int
+	Text       string
+	Status     model.ReviewStatus
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func toAuditReview(review *model.Review, key []byte) *auditReview {
+	if review == nil {
+		return nil
+	}
+	return &auditReview{
+		ReviewID:   review.ReviewID,
+		ProductID:  review.ProductID,
+		AuthorID:   review.AuthorID,
+		AuthorName: audit.Redact(key, review.AuthorName),
+		Rating:     review.Rating,
+		Text:       audit.Redact(key, review.Text),
+		Status:     review.Status,
+		CreatedAt:  review.CreatedAt,
+		UpdatedAt:  review.UpdatedAt,
+	}
+}
+
+func (r *reviewRepository) find(operation, where string, args ...interface{}) (_ *model.Review, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrReviewNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues(operation, "review", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var review sqlxReview
+	err = r.client.GetContext(r.ctx, &review, `SELECT `+reviewColumns+` FROM review `+where, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrReviewNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	result := review.toModel()
+	return &result, nil
+}
+
+// updateRating пересчитывает рейтинг товара по одобренным отзывам, чтобы чтение товара не агрегировало отзывы
+func (r *reviewRepository) updateRating(productID uuid.UUID) error {
+	_, err := r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO product_rating (product_id, average_rating, review_count)
+	SELECT ?, COALESCE(AVG(rating), 0), COUNT(*) FROM review WHERE product_id = ? AND status = ?
+	ON DUPLICATE KEY UPDATE
+		average_rating=VALUES(average_rating),
+	    review_count=VALUES(review_count)
+	`,
+		productID,
+		productID,
+		model.ReviewApproved,
+	)
+	return errors.WithStack(err)
+}
+
+const reviewColumns = `review_id, product_id, author_id, author_name, rating, text, status, created_at, updated_at`
+
+type sqlxReview struct {
+	ReviewID   uuid.UUID `db:"review_id"`
+	ProductID  uuid.UUID `db:"product_id"`
+	AuthorID   uuid.UUID `db:"author_id"`
+	AuthorName string    `db:"author_name"`
+	Rating     int       `db:"rating"`
+	Text       string    `db:"text"`
+	Status     int       `db:"status"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (r sqlxReview) toModel() model.Review {
+	return model.Review{
+		ReviewID:   r.ReviewID,
+		ProductID:  r.ProductID,
+		AuthorID:   r.AuthorID,
+		AuthorName: r.AuthorName,
+		Rating:     r.Rating,
+		Text:       r.Text,
+		Status:     model.ReviewStatus(r.Status),
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
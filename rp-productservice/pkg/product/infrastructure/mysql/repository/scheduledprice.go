@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+const auditEntityScheduledPrice = "scheduled_price"
+
+func NewScheduledPriceRepository(ctx context.Context, client mysql.ClientContext) model.ScheduledPriceRepository {
+	return &scheduledPriceRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type scheduledPriceRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (s *scheduledPriceRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (s *scheduledPriceRepository) Store(price model.ScheduledPrice) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "scheduled_price", status).Observe(time.Since(start).Seconds())
+	}()
+
+	before, err := s.Find(price.ScheduledPriceID)
+	if err != nil && !errors.Is(err, model.ErrScheduledPriceNotFound) {
+		return err
+	}
+
+	_, err = s.client.ExecContext(s.ctx,
+		`
+	INSERT INTO scheduled_price (
+		scheduled_price_id, product_id, variant_id, price, currency, previous_price,
+		effective_from, effective_until, status, created_at, updated_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		previous_price=VALUES(previous_price),
+	    status=VALUES(status),
+	    updated_at=VALUES(updated_at)
+	`,
+		price.ScheduledPriceID,
+		price.ProductID,
+		toSQLNull(price.VariantID),
+		price.Price,
+		price.Currency,
+		toSQLNull(price.PreviousPrice),
+		price.EffectiveFrom,
+		toSQLNull(price.EffectiveUntil),
+		price.Status,
+		price.CreatedAt,
+		price.UpdatedAt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return appendAuditRecord(s.ctx, s.client, auditEntityScheduledPrice, price.ScheduledPriceID.String(), before, &price)
+}
+
+func (s *scheduledPriceRepository) Find(scheduledPriceID uuid.UUID) (_ *model.ScheduledPrice, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrScheduledPriceNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "scheduled_price", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var price sqlxScheduledPrice
+	err = s.client.GetContext(
+		s.ctx,
+		&price,
+		`SELECT `+scheduledPriceColumns+` FROM scheduled_price WHERE scheduled_price_id = ?`,
+		scheduledPriceID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrScheduledPriceNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	result := price.toModel()
+	return &result, nil
+}
+
+func (s *scheduledPriceRepository) FindDue(at time.Time, limit int) (_ []model.ScheduledPrice, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_due", "scheduled_price", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var prices []sqlxScheduledPrice
+	err = s.client.SelectContext(
+		s.ctx,
+		&prices,
+		`
+	SELECT `+scheduledPriceColumns+` FROM (
+		(SELECT * FROM scheduled_price WHERE status = ? AND effective_from <= ? ORDER BY effective_from LIMIT ?)
+		UNION ALL
+		(SELECT * FROM scheduled_price WHERE status = ? AND effective_until <= ? ORDER BY effective_until LIMIT ?)
+	) due
+	ORDER BY IF(status = ?, effective_from, effective_until), scheduled_price_id
+	LIMIT ?
+	`,
+		model.ScheduledPricePending, at, limit,
+		model.ScheduledPriceActive, at, limit,
+		model.ScheduledPricePending,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]model.ScheduledPrice, 0, len(prices))
+	for _, price := range prices {
+		result = append(result, price.toModel())
+	}
+	return result, nil
+}
+
+const scheduledPriceColumns = `scheduled_price_id, product_id, variant_id, price, currency, previous_price,
+	effective_from, effective_until, status, created_at, updated_at`
+
+type sqlxScheduledPrice struct {
+	ScheduledPriceID uuid.UUID           `db:"scheduled_price_id"`
+	ProductID        uuid.UUID           `db:"product_id"`
+	VariantID        sql.Null[uuid.UUID] `db:"variant_id"`
+	Price            int64               `db:"price"`
+	Currency         string              `db:"currency"`
+	PreviousPrice    sql.Null[int64]     `db:"previous_price"`
+	EffectiveFrom    time.Time           `db:"effective_from"`
+	EffectiveUntil   sql.Null[time.Time] `db:"effective_until"`
+	Status           int                 `db:"status"`
+	CreatedAt        time.Time           `db:"created_at"`
+	UpdatedAt        time.Time           `db:"updated_at"`
+}
+
+func (s *scheduledPriceRepository) FindUnfinished(productID uuid.UUID) (_ []model.ScheduledPrice, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_unfinished", "scheduled_price", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var prices []sqlxScheduledPrice
+	err = s.client.SelectContext(
+		s.ctx,
+		&prices,
+		`SELECT `+scheduledPriceColumns+` FROM scheduled_price WHERE product_id = ? AND status IN (?, ?)`,
+		productID,
+		model.ScheduledPricePending,
+		model.ScheduledPriceActive,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]model.ScheduledPrice, 0, len(prices))
+	for _, price := range prices {
+		result = append(result, price.toModel())
+	}
+	return result, nil
+}
+
+func (p sqlxScheduledPrice) toModel() model.ScheduledPrice {
+	return model.ScheduledPrice{
+		ScheduledPriceID: p.ScheduledPriceID,
+		ProductID:        p.ProductID,
+		VariantID:        fromSQLNull(p.VariantID),
+		Price:            p.Price,
+		Currency:         p.Currency,
+		PreviousPrice:    fromSQLNull(p.PreviousPrice),
+		EffectiveFrom:    p.EffectiveFrom,
+		EffectiveUntil:   fromSQLNull(p.EffectiveUntil),
+		Status:           model.ScheduledPriceStatus(p.Status),
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/metrics"
+)
+
+func NewUserAccessRepository(ctx context.Context, client mysql.ClientContext) model.UserAccessRepository {
+	return &userAccessRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type userAccessRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *userAccessRepository) Store(access model.UserAccess) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "user_access", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO user_access (user_id, permissions, permissions_updated_at, active, status_updated_at)
+	VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		permissions=VALUES(permissions),
+	    permissions_updated_at=VALUES(permissions_updated_at),
+	    active=VALUES(active),
+	    status_updated_at=VALUES(status_updated_at)
+	`,
+		access.UserID,
+		joinPermissions(access.Permissions),
+		access.PermissionsUpdatedAt,
+		access.Active,
+		access.StatusUpdatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *userAccessRepository) Find(userID uuid.UUID) (_ *model.UserAccess, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_access", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var data struct {
+		UserID               uuid.UUID `db:"user_id"`
+		Permissions          string    `db:"permissions"`
+		PermissionsUpdatedAt time.Time `db:"permissions_updated_at"`
+		Active               bool      `db:"active"`
+		StatusUpdatedAt      time.Time `db:"status_updated_at"`
+	}
+	err = r.client.GetContext(
+		r.ctx,
+		&data,
+		`SELECT user_id, permissions, permissions_updated_at, active, status_updated_at FROM user_access WHERE user_id = ?`,
+		userID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrUserAccessNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.UserAccess{
+		UserID:               data.UserID,
+		Permissions:          splitPermissions(data.Permissions),
+		PermissionsUpdatedAt: data.PermissionsUpdatedAt,
+		Active:               data.Active,
+		StatusUpdatedAt:      data.StatusUpdatedAt,
+	}, nil
+}
+
+func (r *userAccessRepository) Delete(userID uuid.UUID) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "user_access", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM user_access WHERE user_id = ?`, userID)
+	return errors.WithStack(err)
+}
+
+// права хранятся одной строкой через запятую, как роли в userservice
+func joinPermissions(permissions []model.Permission) string {
+	parts := make([]string, len(permissions))
+	for i, permission := range permissions {
+		parts[i] = string(permission)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitPermissions(value string) []model.Permission {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	permissions := make([]model.Permission, len(parts))
+	for i, part := range parts {
+		permissions[i] = model.Permission(part)
+	}
+	return permissions
+}
@@ -21,3 +21,27 @@ type repositoryProvider struct {
 func (r *repositoryProvider) ProductRepository(ctx context.Context) model.ProductRepository {
 	return repository.NewProductRepository(ctx, r.client)
 }
+
+func (r *repositoryProvider) CategoryRepository(ctx context.Context) model.CategoryRepository {
+	return repository.NewCategoryRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ScheduledPriceRepository(ctx context.Context) model.ScheduledPriceRepository {
+	return repository.NewScheduledPriceRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ImageRepository(ctx context.Context) model.ImageRepository {
+	return repository.NewImageRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) ReviewRepository(ctx context.Context) model.ReviewRepository {
+	return repository.NewReviewRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) PurchaseRepository(ctx context.Context) model.PurchaseRepository {
+	return repository.NewPurchaseRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) UserAccessRepository(ctx context.Context) model.UserAccessRepository {
+	return repository.NewUserAccessRepository(ctx, r.client)
+}
@@ -0,0 +1,289 @@
+package productfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "productservice/pkg/product/application/model"
+)
+
+type Format string
+
+const (
+	// FormatCSV - колонки product_id, name, description, price, currency. Варианты в CSV не передаются,
+	// файлы без колонки currency тоже читаются
+	FormatCSV Format = "csv"
+	// FormatJSONL - один товар в строке, вместе с вариантами
+	FormatJSONL Format = "jsonl"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(value); format {
+	case FormatCSV, FormatJSONL:
+		return format, nil
+	default:
+		return "", errors.Errorf("unknown format %q, expected csv or jsonl", value)
+	}
+}
+
+// Row - товар из файла. Err - строку не удалось разобрать, Product не заполнен
+type Row struct {
+	Line    int
+	Product appmodel.Product
+	Err     error
+}
+
+// Read разбирает весь файл. Ошибки отдельных строк возвращаются в Row.Err,
+// error - файл нельзя прочитать целиком
+func Read(format Format, r io.Reader) ([]Row, error) {
+	if format == FormatCSV {
+		return readCSV(r)
+	}
+	return readJSONL(r)
+}
+
+type Writer interface {
+	Write(product appmodel.Product) error
+	Flush() error
+}
+
+// NewWriter пишет товары в формате, который понимает Read.
+// Без withIDs идентификаторы не пишутся, и импорт такого файла создает новые товары
+func NewWriter(format Format, w io.Writer, withIDs bool) (Writer, error) {
+	if format == FormatCSV {
+		csvWriter := csv.NewWriter(w)
+		err := csvWriter.Write(csvColumns)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		return &csvFileWriter{writer: csvWriter, withIDs: withIDs}, nil
+	}
+	bufWriter := bufio.NewWriter(w)
+	return &jsonlFileWriter{writer: bufWriter, encoder: json.NewEncoder(bufWriter), withIDs: withIDs}, nil
+}
+
+var csvColumns = []string{"product_id", "name", "description", "price", "currency"}
+
+// csvColumnsWithoutCurrency - заголовок файлов, выгруженных до появления валют
+var csvColumnsWithoutCurrency = csvColumns[:4]
+
+func readCSV(r io.Reader) ([]Row, error) {
+	reader := csv.NewReader(r)
+	// число колонок задает заголовок
+	reader.FieldsPerRecord = 0
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read csv header")
+	}
+	if !slices.Equal(header, csvColumns) && !slices.Equal(header, csvColumnsWithoutCurrency) {
+		return nil, errors.Errorf("unexpected csv header %v, expected %v", header, csvColumns)
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, Row{Line: parseErr.StartLine, Err: err})
+			continue
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(line, record))
+	}
+}
+
+func csvRow(line int, record []string) Row {
+	price, err := strconv.ParseInt(record[3], 10, 64)
+	if err != nil {
+		return Row{Line: line, Err: fmt.Errorf("invalid price %q", record[3])}
+	}
+	var description *string
+	if record[2] != "" {
+		description = &record[2]
+	}
+	var currency string
+	if len(record) > 4 {
+		currency = record[4]
+	}
+	return newRow(line, productRecord{
+		ProductID:   record[0],
+		Name:        record[1],
+		Description: description,
+		Price:       price,
+		Currency:    currency,
+	})
+}
+
+func readJSONL(r io.Reader) ([]Row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var rows []Row
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var record productRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&record)
+		if err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+		rows = append(rows, newRow(line, record))
+	}
+	return rows, errors.WithStack(scanner.Err())
+}
+
+// newRow проверяет то же, что grpc валидация StoreProduct. Доменные правила проверяет импорт
+func newRow(line int, record productRecord) Row {
+	product, err := record.toProduct()
+	if err != nil {
+		return Row{Line: line, Err: err}
+	}
+	return Row{Line: line, Product: product}
+}
+
+type productRecord struct {
+	ProductID   string           `json:"product_id,omitempty"`
+	Name        string           `json:"name"`
+	Description *string          `json:"description,omitempty"`
+	Price       int64            `json:"price"`
+	Currency    string           `json:"currency,omitempty"`
+	Variants    *[]variantRecord `json:"variants,omitempty"`
+}
+
+type variantRecord struct {
+	VariantID  string            `json:"variant_id,omitempty"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Price      int64             `json:"price"`
+	Stock      int64             `json:"stock"`
+}
+
+func (r productRecord) toProduct() (appmodel.Product, error) {
+	product := appmodel.Product{
+		Name:        r.Name,
+		Description: r.Description,
+		Price:       r.Price,
+		Currency:    r.Currency,
+	}
+	if r.ProductID != "" {
+		productID, err := uuid.Parse(r.ProductID)
+		if err != nil {
+			return appmodel.Product{}, fmt.Errorf("invalid product_id: %w", err)
+		}
+		product.ProductID = productID
+	}
+	if r.Name == "" || utf8.RuneCountInString(r.Name) > 255 {
+		return appmodel.Product{}, errors.New("name must be from 1 to 255 characters")
+	}
+
+	// без variants в файле варианты существующего товара не меняются
+	if r.Variants != nil {
+		product.Variants = make([]appmodel.Variant, 0, len(*r.Variants))
+		for i, v := range *r.Variants {
+			variant := appmodel.Variant{
+				SKU:        v.SKU,
+				Attributes: v.Attributes,
+				Price:      v.Price,
+				Stock:      v.Stock,
+			}
+			if v.VariantID != "" {
+				variantID, err := uuid.Parse(v.VariantID)
+				if err != nil {
+					return appmodel.Product{}, fmt.Errorf("invalid variants[%d].variant_id: %w", i, err)
+				}
+				variant.VariantID = variantID
+			}
+			if v.SKU == "" || utf8.RuneCountInString(v.SKU) > 64 {
+				return appmodel.Product{}, fmt.Errorf("variants[%d].sku must be from 1 to 64 characters", i)
+			}
+			if v.Stock < 0 {
+				return appmodel.Product{}, fmt.Errorf("variants[%d].stock must not be negative", i)
+			}
+			product.Variants = append(product.Variants, variant)
+		}
+	}
+	return product, nil
+}
+
+type csvFileWriter struct {
+	writer  *csv.Writer
+	withIDs bool
+}
+
+func (w *csvFileWriter) Write(product appmodel.Product) error {
+	var productID, description string
+	if w.withIDs {
+		productID = product.ProductID.String()
+	}
+	if product.Description != nil {
+		description = *product.Description
+	}
+	return errors.WithStack(w.writer.Write([]string{
+		productID, product.Name, description, strconv.FormatInt(product.Price, 10), product.Currency,
+	}))
+}
+
+func (w *csvFileWriter) Flush() error {
+	w.writer.Flush()
+	return errors.WithStack(w.writer.Error())
+}
+
+type jsonlFileWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	withIDs bool
+}
+
+func (w *jsonlFileWriter) Write(product appmodel.Product) error {
+	variants := make([]variantRecord, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		v := variantRecord{
+			SKU:        variant.SKU,
+			Attributes: variant.Attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		}
+		if w.withIDs {
+			v.VariantID = variant.VariantID.String()
+		}
+		variants = append(variants, v)
+	}
+	record := productRecord{
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Currency:    product.Currency,
+		Variants:    &variants,
+	}
+	if w.withIDs {
+		record.ProductID = product.ProductID.String()
+	}
+	return errors.WithStack(w.encoder.Encode(record))
+}
+
+func (w *jsonlFileWriter) Flush() error {
+	return errors.WithStack(w.writer.Flush())
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit задает token bucket: Rate токенов в секунду, не больше Burst накопленных токенов
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+type Limiter interface {
+	// Take забирает токен из bucket по ключу, при отказе возвращает время до появления следующего токена
+	Take(ctx context.Context, key string, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func newBucket(limit Limit, now time.Time) bucket {
+	return bucket{
+		tokens:    float64(limit.Burst),
+		updatedAt: now,
+	}
+}
+
+func (b *bucket) take(limit Limit, now time.Time) (bool, time.Duration) {
+	if elapsed := now.Sub(b.updatedAt).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+		b.updatedAt = now
+	}
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// full - bucket восстановился полностью и его можно не хранить
+func (b *bucket) full(limit Limit, now time.Time) bool {
+	return b.tokens+now.Sub(b.updatedAt).Seconds()*limit.Rate >= float64(limit.Burst)
+}
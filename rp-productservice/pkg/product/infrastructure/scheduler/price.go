@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+
+	"productservice/pkg/product/application/service"
+)
+
+type PriceSchedulerConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// PriceScheduler периодически применяет запланированные цены и завершает распродажи
+type PriceScheduler interface {
+	Start(ctx context.Context) error
+}
+
+func NewPriceScheduler(config PriceSchedulerConfig, priceService service.PriceService, logger logging.Logger) PriceScheduler {
+	return &priceScheduler{
+		config:       config,
+		priceService: priceService,
+		logger:       logger.WithField("scheduler", "price"),
+	}
+}
+
+type priceScheduler struct {
+	config       PriceSchedulerConfig
+	priceService service.PriceService
+	logger       logging.Logger
+}
+
+func (s *priceScheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.applyDuePrices(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *priceScheduler) applyDuePrices(ctx context.Context) {
+	for ctx.Err() == nil {
+		applied, err := s.priceService.ApplyDuePrices(ctx, time.Now(), s.config.BatchSize)
+		if err != nil {
+			s.logger.Error(err, "failed to apply scheduled prices")
+			return
+		}
+		// полная пачка - вероятно, есть еще, не ждем следующего тика
+		if applied < s.config.BatchSize {
+			return
+		}
+	}
+}
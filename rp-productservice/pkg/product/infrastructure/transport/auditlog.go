@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"productservice/api/server/productinternal"
+	appmodel "productservice/pkg/product/application/model"
+)
+
+func toAuditRecords(records []appmodel.AuditRecord) []*productinternal.AuditRecord {
+	result := make([]*productinternal.AuditRecord, len(records))
+	for i, record := range records {
+		result[i] = &productinternal.AuditRecord{
+			RecordID:      record.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt.Unix(),
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			Valid:         record.Valid,
+		}
+	}
+	return result
+}
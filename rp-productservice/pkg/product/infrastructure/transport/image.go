@@ -0,0 +1,131 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"productservice/api/server/productinternal"
+	appmodel "productservice/pkg/product/application/model"
+)
+
+func (p *productInternalAPI) AddImage(ctx context.Context, request *productinternal.AddImageRequest) (*productinternal.AddImageResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	imageID, err := p.imageService.AddImage(ctx, appmodel.ExternalImage{
+		ProductID: productID,
+		URL:       request.Url,
+		Width:     int(request.Width),
+		Height:    int(request.Height),
+		AltText:   request.AltText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.AddImageResponse{
+		ImageID: imageID.String(),
+	}, nil
+}
+
+func (p *productInternalAPI) UploadImage(stream productinternal.ProductInternalService_UploadImageServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	metadata := request.GetMetadata()
+	if metadata == nil {
+		return invalidArgumentError("metadata", "must be sent in the first message")
+	}
+	productID, err := parseUUID("productID", metadata.ProductID)
+	if err != nil {
+		return err
+	}
+
+	imageID, err := p.imageService.UploadImage(stream.Context(), appmodel.ImageUpload{
+		ProductID: productID,
+		AltText:   metadata.AltText,
+		Content:   &uploadReader{stream: stream},
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&productinternal.UploadImageResponse{
+		ImageID: imageID.String(),
+	})
+}
+
+func (p *productInternalAPI) ReorderImages(ctx context.Context, request *productinternal.ReorderImagesRequest) (*productinternal.ReorderImagesResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	imageIDs := make([]uuid.UUID, 0, len(request.ImageIDs))
+	for _, id := range request.ImageIDs {
+		imageID, err := parseUUID("imageIDs", id)
+		if err != nil {
+			return nil, err
+		}
+		imageIDs = append(imageIDs, imageID)
+	}
+
+	err = p.imageService.ReorderImages(ctx, productID, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.ReorderImagesResponse{}, nil
+}
+
+func (p *productInternalAPI) RemoveImage(ctx context.Context, request *productinternal.RemoveImageRequest) (*productinternal.RemoveImageResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	imageID, err := parseUUID("imageID", request.ImageID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.imageService.RemoveImage(ctx, productID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.RemoveImageResponse{}, nil
+}
+
+func toImage(image appmodel.Image) *productinternal.Image {
+	return &productinternal.Image{
+		ImageID:     image.ImageID.String(),
+		Url:         image.URL,
+		ObjectKey:   image.ObjectKey,
+		ContentType: image.ContentType,
+		Size:        image.Size,
+		Width:       int32(image.Width),
+		Height:      int32(image.Height),
+		Position:    int32(image.Position),
+		AltText:     image.AltText,
+	}
+}
+
+// uploadReader читает содержимое файла из сообщений chunk потока UploadImage
+type uploadReader struct {
+	stream productinternal.ProductInternalService_UploadImageServer
+	chunk  []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		request, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if request.GetMetadata() != nil {
+			return 0, invalidArgumentError("metadata", "must be sent only once")
+		}
+		r.chunk = request.GetChunk()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
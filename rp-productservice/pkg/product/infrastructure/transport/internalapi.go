@@ -2,6 +2,7 @@ package transport
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 
@@ -9,21 +10,65 @@ import (
 	appmodel "productservice/pkg/product/application/model"
 	"productservice/pkg/product/application/query"
 	"productservice/pkg/product/application/service"
+	"productservice/pkg/product/domain/model"
 )
 
+// MethodPermissions - права из userservice, нужные для вызова методов ProductInternalService.
+// Чтение каталога и отзывы покупателей доступны без прав
+var MethodPermissions = map[string]string{
+	"StoreProduct":         string(model.PermissionManageProducts),
+	"ArchiveProduct":       string(model.PermissionManageProducts),
+	"UnarchiveProduct":     string(model.PermissionManageProducts),
+	"PurgeProduct":         string(model.PermissionPurgeProducts),
+	"StoreCategory":        string(model.PermissionManageProducts),
+	"DeleteCategory":       string(model.PermissionManageProducts),
+	"SetProductCategories": string(model.PermissionManageProducts),
+	"SchedulePrice":        string(model.PermissionManageProducts),
+	"CancelScheduledPrice": string(model.PermissionManageProducts),
+	"AddImage":             string(model.PermissionManageProducts),
+	"UploadImage":          string(model.PermissionManageProducts),
+	"ReorderImages":        string(model.PermissionManageProducts),
+	"RemoveImage":          string(model.PermissionManageProducts),
+	"ModerateReview":       string(model.PermissionManageProducts),
+}
+
 func NewProductInternalAPI(
 	productQueryService query.ProductQueryService,
+	categoryQueryService query.CategoryQueryService,
+	priceQueryService query.PriceQueryService,
+	auditLogQueryService query.AuditLogQueryService,
+	reviewQueryService query.ReviewQueryService,
 	productService service.ProductService,
+	categoryService service.CategoryService,
+	priceService service.PriceService,
+	imageService service.ImageService,
+	reviewService service.ReviewService,
 ) productinternal.ProductInternalServiceServer {
 	return &productInternalAPI{
-		productQueryService: productQueryService,
-		productService:      productService,
+		productQueryService:  productQueryService,
+		categoryQueryService: categoryQueryService,
+		priceQueryService:    priceQueryService,
+		auditLogQueryService: auditLogQueryService,
+		reviewQueryService:   reviewQueryService,
+		productService:       productService,
+		categoryService:      categoryService,
+		priceService:         priceService,
+		imageService:         imageService,
+		reviewService:        reviewService,
 	}
 }
 
 type productInternalAPI struct {
-	productQueryService query.ProductQueryService
-	productService      service.ProductService
+	productQueryService  query.ProductQueryService
+	categoryQueryService query.CategoryQueryService
+	priceQueryService    query.PriceQueryService
+	auditLogQueryService query.AuditLogQueryService
+	reviewQueryService   query.ReviewQueryService
+	productService       service.ProductService
+	categoryService      service.CategoryService
+	priceService         service.PriceService
+	imageService         service.ImageService
+	reviewService        service.ReviewService
 
 	productinternal.UnimplementedProductInternalServiceServer
 }
@@ -34,17 +79,37 @@ func (p *productInternalAPI) StoreProduct(ctx context.Context, request *producti
 		err       error
 	)
 	if request.Product.ProductID != "" {
-		productID, err = uuid.Parse(request.Product.ProductID)
+		productID, err = parseUUID("product.productID", request.Product.ProductID)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	variants := make([]appmodel.Variant, 0, len(request.Product.Variants))
+	for i, variant := range request.Product.Variants {
+		var variantID uuid.UUID
+		if variant.VariantID != "" {
+			variantID, err = parseUUID(fmt.Sprintf("product.variants[%d].variantID", i), variant.VariantID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		variants = append(variants, appmodel.Variant{
+			VariantID:  variantID,
+			SKU:        variant.Sku,
+			Attributes: variant.Attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+
 	productID, err = p.productService.StoreProduct(ctx, appmodel.Product{
 		ProductID:   productID,
 		Name:        request.Product.Name,
 		Price:       request.Product.Price,
+		Currency:    request.Product.Currency,
 		Description: request.Product.Description,
+		Variants:    variants,
 	})
 	if err != nil {
 		return nil, err
@@ -56,11 +121,24 @@ func (p *productInternalAPI) StoreProduct(ctx context.Context, request *producti
 }
 
 func (p *productInternalAPI) FindProduct(ctx context.Context, request *productinternal.FindProductRequest) (*productinternal.FindProductResponse, error) {
-	productID, err := uuid.Parse(request.ProductID)
-	if err != nil {
-		return nil, err
+	if (request.ProductID == "") == (request.Slug == "") {
+		return nil, invalidArgumentError("productID", "exactly one of productID and slug must be set")
+	}
+
+	var (
+		product *appmodel.Product
+		err     error
+	)
+	if request.Slug != "" {
+		product, err = p.productQueryService.FindProductBySlug(ctx, request.Slug)
+	} else {
+		var productID uuid.UUID
+		productID, err = parseUUID("productID", request.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		product, err = p.productQueryService.FindProduct(ctx, productID)
 	}
-	product, err := p.productQueryService.FindProduct(ctx, productID)
 	if err != nil {
 		return nil, err
 	}
@@ -68,11 +146,193 @@ func (p *productInternalAPI) FindProduct(ctx context.Context, request *productin
 		return &productinternal.FindProductResponse{}, nil
 	}
 	return &productinternal.FindProductResponse{
-		Product: &productinternal.Product{
-			ProductID:   product.ProductID.String(),
-			Name:        product.Name,
-			Price:       product.Price,
-			Description: product.Description,
-		},
+		Product: toProduct(*product),
+	}, nil
+}
+
+func (p *productInternalAPI) ArchiveProduct(ctx context.Context, request *productinternal.ArchiveProductRequest) (*productinternal.ArchiveProductResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.productService.ArchiveProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.ArchiveProductResponse{}, nil
+}
+
+func (p *productInternalAPI) UnarchiveProduct(ctx context.Context, request *productinternal.UnarchiveProductRequest) (*productinternal.UnarchiveProductResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.productService.UnarchiveProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.UnarchiveProductResponse{}, nil
+}
+
+func (p *productInternalAPI) PurgeProduct(ctx context.Context, request *productinternal.PurgeProductRequest) (*productinternal.PurgeProductResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.productService.PurgeProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.PurgeProductResponse{}, nil
+}
+
+func (p *productInternalAPI) FindAuditLog(ctx context.Context, request *productinternal.FindAuditLogRequest) (*productinternal.FindAuditLogResponse, error) {
+	records, err := p.auditLogQueryService.FindAuditLog(ctx, request.EntityType, request.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.FindAuditLogResponse{
+		Records: toAuditRecords(records),
+	}, nil
+}
+
+func (p *productInternalAPI) StoreCategory(ctx context.Context, request *productinternal.StoreCategoryRequest) (*productinternal.StoreCategoryResponse, error) {
+	var (
+		category appmodel.Category
+		err      error
+	)
+	if request.Category.CategoryID != "" {
+		category.CategoryID, err = parseUUID("category.categoryID", request.Category.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.Category.ParentID != nil {
+		parentID, err := parseUUID("category.parentID", *request.Category.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		category.ParentID = &parentID
+	}
+	category.Name = request.Category.Name
+
+	categoryID, err := p.categoryService.StoreCategory(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.StoreCategoryResponse{
+		CategoryID: categoryID.String(),
+	}, nil
+}
+
+func (p *productInternalAPI) DeleteCategory(ctx context.Context, request *productinternal.DeleteCategoryRequest) (*productinternal.DeleteCategoryResponse, error) {
+	categoryID, err := parseUUID("categoryID", request.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.categoryService.DeleteCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.DeleteCategoryResponse{}, nil
+}
+
+func (p *productInternalAPI) ListCategories(ctx context.Context, _ *productinternal.ListCategoriesRequest) (*productinternal.ListCategoriesResponse, error) {
+	categories, err := p.categoryQueryService.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*productinternal.Category, 0, len(categories))
+	for _, category := range categories {
+		c := &productinternal.Category{
+			CategoryID: category.CategoryID.String(),
+			Name:       category.Name,
+		}
+		if category.ParentID != nil {
+			parentID := category.ParentID.String()
+			c.ParentID = &parentID
+		}
+		result = append(result, c)
+	}
+	return &productinternal.ListCategoriesResponse{
+		Categories: result,
+	}, nil
+}
+
+func (p *productInternalAPI) SetProductCategories(ctx context.Context, request *productinternal.SetProductCategoriesRequest) (*productinternal.SetProductCategoriesResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	categoryIDs := make([]uuid.UUID, 0, len(request.CategoryIDs))
+	for _, id := range request.CategoryIDs {
+		categoryID, err := parseUUID("categoryIDs", id)
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+
+	err = p.categoryService.SetProductCategories(ctx, productID, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.SetProductCategoriesResponse{}, nil
+}
+
+func (p *productInternalAPI) ListCategoryProducts(ctx context.Context, request *productinternal.ListCategoryProductsRequest) (*productinternal.ListCategoryProductsResponse, error) {
+	categoryID, err := parseUUID("categoryID", request.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	products, err := p.categoryQueryService.ListCategoryProducts(ctx, categoryID, request.IncludeSubcategories)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*productinternal.Product, 0, len(products))
+	for _, product := range products {
+		result = append(result, toProduct(product))
+	}
+	return &productinternal.ListCategoryProductsResponse{
+		Products: result,
 	}, nil
 }
+
+func toProduct(product appmodel.Product) *productinternal.Product {
+	categoryIDs := make([]string, 0, len(product.CategoryIDs))
+	for _, categoryID := range product.CategoryIDs {
+		categoryIDs = append(categoryIDs, categoryID.String())
+	}
+	variants := make([]*productinternal.Variant, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variants = append(variants, &productinternal.Variant{
+			VariantID:  variant.VariantID.String(),
+			Sku:        variant.SKU,
+			Attributes: variant.Attributes,
+			Price:      variant.Price,
+			Stock:      variant.Stock,
+		})
+	}
+	images := make([]*productinternal.Image, 0, len(product.Images))
+	for _, image := range product.Images {
+		images = append(images, toImage(image))
+	}
+	result := &productinternal.Product{
+		ProductID:     product.ProductID.String(),
+		Name:          product.Name,
+		Slug:          product.Slug,
+		Price:         product.Price,
+		Currency:      product.Currency,
+		Description:   product.Description,
+		CategoryIDs:   categoryIDs,
+		Variants:      variants,
+		Images:        images,
+		AverageRating: product.Rating.Average,
+		ReviewCount:   int32(product.Rating.Count), // nolint:gosec
+	}
+	if product.ArchivedAt != nil {
+		archivedAt := product.ArchivedAt.Unix()
+		result.ArchivedAt = &archivedAt
+	}
+	return result
+}
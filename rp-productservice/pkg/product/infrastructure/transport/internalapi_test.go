@@ -0,0 +1,46 @@
+package transport
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"productservice/pkg/product/domain/model"
+	"productservice/pkg/product/infrastructure/transport/middlewares"
+)
+
+type stubAuthorizer map[uuid.UUID][]model.Permission
+
+func (a stubAuthorizer) Authorize(_ context.Context, userID uuid.UUID, permission string) error {
+	if slices.Contains(a[userID], model.Permission(permission)) {
+		return nil
+	}
+	return model.ErrPermissionDenied
+}
+
+func TestMethodPermissions_PurgeProduct(t *testing.T) {
+	manager := uuid.New()
+	admin := uuid.New()
+	authorizer := stubAuthorizer{
+		manager: {model.PermissionManageProducts},
+		admin:   {model.PermissionManageProducts, model.PermissionPurgeProducts},
+	}
+	middleware := middlewares.NewGRPCAuthorizationMiddleware(authorizer, MethodPermissions)
+
+	call := func(userID uuid.UUID, method string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middlewares.UserIDMetadataKey, userID.String()))
+		_, err := middleware(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/Product.ProductInternalService/" + method},
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+		return err
+	}
+
+	// менеджер каталога архивирует товары, но удалить их навсегда может только администратор
+	assert.NoError(t, call(manager, "ArchiveProduct"))
+	assert.ErrorIs(t, call(manager, "PurgeProduct"), model.ErrPermissionDenied)
+	assert.NoError(t, call(admin, "PurgeProduct"))
+}
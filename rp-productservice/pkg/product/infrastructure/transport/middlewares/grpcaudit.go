@@ -0,0 +1,80 @@
+package middlewares
+
+import (
+	"context"
+	"net"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"productservice/pkg/product/infrastructure/audit"
+)
+
+const CorrelationIDMetadataKey = "x-correlation-id"
+
+// NewGRPCAuditMiddleware кладет в контекст вызывающего, метод и correlation id для журнала аудита
+func NewGRPCAuditMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(auditContext(ctx, info.FullMethod, grpc.SetHeader), req)
+	}
+}
+
+func NewGRPCAuditStreamMiddleware() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := auditContext(ss.Context(), info.FullMethod, func(_ context.Context, md metadata.MD) error {
+			return ss.SetHeader(md)
+		})
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func auditContext(ctx context.Context, method string, setHeader func(context.Context, metadata.MD) error) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	correlationID := firstMetadataValue(md, CorrelationIDMetadataKey)
+	if correlationID == "" {
+		correlationID = uuid.NewString()
+	}
+	_ = setHeader(ctx, metadata.Pairs(CorrelationIDMetadataKey, correlationID))
+
+	return audit.WithInfo(ctx, audit.Info{
+		Actor:         auditActor(ctx, md),
+		Method:        method,
+		CorrelationID: correlationID,
+	})
+}
+
+// contextServerStream передает обработчику потокового вызова дополненный контекст
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func auditActor(ctx context.Context, md metadata.MD) string {
+	if userID := firstMetadataValue(md, UserIDMetadataKey); userID != "" {
+		return "user:" + userID
+	}
+	if callerID := firstMetadataValue(md, CallerIDMetadataKey); callerID != "" {
+		return "caller:" + callerID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "peer:" + host
+		}
+		return "peer:" + p.Addr.String()
+	}
+	return audit.SystemActor
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
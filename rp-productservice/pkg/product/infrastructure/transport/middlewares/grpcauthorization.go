@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"context"
+	"path"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"productservice/pkg/product/domain/model"
+)
+
+// Authorizer проверяет право пользователя по локальной копии прав из userservice
+type Authorizer interface {
+	Authorize(ctx context.Context, userID uuid.UUID, permission string) error
+}
+
+// NewGRPCAuthorizationMiddleware пускает к методам из permissions (короткое имя метода -> право)
+// только пользователей с нужным правом. Пользователя передает gateway в x-user-id,
+// вызов такого метода без пользователя отклоняется. Остальные методы не проверяются
+func NewGRPCAuthorizationMiddleware(authorizer Authorizer, permissions map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := authorize(ctx, authorizer, permissions, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func NewGRPCAuthorizationStreamMiddleware(authorizer Authorizer, permissions map[string]string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := authorize(ss.Context(), authorizer, permissions, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authorize(ctx context.Context, authorizer Authorizer, permissions map[string]string, fullMethod string) error {
+	permission, ok := permissions[path.Base(fullMethod)]
+	if !ok {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	userID, err := uuid.Parse(firstMetadataValue(md, UserIDMetadataKey))
+	if err != nil {
+		return model.ErrPermissionDenied
+	}
+	return authorizer.Authorize(ctx, userID, permission)
+}
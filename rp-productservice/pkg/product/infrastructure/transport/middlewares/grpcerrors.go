@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"productservice/pkg/product/domain/model"
+)
+
+const ErrorDomain = "productservice"
+
+type domainError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var domainErrors = []domainError{
+	{err: model.ErrProductNotFound, code: codes.NotFound, reason: "PRODUCT_NOT_FOUND"},
+	{err: model.ErrProductNameAlreadyUsed, code: codes.AlreadyExists, reason: "PRODUCT_NAME_ALREADY_USED"},
+	{err: model.ErrProductSlugAlreadyUsed, code: codes.Aborted, reason: "PRODUCT_SLUG_ALREADY_USED"},
+	{err: model.ErrProductNameRequired, code: codes.InvalidArgument, reason: "PRODUCT_NAME_REQUIRED"},
+	{err: model.ErrNegativePrice, code: codes.InvalidArgument, reason: "NEGATIVE_PRICE"},
+	{err: model.ErrInvalidCurrency, code: codes.InvalidArgument, reason: "INVALID_CURRENCY"},
+	{err: model.ErrProductNotArchived, code: codes.FailedPrecondition, reason: "PRODUCT_NOT_ARCHIVED"},
+	{err: model.ErrVariantNotFound, code: codes.NotFound, reason: "VARIANT_NOT_FOUND"},
+	{err: model.ErrVariantSKUAlreadyUsed, code: codes.AlreadyExists, reason: "VARIANT_SKU_ALREADY_USED"},
+	{err: model.ErrCategoryNotFound, code: codes.NotFound, reason: "CATEGORY_NOT_FOUND"},
+	{err: model.ErrCategoryCycle, code: codes.FailedPrecondition, reason: "CATEGORY_CYCLE"},
+	{err: model.ErrCategoryNotEmpty, code: codes.FailedPrecondition, reason: "CATEGORY_NOT_EMPTY"},
+	{err: model.ErrScheduledPriceNotFound, code: codes.NotFound, reason: "SCHEDULED_PRICE_NOT_FOUND"},
+	{err: model.ErrScheduledPriceNotPending, code: codes.FailedPrecondition, reason: "SCHEDULED_PRICE_NOT_PENDING"},
+	{err: model.ErrInvalidPricePeriod, code: codes.InvalidArgument, reason: "INVALID_PRICE_PERIOD"},
+	{err: model.ErrScheduledPriceOverlaps, code: codes.FailedPrecondition, reason: "SCHEDULED_PRICE_OVERLAPS"},
+	{err: model.ErrImageNotFound, code: codes.NotFound, reason: "IMAGE_NOT_FOUND"},
+	{err: model.ErrImageSourceRequired, code: codes.InvalidArgument, reason: "IMAGE_SOURCE_REQUIRED"},
+	{err: model.ErrImageOrderMismatch, code: codes.InvalidArgument, reason: "IMAGE_ORDER_MISMATCH"},
+	{err: model.ErrUnsupportedImageType, code: codes.InvalidArgument, reason: "UNSUPPORTED_IMAGE_TYPE"},
+	{err: model.ErrImageTooLarge, code: codes.InvalidArgument, reason: "IMAGE_TOO_LARGE"},
+	{err: model.ErrReviewNotFound, code: codes.NotFound, reason: "REVIEW_NOT_FOUND"},
+	{err: model.ErrReviewAlreadyExists, code: codes.AlreadyExists, reason: "REVIEW_ALREADY_EXISTS"},
+	{err: model.ErrInvalidRating, code: codes.InvalidArgument, reason: "INVALID_RATING"},
+	{err: model.ErrProductNotPurchased, code: codes.FailedPrecondition, reason: "PRODUCT_NOT_PURCHASED"},
+	{err: model.ErrInvalidReviewStatus, code: codes.InvalidArgument, reason: "INVALID_REVIEW_STATUS"},
+	{err: model.ErrPermissionDenied, code: codes.PermissionDenied, reason: "PERMISSION_DENIED"},
+}
+
+func NewGRPCErrorsMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(err)
+		}
+		return resp, nil
+	}
+}
+
+func NewGRPCErrorsStreamMiddleware() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			return toStatusError(err)
+		}
+		return nil
+	}
+}
+
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range domainErrors {
+		if errors.Is(err, e.err) {
+			return newStatusError(e.code, e.reason, e.err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		// детали внутренних ошибок наружу не отдаем, они есть в логах
+		return newStatusError(codes.Internal, "INTERNAL", "internal error")
+	}
+}
+
+func newStatusError(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -29,3 +29,23 @@ func NewGRPCLoggingMiddleware(logger logging.Logger) grpc.UnaryServerInterceptor
 		return resp, err
 	}
 }
+
+func NewGRPCLoggingStreamMiddleware(logger logging.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		// сообщения потока не логируем, в них могут быть мегабайты данных
+		l := logger.WithFields(logging.Fields{
+			"duration": time.Since(start).String(),
+			"method":   info.FullMethod,
+		})
+		if err != nil {
+			l.Error(err, "call failed")
+		} else {
+			l.Info("call finished")
+		}
+		return err
+	}
+}
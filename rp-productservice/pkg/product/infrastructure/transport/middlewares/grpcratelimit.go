@@ -0,0 +1,152 @@
+package middlewares
+
+import (
+	"context"
+	"math"
+	"net"
+	"path"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"productservice/pkg/product/infrastructure/ratelimit"
+)
+
+const (
+	RetryAfterMetadataKey = "retry-after"
+	UserIDMetadataKey     = "x-user-id"
+	CallerIDMetadataKey   = "x-caller-id"
+)
+
+// RateLimits - лимиты по методам (короткое имя, например StoreProduct) и по вызывающим (userID или x-caller-id).
+// Лимит вызывающего важнее лимита метода, лимит метода важнее лимита по умолчанию
+type RateLimits struct {
+	Default ratelimit.Limit
+	Methods map[string]ratelimit.Limit
+	Callers map[string]ratelimit.Limit
+}
+
+func (l RateLimits) limit(method, caller string) ratelimit.Limit {
+	if limit, ok := l.Callers[caller]; ok {
+		return limit
+	}
+	if limit, ok := l.Methods[method]; ok {
+		return limit
+	}
+	return l.Default
+}
+
+func NewGRPCRateLimitMiddleware(limiter ratelimit.Limiter, limits RateLimits) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := takeRateLimit(ctx, limiter, limits, info.FullMethod, callerIdentity(ctx, req), grpc.SetHeader)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewGRPCRateLimitStreamMiddleware считает потоковый вызов одним запросом, сообщения потока не учитываются
+func NewGRPCRateLimitStreamMiddleware(limiter ratelimit.Limiter, limits RateLimits) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		err := takeRateLimit(ctx, limiter, limits, info.FullMethod, callerIdentity(ctx, nil), func(_ context.Context, md metadata.MD) error {
+			return ss.SetHeader(md)
+		})
+		if err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func takeRateLimit(
+	ctx context.Context,
+	limiter ratelimit.Limiter,
+	limits RateLimits,
+	fullMethod, caller string,
+	setHeader func(context.Context, metadata.MD) error,
+) error {
+	method := path.Base(fullMethod)
+	limit := limits.limit(method, caller)
+	if limit.Unlimited() {
+		return nil
+	}
+
+	allowed, retryAfter, err := limiter.Take(ctx, fullMethod+"|"+caller, limit)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return newRateLimitError(ctx, method, caller, retryAfter, setHeader)
+	}
+	return nil
+}
+
+// callerIdentity - userID из метаданных или запроса, затем x-caller-id, затем адрес клиента
+func callerIdentity(ctx context.Context, req interface{}) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if userID := firstMetadataValue(md, UserIDMetadataKey); userID != "" {
+		return userID
+	}
+	if msg, ok := req.(proto.Message); ok {
+		if userID := findUserID(msg.ProtoReflect()); userID != "" {
+			return userID
+		}
+	}
+	if callerID := firstMetadataValue(md, CallerIDMetadataKey); callerID != "" {
+		return callerID
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+func findUserID(msg protoreflect.Message) string {
+	fd := msg.Descriptor().Fields().ByName("userID")
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return msg.Get(fd).String()
+}
+
+func newRateLimitError(
+	ctx context.Context,
+	method, caller string,
+	retryAfter time.Duration,
+	setHeader func(context.Context, metadata.MD) error,
+) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	_ = setHeader(ctx, metadata.Pairs(RetryAfterMetadataKey, strconv.FormatInt(seconds, 10)))
+
+	message := "rate limit exceeded for " + method
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "RATE_LIMIT_EXCEEDED",
+			Domain: ErrorDomain,
+		},
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{Subject: caller, Description: message},
+			},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, message)
+	}
+	return st.Err()
+}
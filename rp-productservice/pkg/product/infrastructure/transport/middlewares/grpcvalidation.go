@@ -0,0 +1,190 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"productservice/api/server/productinternal"
+)
+
+// NewGRPCValidationMiddleware проверяет запрос по правилам (rules) из .proto до вызова обработчика
+func NewGRPCValidationMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if violations := validateMessage(msg.ProtoReflect(), ""); len(violations) > 0 {
+				return nil, newValidationError(violations)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewGRPCValidationStreamMiddleware проверяет каждое входящее сообщение потокового вызова
+func NewGRPCValidationStreamMiddleware() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingServerStream{ServerStream: ss})
+	}
+}
+
+type validatingServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if violations := validateMessage(msg.ProtoReflect(), ""); len(violations) > 0 {
+			return newValidationError(violations)
+		}
+	}
+	return nil
+}
+
+func validateMessage(msg protoreflect.Message, prefix string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+
+		if rules, ok := proto.GetExtension(fd.Options(), productinternal.E_Rules).(*productinternal.FieldRules); ok && rules != nil {
+			for _, description := range validateField(msg, fd, rules) {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       path,
+					Description: description,
+				})
+			}
+		}
+
+		if fd.Message() == nil || fd.IsMap() {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				violations = append(violations, validateMessage(list.Get(j).Message(), fmt.Sprintf("%s[%d].", path, j))...)
+			}
+			continue
+		}
+		if msg.Has(fd) {
+			violations = append(violations, validateMessage(msg.Get(fd).Message(), path+".")...)
+		}
+	}
+	return violations
+}
+
+func validateField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, rules *productinternal.FieldRules) []string {
+	if fd.IsList() {
+		list := msg.Get(fd).List()
+		if n := list.Len(); n < int(rules.MinItems) || (rules.Required && n == 0) {
+			return []string{fmt.Sprintf("must contain at least %d items", max(rules.MinItems, 1))}
+		}
+		if rules.MaxItems > 0 && list.Len() > int(rules.MaxItems) {
+			return []string{fmt.Sprintf("must contain at most %d items", rules.MaxItems)}
+		}
+		if fd.Kind() != protoreflect.StringKind {
+			return nil
+		}
+		var violations []string
+		for i := 0; i < list.Len(); i++ {
+			for _, description := range validateString(list.Get(i).String(), rules) {
+				violations = append(violations, fmt.Sprintf("item %d %s", i, description))
+			}
+		}
+		return violations
+	}
+
+	// незаданное optional поле проверяем только на обязательность
+	if fd.HasPresence() && !msg.Has(fd) {
+		if rules.Required {
+			return []string{"is required"}
+		}
+		return nil
+	}
+
+	value := msg.Get(fd)
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return validateString(value.String(), rules)
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return validateInt(value.Int(), rules)
+	default:
+		return nil
+	}
+}
+
+func validateString(value string, rules *productinternal.FieldRules) []string {
+	if value == "" {
+		if rules.Required {
+			return []string{"is required"}
+		}
+		return nil
+	}
+
+	var violations []string
+	length := uint32(utf8.RuneCountInString(value))
+	if rules.MinLen > 0 && length < rules.MinLen {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", rules.MinLen))
+	}
+	if rules.MaxLen > 0 && length > rules.MaxLen {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters", rules.MaxLen))
+	}
+	if rules.Uuid {
+		if _, err := uuid.Parse(value); err != nil {
+			violations = append(violations, "must be a valid UUID")
+		}
+	}
+	if rules.Email {
+		// ParseAddress допускает форму "Name <addr>", поэтому сравниваем с исходной строкой
+		if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+			violations = append(violations, "must be a valid email address")
+		}
+	}
+	return violations
+}
+
+func validateInt(value int64, rules *productinternal.FieldRules) []string {
+	var violations []string
+	if rules.Gt != nil && value <= *rules.Gt {
+		violations = append(violations, fmt.Sprintf("must be greater than %d", *rules.Gt))
+	}
+	if rules.Gte != nil && value < *rules.Gte {
+		violations = append(violations, fmt.Sprintf("must be greater than or equal to %d", *rules.Gte))
+	}
+	return violations
+}
+
+func newValidationError(violations []*errdetails.BadRequest_FieldViolation) error {
+	descriptions := make([]string, 0, len(violations))
+	for _, v := range violations {
+		descriptions = append(descriptions, v.Field+" "+v.Description)
+	}
+	message := "invalid request: " + strings.Join(descriptions, "; ")
+
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "INVALID_ARGUMENT",
+			Domain: ErrorDomain,
+		},
+		&errdetails.BadRequest{FieldViolations: violations},
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
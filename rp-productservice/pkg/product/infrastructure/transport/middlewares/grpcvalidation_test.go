@@ -0,0 +1,165 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"productservice/api/server/productinternal"
+)
+
+func TestValidateString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		rules *productinternal.FieldRules
+		want  []string
+	}{
+		{name: "required_empty", value: "", rules: &productinternal.FieldRules{Required: true}, want: []string{"is required"}},
+		{name: "required_set", value: "x", rules: &productinternal.FieldRules{Required: true}},
+		{name: "optional_empty_skips_rules", value: "", rules: &productinternal.FieldRules{Uuid: true, MinLen: 3}},
+		{name: "uuid_valid", value: "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11", rules: &productinternal.FieldRules{Uuid: true}},
+		{name: "uuid_invalid", value: "not-a-uuid", rules: &productinternal.FieldRules{Uuid: true}, want: []string{"must be a valid UUID"}},
+		{name: "min_len", value: "ab", rules: &productinternal.FieldRules{MinLen: 3}, want: []string{"must be at least 3 characters"}},
+		// длина считается в символах, а не в байтах
+		{name: "min_len_runes", value: "абв", rules: &productinternal.FieldRules{MinLen: 3, MaxLen: 3}},
+		{name: "max_len", value: "abcd", rules: &productinternal.FieldRules{MaxLen: 3}, want: []string{"must be at most 3 characters"}},
+		{name: "email_valid", value: "user@example.com", rules: &productinternal.FieldRules{Email: true}},
+		{name: "email_invalid", value: "user.example.com", rules: &productinternal.FieldRules{Email: true}, want: []string{"must be a valid email address"}},
+		{name: "email_display_name", value: "User <user@example.com>", rules: &productinternal.FieldRules{Email: true}, want: []string{"must be a valid email address"}},
+		{
+			name:  "several_violations",
+			value: "x",
+			rules: &productinternal.FieldRules{MinLen: 3, Uuid: true},
+			want:  []string{"must be at least 3 characters", "must be a valid UUID"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateString(tt.value, tt.rules))
+		})
+	}
+}
+
+func TestValidateInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int64
+		rules *productinternal.FieldRules
+		want  []string
+	}{
+		{name: "gt_equal", value: 0, rules: &productinternal.FieldRules{Gt: proto.Int64(0)}, want: []string{"must be greater than 0"}},
+		{name: "gt_greater", value: 1, rules: &productinternal.FieldRules{Gt: proto.Int64(0)}},
+		{name: "gte_equal", value: 0, rules: &productinternal.FieldRules{Gte: proto.Int64(0)}},
+		{name: "gte_less", value: -1, rules: &productinternal.FieldRules{Gte: proto.Int64(0)}, want: []string{"must be greater than or equal to 0"}},
+		// нулевая граница задается явно, поэтому отсутствие правила и gt = 0 различаются
+		{name: "no_rules", value: -100, rules: &productinternal.FieldRules{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateInt(tt.value, tt.rules))
+		})
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	validID := "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11"
+
+	t.Run("required_message", func(t *testing.T) {
+		violations := validateMessage((&productinternal.StoreProductRequest{}).ProtoReflect(), "")
+		assert.Equal(t, map[string]string{"product": "is required"}, violationMap(violations))
+	})
+
+	t.Run("nested_fields", func(t *testing.T) {
+		request := &productinternal.StoreProductRequest{
+			Product: &productinternal.Product{
+				ProductID: "bad",
+				Price:     -1,
+				Currency:  "RUBL",
+				Variants:  []*productinternal.Variant{{Sku: "", Stock: -1}},
+			},
+		}
+		violations := validateMessage(request.ProtoReflect(), "")
+		assert.Equal(t, map[string]string{
+			"product.productID":         "must be a valid UUID",
+			"product.name":              "is required",
+			"product.price":             "must be greater than or equal to 0",
+			"product.currency":          "must be at most 3 characters",
+			"product.variants[0].sku":   "is required",
+			"product.variants[0].stock": "must be greater than or equal to 0",
+		}, violationMap(violations))
+	})
+
+	t.Run("max_items", func(t *testing.T) {
+		variants := make([]*productinternal.Variant, 101)
+		for i := range variants {
+			variants[i] = &productinternal.Variant{Sku: "SKU"}
+		}
+		request := &productinternal.StoreProductRequest{Product: &productinternal.Product{Name: "Mug", Variants: variants}}
+		violations := validateMessage(request.ProtoReflect(), "")
+		assert.Equal(t, map[string]string{"product.variants": "must contain at most 100 items"}, violationMap(violations))
+	})
+
+	t.Run("optional_presence", func(t *testing.T) {
+		request := &productinternal.SchedulePriceRequest{ProductID: validID, EffectiveFrom: 1}
+		assert.Empty(t, validateMessage(request.ProtoReflect(), ""))
+
+		request.EffectiveUntil = proto.Int64(0)
+		request.VariantID = proto.String("bad")
+		violations := validateMessage(request.ProtoReflect(), "")
+		assert.Equal(t, map[string]string{
+			"variantID":      "must be a valid UUID",
+			"effectiveUntil": "must be greater than 0",
+		}, violationMap(violations))
+	})
+
+	t.Run("empty_list", func(t *testing.T) {
+		// categoryIDs может быть пустым: так у товара снимаются все категории
+		request := &productinternal.SetProductCategoriesRequest{ProductID: validID}
+		assert.Empty(t, validateMessage(request.ProtoReflect(), ""))
+	})
+}
+
+func TestGRPCValidationMiddleware(t *testing.T) {
+	interceptor := NewGRPCValidationMiddleware()
+	handlerCalled := false
+	handler := func(_ context.Context, _ interface{}) (interface{}, error) {
+		handlerCalled = true
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), &productinternal.ArchiveProductRequest{ProductID: "1"}, nil, handler)
+	assert.False(t, handlerCalled)
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+	require.NotNil(t, badRequest)
+	assert.NotEmpty(t, badRequest.FieldViolations)
+
+	_, err = interceptor(context.Background(), &productinternal.ArchiveProductRequest{ProductID: "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11"}, nil, handler)
+	assert.NoError(t, err)
+	assert.True(t, handlerCalled)
+}
+
+func violationMap(violations []*errdetails.BadRequest_FieldViolation) map[string]string {
+	result := make(map[string]string, len(violations))
+	for _, v := range violations {
+		if previous, ok := result[v.Field]; ok {
+			result[v.Field] = previous + "; " + v.Description
+			continue
+		}
+		result[v.Field] = v.Description
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package transport
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"productservice/api/server/productinternal"
+	appmodel "productservice/pkg/product/application/model"
+)
+
+func (p *productInternalAPI) SchedulePrice(ctx context.Context, request *productinternal.SchedulePriceRequest) (*productinternal.SchedulePriceResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	price := appmodel.ScheduledPrice{
+		ProductID:     productID,
+		Price:         request.Price,
+		EffectiveFrom: time.Unix(request.EffectiveFrom, 0),
+	}
+	if request.VariantID != nil {
+		variantID, err := parseUUID("variantID", *request.VariantID)
+		if err != nil {
+			return nil, err
+		}
+		price.VariantID = &variantID
+	}
+	if request.EffectiveUntil != nil {
+		effectiveUntil := time.Unix(*request.EffectiveUntil, 0)
+		price.EffectiveUntil = &effectiveUntil
+	}
+
+	scheduledPriceID, err := p.priceService.SchedulePrice(ctx, price)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.SchedulePriceResponse{
+		ScheduledPriceID: scheduledPriceID.String(),
+	}, nil
+}
+
+func (p *productInternalAPI) CancelScheduledPrice(ctx context.Context, request *productinternal.CancelScheduledPriceRequest) (*productinternal.CancelScheduledPriceResponse, error) {
+	scheduledPriceID, err := parseUUID("scheduledPriceID", request.ScheduledPriceID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.priceService.CancelScheduledPrice(ctx, scheduledPriceID)
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.CancelScheduledPriceResponse{}, nil
+}
+
+func (p *productInternalAPI) GetPriceHistory(ctx context.Context, request *productinternal.GetPriceHistoryRequest) (*productinternal.GetPriceHistoryResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := p.priceQueryService.GetPriceHistory(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*productinternal.PriceRecord, 0, len(history.Records))
+	for _, record := range history.Records {
+		records = append(records, &productinternal.PriceRecord{
+			VariantID: uuidString(record.VariantID),
+			Price:     record.Price,
+			Currency:  record.Currency,
+			ChangedAt: record.ChangedAt.Unix(),
+		})
+	}
+	scheduledPrices := make([]*productinternal.ScheduledPrice, 0, len(history.ScheduledPrices))
+	for _, price := range history.ScheduledPrices {
+		scheduledPrice := &productinternal.ScheduledPrice{
+			ScheduledPriceID: price.ScheduledPriceID.String(),
+			VariantID:        uuidString(price.VariantID),
+			Price:            price.Price,
+			Currency:         price.Currency,
+			PreviousPrice:    price.PreviousPrice,
+			EffectiveFrom:    price.EffectiveFrom.Unix(),
+			Status:           productinternal.ScheduledPriceStatus(price.Status), // nolint:gosec
+		}
+		if price.EffectiveUntil != nil {
+			effectiveUntil := price.EffectiveUntil.Unix()
+			scheduledPrice.EffectiveUntil = &effectiveUntil
+		}
+		scheduledPrices = append(scheduledPrices, scheduledPrice)
+	}
+	return &productinternal.GetPriceHistoryResponse{
+		Records:         records,
+		ScheduledPrices: scheduledPrices,
+	}, nil
+}
+
+func uuidString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"context"
+
+	"productservice/api/server/productinternal"
+	appmodel "productservice/pkg/product/application/model"
+)
+
+const (
+	defaultReviewsLimit = 50
+	maxReviewsLimit     = 100
+)
+
+func (p *productInternalAPI) CreateReview(ctx context.Context, request *productinternal.CreateReviewRequest) (*productinternal.CreateReviewResponse, error) {
+	productID, err := parseUUID("productID", request.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	authorID, err := parseUUID("authorID", request.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewID, err := p.reviewService.CreateReview(ctx, appmodel.Review{
+		ProductID:  productID,
+		AuthorID:   authorID,
+		AuthorName: request.AuthorName,
+		Rating:     int(request.Rating),
+		Text:       request.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.CreateReviewResponse{
+		ReviewID: reviewID.String(),
+	}, nil
+}
+
+func (p *productInternalAPI) ModerateReview(ctx context.Context, request *productinternal.ModerateReviewRequest) (*productinternal.ModerateReviewResponse, error) {
+	reviewID, err := parseUUID("reviewID", request.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+	err = p.reviewService.ModerateReview(ctx, reviewID, appmodel.ReviewStatus(request.Status))
+	if err != nil {
+		return nil, err
+	}
+	return &productinternal.ModerateReviewResponse{}, nil
+}
+
+func (p *productInternalAPI) ListReviews(ctx context.Context, request *productinternal.ListReviewsRequest) (*productinternal.ListReviewsResponse, error) {
+	filter := appmodel.ReviewFilter{
+		Limit: defaultReviewsLimit,
+	}
+	if request.Limit > 0 {
+		filter.Limit = min(int(request.Limit), maxReviewsLimit)
+	}
+	if request.ProductID != nil {
+		productID, err := parseUUID("productID", *request.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		filter.ProductID = &productID
+	}
+	if request.AuthorID != nil {
+		authorID, err := parseUUID("authorID", *request.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		filter.AuthorID = &authorID
+	}
+	if request.Status != nil {
+		status := appmodel.ReviewStatus(*request.Status)
+		filter.Status = &status
+	}
+
+	reviews, err := p.reviewQueryService.ListReviews(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*productinternal.Review, 0, len(reviews))
+	for _, review := range reviews {
+		result = append(result, &productinternal.Review{
+			ReviewID:   review.ReviewID.String(),
+			ProductID:  review.ProductID.String(),
+			AuthorID:   review.AuthorID.String(),
+			AuthorName: review.AuthorName,
+			Rating:     int32(review.Rating), // nolint:gosec
+			Text:       review.Text,
+			Status:     productinternal.ReviewStatus(review.Status), // nolint:gosec
+			CreatedAt:  review.CreatedAt.Unix(),
+		})
+	}
+	return &productinternal.ListReviewsResponse{
+		Reviews: result,
+	}, nil
+}
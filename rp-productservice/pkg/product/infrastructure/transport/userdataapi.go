@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"productservice/api/server/productinternal"
+	"productservice/api/server/userdatainternal"
+	appmodel "productservice/pkg/product/application/model"
+	"productservice/pkg/product/application/query"
+	"productservice/pkg/product/application/service"
+)
+
+const userDataServiceName = "productservice"
+
+// NewUserDataInternalAPI - из данных пользователя каталог хранит его отзывы и купленные товары.
+// Покупки выгружает orderservice вместе с заказами, поэтому здесь выгружаются только отзывы
+func NewUserDataInternalAPI(
+	reviewQueryService query.ReviewQueryService,
+	userDataService service.UserDataService,
+) userdatainternal.UserDataInternalServiceServer {
+	return &userDataInternalAPI{
+		reviewQueryService: reviewQueryService,
+		userDataService:    userDataService,
+	}
+}
+
+type userDataInternalAPI struct {
+	reviewQueryService query.ReviewQueryService
+	userDataService    service.UserDataService
+	userdatainternal.UnimplementedUserDataInternalServiceServer
+}
+
+type exportedReview struct {
+	ReviewID   string `json:"review_id"`
+	ProductID  string `json:"product_id"`
+	AuthorName string `json:"author_name"`
+	Rating     int    `json:"rating"`
+	Text       string `json:"text"`
+	Status     string `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
+type exportedUserData struct {
+	Reviews []exportedReview `json:"reviews"`
+}
+
+func (a *userDataInternalAPI) ExportUserData(ctx context.Context, request *userdatainternal.ExportUserDataRequest) (*userdatainternal.ExportUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := a.reviewQueryService.ListReviews(ctx, appmodel.ReviewFilter{AuthorID: &userID})
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedUserData{
+		Reviews: make([]exportedReview, len(reviews)),
+	}
+	for i, review := range reviews {
+		exported.Reviews[i] = exportedReview{
+			ReviewID:   review.ReviewID.String(),
+			ProductID:  review.ProductID.String(),
+			AuthorName: review.AuthorName,
+			Rating:     review.Rating,
+			Text:       review.Text,
+			Status:     productinternal.ReviewStatus(review.Status).String(), // nolint:gosec
+			CreatedAt:  review.CreatedAt.Unix(),
+		}
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &userdatainternal.ExportUserDataResponse{
+		Service: userDataServiceName,
+		Data:    string(data),
+	}, nil
+}
+
+func (a *userDataInternalAPI) EraseUserData(ctx context.Context, request *userdatainternal.EraseUserDataRequest) (*userdatainternal.EraseUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = a.userDataService.EraseUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &userdatainternal.EraseUserDataResponse{
+		Service:  userDataServiceName,
+		ErasedAt: time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/outbox"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+
+	appservice "userservice/pkg/user/application/service"
+	"userservice/pkg/user/infrastructure/integrationevent"
+	inframysql "userservice/pkg/user/infrastructure/mysql"
+)
+
+type assignRoleConfig struct {
+	Database Database `envconfig:"database" required:"true"`
+}
+
+// assignRole назначает роль в обход проверки прав AssignRole: так выдается роль первому администратору.
+// Событие уходит через outbox, как и при вызове из API
+func assignRole(logger logging.Logger) *cli.Command {
+	return &cli.Command{
+		Name:   "assign-role",
+		Before: migrateImpl(logger),
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "user-id", Required: true},
+			&cli.StringFlag{Name: "role", Required: true},
+		},
+		Action: func(c *cli.Context) error {
+			cnf, err := parseEnvs[assignRoleConfig]()
+			if err != nil {
+				return err
+			}
+			userID, err := uuid.Parse(c.String("user-id"))
+			if err != nil {
+				return err
+			}
+
+			closer := libio.NewMultiCloser()
+			defer func() {
+				err = errors.Join(err, closer.Close())
+			}()
+
+			databaseConnector, err := newDatabaseConnector(cnf.Database)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(databaseConnector)
+			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
+
+			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
+			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
+			eventDispatcher := outbox.NewEventDispatcher(
+				appID,
+				integrationevent.TransportName,
+				integrationevent.NewEventSerializer(),
+				libUoW,
+			)
+
+			userService := appservice.NewUserService(
+				inframysql.NewUnitOfWork(libUoW),
+				inframysql.NewLockableUnitOfWork(libLUow),
+				eventDispatcher,
+				0,
+			)
+			err = userService.AssignRole(c.Context, userID, c.String("role"))
+			if err != nil {
+				return err
+			}
+			logger.WithField("user_id", userID.String()).Info("role assigned")
+			return nil
+		},
+	}
+}
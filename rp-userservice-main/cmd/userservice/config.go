@@ -20,6 +20,62 @@ type Service struct {
 	HTTPAddress string `envconfig:"http_address" default:":8082"`
 }
 
+// RateLimit - лимиты в запросах в секунду, 0 - без ограничений.
+// method_* и caller_* задаются списком вида "StoreUser:5,FindUser:50"
+type RateLimit struct {
+	Mode         string             `envconfig:"mode" default:"memory"`
+	DefaultRate  float64            `envconfig:"default_rate" default:"0"`
+	DefaultBurst int                `envconfig:"default_burst" default:"0"`
+	MethodRates  map[string]float64 `envconfig:"method_rates"`
+	MethodBursts map[string]int     `envconfig:"method_bursts"`
+	CallerRates  map[string]float64 `envconfig:"caller_rates"`
+	CallerBursts map[string]int     `envconfig:"caller_bursts"`
+}
+
+// ContactVerification - sender выбирает способ доставки кодов, пока поддерживается только log
+type ContactVerification struct {
+	Sender  string        `envconfig:"sender" default:"log"`
+	CodeTTL time.Duration `envconfig:"code_ttl" default:"15m"`
+}
+
+// Deletion - сколько хранится мягко удаленный пользователь, пока его можно восстановить
+type Deletion struct {
+	RetentionPeriod time.Duration `envconfig:"retention_period" default:"30s"`
+}
+
+// AutoBlock - threshold отмен заказов за window блокируют пользователя, 0 - автоблокировка выключена.
+// cool_off - через сколько пользователь разблокируется сам, 0 - только вручную
+type AutoBlock struct {
+	Threshold int           `envconfig:"threshold" default:"3"`
+	Window    time.Duration `envconfig:"window" default:"24h"`
+	CoolOff   time.Duration `envconfig:"cool_off" default:"0"`
+}
+
+// Login - сколько прежний логин пользователя недоступен остальным после смены
+type Login struct {
+	ReservationPeriod time.Duration `envconfig:"reservation_period" default:"720h"`
+}
+
+// Auth - token_secret и token_issuer должны совпадать с настройками gateway.
+// После max_failed_attempts неудачных входов подряд вход блокируется на lockout_duration, 0 - без блокировки
+type Auth struct {
+	TokenSecret       string        `envconfig:"token_secret" required:"true"`
+	TokenIssuer       string        `envconfig:"token_issuer" default:"user"`
+	AccessTokenTTL    time.Duration `envconfig:"access_token_ttl" default:"15m"`
+	RefreshTokenTTL   time.Duration `envconfig:"refresh_token_ttl" default:"720h"`
+	PasswordHashCost  int           `envconfig:"password_hash_cost" default:"10"`
+	MaxFailedAttempts int           `envconfig:"max_failed_attempts" default:"5"`
+	LockoutDuration   time.Duration `envconfig:"lockout_duration" default:"15m"`
+}
+
+// Clients - адреса сервисов, в которых хранятся данные пользователя
+type Clients struct {
+	ProductServiceAddress      string `envconfig:"product_service_address" required:"true"`
+	OrderServiceAddress        string `envconfig:"order_service_address" required:"true"`
+	PaymentServiceAddress      string `envconfig:"payment_service_address" required:"true"`
+	NotificationServiceAddress string `envconfig:"notification_service_address" required:"true"`
+}
+
 type Database struct {
 	User                  string        `envconfig:"user" required:"true"`
 	Password              string        `envconfig:"password" required:"true"`
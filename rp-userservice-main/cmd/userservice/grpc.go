@@ -0,0 +1,40 @@
+package main
+
+import (
+	libio "gitea.xscloud.ru/xscloud/golib/pkg/common/io"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"userservice/api/client/userdatainternal"
+	"userservice/pkg/user/infrastructure/transport/middlewares"
+	"userservice/pkg/user/infrastructure/userdata"
+)
+
+func newGRPCClientConn(address string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(
+		address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(middlewares.NewGRPCCallerMiddleware(appID)),
+	)
+	return conn, errors.WithStack(err)
+}
+
+func newUserDataClients(cnf Clients, closer libio.MultiCloser) (userdata.Clients, error) {
+	addresses := map[string]string{
+		userdata.ProductService:      cnf.ProductServiceAddress,
+		userdata.OrderService:        cnf.OrderServiceAddress,
+		userdata.PaymentService:      cnf.PaymentServiceAddress,
+		userdata.NotificationService: cnf.NotificationServiceAddress,
+	}
+	clients := make(userdata.Clients, len(addresses))
+	for service, address := range addresses {
+		conn, err := newGRPCClientConn(address)
+		if err != nil {
+			return nil, err
+		}
+		closer.AddCloser(conn)
+		clients[service] = userdatainternal.NewUserDataInternalServiceClient(conn)
+	}
+	return clients, nil
+}
@@ -27,6 +27,7 @@ func main() {
 			messageHandler(logger),
 			workflowWorker(logger),
 			service(logger),
+			assignRole(logger),
 		},
 	}
 
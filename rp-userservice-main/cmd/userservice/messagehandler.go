@@ -17,13 +17,17 @@ import (
 	"userservice/pkg/user/infrastructure/integrationevent"
 	inframysql "userservice/pkg/user/infrastructure/mysql"
 	"userservice/pkg/user/infrastructure/temporal"
+	"userservice/pkg/user/infrastructure/temporal/workflows"
 )
 
 type messageHandlerConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
-	AMQP     AMQP     `envconfig:"amqp" required:"true"`
-	Temporal Temporal `envconfig:"temporal" required:"true"`
+	Service   Service   `envconfig:"service"`
+	Deletion  Deletion  `envconfig:"deletion"`
+	AutoBlock AutoBlock `envconfig:"auto_block"`
+	Login     Login     `envconfig:"login"`
+	Database  Database  `envconfig:"database" required:"true"`
+	AMQP      AMQP      `envconfig:"amqp" required:"true"`
+	Temporal  Temporal  `envconfig:"temporal" required:"true"`
 }
 
 func messageHandler(logger logging.Logger) *cli.Command {
@@ -79,9 +83,19 @@ func messageHandler(logger logging.Logger) *cli.Command {
 				integrationevent.NewEventSerializer(),
 				libUoW,
 			)
-			userService := appservice.NewUserService(uow, luow, eventDispatcher)
-
-			amqpTransport := integrationevent.NewAMQPTransport(logger, workflowService, userService)
+			userService := appservice.NewUserService(uow, luow, eventDispatcher, cnf.Login.ReservationPeriod)
+
+			amqpTransport := integrationevent.NewAMQPTransport(
+				logger,
+				workflowService,
+				userService,
+				cnf.Deletion.RetentionPeriod,
+				workflows.AutoBlockPolicy{
+					Threshold: cnf.AutoBlock.Threshold,
+					Window:    cnf.AutoBlock.Window,
+					CoolOff:   cnf.AutoBlock.CoolOff,
+				},
+			)
 
 			amqpConnection.Consumer(
 				c.Context,
@@ -90,7 +104,7 @@ func messageHandler(logger logging.Logger) *cli.Command {
 				&amqp.BindConfig{
 					QueueName:    integrationevent.QueueName,
 					ExchangeName: integrationevent.ExchangeName,
-					RoutingKeys:  []string{integrationevent.RoutingKeyPrefix + "#"},
+					RoutingKeys:  []string{integrationevent.RoutingKeyPrefix + "#", integrationevent.OrderCancelledRoutingKey},
 				},
 				nil,
 			)
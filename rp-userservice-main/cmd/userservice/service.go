@@ -19,16 +19,25 @@ import (
 
 	"userservice/api/server/userinternal"
 	appservice "userservice/pkg/user/application/service"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/auth"
 	"userservice/pkg/user/infrastructure/integrationevent"
 	inframysql "userservice/pkg/user/infrastructure/mysql"
 	"userservice/pkg/user/infrastructure/mysql/query"
+	"userservice/pkg/user/infrastructure/temporal"
 	"userservice/pkg/user/infrastructure/transport"
 	"userservice/pkg/user/infrastructure/transport/middlewares"
 )
 
 type serviceConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
+	Service             Service             `envconfig:"service"`
+	RateLimit           RateLimit           `envconfig:"rate_limit"`
+	ContactVerification ContactVerification `envconfig:"contact_verification"`
+	Login               Login               `envconfig:"login"`
+	Database            Database            `envconfig:"database" required:"true"`
+	Temporal            Temporal            `envconfig:"temporal" required:"true"`
+	Clients             Clients             `envconfig:"clients" required:"true"`
+	Auth                Auth                `envconfig:"auth" required:"true"`
 }
 
 func service(logger logging.Logger) *cli.Command {
@@ -53,10 +62,33 @@ func service(logger logging.Logger) *cli.Command {
 			closer.AddCloser(databaseConnector)
 			databaseConnectionPool := mysql.NewConnectionPool(databaseConnector.TransactionalClient())
 
+			temporalClient, err := temporal.NewClient(logger, cnf.Temporal.Host)
+			if err != nil {
+				return err
+			}
+			closer.AddCloser(libio.CloserFunc(func() error {
+				temporalClient.Close()
+				return nil
+			}))
+
+			verificationCodeSender, err := newVerificationCodeSender(cnf.ContactVerification, logger)
+			if err != nil {
+				return err
+			}
+
+			userDataClients, err := newUserDataClients(cnf.Clients, closer)
+			if err != nil {
+				return err
+			}
+
 			libUoW := mysql.NewUnitOfWork(databaseConnectionPool, inframysql.NewRepositoryProvider)
 			libLUow := mysql.NewLockableUnitOfWork(libUoW, mysql.NewLocker(databaseConnectionPool))
 			uow := inframysql.NewUnitOfWork(libUoW)
 			luow := inframysql.NewLockableUnitOfWork(libLUow)
+			rateLimiter, err := newRateLimiter(cnf.RateLimit, libUoW)
+			if err != nil {
+				return err
+			}
 			eventDispatcher := outbox.NewEventDispatcher(
 				appID,
 				integrationevent.TransportName,
@@ -64,9 +96,25 @@ func service(logger logging.Logger) *cli.Command {
 				libUoW,
 			)
 
+			userService := appservice.NewUserService(uow, luow, eventDispatcher, cnf.Login.ReservationPeriod)
 			userInternalAPI := transport.NewUserInternalAPI(
 				query.NewUserQueryService(databaseConnector.TransactionalClient()),
-				appservice.NewUserService(uow, luow, eventDispatcher),
+				query.NewAuditLogQueryService(databaseConnector.TransactionalClient()),
+				query.NewUserErasureQueryService(databaseConnector.TransactionalClient()),
+				userService,
+				appservice.NewContactVerificationService(luow, eventDispatcher, verificationCodeSender, cnf.ContactVerification.CodeTTL),
+				appservice.NewAuthService(
+					luow,
+					auth.NewBcryptPasswordHasher(cnf.Auth.PasswordHashCost),
+					auth.NewTokenIssuer([]byte(cnf.Auth.TokenSecret), cnf.Auth.TokenIssuer, cnf.Auth.AccessTokenTTL),
+					model.LockoutPolicy{
+						MaxFailedAttempts: cnf.Auth.MaxFailedAttempts,
+						LockoutDuration:   cnf.Auth.LockoutDuration,
+					},
+					cnf.Auth.RefreshTokenTTL,
+				),
+				temporal.NewWorkflowService(temporalClient),
+				userDataClients,
 			)
 
 			errGroup := errgroup.Group{}
@@ -76,7 +124,12 @@ func service(logger logging.Logger) *cli.Command {
 					return err
 				}
 				grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+					middlewares.NewGRPCErrorsMiddleware(),
 					middlewares.NewGRPCLoggingMiddleware(logger),
+					middlewares.NewGRPCRateLimitMiddleware(rateLimiter, rateLimits(cnf.RateLimit)),
+					middlewares.NewGRPCAuthorizationMiddleware(userService, transport.MethodPermissions),
+					middlewares.NewGRPCValidationMiddleware(),
+					middlewares.NewGRPCAuditMiddleware(),
 				))
 				userinternal.RegisterUserInternalServiceServer(grpcServer, userInternalAPI)
 				reflection.Register(grpcServer)
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+	"github.com/pkg/errors"
+
+	appservice "userservice/pkg/user/application/service"
+	"userservice/pkg/user/infrastructure/verification"
+)
+
+const verificationSenderLog = "log"
+
+func newVerificationCodeSender(config ContactVerification, logger logging.Logger) (appservice.VerificationCodeSender, error) {
+	switch config.Sender {
+	case verificationSenderLog:
+		return verification.NewLogSender(logger), nil
+	default:
+		return nil, errors.Errorf("unknown verification code sender %q", config.Sender)
+	}
+}
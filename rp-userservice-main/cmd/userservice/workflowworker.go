@@ -20,9 +20,12 @@ import (
 )
 
 type workflowWorkerConfig struct {
-	Service  Service  `envconfig:"service"`
-	Database Database `envconfig:"database" required:"true"`
-	Temporal Temporal `envconfig:"temporal" required:"true"`
+	Service             Service             `envconfig:"service"`
+	ContactVerification ContactVerification `envconfig:"contact_verification"`
+	Login               Login               `envconfig:"login"`
+	Database            Database            `envconfig:"database" required:"true"`
+	Temporal            Temporal            `envconfig:"temporal" required:"true"`
+	Clients             Clients             `envconfig:"clients" required:"true"`
 }
 
 func workflowWorker(logger logging.Logger) *cli.Command {
@@ -62,11 +65,33 @@ func workflowWorker(logger logging.Logger) *cli.Command {
 			luow := inframysql.NewLockableUnitOfWork(libLUow)
 
 			eventDispatcher := outbox.NewEventDispatcher(appID, integrationevent.TransportName, integrationevent.NewEventSerializer(), libUoW)
-			userService := appservice.NewUserService(uow, luow, eventDispatcher)
+			userService := appservice.NewUserService(uow, luow, eventDispatcher, cnf.Login.ReservationPeriod)
+
+			verificationCodeSender, err := newVerificationCodeSender(cnf.ContactVerification, logger)
+			if err != nil {
+				return err
+			}
+			contactVerificationService := appservice.NewContactVerificationService(
+				luow,
+				eventDispatcher,
+				verificationCodeSender,
+				cnf.ContactVerification.CodeTTL,
+			)
+
+			userDataClients, err := newUserDataClients(cnf.Clients, closer)
+			if err != nil {
+				return err
+			}
 
 			errGroup := errgroup.Group{}
 			errGroup.Go(func() error {
-				w := worker.NewWorker(temporalClient, userService)
+				w := worker.NewWorker(
+					temporalClient,
+					userService,
+					contactVerificationService,
+					appservice.NewUserErasureService(uow),
+					userDataClients,
+				)
 				return w.Run(worker.InterruptChannel())
 			})
 
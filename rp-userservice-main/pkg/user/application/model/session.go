@@ -0,0 +1,13 @@
+package model
+
+import "github.com/google/uuid"
+
+type Session struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	// AccessToken - подписанный JWT, RefreshToken отдается клиенту один раз и у нас хранится только хешем
+	AccessToken           string
+	AccessTokenExpiresAt  int64
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
+}
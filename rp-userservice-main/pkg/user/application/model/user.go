@@ -3,9 +3,29 @@ package model
 import "github.com/google/uuid"
 
 type User struct {
-	UserID   uuid.UUID
-	Status   int
-	Login    string
-	Email    *string
-	Telegram *string
+	UserID           uuid.UUID
+	Status           int
+	Login            string
+	Email            *string
+	Telegram         *string
+	EmailVerified    bool
+	TelegramVerified bool
+	// BlockReason - model.BlockReason, пустая у активного пользователя
+	BlockReason string
+	Roles       []string
+	Version     int64
+	CreatedAt   int64
+}
+
+// UserPatch - меняются только поля, для которых выставлен флаг Set*. Email и Telegram со значением nil удаляют контакт
+type UserPatch struct {
+	UserID      uuid.UUID
+	SetStatus   bool
+	Status      int
+	SetEmail    bool
+	Email       *string
+	SetTelegram bool
+	Telegram    *string
+	// ExpectedVersion - если задана, изменение применяется только к пользователю с этой версией
+	ExpectedVersion *int64
 }
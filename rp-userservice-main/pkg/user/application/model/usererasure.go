@@ -0,0 +1,6 @@
+package model
+
+type UserErasure struct {
+	Service  string
+	ErasedAt int64
+}
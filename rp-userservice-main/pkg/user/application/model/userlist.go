@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserCursor - позиция в выдаче, отсортированной по (CreatedAt, UserID)
+type UserCursor struct {
+	CreatedAt time.Time
+	UserID    uuid.UUID
+}
+
+// ListUsersSpec - пустые поля не фильтруют. Удаленные пользователи попадают в выдачу,
+// только если статус Deleted указан в Statuses явно
+type ListUsersSpec struct {
+	Statuses      []int
+	LoginPrefix   *string
+	EmailPrefix   *string
+	CreatedFrom   *time.Time
+	CreatedBefore *time.Time
+	After         *UserCursor
+	Limit         int
+}
+
+type UserList struct {
+	Users []User
+	// Next - курсор следующей страницы, nil на последней
+	Next *UserCursor
+}
@@ -0,0 +1,11 @@
+package query
+
+import (
+	"context"
+
+	appmodel "userservice/pkg/user/application/model"
+)
+
+type AuditLogQueryService interface {
+	FindAuditLog(ctx context.Context, entityType, entityID string) ([]appmodel.AuditRecord, error)
+}
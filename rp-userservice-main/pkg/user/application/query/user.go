@@ -10,4 +10,7 @@ import (
 
 type UserQueryService interface {
 	FindUser(ctx context.Context, userID uuid.UUID) (*appmodel.User, error)
+	// FindUsers возвращает найденных пользователей в порядке userIDs, ненайденные пропускаются
+	FindUsers(ctx context.Context, userIDs []uuid.UUID) ([]appmodel.User, error)
+	ListUsers(ctx context.Context, spec appmodel.ListUsersSpec) (appmodel.UserList, error)
 }
@@ -0,0 +1,13 @@
+package query
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	appmodel "userservice/pkg/user/application/model"
+)
+
+type UserErasureQueryService interface {
+	FindUserErasures(ctx context.Context, userID uuid.UUID) ([]appmodel.UserErasure, error)
+}
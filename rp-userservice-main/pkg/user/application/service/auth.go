@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	appmodel "userservice/pkg/user/application/model"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/domain/service"
+)
+
+const refreshTokenBytes = 32
+
+// AccessTokenIssuer подписывает access token сессии
+type AccessTokenIssuer interface {
+	Issue(userID, sessionID uuid.UUID) (token string, expiresAt time.Time, err error)
+}
+
+type AuthService interface {
+	SetPassword(ctx context.Context, userID uuid.UUID, password string) error
+	Authenticate(ctx context.Context, login, password string) (appmodel.Session, error)
+	RefreshSession(ctx context.Context, refreshToken string) (appmodel.Session, error)
+	RevokeSession(ctx context.Context, refreshToken string) error
+}
+
+func NewAuthService(
+	luow LockableUnitOfWork,
+	passwordHasher model.PasswordHasher,
+	tokenIssuer AccessTokenIssuer,
+	lockoutPolicy model.LockoutPolicy,
+	sessionTTL time.Duration,
+) AuthService {
+	return &authService{
+		luow:           luow,
+		passwordHasher: passwordHasher,
+		tokenIssuer:    tokenIssuer,
+		lockoutPolicy:  lockoutPolicy,
+		sessionTTL:     sessionTTL,
+	}
+}
+
+type authService struct {
+	luow           LockableUnitOfWork
+	passwordHasher model.PasswordHasher
+	tokenIssuer    AccessTokenIssuer
+	lockoutPolicy  model.LockoutPolicy
+	sessionTTL     time.Duration
+}
+
+func (s *authService) SetPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).SetPassword(userID, password)
+	})
+}
+
+func (s *authService) Authenticate(ctx context.Context, login, password string) (appmodel.Session, error) {
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+
+	// счетчик неудач меняется под той же блокировкой пользователя, что и пароль в SetPassword.
+	// Логин блокируем тоже, чтобы его не передали другому пользователю, пока идет проверка
+	userID, err := s.findUserIDByLogin(ctx, login)
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+	// порядок блокировок тот же, что в ChangeLogin
+	var lockNames []string
+	if userID != uuid.Nil {
+		lockNames = append(lockNames, userLock(userID))
+	}
+	lockNames = append(lockNames, userLoginLock(login))
+
+	var (
+		session model.Session
+		authErr error
+	)
+	err = s.luow.Execute(ctx, lockNames, func(provider RepositoryProvider) error {
+		user, err2 := provider.UserRepository(ctx).Find(model.FindSpec{Login: &login})
+		if err2 != nil && !errors.Is(err2, model.ErrUserNotFound) {
+			return err2
+		}
+		if user != nil && user.UserID != userID {
+			// логин сменил владельца до того, как мы взяли блокировку
+			return model.ErrInvalidCredentials
+		}
+		session, authErr = s.domainService(ctx, provider).Authenticate(login, password, model.HashRefreshToken(refreshToken), s.sessionTTL)
+		if errors.Is(authErr, model.ErrInvalidCredentials) {
+			// счетчик неудачных попыток должен сохраниться, поэтому транзакцию не откатываем
+			return nil
+		}
+		return authErr
+	})
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+	if authErr != nil {
+		return appmodel.Session{}, authErr
+	}
+	return s.issue(session, refreshToken)
+}
+
+// findUserIDByLogin возвращает uuid.Nil, если логин не занят
+func (s *authService) findUserIDByLogin(ctx context.Context, login string) (uuid.UUID, error) {
+	var userID uuid.UUID
+	err := s.luow.Execute(ctx, []string{userLoginLock(login)}, func(provider RepositoryProvider) error {
+		user, err := provider.UserRepository(ctx).Find(model.FindSpec{Login: &login})
+		if err != nil {
+			if errors.Is(err, model.ErrUserNotFound) {
+				return nil
+			}
+			return err
+		}
+		userID = user.UserID
+		return nil
+	})
+	return userID, err
+}
+
+func (s *authService) RefreshSession(ctx context.Context, refreshToken string) (appmodel.Session, error) {
+	newRefreshToken, err := generateRefreshToken()
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+
+	refreshTokenHash := model.HashRefreshToken(refreshToken)
+	var session model.Session
+	err = s.luow.Execute(ctx, []string{sessionLock(refreshTokenHash)}, func(provider RepositoryProvider) error {
+		var err2 error
+		session, err2 = s.domainService(ctx, provider).RefreshSession(refreshTokenHash, model.HashRefreshToken(newRefreshToken), s.sessionTTL)
+		return err2
+	})
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+	return s.issue(session, newRefreshToken)
+}
+
+func (s *authService) RevokeSession(ctx context.Context, refreshToken string) error {
+	refreshTokenHash := model.HashRefreshToken(refreshToken)
+	return s.luow.Execute(ctx, []string{sessionLock(refreshTokenHash)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).RevokeSession(refreshTokenHash)
+	})
+}
+
+func (s *authService) issue(session model.Session, refreshToken string) (appmodel.Session, error) {
+	accessToken, accessTokenExpiresAt, err := s.tokenIssuer.Issue(session.UserID, session.SessionID)
+	if err != nil {
+		return appmodel.Session{}, err
+	}
+	return appmodel.Session{
+		SessionID:             session.SessionID,
+		UserID:                session.UserID,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessTokenExpiresAt.Unix(),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *authService) domainService(ctx context.Context, provider RepositoryProvider) service.AuthService {
+	return service.NewAuthService(
+		provider.UserRepository(ctx),
+		provider.CredentialsRepository(ctx),
+		provider.SessionRepository(ctx),
+		s.passwordHasher,
+		s.lockoutPolicy,
+	)
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, refreshTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func sessionLock(refreshTokenHash string) string {
+	return "session_" + refreshTokenHash
+}
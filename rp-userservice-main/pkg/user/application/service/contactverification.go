@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
+	"github.com/google/uuid"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/domain/service"
+)
+
+const verificationCodeDigits = 6
+
+// VerificationCodeSender доставляет одноразовый код на контакт пользователя
+type VerificationCodeSender interface {
+	Send(ctx context.Context, contactType int, contact, code string) error
+}
+
+type ContactVerificationService interface {
+	// SendVerificationCode возвращает время истечения кода, нулевое время - подтверждать нечего
+	SendVerificationCode(ctx context.Context, userID uuid.UUID, contactType int) (time.Time, error)
+	ConfirmContact(ctx context.Context, userID uuid.UUID, contactType int, code string) error
+	ExpireVerification(ctx context.Context, userID uuid.UUID, contactType int) error
+}
+
+func NewContactVerificationService(
+	luow LockableUnitOfWork,
+	eventDispatcher outbox.EventDispatcher[outbox.Event],
+	codeSender VerificationCodeSender,
+	codeTTL time.Duration,
+) ContactVerificationService {
+	return &contactVerificationService{
+		luow:            luow,
+		eventDispatcher: eventDispatcher,
+		codeSender:      codeSender,
+		codeTTL:         codeTTL,
+	}
+}
+
+type contactVerificationService struct {
+	luow            LockableUnitOfWork
+	eventDispatcher outbox.EventDispatcher[outbox.Event]
+	codeSender      VerificationCodeSender
+	codeTTL         time.Duration
+}
+
+func (s *contactVerificationService) SendVerificationCode(ctx context.Context, userID uuid.UUID, contactType int) (time.Time, error) {
+	code, err := generateVerificationCode()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var verification model.ContactVerification
+	err = s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		var err2 error
+		verification, err2 = s.domainService(ctx, provider).RequestVerification(userID, model.ContactType(contactType), code, s.codeTTL)
+		return err2
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) ||
+			errors.Is(err, model.ErrContactNotFound) ||
+			errors.Is(err, model.ErrContactAlreadyVerified) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	// код отправляем после фиксации транзакции, иначе можно отправить код, которого нет в базе
+	err = s.codeSender.Send(ctx, contactType, verification.Value, code)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return verification.ExpiresAt, nil
+}
+
+func (s *contactVerificationService) ConfirmContact(ctx context.Context, userID uuid.UUID, contactType int, code string) error {
+	var confirmErr error
+	err := s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		confirmErr = s.domainService(ctx, provider).ConfirmContact(userID, model.ContactType(contactType), code)
+		if errors.Is(confirmErr, model.ErrInvalidVerificationCode) {
+			// неудачная попытка должна сохраниться, поэтому транзакцию не откатываем
+			return nil
+		}
+		return confirmErr
+	})
+	if err != nil {
+		return err
+	}
+	return confirmErr
+}
+
+func (s *contactVerificationService) ExpireVerification(ctx context.Context, userID uuid.UUID, contactType int) error {
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider).ExpireVerification(userID, model.ContactType(contactType))
+	})
+}
+
+func (s *contactVerificationService) domainService(ctx context.Context, provider RepositoryProvider) service.ContactVerificationService {
+	return service.NewContactVerificationService(
+		provider.UserRepository(ctx),
+		provider.ContactVerificationRepository(ctx),
+		&domainEventDispatcher{
+			ctx:             ctx,
+			eventDispatcher: s.eventDispatcher,
+		},
+	)
+}
+
+func generateVerificationCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n), nil
+}
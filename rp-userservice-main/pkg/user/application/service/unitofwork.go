@@ -8,6 +8,11 @@ import (
 
 type RepositoryProvider interface {
 	UserRepository(ctx context.Context) model.UserRepository
+	ContactVerificationRepository(ctx context.Context) model.ContactVerificationRepository
+	UserErasureRepository(ctx context.Context) model.UserErasureRepository
+	CredentialsRepository(ctx context.Context) model.CredentialsRepository
+	SessionRepository(ctx context.Context) model.SessionRepository
+	LoginHistoryRepository(ctx context.Context) model.LoginHistoryRepository
 }
 
 type LockableUnitOfWork interface {
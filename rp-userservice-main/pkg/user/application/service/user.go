@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/outbox"
 	"github.com/google/uuid"
@@ -15,35 +16,49 @@ import (
 type UserService interface {
 	StoreUser(ctx context.Context, user appmodel.User) (uuid.UUID, error)
 	SetUserStatus(ctx context.Context, userID uuid.UUID, status int) error
+	// BlockUser блокирует с причиной: от нее зависит, что потом снимет блокировку
+	BlockUser(ctx context.Context, userID uuid.UUID, reason string) error
 	FindUser(ctx context.Context, userID uuid.UUID) (appmodel.User, error)
+	// PatchUser возвращает пользователя после изменения, в том числе с новой версией
+	PatchUser(ctx context.Context, patch appmodel.UserPatch) (appmodel.User, error)
 	DeleteUser(ctx context.Context, userID uuid.UUID, hard bool) error
+	HardDeleteUser(ctx context.Context, userID uuid.UUID, deletedBefore time.Time) error
+	RestoreUser(ctx context.Context, userID uuid.UUID) error
+	// ChangeLogin оставляет прежний логин зарезервированным за пользователем на loginReservationPeriod
+	ChangeLogin(ctx context.Context, userID uuid.UUID, login string) error
+	AssignRole(ctx context.Context, userID uuid.UUID, role string) error
+	RevokeRole(ctx context.Context, userID uuid.UUID, role string) error
+	// Authorize проверяет право вызывающего пользователя по его текущим ролям и статусу
+	Authorize(ctx context.Context, userID uuid.UUID, permission string) error
 }
 
 func NewUserService(
 	uow UnitOfWork,
 	luow LockableUnitOfWork,
 	eventDispatcher outbox.EventDispatcher[outbox.Event],
+	loginReservationPeriod time.Duration,
 ) UserService {
 	return &userService{
-		uow:             uow,
-		luow:            luow,
-		eventDispatcher: eventDispatcher,
+		uow:                    uow,
+		luow:                   luow,
+		eventDispatcher:        eventDispatcher,
+		loginReservationPeriod: loginReservationPeriod,
 	}
 }
 
 type userService struct {
-	uow             UnitOfWork
-	luow            LockableUnitOfWork
-	eventDispatcher outbox.EventDispatcher[outbox.Event]
+	uow                    UnitOfWork
+	luow                   LockableUnitOfWork
+	eventDispatcher        outbox.EventDispatcher[outbox.Event]
+	loginReservationPeriod time.Duration
 }
 
 func (s *userService) StoreUser(ctx context.Context, user appmodel.User) (uuid.UUID, error) {
 	var lockNames []string
 	if user.UserID != uuid.Nil {
 		lockNames = append(lockNames, userLock(user.UserID))
-	} else {
-		lockNames = append(lockNames, userLoginLock(user.Login))
 	}
+	lockNames = append(lockNames, userLoginLock(user.Login))
 	if user.Email != nil {
 		lockNames = append(lockNames, userEmailLock(*user.Email))
 	}
@@ -54,30 +69,34 @@ func (s *userService) StoreUser(ctx context.Context, user appmodel.User) (uuid.U
 	userID := user.UserID
 	err := s.luow.Execute(ctx, lockNames, func(provider RepositoryProvider) error {
 		domainService := s.domainService(ctx, provider.UserRepository(ctx))
+		loginService := s.loginService(ctx, provider)
+		patch := model.UserPatch{
+			SetEmail:    true,
+			Email:       user.Email,
+			SetTelegram: true,
+			Telegram:    user.Telegram,
+		}
 		if user.UserID == uuid.Nil {
+			err := loginService.CheckLoginAvailable(uuid.Nil, user.Login)
+			if err != nil {
+				return err
+			}
 			uID, err := domainService.CreateUser(model.UserStatus(user.Status), user.Login)
 			if err != nil {
 				return err
 			}
 			userID = uID
 		} else {
-			err := domainService.UpdateUserStatus(userID, model.UserStatus(user.Status))
+			// логин меняется по тем же правилам, что и через ChangeLogin
+			err := loginService.ChangeLogin(userID, user.Login)
 			if err != nil {
 				return err
 			}
+			status := model.UserStatus(user.Status)
+			patch.Status = &status
 		}
 
-		err := domainService.UpdateUserEmail(userID, user.Email)
-		if err != nil {
-			return err
-		}
-
-		err = domainService.UpdateUserTelegram(userID, user.Telegram)
-		if err != nil {
-			return err
-		}
-
-		return nil
+		return domainService.PatchUser(userID, patch, nil)
 	})
 	return userID, err
 }
@@ -88,6 +107,12 @@ func (s *userService) SetUserStatus(ctx context.Context, userID uuid.UUID, statu
 	})
 }
 
+func (s *userService) BlockUser(ctx context.Context, userID uuid.UUID, reason string) error {
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).BlockUser(userID, model.BlockReason(reason))
+	})
+}
+
 func (s *userService) FindUser(ctx context.Context, userID uuid.UUID) (appmodel.User, error) {
 	var user appmodel.User
 	err := s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
@@ -95,13 +120,44 @@ func (s *userService) FindUser(ctx context.Context, userID uuid.UUID) (appmodel.
 		if err != nil {
 			return err
 		}
-		user = appmodel.User{
-			UserID:   domainUser.UserID,
-			Status:   int(domainUser.Status),
-			Login:    domainUser.Login,
-			Email:    domainUser.Email,
-			Telegram: domainUser.Telegram,
+		user = toAppUser(domainUser)
+		return nil
+	})
+	return user, err
+}
+
+func (s *userService) PatchUser(ctx context.Context, patch appmodel.UserPatch) (appmodel.User, error) {
+	lockNames := []string{userLock(patch.UserID)}
+	if patch.SetEmail && patch.Email != nil {
+		lockNames = append(lockNames, userEmailLock(*patch.Email))
+	}
+	if patch.SetTelegram && patch.Telegram != nil {
+		lockNames = append(lockNames, userTelegramLock(*patch.Telegram))
+	}
+
+	domainPatch := model.UserPatch{
+		SetEmail:    patch.SetEmail,
+		Email:       patch.Email,
+		SetTelegram: patch.SetTelegram,
+		Telegram:    patch.Telegram,
+	}
+	if patch.SetStatus {
+		status := model.UserStatus(patch.Status)
+		domainPatch.Status = &status
+	}
+
+	var user appmodel.User
+	err := s.luow.Execute(ctx, lockNames, func(provider RepositoryProvider) error {
+		repository := provider.UserRepository(ctx)
+		err := s.domainService(ctx, repository).PatchUser(patch.UserID, domainPatch, patch.ExpectedVersion)
+		if err != nil {
+			return err
 		}
+		domainUser, err := repository.Find(model.FindSpec{UserID: &patch.UserID})
+		if err != nil {
+			return err
+		}
+		user = toAppUser(domainUser)
 		return nil
 	})
 	return user, err
@@ -113,10 +169,63 @@ func (s *userService) DeleteUser(ctx context.Context, userID uuid.UUID, hard boo
 	})
 }
 
+func (s *userService) HardDeleteUser(ctx context.Context, userID uuid.UUID, deletedBefore time.Time) error {
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).HardDeleteUser(userID, deletedBefore)
+	})
+}
+
+func (s *userService) RestoreUser(ctx context.Context, userID uuid.UUID) error {
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).RestoreUser(userID)
+	})
+}
+
+func (s *userService) ChangeLogin(ctx context.Context, userID uuid.UUID, login string) error {
+	return s.luow.Execute(ctx, []string{userLock(userID), userLoginLock(login)}, func(provider RepositoryProvider) error {
+		return s.loginService(ctx, provider).ChangeLogin(userID, login)
+	})
+}
+
+func (s *userService) AssignRole(ctx context.Context, userID uuid.UUID, role string) error {
+	domainRole, err := model.ParseRole(role)
+	if err != nil {
+		return err
+	}
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).AssignRole(userID, domainRole)
+	})
+}
+
+func (s *userService) RevokeRole(ctx context.Context, userID uuid.UUID, role string) error {
+	domainRole, err := model.ParseRole(role)
+	if err != nil {
+		return err
+	}
+	return s.luow.Execute(ctx, []string{userLock(userID)}, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).RevokeRole(userID, domainRole)
+	})
+}
+
+func (s *userService) Authorize(ctx context.Context, userID uuid.UUID, permission string) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return s.domainService(ctx, provider.UserRepository(ctx)).Authorize(userID, model.Permission(permission))
+	})
+}
+
 func (s *userService) domainService(ctx context.Context, repository model.UserRepository) service.UserService {
 	return service.NewUserService(repository, s.domainEventDispatcher(ctx))
 }
 
+func (s *userService) loginService(ctx context.Context, provider RepositoryProvider) service.LoginService {
+	return service.NewLoginService(
+		provider.UserRepository(ctx),
+		provider.LoginHistoryRepository(ctx),
+		s.domainEventDispatcher(ctx),
+		s.loginReservationPeriod,
+	)
+}
+
 func (s *userService) domainEventDispatcher(ctx context.Context) domain.EventDispatcher {
 	return &domainEventDispatcher{
 		ctx:             ctx,
@@ -124,6 +233,26 @@ func (s *userService) domainEventDispatcher(ctx context.Context) domain.EventDis
 	}
 }
 
+func toAppUser(user *model.User) appmodel.User {
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = string(role)
+	}
+	return appmodel.User{
+		UserID:           user.UserID,
+		Status:           int(user.Status),
+		Login:            user.Login,
+		Email:            user.Email,
+		Telegram:         user.Telegram,
+		EmailVerified:    user.EmailVerified,
+		TelegramVerified: user.TelegramVerified,
+		BlockReason:      string(user.BlockReason),
+		Roles:            roles,
+		Version:          user.Version,
+		CreatedAt:        user.CreatedAt.Unix(),
+	}
+}
+
 const baseUserLock = "user_"
 
 func userLock(id uuid.UUID) string {
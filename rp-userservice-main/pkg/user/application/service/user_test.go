@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +24,31 @@ func (m *MockRepositoryProvider) UserRepository(ctx context.Context) domainmodel
 	return args.Get(0).(domainmodel.UserRepository)
 }
 
+func (m *MockRepositoryProvider) ContactVerificationRepository(ctx context.Context) domainmodel.ContactVerificationRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.ContactVerificationRepository)
+}
+
+func (m *MockRepositoryProvider) UserErasureRepository(ctx context.Context) domainmodel.UserErasureRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.UserErasureRepository)
+}
+
+func (m *MockRepositoryProvider) CredentialsRepository(ctx context.Context) domainmodel.CredentialsRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.CredentialsRepository)
+}
+
+func (m *MockRepositoryProvider) SessionRepository(ctx context.Context) domainmodel.SessionRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.SessionRepository)
+}
+
+func (m *MockRepositoryProvider) LoginHistoryRepository(ctx context.Context) domainmodel.LoginHistoryRepository {
+	args := m.Called(ctx)
+	return args.Get(0).(domainmodel.LoginHistoryRepository)
+}
+
 type MockLockableUnitOfWork struct {
 	mock.Mock
 }
@@ -70,6 +96,20 @@ func (m *StubUserRepository) HardDelete(_ uuid.UUID) error {
 	return nil
 }
 
+func (m *StubUserRepository) IsHardDeleted(_ uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+type StubLoginHistoryRepository struct{}
+
+func (m *StubLoginHistoryRepository) Store(_ domainmodel.LoginHistory) error {
+	return nil
+}
+
+func (m *StubLoginHistoryRepository) IsReserved(_ string, _ uuid.UUID, _ time.Time) (bool, error) {
+	return false, nil
+}
+
 type DummyDispatcher struct{}
 
 // ИСПРАВЛЕНО: ctx и event заменены на _
@@ -82,7 +122,7 @@ func TestUserService_StoreUser_Create(t *testing.T) {
 	luow := new(MockLockableUnitOfWork)
 	uow := new(MockUnitOfWork)
 
-	service := NewUserService(uow, luow, &DummyDispatcher{})
+	service := NewUserService(uow, luow, &DummyDispatcher{}, time.Hour)
 
 	repo := new(StubUserRepository)
 
@@ -100,6 +140,7 @@ func TestUserService_StoreUser_Create(t *testing.T) {
 	luow.On("Execute", ctx, mock.AnythingOfType("[]string")).Return(provider)
 
 	provider.On("UserRepository", ctx).Return(repo)
+	provider.On("LoginHistoryRepository", ctx).Return(&StubLoginHistoryRepository{})
 
 	repo.On("Find", domainmodel.FindSpec{Login: &login}).Return(nil, domainmodel.ErrUserNotFound)
 	repo.On("NextID").Return(userID, nil)
@@ -123,7 +164,7 @@ func TestUserService_StoreUser_Create(t *testing.T) {
 func TestUserService_FindUser(t *testing.T) {
 	provider := new(MockRepositoryProvider)
 	luow := new(MockLockableUnitOfWork)
-	service := NewUserService(nil, luow, &DummyDispatcher{})
+	service := NewUserService(nil, luow, &DummyDispatcher{}, time.Hour)
 	repo := new(StubUserRepository)
 
 	ctx := context.Background()
@@ -144,3 +185,37 @@ func TestUserService_FindUser(t *testing.T) {
 	assert.Equal(t, login, u.Login)
 	assert.Equal(t, int(domainmodel.Active), u.Status)
 }
+
+func TestUserService_PatchUser(t *testing.T) {
+	provider := new(MockRepositoryProvider)
+	luow := new(MockLockableUnitOfWork)
+	service := NewUserService(nil, luow, &DummyDispatcher{}, time.Hour)
+	repo := new(StubUserRepository)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "patched@email.com"
+
+	luow.On("Execute", ctx, []string{userLock(userID), userEmailLock(email)}).Return(provider)
+	provider.On("UserRepository", ctx).Return(repo)
+
+	repo.On("Find", domainmodel.FindSpec{UserID: &userID}).Return(&domainmodel.User{UserID: userID, Status: domainmodel.Active, Version: 2}, nil).Once()
+	repo.On("Find", domainmodel.FindSpec{Email: &email}).Return(nil, domainmodel.ErrUserNotFound).Once()
+	repo.On("Store", mock.MatchedBy(func(u domainmodel.User) bool {
+		return u.Email != nil && *u.Email == email && u.Status == domainmodel.Active
+	})).Return(nil).Once()
+	repo.On("Find", domainmodel.FindSpec{UserID: &userID}).Return(&domainmodel.User{UserID: userID, Status: domainmodel.Active, Email: &email, Version: 3}, nil).Once()
+
+	expectedVersion := int64(2)
+	u, err := service.PatchUser(ctx, appmodel.UserPatch{
+		UserID:          userID,
+		SetEmail:        true,
+		Email:           &email,
+		ExpectedVersion: &expectedVersion,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), u.Version)
+	assert.Equal(t, &email, u.Email)
+	repo.AssertExpectations(t)
+	luow.AssertExpectations(t)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type UserErasureService interface {
+	// ConfirmErasure фиксирует, что сервис удалил у себя данные пользователя. Повторное подтверждение перезаписывает время
+	ConfirmErasure(ctx context.Context, userID uuid.UUID, service string, erasedAt time.Time) error
+}
+
+func NewUserErasureService(uow UnitOfWork) UserErasureService {
+	return &userErasureService{uow: uow}
+}
+
+type userErasureService struct {
+	uow UnitOfWork
+}
+
+func (s *userErasureService) ConfirmErasure(ctx context.Context, userID uuid.UUID, service string, erasedAt time.Time) error {
+	return s.uow.Execute(ctx, func(provider RepositoryProvider) error {
+		return provider.UserErasureRepository(ctx).Store(model.UserErasure{
+			UserID:   userID,
+			Service:  service,
+			ErasedAt: erasedAt,
+		})
+	})
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxVerificationAttempts - после стольких неверных кодов нужно запросить новый
+const MaxVerificationAttempts = 5
+
+var (
+	ErrContactNotFound             = errors.New("contact not found")
+	ErrContactAlreadyVerified      = errors.New("contact already verified")
+	ErrContactVerificationNotFound = errors.New("contact verification not found")
+	ErrContactVerificationExpired  = errors.New("contact verification expired")
+	ErrInvalidVerificationCode     = errors.New("invalid verification code")
+	ErrTooManyVerificationAttempts = errors.New("too many verification attempts")
+	ErrUnknownContactType          = errors.New("unknown contact type")
+)
+
+type ContactType int
+
+const (
+	EmailContact ContactType = iota
+	TelegramContact
+)
+
+// ContactVerification - ожидающее подтверждение контакта, в базе хранится только хэш кода
+type ContactVerification struct {
+	UserID      uuid.UUID
+	ContactType ContactType
+	Value       string
+	CodeHash    string
+	Attempts    int
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+func (v ContactVerification) Expired(now time.Time) bool {
+	return !now.Before(v.ExpiresAt)
+}
+
+func (v ContactVerification) CodeMatches(code string) bool {
+	return subtle.ConstantTimeCompare([]byte(v.CodeHash), []byte(HashVerificationCode(code))) == 1
+}
+
+func HashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+type ContactVerificationRepository interface {
+	Store(verification ContactVerification) error
+	Find(userID uuid.UUID, contactType ContactType) (*ContactVerification, error)
+	Delete(userID uuid.UUID, contactType ContactType) error
+}
+
+// Contact возвращает текущее значение контакта и признак его подтверждения
+func (u User) Contact(contactType ContactType) (*string, bool, error) {
+	switch contactType {
+	case EmailContact:
+		return u.Email, u.EmailVerified, nil
+	case TelegramContact:
+		return u.Telegram, u.TelegramVerified, nil
+	default:
+		return nil, false, ErrUnknownContactType
+	}
+}
+
+// HasVerifiedContact - активным может быть только пользователь хотя бы с одним подтвержденным контактом
+func (u User) HasVerifiedContact() bool {
+	return (u.Email != nil && u.EmailVerified) || (u.Telegram != nil && u.TelegramVerified)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidCredentials - неверный логин или пароль либо вход временно заблокирован, что именно не так, не уточняем
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrCredentialsNotFound = errors.New("credentials not found")
+	ErrUserNotActive       = errors.New("user is not active")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionExpired      = errors.New("session expired")
+	ErrSessionRevoked      = errors.New("session revoked")
+)
+
+type Credentials struct {
+	UserID         uuid.UUID
+	PasswordHash   string
+	FailedAttempts int
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+func (c Credentials) Locked(now time.Time) bool {
+	return c.LockedUntil != nil && now.Before(*c.LockedUntil)
+}
+
+// LockoutPolicy - после MaxFailedAttempts неудачных входов подряд вход запрещен на LockoutDuration
+type LockoutPolicy struct {
+	MaxFailedAttempts int
+	LockoutDuration   time.Duration
+}
+
+// PasswordHasher - медленное хеширование паролей, реализация в инфраструктуре
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) bool
+}
+
+type CredentialsRepository interface {
+	Store(credentials Credentials) error
+	Find(userID uuid.UUID) (*Credentials, error)
+}
+
+// Session - сессия входа. Refresh token хранится только хешем и меняется при каждом обновлении
+type Session struct {
+	SessionID        uuid.UUID
+	UserID           uuid.UUID
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+	RevokedAt        *time.Time
+}
+
+func (s Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type SessionRepository interface {
+	NextID() (uuid.UUID, error)
+	Store(session Session) error
+	FindByRefreshTokenHash(hash string) (*Session, error)
+}
@@ -22,9 +22,12 @@ func (u UserCreated) Type() string {
 type UserUpdated struct {
 	UserID        uuid.UUID
 	UpdatedFields *struct {
-		Status   *UserStatus
-		Email    *string
-		Telegram *string
+		Status           *UserStatus
+		Email            *string
+		Telegram         *string
+		EmailVerified    *bool
+		TelegramVerified *bool
+		Roles            *[]Role
 	}
 	RemovedFields *struct {
 		Email    *bool
@@ -47,3 +50,24 @@ type UserDeleted struct {
 func (u UserDeleted) Type() string {
 	return "user_deleted"
 }
+
+type UserRestored struct {
+	UserID     uuid.UUID
+	Status     UserStatus
+	RestoredAt time.Time
+}
+
+func (u UserRestored) Type() string {
+	return "user_restored"
+}
+
+type UserLoginChanged struct {
+	UserID    uuid.UUID
+	OldLogin  string
+	Login     string
+	ChangedAt time.Time
+}
+
+func (u UserLoginChanged) Type() string {
+	return "user_login_changed"
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUserLoginReserved = errors.New("user login reserved")
+
+// LoginHistory - прежний логин пользователя. До ReservedUntil его может вернуть себе только этот пользователь
+type LoginHistory struct {
+	UserID        uuid.UUID
+	Login         string
+	ChangedAt     time.Time
+	ReservedUntil time.Time
+}
+
+type LoginHistoryRepository interface {
+	Store(entry LoginHistory) error
+	// IsReserved - логин был у другого пользователя и срок резерва на момент at еще не истек
+	IsReserved(login string, exceptUserID uuid.UUID, at time.Time) (bool, error)
+}
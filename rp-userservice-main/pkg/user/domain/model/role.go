@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrUnknownRole      = errors.New("unknown role")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+type Role string
+
+const (
+	RoleAdmin          Role = "admin"
+	RoleProductManager Role = "product_manager"
+	RoleBalanceManager Role = "balance_manager"
+)
+
+type Permission string
+
+const (
+	// PermissionManageProducts - изменение каталога в productservice
+	PermissionManageProducts Permission = "products.manage"
+	// PermissionPurgeProducts - окончательное удаление товаров, есть только у администратора
+	PermissionPurgeProducts Permission = "products.purge"
+	// PermissionManageBalances - StoreUserBalance в paymentservice
+	PermissionManageBalances Permission = "balances.manage"
+	// PermissionManageExchangeRates - SetExchangeRate в orderservice, есть только у администратора
+	PermissionManageExchangeRates Permission = "exchange_rates.manage"
+	// PermissionManagePromotions - StorePromotion в orderservice, есть только у администратора
+	PermissionManagePromotions Permission = "promotions.manage"
+	PermissionManageUsers      Permission = "users.manage"
+	PermissionManageRoles      Permission = "roles.manage"
+)
+
+// rolePermissions - единственное место, где роль раскрывается в права.
+// В событиях вместе с ролями передаются и права, поэтому другим сервисам эта таблица не нужна
+var rolePermissions = map[Role][]Permission{
+	RoleAdmin: {
+		PermissionManageProducts,
+		PermissionPurgeProducts,
+		PermissionManageBalances,
+		PermissionManageExchangeRates,
+		PermissionManagePromotions,
+		PermissionManageUsers,
+		PermissionManageRoles,
+	},
+	RoleProductManager: {PermissionManageProducts},
+	RoleBalanceManager: {PermissionManageBalances},
+}
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", ErrUnknownRole
+	}
+	return role, nil
+}
+
+// PermissionsOf возвращает отсортированный список прав без повторов
+func PermissionsOf(roles []Role) []Permission {
+	permissions := make([]Permission, 0)
+	for _, role := range roles {
+		for _, permission := range rolePermissions[role] {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	slices.Sort(permissions)
+	return permissions
+}
+
+func HasPermission(roles []Role, permission Permission) bool {
+	return slices.Contains(PermissionsOf(roles), permission)
+}
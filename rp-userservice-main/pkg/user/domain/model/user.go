@@ -12,6 +12,11 @@ var (
 	ErrUserLoginAlreadyUsed    = errors.New("user login already used")
 	ErrUserEmailAlreadyUsed    = errors.New("user email already used")
 	ErrUserTelegramAlreadyUsed = errors.New("user telegram already used")
+	ErrUserNotDeleted          = errors.New("user not deleted")
+	ErrUserHardDeleted         = errors.New("user hard deleted, restore is impossible")
+	ErrUserRetentionNotExpired = errors.New("user retention period not expired")
+	ErrUserVersionMismatch     = errors.New("user version mismatch")
+	ErrUserDeleted             = errors.New("user deleted")
 )
 
 type UserStatus int
@@ -22,17 +27,47 @@ const (
 	Deleted
 )
 
+// BlockReason - почему пользователь в статусе Blocked. От причины зависит, что снимает блокировку:
+// подтверждение контакта - только BlockReasonUnverifiedContacts, истечение автоблокировки - только BlockReasonAutoBlock
+type BlockReason string
+
+const (
+	BlockReasonUnverifiedContacts BlockReason = "unverified_contacts"
+	BlockReasonAutoBlock          BlockReason = "auto_block"
+	BlockReasonManual             BlockReason = "manual"
+)
+
 type User struct {
-	UserID    uuid.UUID
-	Status    UserStatus
-	Login     string
-	Email     *string
-	Telegram  *string
+	UserID           uuid.UUID
+	Status           UserStatus
+	Login            string
+	Email            *string
+	Telegram         *string
+	EmailVerified    bool
+	TelegramVerified bool
+	// BlockReason пустая у активного пользователя. Удаление ее не сбрасывает, чтобы восстановление не сняло блокировку
+	BlockReason BlockReason
+	// Roles - без повторов, в порядке назначения
+	Roles []Role
+	// Version ведет репозиторий: каждое сохранение увеличивает ее на единицу
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt *time.Time
 }
 
+// UserPatch - Status меняется, если не nil. Контакт меняется, только если выставлен SetEmail/SetTelegram,
+// nil значение при этом удаляет контакт
+type UserPatch struct {
+	Status      *UserStatus
+	SetEmail    bool
+	Email       *string
+	SetTelegram bool
+	Telegram    *string
+	// BlockReason - причина для Status Blocked, по умолчанию BlockReasonManual
+	BlockReason BlockReason
+}
+
 type FindSpec struct {
 	UserID   *uuid.UUID
 	Login    *string
@@ -45,4 +80,6 @@ type UserRepository interface {
 	Store(user User) error
 	Find(spec FindSpec) (*User, error)
 	HardDelete(userID uuid.UUID) error
+	// IsHardDeleted - пользователь был удален окончательно, данных для восстановления нет
+	IsHardDeleted(userID uuid.UUID) (bool, error)
 }
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserErasure - подтверждение сервиса, что персональные данные пользователя у него удалены
+type UserErasure struct {
+	UserID   uuid.UUID
+	Service  string
+	ErasedAt time.Time
+}
+
+type UserErasureRepository interface {
+	Store(erasure UserErasure) error
+	FindForUser(userID uuid.UUID) ([]UserErasure, error)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type AuthService interface {
+	SetPassword(userID uuid.UUID, password string) error
+	// Authenticate на неизвестный логин, неверный пароль и заблокированный после неудач вход одинаково
+	// возвращает ErrInvalidCredentials, при неверном пароле счетчик неудач уже сохранен
+	Authenticate(login, password, refreshTokenHash string, sessionTTL time.Duration) (model.Session, error)
+	// RefreshSession заменяет refresh token сессии новым, старый после этого не действует
+	RefreshSession(refreshTokenHash, newRefreshTokenHash string, sessionTTL time.Duration) (model.Session, error)
+	RevokeSession(refreshTokenHash string) error
+}
+
+func NewAuthService(
+	userRepository model.UserRepository,
+	credentialsRepository model.CredentialsRepository,
+	sessionRepository model.SessionRepository,
+	passwordHasher model.PasswordHasher,
+	lockoutPolicy model.LockoutPolicy,
+) AuthService {
+	return &authService{
+		userRepository:        userRepository,
+		credentialsRepository: credentialsRepository,
+		sessionRepository:     sessionRepository,
+		passwordHasher:        passwordHasher,
+		lockoutPolicy:         lockoutPolicy,
+	}
+}
+
+type authService struct {
+	userRepository        model.UserRepository
+	credentialsRepository model.CredentialsRepository
+	sessionRepository     model.SessionRepository
+	passwordHasher        model.PasswordHasher
+	lockoutPolicy         model.LockoutPolicy
+}
+
+func (s authService) SetPassword(userID uuid.UUID, password string) error {
+	_, err := s.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	return s.credentialsRepository.Store(model.Credentials{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+		UpdatedAt:    time.Now(),
+	})
+}
+
+func (s authService) Authenticate(login, password, refreshTokenHash string, sessionTTL time.Duration) (model.Session, error) {
+	user, err := s.userRepository.Find(model.FindSpec{
+		Login: &login,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.Session{}, s.invalidCredentials(password)
+		}
+		return model.Session{}, err
+	}
+
+	credentials, err := s.credentialsRepository.Find(user.UserID)
+	if err != nil {
+		if errors.Is(err, model.ErrCredentialsNotFound) {
+			return model.Session{}, s.invalidCredentials(password)
+		}
+		return model.Session{}, err
+	}
+
+	// пароль проверяем и у заблокированного: иначе по времени ответа видно, что вход заблокирован
+	passwordValid := s.passwordHasher.Verify(credentials.PasswordHash, password)
+	currentTime := time.Now()
+	if credentials.Locked(currentTime) {
+		return model.Session{}, model.ErrInvalidCredentials
+	}
+
+	if !passwordValid {
+		credentials.FailedAttempts++
+		if s.lockoutPolicy.MaxFailedAttempts > 0 && credentials.FailedAttempts >= s.lockoutPolicy.MaxFailedAttempts {
+			lockedUntil := currentTime.Add(s.lockoutPolicy.LockoutDuration)
+			credentials.LockedUntil = &lockedUntil
+			credentials.FailedAttempts = 0
+		}
+		credentials.UpdatedAt = currentTime
+		err = s.credentialsRepository.Store(*credentials)
+		if err != nil {
+			return model.Session{}, err
+		}
+		return model.Session{}, model.ErrInvalidCredentials
+	}
+
+	// статус проверяем после пароля, чтобы по ответу нельзя было узнать статус чужого логина
+	if user.Status != model.Active {
+		return model.Session{}, model.ErrUserNotActive
+	}
+
+	if credentials.FailedAttempts != 0 || credentials.LockedUntil != nil {
+		credentials.FailedAttempts = 0
+		credentials.LockedUntil = nil
+		credentials.UpdatedAt = currentTime
+		err = s.credentialsRepository.Store(*credentials)
+		if err != nil {
+			return model.Session{}, err
+		}
+	}
+
+	sessionID, err := s.sessionRepository.NextID()
+	if err != nil {
+		return model.Session{}, err
+	}
+	session := model.Session{
+		SessionID:        sessionID,
+		UserID:           user.UserID,
+		RefreshTokenHash: refreshTokenHash,
+		ExpiresAt:        currentTime.Add(sessionTTL),
+		CreatedAt:        currentTime,
+	}
+	return session, s.sessionRepository.Store(session)
+}
+
+// invalidCredentials тратит на отказ столько же, сколько проверка пароля, чтобы по времени ответа нельзя было узнать, есть ли логин
+func (s authService) invalidCredentials(password string) error {
+	_, _ = s.passwordHasher.Hash(password)
+	return model.ErrInvalidCredentials
+}
+
+func (s authService) RefreshSession(refreshTokenHash, newRefreshTokenHash string, sessionTTL time.Duration) (model.Session, error) {
+	session, err := s.sessionRepository.FindByRefreshTokenHash(refreshTokenHash)
+	if err != nil {
+		return model.Session{}, err
+	}
+
+	currentTime := time.Now()
+	if session.RevokedAt != nil {
+		return model.Session{}, model.ErrSessionRevoked
+	}
+	if session.Expired(currentTime) {
+		return model.Session{}, model.ErrSessionExpired
+	}
+
+	// пользователя могли заблокировать или удалить после входа
+	user, err := s.userRepository.Find(model.FindSpec{
+		UserID: &session.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.Session{}, model.ErrUserNotActive
+		}
+		return model.Session{}, err
+	}
+	if user.Status != model.Active {
+		return model.Session{}, model.ErrUserNotActive
+	}
+
+	session.RefreshTokenHash = newRefreshTokenHash
+	session.ExpiresAt = currentTime.Add(sessionTTL)
+	return *session, s.sessionRepository.Store(*session)
+}
+
+func (s authService) RevokeSession(refreshTokenHash string) error {
+	session, err := s.sessionRepository.FindByRefreshTokenHash(refreshTokenHash)
+	if err != nil {
+		return err
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+
+	currentTime := time.Now()
+	session.RevokedAt = &currentTime
+	return s.sessionRepository.Store(*session)
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type MockCredentialsRepository struct {
+	mock.Mock
+}
+
+func (m *MockCredentialsRepository) Store(credentials model.Credentials) error {
+	args := m.Called(credentials)
+	return args.Error(0)
+}
+
+func (m *MockCredentialsRepository) Find(userID uuid.UUID) (*model.Credentials, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Credentials), args.Error(1)
+}
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) NextID() (uuid.UUID, error) {
+	args := m.Called()
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockSessionRepository) Store(session model.Session) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) FindByRefreshTokenHash(hash string) (*model.Session, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+// plainHasher - хеш равен паролю, в тестах скорость важнее стойкости
+type plainHasher struct{}
+
+func (plainHasher) Hash(password string) (string, error) {
+	return password, nil
+}
+
+func (plainHasher) Verify(hash, password string) bool {
+	return hash == password
+}
+
+func TestAuthService_Authenticate(t *testing.T) {
+	repo := new(MockUserRepository)
+	credentialsRepo := new(MockCredentialsRepository)
+	sessionRepo := new(MockSessionRepository)
+	policy := model.LockoutPolicy{MaxFailedAttempts: 3, LockoutDuration: time.Minute}
+	service := NewAuthService(repo, credentialsRepo, sessionRepo, plainHasher{}, policy)
+
+	userID := uuid.New()
+	login := "test_user"
+
+	t.Run("success", func(t *testing.T) {
+		sessionID := uuid.New()
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret"}, nil).Once()
+		sessionRepo.On("NextID").Return(sessionID, nil).Once()
+		sessionRepo.On("Store", mock.MatchedBy(func(s model.Session) bool {
+			return s.SessionID == sessionID && s.UserID == userID && s.RefreshTokenHash == "hash"
+		})).Return(nil).Once()
+
+		session, err := service.Authenticate(login, "secret", "hash", time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, session.UserID)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown_login", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{Login: &login}).Return(nil, model.ErrUserNotFound).Once()
+
+		_, err := service.Authenticate(login, "secret", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+
+	t.Run("wrong_password", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret"}, nil).Once()
+		credentialsRepo.On("Store", mock.MatchedBy(func(c model.Credentials) bool {
+			return c.FailedAttempts == 1 && c.LockedUntil == nil
+		})).Return(nil).Once()
+
+		_, err := service.Authenticate(login, "wrong", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+		credentialsRepo.AssertExpectations(t)
+	})
+
+	t.Run("lockout_after_max_attempts", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret", FailedAttempts: 2}, nil).Once()
+		credentialsRepo.On("Store", mock.MatchedBy(func(c model.Credentials) bool {
+			return c.FailedAttempts == 0 && c.LockedUntil != nil && c.LockedUntil.After(time.Now())
+		})).Return(nil).Once()
+
+		_, err := service.Authenticate(login, "wrong", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+		credentialsRepo.AssertExpectations(t)
+	})
+
+	t.Run("locked_out", func(t *testing.T) {
+		lockedUntil := time.Now().Add(time.Minute)
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret", LockedUntil: &lockedUntil}, nil).Once()
+
+		// ни верный, ни неверный пароль не выдают блокировку и не сдвигают ее срок
+		_, err := service.Authenticate(login, "secret", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret", LockedUntil: &lockedUntil}, nil).Once()
+
+		_, err = service.Authenticate(login, "wrong", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+
+	t.Run("no_password_set", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(nil, model.ErrCredentialsNotFound).Once()
+
+		_, err := service.Authenticate(login, "secret", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrInvalidCredentials)
+	})
+
+	t.Run("blocked_user", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{Login: &login}).Return(&model.User{UserID: userID, Status: model.Blocked}, nil).Once()
+		credentialsRepo.On("Find", userID).Return(&model.Credentials{UserID: userID, PasswordHash: "secret"}, nil).Once()
+
+		_, err := service.Authenticate(login, "secret", "hash", time.Hour)
+		assert.ErrorIs(t, err, model.ErrUserNotActive)
+	})
+}
+
+func TestAuthService_RefreshSession(t *testing.T) {
+	repo := new(MockUserRepository)
+	credentialsRepo := new(MockCredentialsRepository)
+	sessionRepo := new(MockSessionRepository)
+	service := NewAuthService(repo, credentialsRepo, sessionRepo, plainHasher{}, model.LockoutPolicy{})
+
+	userID := uuid.New()
+	newSession := func() *model.Session {
+		return &model.Session{
+			SessionID:        uuid.New(),
+			UserID:           userID,
+			RefreshTokenHash: "old",
+			ExpiresAt:        time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		sessionRepo.On("FindByRefreshTokenHash", "old").Return(newSession(), nil).Once()
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+		sessionRepo.On("Store", mock.MatchedBy(func(s model.Session) bool {
+			return s.RefreshTokenHash == "new"
+		})).Return(nil).Once()
+
+		session, err := service.RefreshSession("old", "new", time.Hour)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", session.RefreshTokenHash)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		session := newSession()
+		revokedAt := time.Now()
+		session.RevokedAt = &revokedAt
+		sessionRepo.On("FindByRefreshTokenHash", "old").Return(session, nil).Once()
+
+		_, err := service.RefreshSession("old", "new", time.Hour)
+		assert.ErrorIs(t, err, model.ErrSessionRevoked)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		session := newSession()
+		session.ExpiresAt = time.Now().Add(-time.Second)
+		sessionRepo.On("FindByRefreshTokenHash", "old").Return(session, nil).Once()
+
+		_, err := service.RefreshSession("old", "new", time.Hour)
+		assert.ErrorIs(t, err, model.ErrSessionExpired)
+	})
+
+	t.Run("deleted_user", func(t *testing.T) {
+		sessionRepo.On("FindByRefreshTokenHash", "old").Return(newSession(), nil).Once()
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Deleted}, nil).Once()
+
+		_, err := service.RefreshSession("old", "new", time.Hour)
+		assert.ErrorIs(t, err, model.ErrUserNotActive)
+	})
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	sessionRepo := new(MockSessionRepository)
+	service := NewAuthService(new(MockUserRepository), new(MockCredentialsRepository), sessionRepo, plainHasher{}, model.LockoutPolicy{})
+
+	sessionRepo.On("FindByRefreshTokenHash", "hash").Return(&model.Session{RefreshTokenHash: "hash"}, nil).Once()
+	sessionRepo.On("Store", mock.MatchedBy(func(s model.Session) bool {
+		return s.RevokedAt != nil
+	})).Return(nil).Once()
+
+	err := service.RevokeSession("hash")
+	assert.NoError(t, err)
+	sessionRepo.AssertExpectations(t)
+}
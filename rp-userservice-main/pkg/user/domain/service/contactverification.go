@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"userservice/pkg/common/domain"
+	"userservice/pkg/user/domain/model"
+)
+
+type ContactVerificationService interface {
+	// RequestVerification заводит ожидающее подтверждение с новым кодом и возвращает контакт, куда его отправить
+	RequestVerification(userID uuid.UUID, contactType model.ContactType, code string, ttl time.Duration) (model.ContactVerification, error)
+	ConfirmContact(userID uuid.UUID, contactType model.ContactType, code string) error
+	// ExpireVerification удаляет подтверждение, если оно истекло и за это время не было запрошено новое
+	ExpireVerification(userID uuid.UUID, contactType model.ContactType) error
+}
+
+func NewContactVerificationService(
+	userRepository model.UserRepository,
+	verificationRepository model.ContactVerificationRepository,
+	eventDispatcher domain.EventDispatcher,
+) ContactVerificationService {
+	return &contactVerificationService{
+		userRepository:         userRepository,
+		verificationRepository: verificationRepository,
+		eventDispatcher:        eventDispatcher,
+	}
+}
+
+type contactVerificationService struct {
+	userRepository         model.UserRepository
+	verificationRepository model.ContactVerificationRepository
+	eventDispatcher        domain.EventDispatcher
+}
+
+func (s contactVerificationService) RequestVerification(
+	userID uuid.UUID,
+	contactType model.ContactType,
+	code string,
+	ttl time.Duration,
+) (model.ContactVerification, error) {
+	user, err := s.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		return model.ContactVerification{}, err
+	}
+
+	contact, verified, err := user.Contact(contactType)
+	if err != nil {
+		return model.ContactVerification{}, err
+	}
+	if contact == nil {
+		return model.ContactVerification{}, model.ErrContactNotFound
+	}
+	if verified {
+		return model.ContactVerification{}, model.ErrContactAlreadyVerified
+	}
+
+	currentTime := time.Now()
+	verification := model.ContactVerification{
+		UserID:      userID,
+		ContactType: contactType,
+		Value:       *contact,
+		CodeHash:    model.HashVerificationCode(code),
+		ExpiresAt:   currentTime.Add(ttl),
+		CreatedAt:   currentTime,
+	}
+	return verification, s.verificationRepository.Store(verification)
+}
+
+func (s contactVerificationService) ConfirmContact(userID uuid.UUID, contactType model.ContactType, code string) error {
+	verification, err := s.verificationRepository.Find(userID, contactType)
+	if err != nil {
+		return err
+	}
+
+	currentTime := time.Now()
+	if verification.Expired(currentTime) {
+		return model.ErrContactVerificationExpired
+	}
+	if verification.Attempts >= model.MaxVerificationAttempts {
+		return model.ErrTooManyVerificationAttempts
+	}
+	if !verification.CodeMatches(code) {
+		verification.Attempts++
+		err = s.verificationRepository.Store(*verification)
+		if err != nil {
+			return err
+		}
+		return model.ErrInvalidVerificationCode
+	}
+
+	user, err := s.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// контакт поменяли после отправки кода, подтверждать старое значение нельзя
+	contact, verified, err := user.Contact(contactType)
+	if err != nil {
+		return err
+	}
+	if contact == nil || *contact != verification.Value {
+		err = s.verificationRepository.Delete(userID, contactType)
+		if err != nil {
+			return err
+		}
+		return model.ErrContactVerificationNotFound
+	}
+	if verified {
+		return model.ErrContactAlreadyVerified
+	}
+
+	updatedFields := &struct {
+		Status           *model.UserStatus
+		Email            *string
+		Telegram         *string
+		EmailVerified    *bool
+		TelegramVerified *bool
+		Roles            *[]model.Role
+	}{}
+	switch contactType {
+	case model.EmailContact:
+		user.EmailVerified = true
+		updatedFields.EmailVerified = toPtr(true)
+	case model.TelegramContact:
+		user.TelegramVerified = true
+		updatedFields.TelegramVerified = toPtr(true)
+	}
+	user.UpdatedAt = currentTime
+	err = s.userRepository.Store(*user)
+	if err != nil {
+		return err
+	}
+
+	err = s.verificationRepository.Delete(userID, contactType)
+	if err != nil {
+		return err
+	}
+
+	// тута кричим, что контакт подтвержден
+	return s.eventDispatcher.Dispatch(&model.UserUpdated{
+		UserID:        userID,
+		UpdatedAt:     currentTime,
+		UpdatedFields: updatedFields,
+	})
+}
+
+func (s contactVerificationService) ExpireVerification(userID uuid.UUID, contactType model.ContactType) error {
+	verification, err := s.verificationRepository.Find(userID, contactType)
+	if err != nil {
+		if errors.Is(err, model.ErrContactVerificationNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !verification.Expired(time.Now()) {
+		return nil
+	}
+	return s.verificationRepository.Delete(userID, contactType)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type MockContactVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockContactVerificationRepository) Store(verification model.ContactVerification) error {
+	args := m.Called(verification)
+	return args.Error(0)
+}
+
+func (m *MockContactVerificationRepository) Find(userID uuid.UUID, contactType model.ContactType) (*model.ContactVerification, error) {
+	args := m.Called(userID, contactType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ContactVerification), args.Error(1)
+}
+
+func (m *MockContactVerificationRepository) Delete(userID uuid.UUID, contactType model.ContactType) error {
+	args := m.Called(userID, contactType)
+	return args.Error(0)
+}
+
+func TestContactVerificationService_RequestVerification(t *testing.T) {
+	repo := new(MockUserRepository)
+	verificationRepo := new(MockContactVerificationRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewContactVerificationService(repo, verificationRepo, dispatcher)
+
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("success", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Email: &email}, nil).Once()
+		verificationRepo.On("Store", mock.MatchedBy(func(v model.ContactVerification) bool {
+			return v.Value == email && v.CodeHash == model.HashVerificationCode("123456") && v.Attempts == 0
+		})).Return(nil).Once()
+
+		verification, err := service.RequestVerification(userID, model.EmailContact, "123456", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, email, verification.Value)
+		verificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("no_contact", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID}, nil).Once()
+
+		_, err := service.RequestVerification(userID, model.TelegramContact, "123456", time.Minute)
+		assert.ErrorIs(t, err, model.ErrContactNotFound)
+	})
+
+	t.Run("already_verified", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Email: &email, EmailVerified: true}, nil).Once()
+
+		_, err := service.RequestVerification(userID, model.EmailContact, "123456", time.Minute)
+		assert.ErrorIs(t, err, model.ErrContactAlreadyVerified)
+	})
+}
+
+func TestContactVerificationService_ConfirmContact(t *testing.T) {
+	repo := new(MockUserRepository)
+	verificationRepo := new(MockContactVerificationRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewContactVerificationService(repo, verificationRepo, dispatcher)
+
+	userID := uuid.New()
+	email := "test@example.com"
+	newVerification := func() *model.ContactVerification {
+		return &model.ContactVerification{
+			UserID:      userID,
+			ContactType: model.EmailContact,
+			Value:       email,
+			CodeHash:    model.HashVerificationCode("123456"),
+			ExpiresAt:   time.Now().Add(time.Minute),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		verificationRepo.On("Find", userID, model.EmailContact).Return(newVerification(), nil).Once()
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Email: &email}, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.EmailVerified
+		})).Return(nil).Once()
+		verificationRepo.On("Delete", userID, model.EmailContact).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserUpdated) bool {
+			return e.UserID == userID && e.UpdatedFields.EmailVerified != nil && *e.UpdatedFields.EmailVerified
+		})).Return(nil).Once()
+
+		err := service.ConfirmContact(userID, model.EmailContact, "123456")
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		verificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid_code", func(t *testing.T) {
+		verificationRepo.On("Find", userID, model.EmailContact).Return(newVerification(), nil).Once()
+		verificationRepo.On("Store", mock.MatchedBy(func(v model.ContactVerification) bool {
+			return v.Attempts == 1
+		})).Return(nil).Once()
+
+		err := service.ConfirmContact(userID, model.EmailContact, "000000")
+		assert.ErrorIs(t, err, model.ErrInvalidVerificationCode)
+	})
+
+	t.Run("too_many_attempts", func(t *testing.T) {
+		verification := newVerification()
+		verification.Attempts = model.MaxVerificationAttempts
+		verificationRepo.On("Find", userID, model.EmailContact).Return(verification, nil).Once()
+
+		err := service.ConfirmContact(userID, model.EmailContact, "123456")
+		assert.ErrorIs(t, err, model.ErrTooManyVerificationAttempts)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		verification := newVerification()
+		verification.ExpiresAt = time.Now().Add(-time.Second)
+		verificationRepo.On("Find", userID, model.EmailContact).Return(verification, nil).Once()
+
+		err := service.ConfirmContact(userID, model.EmailContact, "123456")
+		assert.ErrorIs(t, err, model.ErrContactVerificationExpired)
+	})
+
+	t.Run("contact_changed", func(t *testing.T) {
+		otherEmail := "other@example.com"
+		verificationRepo.On("Find", userID, model.EmailContact).Return(newVerification(), nil).Once()
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Email: &otherEmail}, nil).Once()
+		verificationRepo.On("Delete", userID, model.EmailContact).Return(nil).Once()
+
+		err := service.ConfirmContact(userID, model.EmailContact, "123456")
+		assert.ErrorIs(t, err, model.ErrContactVerificationNotFound)
+	})
+}
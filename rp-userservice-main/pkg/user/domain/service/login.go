@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"userservice/pkg/common/domain"
+	"userservice/pkg/user/domain/model"
+)
+
+type LoginService interface {
+	// CheckLoginAvailable - логин не занят и не зарезервирован за другим пользователем.
+	// Для нового пользователя userID равен uuid.Nil
+	CheckLoginAvailable(userID uuid.UUID, login string) error
+	// ChangeLogin сохраняет прежний логин в истории и резервирует его за пользователем на reservationPeriod
+	ChangeLogin(userID uuid.UUID, login string) error
+}
+
+func NewLoginService(
+	userRepository model.UserRepository,
+	loginHistoryRepository model.LoginHistoryRepository,
+	eventDispatcher domain.EventDispatcher,
+	reservationPeriod time.Duration,
+) LoginService {
+	return &loginService{
+		userRepository:         userRepository,
+		loginHistoryRepository: loginHistoryRepository,
+		eventDispatcher:        eventDispatcher,
+		reservationPeriod:      reservationPeriod,
+	}
+}
+
+type loginService struct {
+	userRepository         model.UserRepository
+	loginHistoryRepository model.LoginHistoryRepository
+	eventDispatcher        domain.EventDispatcher
+	reservationPeriod      time.Duration
+}
+
+func (s loginService) CheckLoginAvailable(userID uuid.UUID, login string) error {
+	userWithLogin, err := s.userRepository.Find(model.FindSpec{
+		Login: &login,
+	})
+	if err != nil && !errors.Is(err, model.ErrUserNotFound) {
+		return err
+	}
+	if userWithLogin != nil && userWithLogin.UserID != userID {
+		return model.ErrUserLoginAlreadyUsed
+	}
+
+	reserved, err := s.loginHistoryRepository.IsReserved(login, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return model.ErrUserLoginReserved
+	}
+	return nil
+}
+
+func (s loginService) ChangeLogin(userID uuid.UUID, login string) error {
+	user, err := s.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		return err
+	}
+	if user.Login == login {
+		return nil
+	}
+
+	err = s.CheckLoginAvailable(userID, login)
+	if err != nil {
+		return err
+	}
+
+	currentTime := time.Now()
+	err = s.loginHistoryRepository.Store(model.LoginHistory{
+		UserID:        userID,
+		Login:         user.Login,
+		ChangedAt:     currentTime,
+		ReservedUntil: currentTime.Add(s.reservationPeriod),
+	})
+	if err != nil {
+		return err
+	}
+
+	oldLogin := user.Login
+	user.Login = login
+	user.UpdatedAt = currentTime
+	err = s.userRepository.Store(*user)
+	if err != nil {
+		return err
+	}
+
+	// тута кричим, что у юзера новый логин
+	return s.eventDispatcher.Dispatch(&model.UserLoginChanged{
+		UserID:    userID,
+		OldLogin:  oldLogin,
+		Login:     login,
+		ChangedAt: currentTime,
+	})
+}
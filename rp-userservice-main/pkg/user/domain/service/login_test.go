@@ -0,0 +1,83 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type MockLoginHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginHistoryRepository) Store(entry model.LoginHistory) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockLoginHistoryRepository) IsReserved(login string, exceptUserID uuid.UUID, at time.Time) (bool, error) {
+	args := m.Called(login, exceptUserID, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestLoginService_ChangeLogin(t *testing.T) {
+	repo := new(MockUserRepository)
+	historyRepo := new(MockLoginHistoryRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewLoginService(repo, historyRepo, dispatcher, 24*time.Hour)
+
+	userID := uuid.New()
+	oldLogin := "old_login"
+	newLogin := "new_login"
+
+	t.Run("success", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Login: oldLogin}, nil).Once()
+		repo.On("Find", model.FindSpec{Login: &newLogin}).Return(nil, model.ErrUserNotFound).Once()
+		historyRepo.On("IsReserved", newLogin, userID, mock.Anything).Return(false, nil).Once()
+		historyRepo.On("Store", mock.MatchedBy(func(e model.LoginHistory) bool {
+			return e.UserID == userID && e.Login == oldLogin && e.ReservedUntil.Sub(e.ChangedAt) == 24*time.Hour
+		})).Return(nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Login == newLogin
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserLoginChanged) bool {
+			return e.UserID == userID && e.OldLogin == oldLogin && e.Login == newLogin
+		})).Return(nil).Once()
+
+		err := service.ChangeLogin(userID, newLogin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		historyRepo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("already_used", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Login: oldLogin}, nil).Once()
+		repo.On("Find", model.FindSpec{Login: &newLogin}).Return(&model.User{UserID: uuid.New(), Login: newLogin}, nil).Once()
+
+		err := service.ChangeLogin(userID, newLogin)
+		assert.ErrorIs(t, err, model.ErrUserLoginAlreadyUsed)
+	})
+
+	t.Run("reserved", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Login: oldLogin}, nil).Once()
+		repo.On("Find", model.FindSpec{Login: &newLogin}).Return(nil, model.ErrUserNotFound).Once()
+		historyRepo.On("IsReserved", newLogin, userID, mock.Anything).Return(true, nil).Once()
+
+		err := service.ChangeLogin(userID, newLogin)
+		assert.ErrorIs(t, err, model.ErrUserLoginReserved)
+	})
+
+	t.Run("same_login", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Login: newLogin}, nil).Once()
+
+		err := service.ChangeLogin(userID, newLogin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
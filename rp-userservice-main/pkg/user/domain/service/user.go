@@ -2,7 +2,7 @@ package service
 
 import (
 	"errors"
-	"reflect"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,9 +14,23 @@ import (
 type UserService interface {
 	CreateUser(status model.UserStatus, login string) (uuid.UUID, error)
 	UpdateUserStatus(userID uuid.UUID, status model.UserStatus) error
+	BlockUser(userID uuid.UUID, reason model.BlockReason) error
 	UpdateUserEmail(userID uuid.UUID, email *string) error
 	UpdateUserTelegram(userID uuid.UUID, telegram *string) error
 	DeleteUser(userID uuid.UUID, hard bool) error
+	// HardDeleteUser окончательно удаляет пользователя, который все еще находится в статусе Deleted
+	// и удален не позже deletedBefore. Пустой deletedBefore время удаления не проверяет
+	HardDeleteUser(userID uuid.UUID, deletedBefore time.Time) error
+	RestoreUser(userID uuid.UUID) error
+	// PatchUser применяет все изменения разом и публикует одно событие UserUpdated.
+	// Если expectedVersion не nil и не совпадает с версией пользователя, возвращает ErrUserVersionMismatch
+	PatchUser(userID uuid.UUID, patch model.UserPatch, expectedVersion *int64) error
+	// AssignRole и RevokeRole идемпотентны: повторный вызов не меняет пользователя и не порождает событие.
+	// Удаленному пользователю роль не назначается, отозвать роль можно
+	AssignRole(userID uuid.UUID, role model.Role) error
+	RevokeRole(userID uuid.UUID, role model.Role) error
+	// Authorize возвращает ErrPermissionDenied, если пользователя нет, он не активен или у его ролей нет права
+	Authorize(userID uuid.UUID, permission model.Permission) error
 }
 
 func NewUserService(
@@ -50,13 +64,20 @@ func (u userService) CreateUser(status model.UserStatus, login string) (uuid.UUI
 		return uuid.Nil, err
 	}
 
+	var blockReason model.BlockReason
+	if status == model.Blocked {
+		// у нового пользователя еще нет контактов, блокировку снимет их подтверждение
+		blockReason = model.BlockReasonUnverifiedContacts
+	}
+
 	currentTime := time.Now()
 	err = u.userRepository.Store(model.User{
-		UserID:    userID,
-		Status:    status,
-		Login:     login,
-		CreatedAt: currentTime,
-		UpdatedAt: currentTime,
+		UserID:      userID,
+		Status:      status,
+		BlockReason: blockReason,
+		Login:       login,
+		CreatedAt:   currentTime,
+		UpdatedAt:   currentTime,
 	})
 	if err != nil {
 		return uuid.Nil, err
@@ -72,181 +93,338 @@ func (u userService) CreateUser(status model.UserStatus, login string) (uuid.UUI
 }
 
 func (u userService) UpdateUserStatus(userID uuid.UUID, status model.UserStatus) error {
+	return u.PatchUser(userID, model.UserPatch{Status: &status}, nil)
+}
+
+func (u userService) BlockUser(userID uuid.UUID, reason model.BlockReason) error {
+	status := model.Blocked
+	return u.PatchUser(userID, model.UserPatch{Status: &status, BlockReason: reason}, nil)
+}
+
+func (u userService) UpdateUserEmail(userID uuid.UUID, email *string) error {
+	return u.PatchUser(userID, model.UserPatch{SetEmail: true, Email: email}, nil)
+}
+
+func (u userService) UpdateUserTelegram(userID uuid.UUID, telegram *string) error {
+	return u.PatchUser(userID, model.UserPatch{SetTelegram: true, Telegram: telegram}, nil)
+}
+
+func (u userService) PatchUser(userID uuid.UUID, patch model.UserPatch, expectedVersion *int64) error {
 	user, err := u.userRepository.Find(model.FindSpec{
 		UserID: &userID,
 	})
 	if err != nil {
 		return err
 	}
+	if expectedVersion != nil && user.Version != *expectedVersion {
+		return model.ErrUserVersionMismatch
+	}
+
+	updatedFields := &struct {
+		Status           *model.UserStatus
+		Email            *string
+		Telegram         *string
+		EmailVerified    *bool
+		TelegramVerified *bool
+		Roles            *[]model.Role
+	}{}
+	removedFields := &struct {
+		Email    *bool
+		Telegram *bool
+	}{}
+	changed := false
+
+	if patch.Status != nil {
+		var blockReason model.BlockReason
+		if *patch.Status == model.Blocked {
+			blockReason = patch.BlockReason
+			if blockReason == "" {
+				blockReason = model.BlockReasonManual
+			}
+		}
+		if *patch.Status != user.Status {
+			user.Status = *patch.Status
+			updatedFields.Status = patch.Status
+			changed = true
+		}
+		// смена причины без смены статуса в событие не попадает
+		if blockReason != user.BlockReason {
+			user.BlockReason = blockReason
+			changed = true
+		}
+	}
+
+	if patch.SetEmail && !equalPtr(user.Email, patch.Email) {
+		if patch.Email != nil {
+			err = u.checkContactNotUsed(userID, model.FindSpec{Email: patch.Email}, model.ErrUserEmailAlreadyUsed)
+			if err != nil {
+				return err
+			}
+			updatedFields.Email = patch.Email
+		} else {
+			removedFields.Email = toPtr(true)
+		}
+		// новый контакт нужно подтвердить заново
+		if user.EmailVerified {
+			updatedFields.EmailVerified = toPtr(false)
+		}
+		user.Email = patch.Email
+		user.EmailVerified = false
+		changed = true
+	}
+
+	if patch.SetTelegram && !equalPtr(user.Telegram, patch.Telegram) {
+		if patch.Telegram != nil {
+			err = u.checkContactNotUsed(userID, model.FindSpec{Telegram: patch.Telegram}, model.ErrUserTelegramAlreadyUsed)
+			if err != nil {
+				return err
+			}
+			updatedFields.Telegram = patch.Telegram
+		} else {
+			removedFields.Telegram = toPtr(true)
+		}
+		if user.TelegramVerified {
+			updatedFields.TelegramVerified = toPtr(false)
+		}
+		user.Telegram = patch.Telegram
+		user.TelegramVerified = false
+		changed = true
+	}
 
-	if user.Status == status {
+	if !changed {
 		return nil
 	}
 
 	currentTime := time.Now()
-	user.Status = status
 	user.UpdatedAt = currentTime
 	err = u.userRepository.Store(*user)
 	if err != nil {
 		return err
 	}
 
-	// тута кричим, что юзер обновлен
-	return u.eventDispatcher.Dispatch(&model.UserUpdated{
+	// тута кричим, что юзер обновлен: одно событие на все изменение
+	event := &model.UserUpdated{
 		UserID:    userID,
 		UpdatedAt: currentTime,
-		UpdatedFields: &struct {
-			Status   *model.UserStatus
-			Email    *string
-			Telegram *string
-		}{Status: &status},
-	})
+	}
+	if updatedFields.Status != nil || updatedFields.Email != nil || updatedFields.Telegram != nil ||
+		updatedFields.EmailVerified != nil || updatedFields.TelegramVerified != nil {
+		event.UpdatedFields = updatedFields
+	}
+	if removedFields.Email != nil || removedFields.Telegram != nil {
+		event.RemovedFields = removedFields
+	}
+	if event.UpdatedFields == nil && event.RemovedFields == nil {
+		return nil
+	}
+	return u.eventDispatcher.Dispatch(event)
 }
 
-// nolint:dupl
-func (u userService) UpdateUserEmail(userID uuid.UUID, email *string) error {
+func (u userService) checkContactNotUsed(userID uuid.UUID, spec model.FindSpec, errAlreadyUsed error) error {
+	userWithContact, err := u.userRepository.Find(spec)
+	if err != nil && !errors.Is(err, model.ErrUserNotFound) {
+		return err
+	}
+	if userWithContact != nil && userWithContact.UserID != userID {
+		return errAlreadyUsed
+	}
+	return nil
+}
+
+func (u userService) DeleteUser(userID uuid.UUID, hard bool) error {
 	user, err := u.userRepository.Find(model.FindSpec{
 		UserID: &userID,
 	})
 	if err != nil {
 		return err
 	}
-	if reflect.DeepEqual(user.Email, email) {
-		return nil
-	}
 
-	if email != nil {
-		userWithEmail, err := u.userRepository.Find(model.FindSpec{
-			Email: email,
-		})
-		if err != nil && !errors.Is(err, model.ErrUserNotFound) {
-			return err
-		}
-		if userWithEmail != nil && userWithEmail.UserID != user.UserID {
-			return model.ErrUserEmailAlreadyUsed
-		}
+	if hard {
+		return u.hardDelete(userID)
 	}
 
 	currentTime := time.Now()
-	user.Email = email
+	user.Status = model.Deleted
 	user.UpdatedAt = currentTime
+	user.DeletedAt = &currentTime
 	err = u.userRepository.Store(*user)
 	if err != nil {
 		return err
 	}
 
-	if email == nil {
-		return u.eventDispatcher.Dispatch(&model.UserUpdated{
-			UserID:    userID,
-			UpdatedAt: currentTime,
-			RemovedFields: &struct {
-				Email    *bool
-				Telegram *bool
-			}{Email: toPtr(true)},
-		})
-	}
-
-	// тута кричим, что юзер обновлен
-	return u.eventDispatcher.Dispatch(&model.UserUpdated{
+	// тута кричим, что юзер удален
+	return u.eventDispatcher.Dispatch(&model.UserDeleted{
 		UserID:    userID,
-		UpdatedAt: currentTime,
-		UpdatedFields: &struct {
-			Status   *model.UserStatus
-			Email    *string
-			Telegram *string
-		}{Email: email},
+		Status:    model.Deleted,
+		DeletedAt: currentTime,
+		Hard:      hard,
 	})
 }
 
-// nolint:dupl
-func (u userService) UpdateUserTelegram(userID uuid.UUID, telegram *string) error {
+func (u userService) HardDeleteUser(userID uuid.UUID, deletedBefore time.Time) error {
 	user, err := u.userRepository.Find(model.FindSpec{
 		UserID: &userID,
 	})
 	if err != nil {
 		return err
 	}
-	if reflect.DeepEqual(user.Telegram, telegram) {
-		return nil
+	// пользователя восстановили, пока шел срок хранения
+	if user.Status != model.Deleted {
+		return model.ErrUserNotDeleted
+	}
+	// пользователя восстановили и удалили снова: срок хранения считается от нового удаления.
+	// deleted_at хранится с точностью до секунды, отсюда запас
+	if !deletedBefore.IsZero() && (user.DeletedAt == nil || user.DeletedAt.After(deletedBefore.Add(time.Second))) {
+		return model.ErrUserRetentionNotExpired
 	}
 
-	if telegram != nil {
-		userWithTelegram, err := u.userRepository.Find(model.FindSpec{
-			Telegram: telegram,
-		})
-		if err != nil && !errors.Is(err, model.ErrUserNotFound) {
+	return u.hardDelete(userID)
+}
+
+func (u userService) RestoreUser(userID uuid.UUID) error {
+	user, err := u.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		if !errors.Is(err, model.ErrUserNotFound) {
 			return err
 		}
-		if userWithTelegram != nil && userWithTelegram.UserID != user.UserID {
-			return model.ErrUserTelegramAlreadyUsed
+		hardDeleted, err2 := u.userRepository.IsHardDeleted(userID)
+		if err2 != nil {
+			return err2
+		}
+		if hardDeleted {
+			return model.ErrUserHardDeleted
 		}
+		return err
+	}
+	if user.Status != model.Deleted {
+		return model.ErrUserNotDeleted
+	}
+
+	// блокировка вручную или автоблокировкой переживает удаление, остальных статус определяют контакты
+	status := model.Blocked
+	switch {
+	case user.BlockReason == model.BlockReasonManual || user.BlockReason == model.BlockReasonAutoBlock:
+	case user.HasVerifiedContact():
+		status = model.Active
+		user.BlockReason = ""
+	default:
+		user.BlockReason = model.BlockReasonUnverifiedContacts
 	}
 
 	currentTime := time.Now()
-	user.Telegram = telegram
+	user.Status = status
 	user.UpdatedAt = currentTime
+	user.DeletedAt = nil
 	err = u.userRepository.Store(*user)
 	if err != nil {
 		return err
 	}
 
-	// тута кричим, что юзер обновлен
-	if telegram == nil {
-		return u.eventDispatcher.Dispatch(&model.UserUpdated{
-			UserID:    userID,
-			UpdatedAt: currentTime,
-			RemovedFields: &struct {
-				Email    *bool
-				Telegram *bool
-			}{Telegram: toPtr(true)},
-		})
-	}
+	// тута кричим, что юзер восстановлен
+	return u.eventDispatcher.Dispatch(&model.UserRestored{
+		UserID:     userID,
+		Status:     status,
+		RestoredAt: currentTime,
+	})
+}
 
-	// тута кричим, что юзер обновлен
-	return u.eventDispatcher.Dispatch(&model.UserUpdated{
-		UserID:    userID,
-		UpdatedAt: currentTime,
-		UpdatedFields: &struct {
-			Status   *model.UserStatus
-			Email    *string
-			Telegram *string
-		}{Telegram: telegram},
+func (u userService) AssignRole(userID uuid.UUID, role model.Role) error {
+	user, err := u.userRepository.Find(model.FindSpec{
+		UserID: &userID,
 	})
+	if err != nil {
+		return err
+	}
+	if user.Status == model.Deleted {
+		return model.ErrUserDeleted
+	}
+	if slices.Contains(user.Roles, role) {
+		return nil
+	}
+
+	return u.updateRoles(user, append(slices.Clone(user.Roles), role))
 }
 
-func (u userService) DeleteUser(userID uuid.UUID, hard bool) error {
+func (u userService) RevokeRole(userID uuid.UUID, role model.Role) error {
 	user, err := u.userRepository.Find(model.FindSpec{
 		UserID: &userID,
 	})
 	if err != nil {
 		return err
 	}
+	if !slices.Contains(user.Roles, role) {
+		return nil
+	}
 
-	if hard {
-		err = u.userRepository.HardDelete(userID)
-		if err != nil {
-			return err
+	return u.updateRoles(user, slices.DeleteFunc(slices.Clone(user.Roles), func(r model.Role) bool {
+		return r == role
+	}))
+}
+
+func (u userService) Authorize(userID uuid.UUID, permission model.Permission) error {
+	user, err := u.userRepository.Find(model.FindSpec{
+		UserID: &userID,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ErrPermissionDenied
 		}
+		return err
+	}
+	if user.Status != model.Active || !model.HasPermission(user.Roles, permission) {
+		return model.ErrPermissionDenied
 	}
+	return nil
+}
 
+func (u userService) updateRoles(user *model.User, roles []model.Role) error {
 	currentTime := time.Now()
-	user.Status = model.Deleted
+	user.Roles = roles
 	user.UpdatedAt = currentTime
-	user.DeletedAt = &currentTime
-	err = u.userRepository.Store(*user)
+	err := u.userRepository.Store(*user)
 	if err != nil {
 		return err
 	}
 
-	// тута кричим, что юзер удален
+	// в событии всегда полный список ролей, чтобы потребителям не нужно было применять изменения по одному
+	return u.eventDispatcher.Dispatch(&model.UserUpdated{
+		UserID:    user.UserID,
+		UpdatedAt: currentTime,
+		UpdatedFields: &struct {
+			Status           *model.UserStatus
+			Email            *string
+			Telegram         *string
+			EmailVerified    *bool
+			TelegramVerified *bool
+			Roles            *[]model.Role
+		}{Roles: &roles},
+	})
+}
+
+func (u userService) hardDelete(userID uuid.UUID) error {
+	err := u.userRepository.HardDelete(userID)
+	if err != nil {
+		return err
+	}
+
+	// тута кричим, что юзер удален окончательно
 	return u.eventDispatcher.Dispatch(&model.UserDeleted{
 		UserID:    userID,
 		Status:    model.Deleted,
-		DeletedAt: currentTime,
-		Hard:      hard,
+		DeletedAt: time.Now(),
+		Hard:      true,
 	})
 }
 
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func toPtr[T any](v T) *T {
 	return &v
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,11 @@ func (m *MockUserRepository) HardDelete(userID uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) IsHardDeleted(userID uuid.UUID) (bool, error) {
+	args := m.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
 type MockEventDispatcher struct {
 	mock.Mock
 }
@@ -118,6 +124,60 @@ func TestUserService_UpdateUserEmail(t *testing.T) {
 	})
 }
 
+func TestUserService_PatchUser(t *testing.T) {
+	repo := new(MockUserRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewUserService(repo, dispatcher)
+
+	userID := uuid.New()
+	email := "new@example.com"
+	oldEmail := "old@example.com"
+	telegram := "@old"
+
+	t.Run("single_event", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Blocked, Email: &oldEmail, EmailVerified: true, Telegram: &telegram, Version: 3}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+		repo.On("Find", model.FindSpec{Email: &email}).Return(nil, model.ErrUserNotFound).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Active && *u.Email == email && !u.EmailVerified && u.Telegram == nil
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserUpdated) bool {
+			return e.UpdatedFields != nil && *e.UpdatedFields.Status == model.Active &&
+				*e.UpdatedFields.Email == email && *e.UpdatedFields.EmailVerified == false &&
+				e.RemovedFields != nil && *e.RemovedFields.Telegram
+		})).Return(nil).Once()
+
+		status := model.Active
+		err := service.PatchUser(userID, model.UserPatch{
+			Status:      &status,
+			SetEmail:    true,
+			Email:       &email,
+			SetTelegram: true,
+		}, toPtr(int64(3)))
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("version_mismatch", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Version: 4}, nil).Once()
+
+		err := service.PatchUser(userID, model.UserPatch{SetEmail: true, Email: &email}, toPtr(int64(3)))
+		assert.ErrorIs(t, err, model.ErrUserVersionMismatch)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no_changes", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Active, Email: &email}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+
+		status := model.Active
+		err := service.PatchUser(userID, model.UserPatch{Status: &status, SetEmail: true, Email: toPtr(email)}, nil)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
 func TestUserService_DeleteUser(t *testing.T) {
 	repo := new(MockUserRepository)
 	dispatcher := new(MockEventDispatcher)
@@ -141,14 +201,309 @@ func TestUserService_DeleteUser(t *testing.T) {
 	t.Run("hard_delete", func(t *testing.T) {
 		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID}, nil).Once()
 		repo.On("HardDelete", userID).Return(nil).Once()
-		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
-			return u.Status == model.Deleted
-		})).Return(nil).Once()
 		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserDeleted) bool {
 			return e.UserID == userID && e.Hard
 		})).Return(nil).Once()
 
 		err := service.DeleteUser(userID, true)
 		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_HardDeleteUser(t *testing.T) {
+	repo := new(MockUserRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewUserService(repo, dispatcher)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Deleted}, nil).Once()
+		repo.On("HardDelete", userID).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserDeleted) bool {
+			return e.UserID == userID && e.Hard
+		})).Return(nil).Once()
+
+		err := service.HardDeleteUser(userID, time.Time{})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("restored", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+
+		err := service.HardDeleteUser(userID, time.Time{})
+		assert.ErrorIs(t, err, model.ErrUserNotDeleted)
+	})
+
+	t.Run("retention_expired", func(t *testing.T) {
+		deletedAt := time.Now().Add(-time.Hour)
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Deleted, DeletedAt: &deletedAt}, nil).Once()
+		repo.On("HardDelete", userID).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.Anything).Return(nil).Once()
+
+		err := service.HardDeleteUser(userID, deletedAt)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("deleted_again", func(t *testing.T) {
+		// восстановили и удалили снова, пока старый workflow ждал: удалять должен только новый
+		deletedAt := time.Now()
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Deleted, DeletedAt: &deletedAt}, nil).Once()
+
+		err := service.HardDeleteUser(userID, deletedAt.Add(-time.Hour))
+		assert.ErrorIs(t, err, model.ErrUserRetentionNotExpired)
+	})
+}
+
+func TestUserService_RestoreUser(t *testing.T) {
+	repo := new(MockUserRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewUserService(repo, dispatcher)
+
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("success", func(t *testing.T) {
+		deletedAt := time.Now()
+		existing := &model.User{UserID: userID, Status: model.Deleted, Email: &email, EmailVerified: true, DeletedAt: &deletedAt}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Active && u.DeletedAt == nil
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserRestored) bool {
+			return e.UserID == userID && e.Status == model.Active
+		})).Return(nil).Once()
+
+		err := service.RestoreUser(userID)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("auto_blocked_stays_blocked", func(t *testing.T) {
+		deletedAt := time.Now()
+		existing := &model.User{
+			UserID:        userID,
+			Status:        model.Deleted,
+			BlockReason:   model.BlockReasonAutoBlock,
+			Email:         &email,
+			EmailVerified: true,
+			DeletedAt:     &deletedAt,
+		}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Blocked && u.BlockReason == model.BlockReasonAutoBlock
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserRestored) bool {
+			return e.UserID == userID && e.Status == model.Blocked
+		})).Return(nil).Once()
+
+		err := service.RestoreUser(userID)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not_deleted", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+
+		err := service.RestoreUser(userID)
+		assert.ErrorIs(t, err, model.ErrUserNotDeleted)
+	})
+
+	t.Run("hard_deleted", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(nil, model.ErrUserNotFound).Once()
+		repo.On("IsHardDeleted", userID).Return(true, nil).Once()
+
+		err := service.RestoreUser(userID)
+		assert.ErrorIs(t, err, model.ErrUserHardDeleted)
+	})
+}
+
+func TestUserService_AssignRole(t *testing.T) {
+	repo := new(MockUserRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewUserService(repo, dispatcher)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Active, Roles: []model.Role{model.RoleProductManager}}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return assert.ObjectsAreEqual([]model.Role{model.RoleProductManager, model.RoleBalanceManager}, u.Roles)
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserUpdated) bool {
+			return e.UserID == userID && e.UpdatedFields != nil && e.UpdatedFields.Roles != nil && len(*e.UpdatedFields.Roles) == 2
+		})).Return(nil).Once()
+
+		err := service.AssignRole(userID, model.RoleBalanceManager)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("already_assigned", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Active, Roles: []model.Role{model.RoleAdmin}}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+
+		err := service.AssignRole(userID, model.RoleAdmin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("deleted_user", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Deleted}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+
+		err := service.AssignRole(userID, model.RoleAdmin)
+		assert.ErrorIs(t, err, model.ErrUserDeleted)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestUserService_Authorize(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name string
+		user *model.User
+		err  error
+	}{
+		{
+			name: "granted",
+			user: &model.User{UserID: userID, Status: model.Active, Roles: []model.Role{model.RoleAdmin}},
+		},
+		{
+			name: "no_permission",
+			user: &model.User{UserID: userID, Status: model.Active, Roles: []model.Role{model.RoleProductManager}},
+			err:  model.ErrPermissionDenied,
+		},
+		{
+			name: "blocked",
+			user: &model.User{UserID: userID, Status: model.Blocked, Roles: []model.Role{model.RoleAdmin}},
+			err:  model.ErrPermissionDenied,
+		},
+		{
+			name: "deleted",
+			user: &model.User{UserID: userID, Status: model.Deleted, Roles: []model.Role{model.RoleAdmin}},
+			err:  model.ErrPermissionDenied,
+		},
+		{
+			name: "not_found",
+			err:  model.ErrPermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUserRepository)
+			if tt.user != nil {
+				repo.On("Find", model.FindSpec{UserID: &userID}).Return(tt.user, nil)
+			} else {
+				repo.On("Find", model.FindSpec{UserID: &userID}).Return(nil, model.ErrUserNotFound)
+			}
+
+			err := NewUserService(repo, new(MockEventDispatcher)).Authorize(userID, model.PermissionManageRoles)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserService_RevokeRole(t *testing.T) {
+	repo := new(MockUserRepository)
+	dispatcher := new(MockEventDispatcher)
+	service := NewUserService(repo, dispatcher)
+
+	userID := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		existing := &model.User{UserID: userID, Status: model.Active, Roles: []model.Role{model.RoleAdmin, model.RoleProductManager}}
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(existing, nil).Once()
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return assert.ObjectsAreEqual([]model.Role{model.RoleProductManager}, u.Roles)
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.MatchedBy(func(e *model.UserUpdated) bool {
+			return e.UpdatedFields != nil && e.UpdatedFields.Roles != nil && len(*e.UpdatedFields.Roles) == 1
+		})).Return(nil).Once()
+
+		err := service.RevokeRole(userID, model.RoleAdmin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("not_assigned", func(t *testing.T) {
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Active}, nil).Once()
+
+		err := service.RevokeRole(userID, model.RoleAdmin)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestHasPermission(t *testing.T) {
+	assert.True(t, model.HasPermission([]model.Role{model.RoleAdmin}, model.PermissionManageBalances))
+	assert.True(t, model.HasPermission([]model.Role{model.RoleProductManager}, model.PermissionManageProducts))
+	assert.False(t, model.HasPermission([]model.Role{model.RoleProductManager}, model.PermissionManageBalances))
+	assert.True(t, model.HasPermission([]model.Role{model.RoleAdmin}, model.PermissionPurgeProducts))
+	assert.False(t, model.HasPermission([]model.Role{model.RoleProductManager}, model.PermissionPurgeProducts))
+	assert.True(t, model.HasPermission([]model.Role{model.RoleAdmin}, model.PermissionManageExchangeRates))
+	assert.False(t, model.HasPermission([]model.Role{model.RoleBalanceManager}, model.PermissionManageExchangeRates))
+	assert.True(t, model.HasPermission([]model.Role{model.RoleAdmin}, model.PermissionManagePromotions))
+	assert.False(t, model.HasPermission([]model.Role{model.RoleProductManager}, model.PermissionManagePromotions))
+	assert.False(t, model.HasPermission(nil, model.PermissionManageProducts))
+}
+
+func TestUserService_BlockReason(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("block_user", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		dispatcher := new(MockEventDispatcher)
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Active, Email: &email, EmailVerified: true}, nil)
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Blocked && u.BlockReason == model.BlockReasonAutoBlock
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.AnythingOfType("*model.UserUpdated")).Return(nil).Once()
+
+		err := NewUserService(repo, dispatcher).BlockUser(userID, model.BlockReasonAutoBlock)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertExpectations(t)
+	})
+
+	t.Run("manual_block_of_unverified_user", func(t *testing.T) {
+		// статус не меняется, поэтому события нет, но подтверждение контакта блокировку больше не снимет
+		repo := new(MockUserRepository)
+		dispatcher := new(MockEventDispatcher)
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Blocked, BlockReason: model.BlockReasonUnverifiedContacts}, nil)
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Blocked && u.BlockReason == model.BlockReasonManual
+		})).Return(nil).Once()
+
+		err := NewUserService(repo, dispatcher).UpdateUserStatus(userID, model.Blocked)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		dispatcher.AssertNotCalled(t, "Dispatch", mock.Anything)
+	})
+
+	t.Run("activation_clears_reason", func(t *testing.T) {
+		repo := new(MockUserRepository)
+		dispatcher := new(MockEventDispatcher)
+		repo.On("Find", model.FindSpec{UserID: &userID}).Return(&model.User{UserID: userID, Status: model.Blocked, BlockReason: model.BlockReasonManual}, nil)
+		repo.On("Store", mock.MatchedBy(func(u model.User) bool {
+			return u.Status == model.Active && u.BlockReason == ""
+		})).Return(nil).Once()
+		dispatcher.On("Dispatch", mock.AnythingOfType("*model.UserUpdated")).Return(nil).Once()
+
+		err := NewUserService(repo, dispatcher).UpdateUserStatus(userID, model.Active)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,103 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// SystemActor - изменения, сделанные не по запросу клиента (обработчики событий, workflow)
+const SystemActor = "system"
+
+type Info struct {
+	Actor         string
+	Method        string
+	CorrelationID string
+}
+
+type infoKey struct{}
+
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, infoKey{}, info)
+}
+
+func InfoFromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(infoKey{}).(Info)
+	if info.Actor == "" {
+		info.Actor = SystemActor
+	}
+	return info
+}
+
+// Record - запись журнала аудита. Записи образуют цепочку: Hash считается от всех полей и PrevHash,
+// поэтому изменение или удаление записи ломает проверку всех последующих
+type Record struct {
+	Actor         string
+	Method        string
+	EntityType    string
+	EntityID      string
+	Before        *string
+	After         *string
+	CorrelationID string
+	CreatedAt     time.Time
+	PrevHash      string
+}
+
+func (r Record) Hash() string {
+	data, _ := json.Marshal(struct {
+		Actor         string  `json:"actor"`
+		Method        string  `json:"method"`
+		EntityType    string  `json:"entity_type"`
+		EntityID      string  `json:"entity_id"`
+		Before        *string `json:"before"`
+		After         *string `json:"after"`
+		CorrelationID string  `json:"correlation_id"`
+		CreatedAt     string  `json:"created_at"`
+		PrevHash      string  `json:"prev_hash"`
+	}{
+		Actor:         r.Actor,
+		Method:        r.Method,
+		EntityType:    r.EntityType,
+		EntityID:      r.EntityID,
+		Before:        r.Before,
+		After:         r.After,
+		CorrelationID: r.CorrelationID,
+		CreatedAt:     r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		PrevHash:      r.PrevHash,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify проверяет запись на месте в цепочке: хеш должен совпасть с пересчитанным по полям,
+// а PrevHash - с хешем записи, идущей перед ней по record_id. Удаление или перестановка строк
+// рвет вторую проверку, изменение полей - первую
+func Verify(record Record, hash, precedingHash string) bool {
+	return record.Hash() == hash && record.PrevHash == precedingHash
+}
+
+// Redact заменяет персональные данные в записи аудита HMAC на секретном ключе субъекта данных.
+// Журнал нельзя чистить при удалении пользователя, не сломав цепочку, поэтому сами значения в него не попадают,
+// а по хешу по-прежнему видно, менялось ли поле. Ключ хранится вне журнала и удаляется вместе с данными субъекта:
+// без него хеш не проверить перебором значений
+func Redact(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSubjectKey создает секретный ключ субъекта данных для Redact
+func NewSubjectKey() ([]byte, error) {
+	key := make([]byte, sha256.Size)
+	_, err := rand.Read(key)
+	return key, err
+}
+
+// Now - время записи с точностью DATETIME(6), иначе хеш не сойдется после чтения из базы
+func Now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
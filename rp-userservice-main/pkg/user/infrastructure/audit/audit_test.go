@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord_Hash(t *testing.T) {
+	before := `{"status":1}`
+	after := `{"status":2}`
+	record := Record{
+		Actor:         "admin",
+		Method:        "/api/Update",
+		EntityType:    "entity",
+		EntityID:      "42",
+		Before:        &before,
+		After:         &after,
+		CorrelationID: "corr",
+		CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 6000, time.UTC),
+		PrevHash:      "prev",
+	}
+	hash := record.Hash()
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, record.Hash())
+
+	// часовой пояс не влияет на хеш, момент времени - тот же
+	moved := record
+	moved.CreatedAt = record.CreatedAt.In(time.FixedZone("MSK", 3*60*60))
+	assert.Equal(t, hash, moved.Hash())
+
+	changes := map[string]func(r *Record){
+		"actor":          func(r *Record) { r.Actor = "user" },
+		"method":         func(r *Record) { r.Method = "/api/Delete" },
+		"entity_type":    func(r *Record) { r.EntityType = "other" },
+		"entity_id":      func(r *Record) { r.EntityID = "43" },
+		"before":         func(r *Record) { r.Before = nil },
+		"after":          func(r *Record) { v := `{"status":3}`; r.After = &v },
+		"correlation_id": func(r *Record) { r.CorrelationID = "other" },
+		"created_at":     func(r *Record) { r.CreatedAt = r.CreatedAt.Add(time.Microsecond) },
+		"prev_hash":      func(r *Record) { r.PrevHash = "other" },
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			changed := record
+			change(&changed)
+			assert.NotEqual(t, hash, changed.Hash())
+		})
+	}
+}
+
+type storedRecord struct {
+	record Record
+	hash   string
+}
+
+// buildChain повторяет то, что делает appendAuditRecord: каждая запись ссылается на хеш предыдущей
+func buildChain(n int) []storedRecord {
+	chain := make([]storedRecord, n)
+	prevHash := ""
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range chain {
+		after := string(rune('a' + i))
+		record := Record{
+			Actor:      SystemActor,
+			Method:     "test",
+			EntityType: "entity",
+			EntityID:   "1",
+			After:      &after,
+			CreatedAt:  createdAt.Add(time.Duration(i) * time.Second),
+			PrevHash:   prevHash,
+		}
+		prevHash = record.Hash()
+		chain[i] = storedRecord{record: record, hash: prevHash}
+	}
+	return chain
+}
+
+// verifyChain проверяет строки в том порядке, в каком их вернула бы выборка по record_id
+func verifyChain(rows []storedRecord) []bool {
+	result := make([]bool, len(rows))
+	precedingHash := ""
+	for i, row := range rows {
+		result[i] = Verify(row.record, row.hash, precedingHash)
+		precedingHash = row.hash
+	}
+	return result
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("intact", func(t *testing.T) {
+		assert.Equal(t, []bool{true, true, true, true}, verifyChain(buildChain(4)))
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		chain := buildChain(4)
+		rows := append(chain[:1:1], chain[2:]...)
+		assert.Equal(t, []bool{true, false, true}, verifyChain(rows))
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		rows := buildChain(4)
+		tampered := "tampered"
+		rows[1].record.After = &tampered
+		assert.Equal(t, []bool{true, false, true, true}, verifyChain(rows))
+	})
+
+	t.Run("modified_with_rehash", func(t *testing.T) {
+		// пересчет хеша измененной записи не спасает: следующая запись ссылается на старый
+		rows := buildChain(4)
+		tampered := "tampered"
+		rows[1].record.After = &tampered
+		rows[1].hash = rows[1].record.Hash()
+		assert.Equal(t, []bool{true, true, false, true}, verifyChain(rows))
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		rows := buildChain(4)
+		rows[1], rows[2] = rows[2], rows[1]
+		assert.Equal(t, []bool{true, false, false, false}, verifyChain(rows))
+	})
+}
+
+func TestRedact(t *testing.T) {
+	key, err := NewSubjectKey()
+	require.NoError(t, err)
+	otherKey, err := NewSubjectKey()
+	require.NoError(t, err)
+
+	redacted := Redact(key, "user@example.com")
+	assert.NotContains(t, redacted, "user@example.com")
+	assert.Equal(t, redacted, Redact(key, "user@example.com"))
+	assert.NotEqual(t, redacted, Redact(key, "other@example.com"))
+	// одинаковые значения разных субъектов не сопоставить по хешу
+	assert.NotEqual(t, redacted, Redact(otherKey, "user@example.com"))
+}
@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"userservice/pkg/user/domain/model"
+)
+
+func NewBcryptPasswordHasher(cost int) model.PasswordHasher {
+	return &bcryptPasswordHasher{cost: cost}
+}
+
+type bcryptPasswordHasher struct {
+	cost int
+}
+
+func (h *bcryptPasswordHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	return string(hash), errors.WithStack(err)
+}
+
+func (h *bcryptPasswordHasher) Verify(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
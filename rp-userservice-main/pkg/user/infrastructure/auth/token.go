@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/application/service"
+)
+
+// NewTokenIssuer выпускает HS256 access token, gateway проверяет его тем же секретом и issuer
+func NewTokenIssuer(secret []byte, issuer string, ttl time.Duration) service.AccessTokenIssuer {
+	return &tokenIssuer{
+		secret: secret,
+		issuer: issuer,
+		ttl:    ttl,
+	}
+}
+
+type tokenIssuer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+func (i *tokenIssuer) Issue(userID, sessionID uuid.UUID) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    i.issuer,
+		Subject:   userID.String(),
+		ID:        sessionID.String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	signed, err := token.SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, errors.WithStack(err)
+	}
+	return signed, expiresAt, nil
+}
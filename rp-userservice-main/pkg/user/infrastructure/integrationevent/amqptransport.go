@@ -14,6 +14,7 @@ import (
 	"userservice/pkg/user/domain/model"
 	"userservice/pkg/user/infrastructure/metrics"
 	"userservice/pkg/user/infrastructure/temporal"
+	"userservice/pkg/user/infrastructure/temporal/workflows"
 )
 
 var (
@@ -21,11 +22,19 @@ var (
 	errProcessed         = errors.New("processed")
 )
 
-func NewAMQPTransport(logger logging.Logger, workflowService temporal.WorkflowService, userService service.UserService) AMQPTransport {
+func NewAMQPTransport(
+	logger logging.Logger,
+	workflowService temporal.WorkflowService,
+	userService service.UserService,
+	deletionRetentionPeriod time.Duration,
+	autoBlockPolicy workflows.AutoBlockPolicy,
+) AMQPTransport {
 	return &amqpTransport{
-		logger:          logger,
-		workflowService: workflowService,
-		userService:     userService,
+		logger:                  logger,
+		workflowService:         workflowService,
+		userService:             userService,
+		deletionRetentionPeriod: deletionRetentionPeriod,
+		autoBlockPolicy:         autoBlockPolicy,
 	}
 }
 
@@ -34,9 +43,11 @@ type AMQPTransport interface {
 }
 
 type amqpTransport struct {
-	logger          logging.Logger
-	workflowService temporal.WorkflowService
-	userService     service.UserService
+	logger                  logging.Logger
+	workflowService         temporal.WorkflowService
+	userService             service.UserService
+	deletionRetentionPeriod time.Duration
+	autoBlockPolicy         workflows.AutoBlockPolicy
 }
 
 func (t *amqpTransport) Handler() amqp.Handler {
@@ -58,13 +69,25 @@ func (t *amqpTransport) handle(ctx context.Context, delivery amqp.Delivery) erro
 		}
 		if e.UpdatedFields != nil {
 			de.UpdatedFields = &struct {
-				Status   *model.UserStatus
-				Email    *string
-				Telegram *string
+				Status           *model.UserStatus
+				Email            *string
+				Telegram         *string
+				EmailVerified    *bool
+				TelegramVerified *bool
+				Roles            *[]model.Role
 			}{
-				Status:   (*model.UserStatus)(e.UpdatedFields.Status),
-				Email:    e.UpdatedFields.Email,
-				Telegram: e.UpdatedFields.Telegram,
+				Status:           (*model.UserStatus)(e.UpdatedFields.Status),
+				Email:            e.UpdatedFields.Email,
+				Telegram:         e.UpdatedFields.Telegram,
+				EmailVerified:    e.UpdatedFields.EmailVerified,
+				TelegramVerified: e.UpdatedFields.TelegramVerified,
+			}
+			if e.UpdatedFields.Roles != nil {
+				roles := make([]model.Role, len(*e.UpdatedFields.Roles))
+				for i, role := range *e.UpdatedFields.Roles {
+					roles[i] = model.Role(role)
+				}
+				de.UpdatedFields.Roles = &roles
 			}
 		}
 		if e.RemovedFields != nil {
@@ -76,6 +99,7 @@ func (t *amqpTransport) handle(ctx context.Context, delivery amqp.Delivery) erro
 				Telegram: e.RemovedFields.Telegram,
 			}
 		}
+		// подтверждение нового контакта запускает сам UserUpdatedWorkflow
 		err = t.workflowService.RunUserUpdatedWorkflow(ctx, delivery.CorrelationID, de)
 		if err != nil {
 			return nil
@@ -91,10 +115,50 @@ func (t *amqpTransport) handle(ctx context.Context, delivery amqp.Delivery) erro
 		}
 		if !e.Hard {
 			t.logger.Info("User soft deleted, starting cleanup workflow", "user_id", e.UserID)
-			err = t.workflowService.RunUserDeletedWorkflow(ctx, delivery.CorrelationID+"_del", e.UserID)
+			err = t.workflowService.RunUserDeletedWorkflow(ctx, e.UserID, t.deletionRetentionPeriod)
 			if err != nil {
 				return nil
 			}
+			return errProcessed
+		}
+		userID, err := uuid.Parse(e.UserID)
+		if err != nil {
+			t.logger.Error(err, "invalid user_id in UserDeleted")
+			return nil
+		}
+		t.logger.Info("User hard deleted, starting erasure workflow", "user_id", e.UserID)
+		err = t.workflowService.RunUserErasureWorkflow(ctx, userID)
+		if err != nil {
+			return nil
+		}
+		return errProcessed
+
+	case orderCancelledEventType:
+		if t.autoBlockPolicy.Threshold <= 0 {
+			return errProcessed
+		}
+		var e OrderCancelled
+		err := json.Unmarshal(delivery.Body, &e)
+		if err != nil {
+			t.logger.Error(err, "failed to unmarshal OrderCancelled")
+			return nil
+		}
+		// события, опубликованные до появления user_id, пропускаем
+		if e.UserID == "" {
+			return errProcessed
+		}
+		userID, err := uuid.Parse(e.UserID)
+		if err != nil {
+			t.logger.Error(err, "invalid user_id in OrderCancelled")
+			return nil
+		}
+		err = t.workflowService.SignalUserFailure(ctx, userID, workflows.UserFailure{
+			Source:     orderCancelledEventType,
+			Reason:     e.Reason,
+			OccurredAt: time.Unix(e.CancelledAt, 0),
+		}, t.autoBlockPolicy)
+		if err != nil {
+			return nil
 		}
 		return errProcessed
 
@@ -151,3 +215,13 @@ func (t *amqpTransport) withLog(handler amqp.Handler) amqp.Handler {
 		return nil
 	}
 }
+
+// orderCancelledEventType - событие orderservice, неудачная оплата тоже приходит отменой заказа
+const orderCancelledEventType = "order_cancelled"
+
+type OrderCancelled struct {
+	OrderID     string `json:"order_id"`
+	UserID      string `json:"user_id"`
+	Reason      string `json:"reason"`
+	CancelledAt int64  `json:"cancelled_at"`
+}
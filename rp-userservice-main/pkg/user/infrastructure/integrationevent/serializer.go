@@ -19,12 +19,15 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 	switch e := event.(type) {
 	case *model.UserCreated:
 		b, err := json.Marshal(UserCreated{
-			UserID:    e.UserID.String(),
-			Status:    int(e.Status),
-			Login:     e.Login,
-			Email:     e.Email,
-			Telegram:  e.Telegram,
-			CreatedAt: e.CreatedAt.Unix(),
+			UserID:   e.UserID.String(),
+			Status:   int(e.Status),
+			Login:    e.Login,
+			Email:    e.Email,
+			Telegram: e.Telegram,
+			// новый пользователь создается без ролей, пустые списки нужны, чтобы потребители сразу завели кэш прав
+			Roles:       []string{},
+			Permissions: []string{},
+			CreatedAt:   e.CreatedAt.Unix(),
 		})
 		return string(b), errors.WithStack(err)
 	case *model.UserUpdated:
@@ -34,13 +37,24 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 		}
 		if e.UpdatedFields != nil {
 			ie.UpdatedFields = &struct {
-				Status   *int    `json:"status,omitempty"`
-				Email    *string `json:"email,omitempty"`
-				Telegram *string `json:"telegram,omitempty"`
+				Status           *int      `json:"status,omitempty"`
+				Email            *string   `json:"email,omitempty"`
+				Telegram         *string   `json:"telegram,omitempty"`
+				EmailVerified    *bool     `json:"email_verified,omitempty"`
+				TelegramVerified *bool     `json:"telegram_verified,omitempty"`
+				Roles            *[]string `json:"roles,omitempty"`
+				Permissions      *[]string `json:"permissions,omitempty"`
 			}{
-				Status:   (*int)(e.UpdatedFields.Status),
-				Email:    e.UpdatedFields.Email,
-				Telegram: e.UpdatedFields.Telegram,
+				Status:           (*int)(e.UpdatedFields.Status),
+				Email:            e.UpdatedFields.Email,
+				Telegram:         e.UpdatedFields.Telegram,
+				EmailVerified:    e.UpdatedFields.EmailVerified,
+				TelegramVerified: e.UpdatedFields.TelegramVerified,
+			}
+			if e.UpdatedFields.Roles != nil {
+				roles, permissions := serializeRoles(*e.UpdatedFields.Roles)
+				ie.UpdatedFields.Roles = &roles
+				ie.UpdatedFields.Permissions = &permissions
 			}
 		}
 		if e.RemovedFields != nil {
@@ -62,26 +76,62 @@ func (s eventSerializer) Serialize(event outbox.Event) (string, error) {
 			Hard:      e.Hard,
 		})
 		return string(b), errors.WithStack(err)
+	case *model.UserRestored:
+		b, err := json.Marshal(UserRestored{
+			UserID:     e.UserID.String(),
+			Status:     int(e.Status),
+			RestoredAt: e.RestoredAt.Unix(),
+		})
+		return string(b), errors.WithStack(err)
+	case *model.UserLoginChanged:
+		b, err := json.Marshal(UserLoginChanged{
+			UserID:    e.UserID.String(),
+			OldLogin:  e.OldLogin,
+			Login:     e.Login,
+			ChangedAt: e.ChangedAt.Unix(),
+		})
+		return string(b), errors.WithStack(err)
 	default:
 		return "", errors.Errorf("unknown event %q", event.Type())
 	}
 }
 
+// serializeRoles вместе с ролями отдает раскрытые из них права, чтобы потребители проверяли доступ
+// по своему кэшу и не знали, какие права дает каждая роль
+func serializeRoles(roles []model.Role) ([]string, []string) {
+	serializedRoles := make([]string, len(roles))
+	for i, role := range roles {
+		serializedRoles[i] = string(role)
+	}
+	permissions := model.PermissionsOf(roles)
+	serializedPermissions := make([]string, len(permissions))
+	for i, permission := range permissions {
+		serializedPermissions[i] = string(permission)
+	}
+	return serializedRoles, serializedPermissions
+}
+
 type UserCreated struct {
-	UserID    string  `json:"user_id"`
-	Status    int     `json:"status"`
-	Login     string  `json:"login"`
-	Email     *string `json:"email,omitempty"`
-	Telegram  *string `json:"telegram,omitempty"`
-	CreatedAt int64   `json:"created_at"`
+	UserID      string   `json:"user_id"`
+	Status      int      `json:"status"`
+	Login       string   `json:"login"`
+	Email       *string  `json:"email,omitempty"`
+	Telegram    *string  `json:"telegram,omitempty"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   int64    `json:"created_at"`
 }
 
 type UserUpdated struct {
 	UserID        string `json:"user_id"`
 	UpdatedFields *struct {
-		Status   *int    `json:"status,omitempty"`
-		Email    *string `json:"email,omitempty"`
-		Telegram *string `json:"telegram,omitempty"`
+		Status           *int      `json:"status,omitempty"`
+		Email            *string   `json:"email,omitempty"`
+		Telegram         *string   `json:"telegram,omitempty"`
+		EmailVerified    *bool     `json:"email_verified,omitempty"`
+		TelegramVerified *bool     `json:"telegram_verified,omitempty"`
+		Roles            *[]string `json:"roles,omitempty"`
+		Permissions      *[]string `json:"permissions,omitempty"`
 	} `json:"updated_fields,omitempty"`
 	RemovedFields *struct {
 		Email    *bool `json:"email,omitempty"`
@@ -96,3 +146,16 @@ type UserDeleted struct {
 	DeletedAt int64  `json:"deleted_at"`
 	Hard      bool   `json:"hard"`
 }
+
+type UserRestored struct {
+	UserID     string `json:"user_id"`
+	Status     int    `json:"status"`
+	RestoredAt int64  `json:"restored_at"`
+}
+
+type UserLoginChanged struct {
+	UserID    string `json:"user_id"`
+	OldLogin  string `json:"old_login"`
+	Login     string `json:"login"`
+	ChangedAt int64  `json:"changed_at"`
+}
@@ -14,7 +14,9 @@ const (
 	ExchangeKind     = "topic"
 	QueueName        = "user_domain_event"
 	RoutingKeyPrefix = "user."
-	ContentType      = "application/json"
+	// OrderCancelledRoutingKey - отмены заказов считаются сбоями для автоблокировки пользователя
+	OrderCancelledRoutingKey = "order.order_cancelled"
+	ContentType              = "application/json"
 )
 
 func NewTransport(logger logging.Logger, producer amqp.Producer) outbox.Transport {
@@ -44,4 +44,16 @@ func NewDatabaseMigrator(
 
 var builderFunctions = []MigrationBuilderFunc{
 	NewVersion1722266003,
+	NewVersion1792400001,
+	NewVersion1792400002,
+	NewVersion1792400003,
+	NewVersion1792400004,
+	NewVersion1792400005,
+	NewVersion1792400006,
+	NewVersion1792400007,
+	NewVersion1792400008,
+	NewVersion1792400009,
+	NewVersion1792400010,
+	NewVersion1792400011,
+	NewVersion1792400012,
 }
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400003(client mysql.ClientContext) migrator.Migration {
+	return &version1792400003{
+		client: client,
+	}
+}
+
+type version1792400003 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400003) Version() int64 {
+	return 1792400003
+}
+
+func (v version1792400003) Description() string {
+	return "Add contact verification flags to 'user' and create 'contact_verification' table"
+}
+
+func (v version1792400003) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE user
+		    ADD COLUMN email_verified    BOOLEAN NOT NULL DEFAULT FALSE AFTER telegram,
+		    ADD COLUMN telegram_verified BOOLEAN NOT NULL DEFAULT FALSE AFTER email_verified
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// контакты, заведенные до появления подтверждения, считаем подтвержденными, чтобы не заблокировать активных пользователей
+	_, err = v.client.ExecContext(ctx, `
+		UPDATE user SET email_verified = email IS NOT NULL, telegram_verified = telegram IS NOT NULL
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE contact_verification
+		(
+		    user_id      VARCHAR(64)  NOT NULL,
+		    contact_type INT          NOT NULL,
+		    value        VARCHAR(255) NOT NULL,
+		    code_hash    CHAR(64)     NOT NULL,
+		    attempts     INT          NOT NULL DEFAULT 0,
+		    expires_at   DATETIME(6)  NOT NULL,
+		    created_at   DATETIME(6)  NOT NULL,
+		    PRIMARY KEY (user_id, contact_type)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400005(client mysql.ClientContext) migrator.Migration {
+	return &version1792400005{
+		client: client,
+	}
+}
+
+type version1792400005 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400005) Version() int64 {
+	return 1792400005
+}
+
+func (v version1792400005) Description() string {
+	return "Create 'user_erasure_confirmation' table"
+}
+
+func (v version1792400005) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE user_erasure_confirmation
+		(
+		    user_id   VARCHAR(64) NOT NULL,
+		    service   VARCHAR(64) NOT NULL,
+		    erased_at DATETIME    NOT NULL,
+		    PRIMARY KEY (user_id, service)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400006(client mysql.ClientContext) migrator.Migration {
+	return &version1792400006{
+		client: client,
+	}
+}
+
+type version1792400006 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400006) Version() int64 {
+	return 1792400006
+}
+
+func (v version1792400006) Description() string {
+	return "Add 'user' indexes for listing"
+}
+
+func (v version1792400006) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE user
+		    ADD INDEX user_created_at_idx (created_at, user_id),
+		    ADD INDEX user_login_idx (login),
+		    ADD INDEX user_email_idx (email)
+	`)
+	return errors.WithStack(err)
+}
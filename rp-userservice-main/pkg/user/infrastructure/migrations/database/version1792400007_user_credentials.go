@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400007(client mysql.ClientContext) migrator.Migration {
+	return &version1792400007{
+		client: client,
+	}
+}
+
+type version1792400007 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400007) Version() int64 {
+	return 1792400007
+}
+
+func (v version1792400007) Description() string {
+	return "Create 'user_credentials' and 'user_session' tables"
+}
+
+func (v version1792400007) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE user_credentials
+		(
+		    user_id         VARCHAR(64)  NOT NULL,
+		    password_hash   VARCHAR(255) NOT NULL,
+		    failed_attempts INT          NOT NULL DEFAULT 0,
+		    locked_until    DATETIME,
+		    updated_at      DATETIME     NOT NULL,
+		    PRIMARY KEY (user_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = v.client.ExecContext(ctx, `
+		CREATE TABLE user_session
+		(
+		    session_id         VARCHAR(64) NOT NULL,
+		    user_id            VARCHAR(64) NOT NULL,
+		    refresh_token_hash VARCHAR(64) NOT NULL,
+		    expires_at         DATETIME    NOT NULL,
+		    created_at         DATETIME    NOT NULL,
+		    revoked_at         DATETIME,
+		    PRIMARY KEY (session_id),
+		    UNIQUE INDEX user_session_refresh_token_hash_uidx (refresh_token_hash),
+		    INDEX user_session_user_id_idx (user_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
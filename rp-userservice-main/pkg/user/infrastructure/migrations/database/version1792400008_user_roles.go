@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400008(client mysql.ClientContext) migrator.Migration {
+	return &version1792400008{
+		client: client,
+	}
+}
+
+type version1792400008 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400008) Version() int64 {
+	return 1792400008
+}
+
+func (v version1792400008) Description() string {
+	return "Add 'user' roles"
+}
+
+func (v version1792400008) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE user ADD COLUMN roles VARCHAR(255) NOT NULL DEFAULT '' AFTER telegram_verified
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400009(client mysql.ClientContext) migrator.Migration {
+	return &version1792400009{
+		client: client,
+	}
+}
+
+type version1792400009 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400009) Version() int64 {
+	return 1792400009
+}
+
+func (v version1792400009) Description() string {
+	return "Add 'user' version"
+}
+
+func (v version1792400009) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE user ADD COLUMN version BIGINT NOT NULL DEFAULT 1 AFTER roles
+	`)
+	return errors.WithStack(err)
+}
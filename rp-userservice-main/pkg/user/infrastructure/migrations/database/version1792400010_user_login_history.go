@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400010(client mysql.ClientContext) migrator.Migration {
+	return &version1792400010{
+		client: client,
+	}
+}
+
+type version1792400010 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400010) Version() int64 {
+	return 1792400010
+}
+
+func (v version1792400010) Description() string {
+	return "Create 'user_login_history' table"
+}
+
+func (v version1792400010) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		CREATE TABLE user_login_history
+		(
+		    id             BIGINT      NOT NULL AUTO_INCREMENT,
+		    user_id        VARCHAR(64) NOT NULL,
+		    login          VARCHAR(32) NOT NULL,
+		    changed_at     DATETIME    NOT NULL,
+		    reserved_until DATETIME    NOT NULL,
+		    PRIMARY KEY (id),
+		    INDEX user_login_history_login_idx (login, reserved_until),
+		    INDEX user_login_history_user_id_idx (user_id)
+		)
+		    ENGINE = InnoDB
+		    CHARACTER SET = utf8mb4
+		    COLLATE utf8mb4_unicode_ci
+	`)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/migrator"
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/pkg/errors"
+)
+
+func NewVersion1792400011(client mysql.ClientContext) migrator.Migration {
+	return &version1792400011{
+		client: client,
+	}
+}
+
+type version1792400011 struct {
+	client mysql.ClientContext
+}
+
+func (v version1792400011) Version() int64 {
+	return 1792400011
+}
+
+func (v version1792400011) Description() string {
+	return "Add 'user' block_reason"
+}
+
+func (v version1792400011) Up(ctx context.Context) error {
+	_, err := v.client.ExecContext(ctx, `
+		ALTER TABLE user ADD COLUMN block_reason VARCHAR(32) NOT NULL DEFAULT '' AFTER status
+	`)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// без подтвержденного контакта пользователь заблокирован до подтверждения. Остальные заблокированы вручную
+	// или автоблокировкой, различить их нельзя: считаем автоблокировкой, чтобы ее workflow по-прежнему
+	// снял блокировку в срок, а смена контакта - нет
+	_, err = v.client.ExecContext(ctx, `
+		UPDATE user
+		SET block_reason = IF((email IS NOT NULL AND email_verified) OR (telegram IS NOT NULL AND telegram_verified), 'auto_block', 'unverified_contacts')
+		WHERE status = 0
+	`)
+	return errors.WithStack(err)
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
@@ -35,18 +36,11 @@ func (u *userQueryService) FindUser(ctx context.Context, userID uuid.UUID) (_ *a
 		metrics.DatabaseDuration.WithLabelValues("find_query", "user", status).Observe(time.Since(start).Seconds())
 	}()
 
-	user := struct {
-		UserID   uuid.UUID        `db:"user_id"`
-		Status   int              `db:"status"`
-		Login    string           `db:"login"`
-		Email    sql.Null[string] `db:"email"`
-		Telegram sql.Null[string] `db:"telegram"`
-	}{}
-
+	var user sqlxUser
 	err = u.client.GetContext(
 		ctx,
 		&user,
-		`SELECT user_id, status, login, email, telegram FROM user WHERE user_id = ?`,
+		`SELECT `+userColumns+` FROM user WHERE user_id = ?`,
 		userID,
 	)
 	if err != nil {
@@ -56,13 +50,173 @@ func (u *userQueryService) FindUser(ctx context.Context, userID uuid.UUID) (_ *a
 		return nil, errors.WithStack(err)
 	}
 
-	return &appmodel.User{
-		UserID:   user.UserID,
-		Status:   user.Status,
-		Login:    user.Login,
-		Email:    fromSQLNull(user.Email),
-		Telegram: fromSQLNull(user.Telegram),
-	}, nil
+	result := user.toAppModel()
+	return &result, nil
+}
+
+func (u *userQueryService) FindUsers(ctx context.Context, userIDs []uuid.UUID) (_ []appmodel.User, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_batch_query", "user", status).Observe(time.Since(start).Seconds())
+	}()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(userIDs))
+	args := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		placeholders[i] = "?"
+		args[i] = userID
+	}
+	var users []sqlxUser
+	err = u.client.SelectContext(
+		ctx,
+		&users,
+		`SELECT `+userColumns+` FROM user WHERE user_id IN (`+strings.Join(placeholders, ", ")+`)`,
+		args...,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	found := make(map[uuid.UUID]sqlxUser, len(users))
+	for _, user := range users {
+		found[user.UserID] = user
+	}
+	result := make([]appmodel.User, 0, len(users))
+	for _, userID := range userIDs {
+		if user, ok := found[userID]; ok {
+			result = append(result, user.toAppModel())
+			// повторный ID в запросе не дублирует пользователя в ответе
+			delete(found, userID)
+		}
+	}
+	return result, nil
+}
+
+func (u *userQueryService) ListUsers(ctx context.Context, spec appmodel.ListUsersSpec) (_ appmodel.UserList, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("list_query", "user", status).Observe(time.Since(start).Seconds())
+	}()
+
+	conditions, args := listUsersConditions(spec)
+	query := `SELECT ` + userColumns + ` FROM user`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	// на одну запись больше, чтобы понять, есть ли следующая страница
+	query += ` ORDER BY created_at, user_id LIMIT ?`
+	args = append(args, spec.Limit+1)
+
+	var users []sqlxUser
+	err = u.client.SelectContext(ctx, &users, query, args...)
+	if err != nil {
+		return appmodel.UserList{}, errors.WithStack(err)
+	}
+
+	var list appmodel.UserList
+	if len(users) > spec.Limit {
+		users = users[:spec.Limit]
+		last := users[len(users)-1]
+		list.Next = &appmodel.UserCursor{
+			CreatedAt: last.CreatedAt,
+			UserID:    last.UserID,
+		}
+	}
+	list.Users = make([]appmodel.User, len(users))
+	for i, user := range users {
+		list.Users[i] = user.toAppModel()
+	}
+	return list, nil
+}
+
+func listUsersConditions(spec appmodel.ListUsersSpec) (conditions []string, args []interface{}) {
+	if len(spec.Statuses) > 0 {
+		placeholders := make([]string, len(spec.Statuses))
+		for i, status := range spec.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	} else {
+		conditions = append(conditions, "status <> ?")
+		args = append(args, int(model.Deleted))
+	}
+	if spec.LoginPrefix != nil {
+		conditions = append(conditions, "login LIKE ?")
+		args = append(args, escapeLike(*spec.LoginPrefix)+"%")
+	}
+	if spec.EmailPrefix != nil {
+		conditions = append(conditions, "email LIKE ?")
+		args = append(args, escapeLike(*spec.EmailPrefix)+"%")
+	}
+	if spec.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *spec.CreatedFrom)
+	}
+	if spec.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *spec.CreatedBefore)
+	}
+	if spec.After != nil {
+		conditions = append(conditions, "(created_at > ? OR (created_at = ? AND user_id > ?))")
+		args = append(args, spec.After.CreatedAt, spec.After.CreatedAt, spec.After.UserID)
+	}
+	return conditions, args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+const userColumns = `user_id, status, login, email, telegram, email_verified, telegram_verified, roles, version, created_at`
+
+type sqlxUser struct {
+	UserID           uuid.UUID        `db:"user_id"`
+	Status           int              `db:"status"`
+	Login            string           `db:"login"`
+	Email            sql.Null[string] `db:"email"`
+	Telegram         sql.Null[string] `db:"telegram"`
+	EmailVerified    bool             `db:"email_verified"`
+	TelegramVerified bool             `db:"telegram_verified"`
+	Roles            string           `db:"roles"`
+	Version          int64            `db:"version"`
+	CreatedAt        time.Time        `db:"created_at"`
+}
+
+func (u sqlxUser) toAppModel() appmodel.User {
+	return appmodel.User{
+		UserID:           u.UserID,
+		Status:           u.Status,
+		Login:            u.Login,
+		Email:            fromSQLNull(u.Email),
+		Telegram:         fromSQLNull(u.Telegram),
+		EmailVerified:    u.EmailVerified,
+		TelegramVerified: u.TelegramVerified,
+		Roles:            splitRoles(u.Roles),
+		Version:          u.Version,
+		CreatedAt:        u.CreatedAt.Unix(),
+	}
+}
+
+func splitRoles(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
 }
 
 func fromSQLNull[T any](v sql.Null[T]) *T {
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "userservice/pkg/user/application/model"
+	"userservice/pkg/user/application/query"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+func NewUserErasureQueryService(client mysql.ClientContext) query.UserErasureQueryService {
+	return &userErasureQueryService{
+		client: client,
+	}
+}
+
+type userErasureQueryService struct {
+	client mysql.ClientContext
+}
+
+func (s *userErasureQueryService) FindUserErasures(ctx context.Context, userID uuid.UUID) (_ []appmodel.UserErasure, err error) {
+	start := time.Now()
+	defer func() {
+		status := "success"
+		if err != nil {
+			status = "error"
+		}
+		metrics.DatabaseDuration.WithLabelValues("find_query", "user_erasure_confirmation", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var erasures []struct {
+		Service  string    `db:"service"`
+		ErasedAt time.Time `db:"erased_at"`
+	}
+	err = s.client.SelectContext(ctx, &erasures,
+		`SELECT service, erased_at FROM user_erasure_confirmation WHERE user_id = ? ORDER BY service`,
+		userID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]appmodel.UserErasure, len(erasures))
+	for i, erasure := range erasures {
+		result[i] = appmodel.UserErasure{
+			Service:  erasure.Service,
+			ErasedAt: erasure.ErasedAt.Unix(),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+func NewContactVerificationRepository(ctx context.Context, client mysql.ClientContext) model.ContactVerificationRepository {
+	return &contactVerificationRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type contactVerificationRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *contactVerificationRepository) Store(verification model.ContactVerification) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "contact_verification", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO contact_verification (user_id, contact_type, value, code_hash, attempts, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		value=VALUES(value),
+	    code_hash=VALUES(code_hash),
+	    attempts=VALUES(attempts),
+	    expires_at=VALUES(expires_at),
+	    created_at=VALUES(created_at)
+	`,
+		verification.UserID,
+		verification.ContactType,
+		verification.Value,
+		verification.CodeHash,
+		verification.Attempts,
+		verification.ExpiresAt,
+		verification.CreatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *contactVerificationRepository) Find(userID uuid.UUID, contactType model.ContactType) (_ *model.ContactVerification, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrContactVerificationNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "contact_verification", status).Observe(time.Since(start).Seconds())
+	}()
+
+	verification := struct {
+		UserID      uuid.UUID `db:"user_id"`
+		ContactType int       `db:"contact_type"`
+		Value       string    `db:"value"`
+		CodeHash    string    `db:"code_hash"`
+		Attempts    int       `db:"attempts"`
+		ExpiresAt   time.Time `db:"expires_at"`
+		CreatedAt   time.Time `db:"created_at"`
+	}{}
+	err = r.client.GetContext(
+		r.ctx,
+		&verification,
+		`SELECT user_id, contact_type, value, code_hash, attempts, expires_at, created_at FROM contact_verification WHERE user_id = ? AND contact_type = ?`,
+		userID,
+		contactType,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrContactVerificationNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.ContactVerification{
+		UserID:      verification.UserID,
+		ContactType: model.ContactType(verification.ContactType),
+		Value:       verification.Value,
+		CodeHash:    verification.CodeHash,
+		Attempts:    verification.Attempts,
+		ExpiresAt:   verification.ExpiresAt,
+		CreatedAt:   verification.CreatedAt,
+	}, nil
+}
+
+func (r *contactVerificationRepository) Delete(userID uuid.UUID, contactType model.ContactType) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("delete", "contact_verification", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx, `DELETE FROM contact_verification WHERE user_id = ? AND contact_type = ?`, userID, contactType)
+	return errors.WithStack(err)
+}
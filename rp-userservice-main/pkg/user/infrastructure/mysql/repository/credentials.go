@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+// учетные данные и сессии в журнал аудита не пишем: там оказались бы хеши паролей и токенов
+
+func NewCredentialsRepository(ctx context.Context, client mysql.ClientContext) model.CredentialsRepository {
+	return &credentialsRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type credentialsRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *credentialsRepository) Store(credentials model.Credentials) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "user_credentials", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO user_credentials (user_id, password_hash, failed_attempts, locked_until, updated_at) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		password_hash=VALUES(password_hash),
+	    failed_attempts=VALUES(failed_attempts),
+	    locked_until=VALUES(locked_until),
+	    updated_at=VALUES(updated_at)
+	`,
+		credentials.UserID,
+		credentials.PasswordHash,
+		credentials.FailedAttempts,
+		toSQLNull(credentials.LockedUntil),
+		credentials.UpdatedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *credentialsRepository) Find(userID uuid.UUID) (_ *model.Credentials, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrCredentialsNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_credentials", status).Observe(time.Since(start).Seconds())
+	}()
+
+	credentials := struct {
+		UserID         uuid.UUID           `db:"user_id"`
+		PasswordHash   string              `db:"password_hash"`
+		FailedAttempts int                 `db:"failed_attempts"`
+		LockedUntil    sql.Null[time.Time] `db:"locked_until"`
+		UpdatedAt      time.Time           `db:"updated_at"`
+	}{}
+	err = r.client.GetContext(
+		r.ctx,
+		&credentials,
+		`SELECT user_id, password_hash, failed_attempts, locked_until, updated_at FROM user_credentials WHERE user_id = ?`,
+		userID,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrCredentialsNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Credentials{
+		UserID:         credentials.UserID,
+		PasswordHash:   credentials.PasswordHash,
+		FailedAttempts: credentials.FailedAttempts,
+		LockedUntil:    fromSQLNull(credentials.LockedUntil),
+		UpdatedAt:      credentials.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+func NewLoginHistoryRepository(ctx context.Context, client mysql.ClientContext) model.LoginHistoryRepository {
+	return &loginHistoryRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type loginHistoryRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *loginHistoryRepository) Store(entry model.LoginHistory) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "user_login_history", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`INSERT INTO user_login_history (user_id, login, changed_at, reserved_until) VALUES (?, ?, ?, ?)`,
+		entry.UserID,
+		entry.Login,
+		entry.ChangedAt,
+		entry.ReservedUntil,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *loginHistoryRepository) IsReserved(login string, exceptUserID uuid.UUID, at time.Time) (_ bool, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_login_history", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var count int
+	err = r.client.GetContext(r.ctx, &count,
+		`SELECT COUNT(*) FROM user_login_history WHERE login = ? AND reserved_until > ? AND user_id <> ?`,
+		login,
+		at,
+		exceptUserID,
+	)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+func NewSessionRepository(ctx context.Context, client mysql.ClientContext) model.SessionRepository {
+	return &sessionRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type sessionRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *sessionRepository) NextID() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+func (r *sessionRepository) Store(session model.Session) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "user_session", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO user_session (session_id, user_id, refresh_token_hash, expires_at, created_at, revoked_at) VALUES (?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		refresh_token_hash=VALUES(refresh_token_hash),
+	    expires_at=VALUES(expires_at),
+	    revoked_at=VALUES(revoked_at)
+	`,
+		session.SessionID,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.ExpiresAt,
+		session.CreatedAt,
+		toSQLNull(session.RevokedAt),
+	)
+	return errors.WithStack(err)
+}
+
+func (r *sessionRepository) FindByRefreshTokenHash(hash string) (_ *model.Session, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil && !errors.Is(err, model.ErrSessionNotFound) {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_session", status).Observe(time.Since(start).Seconds())
+	}()
+
+	session := struct {
+		SessionID        uuid.UUID           `db:"session_id"`
+		UserID           uuid.UUID           `db:"user_id"`
+		RefreshTokenHash string              `db:"refresh_token_hash"`
+		ExpiresAt        time.Time           `db:"expires_at"`
+		CreatedAt        time.Time           `db:"created_at"`
+		RevokedAt        sql.Null[time.Time] `db:"revoked_at"`
+	}{}
+	err = r.client.GetContext(
+		r.ctx,
+		&session,
+		`SELECT session_id, user_id, refresh_token_hash, expires_at, created_at, revoked_at FROM user_session WHERE refresh_token_hash = ?`,
+		hash,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.WithStack(model.ErrSessionNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return &model.Session{
+		SessionID:        session.SessionID,
+		UserID:           session.UserID,
+		RefreshTokenHash: session.RefreshTokenHash,
+		ExpiresAt:        session.ExpiresAt,
+		CreatedAt:        session.CreatedAt,
+		RevokedAt:        fromSQLNull(session.RevokedAt),
+	}, nil
+}
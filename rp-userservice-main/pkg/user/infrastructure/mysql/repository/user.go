@@ -11,12 +11,15 @@ import (
 	"github.com/pkg/errors"
 
 	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/audit"
 	"userservice/pkg/user/infrastructure/metrics"
 )
 
 const (
 	statusSuccess = "success"
 	statusError   = "error"
+
+	auditEntityUser = "user"
 )
 
 func NewUserRepository(ctx context.Context, client mysql.ClientContext) model.UserRepository {
@@ -45,27 +48,56 @@ func (u *userRepository) Store(user model.User) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("store", "user", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := u.Find(model.FindSpec{UserID: &user.UserID})
+	if err != nil && !errors.Is(err, model.ErrUserNotFound) {
+		return err
+	}
+	// версию считаем от сохраненной записи, а не от пришедшей: так ее нельзя откатить устаревшей копией
+	user.Version = 1
+	if before != nil {
+		user.Version = before.Version + 1
+	}
+
 	_, err = u.client.ExecContext(u.ctx,
 		`
-	INSERT INTO user (user_id, status, login, email, telegram, created_at, updated_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	INSERT INTO user (user_id, status, block_reason, login, email, telegram, email_verified, telegram_verified, roles, version, created_at, updated_at, deleted_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	ON DUPLICATE KEY UPDATE
 		status=VALUES(status),
+	    block_reason=VALUES(block_reason),
 	    login=VALUES(login),
 	    email=VALUES(email),
 	    telegram=VALUES(telegram),
+	    email_verified=VALUES(email_verified),
+	    telegram_verified=VALUES(telegram_verified),
+	    roles=VALUES(roles),
+	    version=VALUES(version),
 	    updated_at=VALUES(updated_at),
 	    deleted_at=VALUES(deleted_at)
 	`,
 		user.UserID,
 		user.Status,
+		user.BlockReason,
 		user.Login,
 		toSQLNull(user.Email),
 		toSQLNull(user.Telegram),
+		user.EmailVerified,
+		user.TelegramVerified,
+		joinRoles(user.Roles),
+		user.Version,
 		user.CreatedAt,
 		user.UpdatedAt,
 		toSQLNull(user.DeletedAt),
 	)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	key, err := auditSubjectKey(u.ctx, u.client, user.UserID.String())
+	if err != nil {
+		return err
+	}
+	return appendAuditRecord(u.ctx, u.client, auditEntityUser, user.UserID.String(), toAuditUser(before, key), toAuditUser(&user, key))
 }
 
 func (u *userRepository) Find(spec model.FindSpec) (_ *model.User, err error) {
@@ -79,21 +111,26 @@ func (u *userRepository) Find(spec model.FindSpec) (_ *model.User, err error) {
 	}()
 
 	user := struct {
-		UserID    uuid.UUID           `db:"user_id"`
-		Status    int                 `db:"status"`
-		Login     string              `db:"login"`
-		Email     sql.Null[string]    `db:"email"`
-		Telegram  sql.Null[string]    `db:"telegram"`
-		CreatedAt time.Time           `db:"created_at"`
-		UpdatedAt time.Time           `db:"updated_at"`
-		DeletedAt sql.Null[time.Time] `db:"deleted_at"`
+		UserID           uuid.UUID           `db:"user_id"`
+		Status           int                 `db:"status"`
+		BlockReason      string              `db:"block_reason"`
+		Login            string              `db:"login"`
+		Email            sql.Null[string]    `db:"email"`
+		Telegram         sql.Null[string]    `db:"telegram"`
+		EmailVerified    bool                `db:"email_verified"`
+		TelegramVerified bool                `db:"telegram_verified"`
+		Roles            string              `db:"roles"`
+		Version          int64               `db:"version"`
+		CreatedAt        time.Time           `db:"created_at"`
+		UpdatedAt        time.Time           `db:"updated_at"`
+		DeletedAt        sql.Null[time.Time] `db:"deleted_at"`
 	}{}
 	query, args := u.buildSpecArgs(spec)
 
 	err = u.client.GetContext(
 		u.ctx,
 		&user,
-		`SELECT user_id, status, login, email, telegram, created_at, updated_at, deleted_at FROM user WHERE `+query,
+		`SELECT user_id, status, block_reason, login, email, telegram, email_verified, telegram_verified, roles, version, created_at, updated_at, deleted_at FROM user WHERE `+query,
 		args...,
 	)
 	if err != nil {
@@ -104,14 +141,19 @@ func (u *userRepository) Find(spec model.FindSpec) (_ *model.User, err error) {
 	}
 
 	return &model.User{
-		UserID:    user.UserID,
-		Status:    model.UserStatus(user.Status),
-		Login:     user.Login,
-		Email:     fromSQLNull(user.Email),
-		Telegram:  fromSQLNull(user.Telegram),
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-		DeletedAt: fromSQLNull(user.DeletedAt),
+		UserID:           user.UserID,
+		Status:           model.UserStatus(user.Status),
+		Login:            user.Login,
+		Email:            fromSQLNull(user.Email),
+		Telegram:         fromSQLNull(user.Telegram),
+		EmailVerified:    user.EmailVerified,
+		TelegramVerified: user.TelegramVerified,
+		BlockReason:      model.BlockReason(user.BlockReason),
+		Roles:            splitRoles(user.Roles),
+		Version:          user.Version,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		DeletedAt:        fromSQLNull(user.DeletedAt),
 	}, nil
 }
 
@@ -125,8 +167,65 @@ func (u *userRepository) HardDelete(userID uuid.UUID) (err error) {
 		metrics.DatabaseDuration.WithLabelValues("delete", "user", status).Observe(time.Since(start).Seconds())
 	}()
 
+	before, err := u.Find(model.FindSpec{UserID: &userID})
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
 	_, err = u.client.ExecContext(u.ctx, `DELETE FROM user WHERE user_id = ?`, userID)
-	return errors.WithStack(err)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// вместе с пользователем удаляем коды подтверждения с контактами, пароль, сессии и прежние логины
+	for _, table := range []string{"contact_verification", "user_credentials", "user_session", "user_login_history"} {
+		_, err = u.client.ExecContext(u.ctx, `DELETE FROM `+table+` WHERE user_id = ?`, userID)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	// от пользователя остается только отметка об удалении, по ней восстановление отличает удаленного от несуществующего
+	_, err = u.client.ExecContext(u.ctx,
+		`INSERT IGNORE INTO user_tombstone (user_id, deleted_at) VALUES (?, ?)`,
+		userID,
+		time.Now(),
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	key, err := auditSubjectKey(u.ctx, u.client, userID.String())
+	if err != nil {
+		return err
+	}
+	// последняя запись еще хешируется ключом, после удаления ключа прежние хеши не сопоставить с пользователем
+	err = forgetAuditSubject(u.ctx, u.client, userID.String())
+	if err != nil {
+		return err
+	}
+	return appendAuditRecord(u.ctx, u.client, auditEntityUser, userID.String(), toAuditUser(before, key), nil)
+}
+
+func (u *userRepository) IsHardDeleted(userID uuid.UUID) (_ bool, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_tombstone", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var count int
+	err = u.client.GetContext(u.ctx, &count, `SELECT COUNT(*) FROM user_tombstone WHERE user_id = ?`, userID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return count > 0, nil
 }
 
 func (u *userRepository) buildSpecArgs(spec model.FindSpec) (query string, args []interface{}) {
@@ -150,6 +249,71 @@ func (u *userRepository) buildSpecArgs(spec model.FindSpec) (query string, args
 	return strings.Join(parts, " AND "), args
 }
 
+// auditUser - пользователь в журнале аудита: логин и контакты записываются хешем на ключе пользователя
+type auditUser struct {
+	UserID           uuid.UUID
+	Status           model.UserStatus
+	BlockReason      model.BlockReason
+	Login            string
+	Email            *string
+	Telegram         *string
+	EmailVerified    bool
+	TelegramVerified bool
+	Roles            []model.Role
+	Version          int64
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time
+}
+
+func toAuditUser(user *model.User, key []byte) *auditUser {
+	if user == nil {
+		return nil
+	}
+	redact := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		return toPtr(audit.Redact(key, *value))
+	}
+	return &auditUser{
+		UserID:           user.UserID,
+		Status:           user.Status,
+		BlockReason:      user.BlockReason,
+		Login:            audit.Redact(key, user.Login),
+		Email:            redact(user.Email),
+		Telegram:         redact(user.Telegram),
+		EmailVerified:    user.EmailVerified,
+		TelegramVerified: user.TelegramVerified,
+		Roles:            user.Roles,
+		Version:          user.Version,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+		DeletedAt:        user.DeletedAt,
+	}
+}
+
+// роли хранятся одной строкой через запятую: их немного, и выбираются они всегда вместе с пользователем
+func joinRoles(roles []model.Role) string {
+	parts := make([]string, len(roles))
+	for i, role := range roles {
+		parts[i] = string(role)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitRoles(value string) []model.Role {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	roles := make([]model.Role, len(parts))
+	for i, part := range parts {
+		roles[i] = model.Role(part)
+	}
+	return roles
+}
+
 func fromSQLNull[T any](v sql.Null[T]) *T {
 	if v.Valid {
 		return &v.V
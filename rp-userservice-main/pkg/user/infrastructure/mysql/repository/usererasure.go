@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/infrastructure/mysql"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/metrics"
+)
+
+func NewUserErasureRepository(ctx context.Context, client mysql.ClientContext) model.UserErasureRepository {
+	return &userErasureRepository{
+		ctx:    ctx,
+		client: client,
+	}
+}
+
+type userErasureRepository struct {
+	ctx    context.Context
+	client mysql.ClientContext
+}
+
+func (r *userErasureRepository) Store(erasure model.UserErasure) (err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("store", "user_erasure_confirmation", status).Observe(time.Since(start).Seconds())
+	}()
+
+	_, err = r.client.ExecContext(r.ctx,
+		`
+	INSERT INTO user_erasure_confirmation (user_id, service, erased_at) VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		erased_at=VALUES(erased_at)
+	`,
+		erasure.UserID,
+		erasure.Service,
+		erasure.ErasedAt,
+	)
+	return errors.WithStack(err)
+}
+
+func (r *userErasureRepository) FindForUser(userID uuid.UUID) (_ []model.UserErasure, err error) {
+	start := time.Now()
+	defer func() {
+		status := statusSuccess
+		if err != nil {
+			status = statusError
+		}
+		metrics.DatabaseDuration.WithLabelValues("find", "user_erasure_confirmation", status).Observe(time.Since(start).Seconds())
+	}()
+
+	var erasures []struct {
+		UserID   uuid.UUID `db:"user_id"`
+		Service  string    `db:"service"`
+		ErasedAt time.Time `db:"erased_at"`
+	}
+	err = r.client.SelectContext(r.ctx, &erasures,
+		`SELECT user_id, service, erased_at FROM user_erasure_confirmation WHERE user_id = ? ORDER BY service`,
+		userID,
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	result := make([]model.UserErasure, len(erasures))
+	for i, erasure := range erasures {
+		result[i] = model.UserErasure{
+			UserID:   erasure.UserID,
+			Service:  erasure.Service,
+			ErasedAt: erasure.ErasedAt,
+		}
+	}
+	return result, nil
+}
@@ -21,3 +21,23 @@ type repositoryProvider struct {
 func (r *repositoryProvider) UserRepository(ctx context.Context) model.UserRepository {
 	return repository.NewUserRepository(ctx, r.client)
 }
+
+func (r *repositoryProvider) ContactVerificationRepository(ctx context.Context) model.ContactVerificationRepository {
+	return repository.NewContactVerificationRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) UserErasureRepository(ctx context.Context) model.UserErasureRepository {
+	return repository.NewUserErasureRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) CredentialsRepository(ctx context.Context) model.CredentialsRepository {
+	return repository.NewCredentialsRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) SessionRepository(ctx context.Context) model.SessionRepository {
+	return repository.NewSessionRepository(ctx, r.client)
+}
+
+func (r *repositoryProvider) LoginHistoryRepository(ctx context.Context) model.LoginHistoryRepository {
+	return repository.NewLoginHistoryRepository(ctx, r.client)
+}
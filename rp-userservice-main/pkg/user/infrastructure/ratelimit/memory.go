@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+// NewMemoryLimiter хранит bucket'ы в памяти процесса, лимиты действуют в рамках одной реплики
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		buckets:   make(map[string]*memoryBucket),
+		cleanupAt: time.Now().Add(memoryCleanupInterval),
+	}
+}
+
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+type memoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	cleanupAt time.Time
+}
+
+func (l *memoryLimiter) Take(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.After(l.cleanupAt) {
+		l.cleanup(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &memoryBucket{bucket: newBucket(limit, now)}
+		l.buckets[key] = b
+	}
+	b.limit = limit
+
+	allowed, retryAfter := b.take(limit, now)
+	return allowed, retryAfter, nil
+}
+
+func (l *memoryLimiter) cleanup(now time.Time) {
+	for key, b := range l.buckets {
+		if b.full(b.limit, now) {
+			delete(l.buckets, key)
+		}
+	}
+	l.cleanupAt = now.Add(memoryCleanupInterval)
+}
@@ -0,0 +1,45 @@
+package activity
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"userservice/pkg/user/application/service"
+	"userservice/pkg/user/infrastructure/userdata"
+)
+
+// LocalUserDataService - подтверждение удаления в самом userservice
+const LocalUserDataService = "userservice"
+
+func NewUserDataActivities(
+	userDataClients userdata.Clients,
+	userErasureService service.UserErasureService,
+) *UserDataActivities {
+	return &UserDataActivities{
+		userDataClients:    userDataClients,
+		userErasureService: userErasureService,
+	}
+}
+
+type UserDataActivities struct {
+	userDataClients    userdata.Clients
+	userErasureService service.UserErasureService
+}
+
+// EraseUserData удаляет данные пользователя в другом сервисе и сохраняет его подтверждение.
+// Сервисы удаляют идемпотентно, поэтому повтор активности после сбоя безопасен
+func (a *UserDataActivities) EraseUserData(ctx context.Context, service string, userID uuid.UUID) error {
+	ctx = withAuditInfo(ctx)
+	erasedAt, err := a.userDataClients.Erase(ctx, service, userID)
+	if err != nil {
+		return err
+	}
+	return a.userErasureService.ConfirmErasure(ctx, userID, service, erasedAt)
+}
+
+// ConfirmLocalErasure - сам пользователь удален до запуска workflow, остается только отметить это
+func (a *UserDataActivities) ConfirmLocalErasure(ctx context.Context, userID uuid.UUID) error {
+	return a.userErasureService.ConfirmErasure(withAuditInfo(ctx), userID, LocalUserDataService, time.Now())
+}
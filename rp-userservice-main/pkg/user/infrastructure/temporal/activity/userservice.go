@@ -2,33 +2,99 @@ package activity
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 
 	appmodel "userservice/pkg/user/application/model"
 	"userservice/pkg/user/application/service"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/audit"
 )
 
-func NewUserServiceActivities(userService service.UserService) *UserServiceActivities {
-	return &UserServiceActivities{userService: userService}
+// UserNotFoundErrorType - тип ошибки активности, по которому workflow узнает об удаленном пользователе
+const UserNotFoundErrorType = "UserNotFound"
+
+// ContactVerificationStarter запускает workflow подтверждения контакта
+type ContactVerificationStarter interface {
+	RunContactVerificationWorkflow(ctx context.Context, userID uuid.UUID, contactType int) error
+}
+
+func NewUserServiceActivities(
+	userService service.UserService,
+	contactVerificationService service.ContactVerificationService,
+	contactVerificationStarter ContactVerificationStarter,
+) *UserServiceActivities {
+	return &UserServiceActivities{
+		userService:                userService,
+		contactVerificationService: contactVerificationService,
+		contactVerificationStarter: contactVerificationStarter,
+	}
 }
 
 type UserServiceActivities struct {
-	userService service.UserService
+	userService                service.UserService
+	contactVerificationService service.ContactVerificationService
+	contactVerificationStarter ContactVerificationStarter
 }
 
 func (a *UserServiceActivities) FindUser(ctx context.Context, userID uuid.UUID) (appmodel.User, error) {
-	return a.userService.FindUser(ctx, userID)
+	user, err := a.userService.FindUser(ctx, userID)
+	return user, userNotFoundError(err)
 }
 
 func (a *UserServiceActivities) SetUserStatus(ctx context.Context, userID uuid.UUID, status int) error {
-	return a.userService.SetUserStatus(ctx, userID, status)
+	return userNotFoundError(a.userService.SetUserStatus(withAuditInfo(ctx), userID, status))
 }
 
-func (a *UserServiceActivities) HardDeleteUser(ctx context.Context, userIDStr string) error {
+func (a *UserServiceActivities) BlockUser(ctx context.Context, userID uuid.UUID, reason string) error {
+	return userNotFoundError(a.userService.BlockUser(withAuditInfo(ctx), userID, reason))
+}
+
+// HardDeleteUser удаляет пользователя, удаленного не позже deletedBefore. Активности, запланированные
+// до появления аргумента, приходят с пустым deletedBefore
+func (a *UserServiceActivities) HardDeleteUser(ctx context.Context, userIDStr string, deletedBefore time.Time) error {
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
 		return err
 	}
-	return a.userService.DeleteUser(ctx, userID, true)
+	err = a.userService.HardDeleteUser(withAuditInfo(ctx), userID, deletedBefore)
+	// пользователя восстановили, уже удалили или удалили снова и его удалит новый workflow
+	if errors.Is(err, model.ErrUserNotDeleted) || errors.Is(err, model.ErrUserNotFound) || errors.Is(err, model.ErrUserRetentionNotExpired) {
+		return nil
+	}
+	return err
+}
+
+func (a *UserServiceActivities) StartContactVerification(ctx context.Context, userID uuid.UUID, contactType int) error {
+	return a.contactVerificationStarter.RunContactVerificationWorkflow(ctx, userID, contactType)
+}
+
+func (a *UserServiceActivities) SendContactVerificationCode(ctx context.Context, userID uuid.UUID, contactType int) (time.Time, error) {
+	return a.contactVerificationService.SendVerificationCode(withAuditInfo(ctx), userID, contactType)
+}
+
+func (a *UserServiceActivities) ExpireContactVerification(ctx context.Context, userID uuid.UUID, contactType int) error {
+	return a.contactVerificationService.ExpireVerification(withAuditInfo(ctx), userID, contactType)
+}
+
+// userNotFoundError не повторяет активность для удаленного пользователя и сохраняет тип ошибки для workflow
+func userNotFoundError(err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), UserNotFoundErrorType, err)
+	}
+	return err
+}
+
+// в журнале аудита изменения из workflow связываются по идентификатору workflow
+func withAuditInfo(ctx context.Context) context.Context {
+	info := activity.GetInfo(ctx)
+	return audit.WithInfo(ctx, audit.Info{
+		Actor:         audit.SystemActor,
+		Method:        info.WorkflowType.Name + "/" + info.ActivityType.Name,
+		CorrelationID: info.WorkflowExecution.ID,
+	})
 }
@@ -2,7 +2,13 @@ package temporal
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
 
 	"userservice/pkg/user/domain/model"
@@ -13,7 +19,14 @@ const TaskQueue = "userservice_task_queue"
 
 type WorkflowService interface {
 	RunUserUpdatedWorkflow(ctx context.Context, id string, event model.UserUpdated) error
-	RunUserDeletedWorkflow(ctx context.Context, id, userID string) error
+	RunUserDeletedWorkflow(ctx context.Context, userID string, retentionPeriod time.Duration) error
+	CancelUserDeletedWorkflow(ctx context.Context, userID uuid.UUID) error
+	// RunContactVerificationWorkflow перезапускает подтверждение, если контакт сменили до ввода кода
+	RunContactVerificationWorkflow(ctx context.Context, userID uuid.UUID, contactType int) error
+	SignalContactConfirmed(ctx context.Context, userID uuid.UUID, contactType int) error
+	RunUserErasureWorkflow(ctx context.Context, userID uuid.UUID) error
+	// SignalUserFailure передает сбой в workflow автоблокировки пользователя, запуская его при необходимости
+	SignalUserFailure(ctx context.Context, userID uuid.UUID, failure workflows.UserFailure, policy workflows.AutoBlockPolicy) error
 }
 
 func NewWorkflowService(temporalClient client.Client) WorkflowService {
@@ -26,26 +39,113 @@ type workflowService struct {
 	temporalClient client.Client
 }
 
+// RunUserUpdatedWorkflow идемпотентен по id события: при повторной доставке уже запущенный
+// или успешно завершившийся workflow не перезапускается, а клиент не возвращает ошибку
 func (s *workflowService) RunUserUpdatedWorkflow(ctx context.Context, id string, event model.UserUpdated) error {
 	_, err := s.temporalClient.ExecuteWorkflow(
 		ctx,
 		client.StartWorkflowOptions{
-			ID:        id,
-			TaskQueue: TaskQueue,
+			ID:                    id,
+			TaskQueue:             TaskQueue,
+			WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE_FAILED_ONLY,
 		},
 		workflows.UserUpdatedWorkflow, event,
 	)
 	return err
 }
 
-func (s *workflowService) RunUserDeletedWorkflow(ctx context.Context, id, userID string) error {
+func (s *workflowService) RunUserDeletedWorkflow(ctx context.Context, userID string, retentionPeriod time.Duration) error {
 	_, err := s.temporalClient.ExecuteWorkflow(
 		ctx,
 		client.StartWorkflowOptions{
-			ID:        id,
+			ID:        userDeletedWorkflowID(userID),
 			TaskQueue: TaskQueue,
 		},
-		workflows.UserDeletedWorkflow, userID,
+		workflows.UserDeletedWorkflow, userID, retentionPeriod,
 	)
 	return err
 }
+
+// CancelUserDeletedWorkflow отменяет отложенное окончательное удаление.
+// Workflow, запущенные со старым идентификатором (correlationID+"_del"), так не найти, но они не удалят
+// пользователя, который уже не в статусе Deleted или удален заново позже, чем начался их срок хранения
+func (s *workflowService) CancelUserDeletedWorkflow(ctx context.Context, userID uuid.UUID) error {
+	err := s.temporalClient.CancelWorkflow(ctx, userDeletedWorkflowID(userID.String()), "")
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *workflowService) RunContactVerificationWorkflow(ctx context.Context, userID uuid.UUID, contactType int) error {
+	_, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:                       contactVerificationWorkflowID(userID, contactType),
+			TaskQueue:                TaskQueue,
+			WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING,
+		},
+		workflows.ContactVerificationWorkflow, userID, contactType,
+	)
+	return err
+}
+
+func (s *workflowService) SignalContactConfirmed(ctx context.Context, userID uuid.UUID, contactType int) error {
+	err := s.temporalClient.SignalWorkflow(ctx, contactVerificationWorkflowID(userID, contactType), "", workflows.ContactConfirmedSignal, nil)
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		// workflow уже завершился
+		return nil
+	}
+	return err
+}
+
+// RunUserErasureWorkflow не перезапускает уже идущее удаление при повторной доставке события
+func (s *workflowService) RunUserErasureWorkflow(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:                       userErasureWorkflowID(userID),
+			TaskQueue:                TaskQueue,
+			WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING,
+		},
+		workflows.UserErasureWorkflow, userID,
+	)
+	return err
+}
+
+func (s *workflowService) SignalUserFailure(
+	ctx context.Context,
+	userID uuid.UUID,
+	failure workflows.UserFailure,
+	policy workflows.AutoBlockPolicy,
+) error {
+	_, err := s.temporalClient.SignalWithStartWorkflow(
+		ctx,
+		userAutoBlockWorkflowID(userID),
+		workflows.UserFailureSignal,
+		failure,
+		client.StartWorkflowOptions{
+			TaskQueue: TaskQueue,
+		},
+		workflows.UserAutoBlockWorkflow, userID, policy, workflows.AutoBlockState{},
+	)
+	return err
+}
+
+func userDeletedWorkflowID(userID string) string {
+	return "user_deleted_" + userID
+}
+
+func contactVerificationWorkflowID(userID uuid.UUID, contactType int) string {
+	return "contact_verification_" + userID.String() + "_" + strconv.Itoa(contactType)
+}
+
+func userErasureWorkflowID(userID uuid.UUID) string {
+	return "user_erasure_" + userID.String()
+}
+
+func userAutoBlockWorkflowID(userID uuid.UUID) string {
+	return "user_auto_block_" + userID.String()
+}
@@ -8,6 +8,7 @@ import (
 	"userservice/pkg/user/infrastructure/temporal"
 	"userservice/pkg/user/infrastructure/temporal/activity"
 	"userservice/pkg/user/infrastructure/temporal/workflows"
+	"userservice/pkg/user/infrastructure/userdata"
 )
 
 func InterruptChannel() <-chan interface{} {
@@ -17,11 +18,22 @@ func InterruptChannel() <-chan interface{} {
 func NewWorker(
 	temporalClient client.Client,
 	userService service.UserService,
+	contactVerificationService service.ContactVerificationService,
+	userErasureService service.UserErasureService,
+	userDataClients userdata.Clients,
 ) worker.Worker {
 	w := worker.New(temporalClient, temporal.TaskQueue, worker.Options{})
-	w.RegisterActivity(activity.NewUserServiceActivities(userService))
+	w.RegisterActivity(activity.NewUserServiceActivities(
+		userService,
+		contactVerificationService,
+		temporal.NewWorkflowService(temporalClient),
+	))
+	w.RegisterActivity(activity.NewUserDataActivities(userDataClients, userErasureService))
 
 	w.RegisterWorkflow(workflows.UserUpdatedWorkflow)
 	w.RegisterWorkflow(workflows.UserDeletedWorkflow)
+	w.RegisterWorkflow(workflows.ContactVerificationWorkflow)
+	w.RegisterWorkflow(workflows.UserErasureWorkflow)
+	w.RegisterWorkflow(workflows.UserAutoBlockWorkflow)
 	return w
 }
@@ -0,0 +1,48 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ContactConfirmedSignal отправляется после успешного ConfirmContact
+const ContactConfirmedSignal = "contact_confirmed"
+
+func ContactVerificationWorkflow(ctx workflow.Context, userID uuid.UUID, contactType int) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting ContactVerificationWorkflow", "UserID", userID, "ContactType", contactType)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	var expiresAt time.Time
+	err := workflow.ExecuteActivity(ctx, userServiceActivities.SendContactVerificationCode, userID, contactType).Get(ctx, &expiresAt)
+	if err != nil {
+		logger.Error("Failed to send verification code", "Error", err)
+		return err
+	}
+	if expiresAt.IsZero() {
+		logger.Info("Nothing to verify", "UserID", userID)
+		return nil
+	}
+
+	confirmed := false
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(workflow.GetSignalChannel(ctx, ContactConfirmedSignal), func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		confirmed = true
+	})
+	selector.AddFuture(workflow.NewTimer(ctx, expiresAt.Sub(workflow.Now(ctx))), func(workflow.Future) {})
+	selector.Select(ctx)
+
+	if confirmed {
+		logger.Info("Contact confirmed", "UserID", userID)
+		return nil
+	}
+
+	logger.Info("Verification code expired", "UserID", userID)
+	return workflow.ExecuteActivity(ctx, userServiceActivities.ExpireContactVerification, userID, contactType).Get(ctx, nil)
+}
@@ -0,0 +1,203 @@
+package workflows
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/workflow"
+
+	appmodel "userservice/pkg/user/application/model"
+	"userservice/pkg/user/domain/model"
+)
+
+const (
+	// UserFailureSignal - заказ пользователя отменен, в том числе из-за неудачной оплаты
+	UserFailureSignal = "user_failure"
+	// AutoBlockStateQuery возвращает AutoBlockState, в том числе причину блокировки
+	AutoBlockStateQuery = "auto_block_state"
+)
+
+// AutoBlockPolicy - Threshold сбоев за Window блокируют пользователя.
+// Если CoolOff не 0, через это время пользователь разблокируется автоматически
+type AutoBlockPolicy struct {
+	Threshold int
+	Window    time.Duration
+	CoolOff   time.Duration
+}
+
+type UserFailure struct {
+	Source     string
+	Reason     string
+	OccurredAt time.Time
+}
+
+type AutoBlockState struct {
+	Failures  []UserFailure
+	BlockedAt *time.Time
+	Reason    string
+}
+
+// UserAutoBlockWorkflow живет, пока в окне есть сбои или пользователь ждет разблокировки.
+// Новый сбой после завершения запустит workflow заново через SignalWithStart
+func UserAutoBlockWorkflow(ctx workflow.Context, userID uuid.UUID, policy AutoBlockPolicy, state AutoBlockState) error {
+	logger := workflow.GetLogger(ctx)
+
+	err := workflow.SetQueryHandler(ctx, AutoBlockStateQuery, func() (AutoBlockState, error) {
+		return state, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+	})
+
+	signals := workflow.GetSignalChannel(ctx, UserFailureSignal)
+	for {
+		// SignalWithStart запускает workflow с пустым состоянием, первый сбой уже ждет в канале
+		drainFailures(signals, &state)
+		state.Failures = failuresInWindow(state.Failures, workflow.Now(ctx), policy.Window)
+
+		if state.BlockedAt == nil && len(state.Failures) >= policy.Threshold {
+			blocked, err := blockUser(ctx, userID, state.Failures, policy)
+			if err != nil {
+				if isUserNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			if blocked != nil {
+				logger.Info("User auto blocked", "UserID", userID, "Reason", blocked.Reason)
+				state = *blocked
+			} else {
+				// пользователь уже не активен, блокировать некого
+				state.Failures = nil
+			}
+		}
+
+		var deadline time.Time
+		switch {
+		case state.BlockedAt != nil && policy.CoolOff > 0:
+			deadline = state.BlockedAt.Add(policy.CoolOff)
+		case state.BlockedAt != nil:
+			// без автоматической разблокировки следить больше не за чем
+			return nil
+		case len(state.Failures) > 0:
+			deadline = state.Failures[0].OccurredAt.Add(policy.Window)
+		default:
+			// сбои могли прийти, пока выполнялись активности
+			if drainFailures(signals, &state) {
+				continue
+			}
+			return nil
+		}
+
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		timerFired := false
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(signals, func(c workflow.ReceiveChannel, _ bool) {
+			var failure UserFailure
+			c.Receive(ctx, &failure)
+			// пока пользователь заблокирован, сбои не копятся
+			if state.BlockedAt == nil {
+				state.Failures = append(state.Failures, failure)
+			}
+		})
+		selector.AddFuture(workflow.NewTimer(timerCtx, deadline.Sub(workflow.Now(ctx))), func(workflow.Future) {
+			timerFired = true
+		})
+		selector.Select(ctx)
+		cancelTimer()
+
+		if timerFired && state.BlockedAt != nil {
+			err = unblockUser(ctx, userID)
+			if err != nil && !isUserNotFound(err) {
+				return err
+			}
+			logger.Info("User auto unblocked", "UserID", userID)
+			state = AutoBlockState{}
+		}
+
+		if workflow.GetInfo(ctx).GetContinueAsNewSuggested() {
+			drainFailures(signals, &state)
+			return workflow.NewContinueAsNewError(ctx, UserAutoBlockWorkflow, userID, policy, state)
+		}
+	}
+}
+
+// blockUser блокирует только активного пользователя: неподтвержденный уже Blocked, а удаленного трогать нельзя
+func blockUser(ctx workflow.Context, userID uuid.UUID, failures []UserFailure, policy AutoBlockPolicy) (*AutoBlockState, error) {
+	var user appmodel.User
+	err := workflow.ExecuteActivity(ctx, userServiceActivities.FindUser, userID).Get(ctx, &user)
+	if err != nil {
+		return nil, err
+	}
+	if model.UserStatus(user.Status) != model.Active {
+		return nil, nil
+	}
+
+	err = blockWithReason(ctx, userID, model.BlockReasonAutoBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedAt := workflow.Now(ctx)
+	last := failures[len(failures)-1]
+	return &AutoBlockState{
+		BlockedAt: &blockedAt,
+		Reason: fmt.Sprintf(
+			"%d failures within %s, last from %s: %s",
+			len(failures), policy.Window, last.Source, last.Reason,
+		),
+	}, nil
+}
+
+// unblockUser возвращает статус по тем же правилам, что и UserUpdatedWorkflow.
+// Если пользователя уже разблокировали, удалили или заблокировали вручную, ничего не меняет
+func unblockUser(ctx workflow.Context, userID uuid.UUID) error {
+	var user appmodel.User
+	err := workflow.ExecuteActivity(ctx, userServiceActivities.FindUser, userID).Get(ctx, &user)
+	if err != nil {
+		return err
+	}
+	if model.UserStatus(user.Status) != model.Blocked || model.BlockReason(user.BlockReason) != model.BlockReasonAutoBlock {
+		return nil
+	}
+	if !(user.Email != nil && user.EmailVerified) && !(user.Telegram != nil && user.TelegramVerified) {
+		return nil
+	}
+	return workflow.ExecuteActivity(ctx, userServiceActivities.SetUserStatus, userID, int(model.Active)).Get(ctx, nil)
+}
+
+// failuresInWindow отбрасывает устаревшие сбои и сортирует оставшиеся: события приходят не по порядку
+func failuresInWindow(failures []UserFailure, now time.Time, window time.Duration) []UserFailure {
+	var result []UserFailure
+	for _, failure := range failures {
+		if now.Sub(failure.OccurredAt) < window {
+			result = append(result, failure)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b UserFailure) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+	return result
+}
+
+// drainFailures забирает уже пришедшие сигналы, иначе они потеряются при ContinueAsNew или завершении.
+// Возвращает true, если сигналы были
+func drainFailures(signals workflow.ReceiveChannel, state *AutoBlockState) bool {
+	received := false
+	for {
+		var failure UserFailure
+		if !signals.ReceiveAsync(&failure) {
+			return received
+		}
+		received = true
+		if state.BlockedAt == nil {
+			state.Failures = append(state.Failures, failure)
+		}
+	}
+}
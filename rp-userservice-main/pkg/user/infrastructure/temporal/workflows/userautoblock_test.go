@@ -0,0 +1,88 @@
+package workflows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+
+	appmodel "userservice/pkg/user/application/model"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/temporal/activity"
+)
+
+// signalFailure отправляет сбой через delay после старта, как SignalUserFailure
+func signalFailure(env *testsuite.TestWorkflowEnvironment, delay time.Duration, reason string) {
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(UserFailureSignal, UserFailure{Source: "orderservice", Reason: reason, OccurredAt: env.Now()})
+	}, delay)
+}
+
+func TestUserAutoBlockWorkflow(t *testing.T) {
+	userID := uuid.New()
+	email := "user@example.com"
+	activeUser := appmodel.User{UserID: userID, Status: int(model.Active), Email: &email, EmailVerified: true}
+
+	t.Run("threshold_reached", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		policy := AutoBlockPolicy{Threshold: 2, Window: time.Hour}
+		signalFailure(env, 0, "payment failed")
+		signalFailure(env, 10*time.Minute, "payment failed")
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(activeUser, nil).Once()
+		env.OnActivity(userServiceActivities.BlockUser, mock.Anything, userID, string(model.BlockReasonAutoBlock)).Return(nil).Once()
+
+		env.ExecuteWorkflow(UserAutoBlockWorkflow, userID, policy, AutoBlockState{})
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("window_expired", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		policy := AutoBlockPolicy{Threshold: 3, Window: time.Hour}
+		// к третьему сбою первый уже вышел из окна
+		signalFailure(env, 0, "payment failed")
+		signalFailure(env, 40*time.Minute, "payment failed")
+		signalFailure(env, 80*time.Minute, "payment failed")
+
+		env.ExecuteWorkflow(UserAutoBlockWorkflow, userID, policy, AutoBlockState{})
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+		env.AssertActivityNotCalled(t, "BlockUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cool_off_unblocks", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		policy := AutoBlockPolicy{Threshold: 1, Window: time.Hour, CoolOff: 24 * time.Hour}
+		signalFailure(env, 0, "payment failed")
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(activeUser, nil).Once()
+		env.OnActivity(userServiceActivities.BlockUser, mock.Anything, userID, string(model.BlockReasonAutoBlock)).Return(nil).Once()
+		blockedUser := activeUser
+		blockedUser.Status = int(model.Blocked)
+		blockedUser.BlockReason = string(model.BlockReasonAutoBlock)
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(blockedUser, nil).Once()
+		env.OnActivity(userServiceActivities.SetUserStatus, mock.Anything, userID, int(model.Active)).Return(nil).Once()
+
+		start := env.Now()
+		env.ExecuteWorkflow(UserAutoBlockWorkflow, userID, policy, AutoBlockState{})
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+		assert.GreaterOrEqual(t, env.Now().Sub(start), policy.CoolOff)
+	})
+
+	t.Run("user_deleted", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		policy := AutoBlockPolicy{Threshold: 1, Window: time.Hour}
+		signalFailure(env, 0, "payment failed")
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).
+			Return(appmodel.User{}, temporal.NewNonRetryableApplicationError("user not found", activity.UserNotFoundErrorType, nil)).Once()
+
+		env.ExecuteWorkflow(UserAutoBlockWorkflow, userID, policy, AutoBlockState{})
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+}
@@ -3,15 +3,27 @@ package workflows
 import (
 	"time"
 
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
-func UserDeletedWorkflow(ctx workflow.Context, userID string) error {
+// defaultRetentionPeriod - срок хранения для workflow, запущенных до появления настройки
+const defaultRetentionPeriod = 30 * time.Second
+
+func UserDeletedWorkflow(ctx workflow.Context, userID string, retentionPeriod time.Duration) error {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting UserDeletedWorkflow", "UserID", userID)
 
-	err := workflow.Sleep(ctx, 30*time.Second)
+	if retentionPeriod <= 0 {
+		retentionPeriod = defaultRetentionPeriod
+	}
+
+	err := workflow.Sleep(ctx, retentionPeriod)
 	if err != nil {
+		// RestoreUser отменяет workflow
+		if temporal.IsCanceledError(err) {
+			logger.Info("User restored, hard delete canceled", "UserID", userID)
+		}
 		return err
 	}
 
@@ -19,8 +31,11 @@ func UserDeletedWorkflow(ctx workflow.Context, userID string) error {
 		StartToCloseTimeout: time.Minute,
 	})
 
+	// удаленный позже пользователь - это повторное удаление после восстановления, его срок хранения еще идет.
+	// Так workflow, который не отменили при восстановлении, не удалит пользователя раньше срока
+	deletedBefore := workflow.Now(ctx).Add(-retentionPeriod)
 	logger.Info("Executing Hard Delete", "UserID", userID)
-	err = workflow.ExecuteActivity(ctx, userServiceActivities.HardDeleteUser, userID).Get(ctx, nil)
+	err = workflow.ExecuteActivity(ctx, userServiceActivities.HardDeleteUser, userID, deletedBefore).Get(ctx, nil)
 	if err != nil {
 		logger.Error("Failed to hard delete user", "Error", err)
 		return err
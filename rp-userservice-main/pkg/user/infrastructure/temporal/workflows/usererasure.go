@@ -0,0 +1,56 @@
+package workflows
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"userservice/pkg/user/infrastructure/temporal/activity"
+	"userservice/pkg/user/infrastructure/userdata"
+)
+
+var userDataActivities *activity.UserDataActivities
+
+// UserErasureWorkflow удаляет данные окончательно удаленного пользователя во всех сервисах.
+// Сервис может быть недоступен долго, поэтому активности повторяются до успеха в пределах суток
+func UserErasureWorkflow(ctx workflow.Context, userID uuid.UUID) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting UserErasureWorkflow", "UserID", userID)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToCloseTimeout: 24 * time.Hour,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    10 * time.Minute,
+		},
+	})
+
+	futures := make([]workflow.Future, len(userdata.Services))
+	for i, service := range userdata.Services {
+		futures[i] = workflow.ExecuteActivity(ctx, userDataActivities.EraseUserData, service, userID)
+	}
+
+	var erasureErr error
+	for i, future := range futures {
+		err := future.Get(ctx, nil)
+		if err != nil {
+			logger.Error("Failed to erase user data", "Service", userdata.Services[i], "Error", err)
+			erasureErr = err
+		}
+	}
+	if erasureErr != nil {
+		return erasureErr
+	}
+
+	err := workflow.ExecuteActivity(ctx, userDataActivities.ConfirmLocalErasure, userID).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("User data erased in all services", "UserID", userID)
+	return nil
+}
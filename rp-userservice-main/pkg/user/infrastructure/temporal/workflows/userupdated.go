@@ -4,6 +4,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 
 	appmodel "userservice/pkg/user/application/model"
@@ -15,7 +17,9 @@ var userServiceActivities *activity.UserServiceActivities
 
 func UserUpdatedWorkflow(ctx workflow.Context, event model.UserUpdated) error {
 	contactInfoChanged := (event.UpdatedFields != nil && (event.UpdatedFields.Telegram != nil ||
-		event.UpdatedFields.Email != nil)) ||
+		event.UpdatedFields.Email != nil ||
+		event.UpdatedFields.TelegramVerified != nil ||
+		event.UpdatedFields.EmailVerified != nil)) ||
 		(event.RemovedFields != nil && (event.RemovedFields.Telegram != nil || event.RemovedFields.Email != nil))
 
 	if !contactInfoChanged {
@@ -26,23 +30,44 @@ func UserUpdatedWorkflow(ctx workflow.Context, event model.UserUpdated) error {
 		StartToCloseTimeout: time.Minute,
 	})
 
+	// новый контакт нужно подтвердить кодом. Подтверждение запускается отсюда, а не из обработчика события,
+	// чтобы сбой запуска повторялся вместе с workflow, а не терялся
+	if workflow.GetVersion(ctx, contactVerificationVersion, workflow.DefaultVersion, 1) != workflow.DefaultVersion {
+		err := startContactVerification(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
 	var user appmodel.User
 	err := workflow.ExecuteActivity(ctx, userServiceActivities.FindUser, event.UserID).Get(ctx, &user)
 	if err != nil {
-		if errors.Is(err, model.ErrUserNotFound) {
+		if isUserNotFound(err) {
 			return nil
 		}
 		return err
 	}
 
-	status := model.Blocked
-	if user.Email != nil || user.Telegram != nil {
-		status = model.Active
+	// статус по контактам меняется только у активного и у заблокированного до подтверждения контакта.
+	// Блокировку вручную или автоблокировкой смена контакта не снимает
+	switch model.UserStatus(user.Status) {
+	case model.Active:
+	case model.Blocked:
+		if model.BlockReason(user.BlockReason) != model.BlockReasonUnverifiedContacts {
+			return nil
+		}
+	default:
+		return nil
 	}
 
-	err = workflow.ExecuteActivity(ctx, userServiceActivities.SetUserStatus, event.UserID, int(status)).Get(ctx, nil)
+	// активным пользователь становится только после подтверждения хотя бы одного контакта
+	if (user.Email != nil && user.EmailVerified) || (user.Telegram != nil && user.TelegramVerified) {
+		err = workflow.ExecuteActivity(ctx, userServiceActivities.SetUserStatus, event.UserID, int(model.Active)).Get(ctx, nil)
+	} else {
+		err = blockWithReason(ctx, event.UserID, model.BlockReasonUnverifiedContacts)
+	}
 	if err != nil {
-		if errors.Is(err, model.ErrUserNotFound) {
+		if isUserNotFound(err) {
 			return nil
 		}
 		return err
@@ -50,3 +75,35 @@ func UserUpdatedWorkflow(ctx workflow.Context, event model.UserUpdated) error {
 
 	return nil
 }
+
+// isUserNotFound - ошибка активности приходит в workflow как ActivityError, errors.Is с доменной ошибкой не сработает
+func isUserNotFound(err error) bool {
+	var appErr *temporal.ApplicationError
+	return errors.As(err, &appErr) && appErr.Type() == activity.UserNotFoundErrorType
+}
+
+// contactVerificationVersion - с этой версии workflow сам запускает подтверждение нового контакта
+const contactVerificationVersion = "contact_verification"
+
+func startContactVerification(ctx workflow.Context, event model.UserUpdated) error {
+	if event.UpdatedFields != nil && event.UpdatedFields.Email != nil {
+		err := workflow.ExecuteActivity(ctx, userServiceActivities.StartContactVerification, event.UserID, int(model.EmailContact)).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+	if event.UpdatedFields != nil && event.UpdatedFields.Telegram != nil {
+		return workflow.ExecuteActivity(ctx, userServiceActivities.StartContactVerification, event.UserID, int(model.TelegramContact)).Get(ctx, nil)
+	}
+	return nil
+}
+
+// blockReasonVersion - с этой версии блокировка сохраняет причину у пользователя
+const blockReasonVersion = "block_reason"
+
+func blockWithReason(ctx workflow.Context, userID uuid.UUID, reason model.BlockReason) error {
+	if workflow.GetVersion(ctx, blockReasonVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return workflow.ExecuteActivity(ctx, userServiceActivities.SetUserStatus, userID, int(model.Blocked)).Get(ctx, nil)
+	}
+	return workflow.ExecuteActivity(ctx, userServiceActivities.BlockUser, userID, string(reason)).Get(ctx, nil)
+}
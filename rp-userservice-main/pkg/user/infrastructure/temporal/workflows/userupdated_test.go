@@ -0,0 +1,138 @@
+package workflows
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+
+	appmodel "userservice/pkg/user/application/model"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/temporal/activity"
+)
+
+func newUserUpdatedTestEnv(t *testing.T) *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&activity.UserServiceActivities{})
+	env.SetTestTimeout(10 * time.Second)
+	t.Cleanup(func() {
+		env.AssertExpectations(t)
+	})
+	return env
+}
+
+func emailChangedEvent(userID uuid.UUID, email string) model.UserUpdated {
+	event := model.UserUpdated{UserID: userID, UpdatedAt: time.Now()}
+	event.UpdatedFields = &struct {
+		Status           *model.UserStatus
+		Email            *string
+		Telegram         *string
+		EmailVerified    *bool
+		TelegramVerified *bool
+		Roles            *[]model.Role
+	}{Email: &email}
+	return event
+}
+
+func TestUserUpdatedWorkflow_StartsContactVerification(t *testing.T) {
+	userID := uuid.New()
+	email := "new@example.com"
+
+	t.Run("started", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).Return(nil).Once()
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(appmodel.User{UserID: userID, Status: int(model.Active), Email: &email}, nil)
+		env.OnActivity(userServiceActivities.BlockUser, mock.Anything, userID, string(model.BlockReasonUnverifiedContacts)).Return(nil).Once()
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("start_failure_retried", func(t *testing.T) {
+		// сбой запуска подтверждения не теряется: активность повторяется, пока запуск не удастся
+		env := newUserUpdatedTestEnv(t)
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).
+			Return(errors.New("temporal unavailable")).Once()
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).Return(nil).Once()
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(appmodel.User{UserID: userID, Status: int(model.Active), Email: &email}, nil)
+		env.OnActivity(userServiceActivities.BlockUser, mock.Anything, userID, string(model.BlockReasonUnverifiedContacts)).Return(nil)
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("start_failed_permanently", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).
+			Return(temporal.NewNonRetryableApplicationError("rejected", "test", nil))
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.Error(t, env.GetWorkflowError())
+	})
+
+	t.Run("started_before_versioning", func(t *testing.T) {
+		// workflow, начатые до появления шага, при воспроизведении его не выполняют
+		env := newUserUpdatedTestEnv(t)
+		env.OnGetVersion(contactVerificationVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(appmodel.User{UserID: userID, Status: int(model.Active), Email: &email}, nil)
+		env.OnActivity(userServiceActivities.BlockUser, mock.Anything, userID, string(model.BlockReasonUnverifiedContacts)).Return(nil).Once()
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+		env.AssertActivityNotCalled(t, "StartContactVerification", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUpdatedWorkflow_KeepsBlockReason(t *testing.T) {
+	userID := uuid.New()
+	email := "new@example.com"
+	telegram := "@user"
+
+	t.Run("auto_blocked_stays_blocked", func(t *testing.T) {
+		// подтвержденный telegram остался, но автоблокировку смена email не снимает
+		env := newUserUpdatedTestEnv(t)
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).Return(nil).Once()
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(appmodel.User{
+			UserID:           userID,
+			Status:           int(model.Blocked),
+			BlockReason:      string(model.BlockReasonAutoBlock),
+			Email:            &email,
+			Telegram:         &telegram,
+			TelegramVerified: true,
+		}, nil)
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("unverified_activated", func(t *testing.T) {
+		env := newUserUpdatedTestEnv(t)
+		env.OnActivity(userServiceActivities.StartContactVerification, mock.Anything, userID, int(model.EmailContact)).Return(nil).Once()
+		env.OnActivity(userServiceActivities.FindUser, mock.Anything, userID).Return(appmodel.User{
+			UserID:           userID,
+			Status:           int(model.Blocked),
+			BlockReason:      string(model.BlockReasonUnverifiedContacts),
+			Email:            &email,
+			Telegram:         &telegram,
+			TelegramVerified: true,
+		}, nil)
+		env.OnActivity(userServiceActivities.SetUserStatus, mock.Anything, userID, int(model.Active)).Return(nil).Once()
+
+		env.ExecuteWorkflow(UserUpdatedWorkflow, emailChangedEvent(userID, email))
+		require.True(t, env.IsWorkflowCompleted())
+		assert.NoError(t, env.GetWorkflowError())
+	})
+}
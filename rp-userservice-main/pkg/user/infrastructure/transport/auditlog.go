@@ -0,0 +1,27 @@
+package transport
+
+import (
+	"userservice/api/server/userinternal"
+	appmodel "userservice/pkg/user/application/model"
+)
+
+func toAuditRecords(records []appmodel.AuditRecord) []*userinternal.AuditRecord {
+	result := make([]*userinternal.AuditRecord, len(records))
+	for i, record := range records {
+		result[i] = &userinternal.AuditRecord{
+			RecordID:      record.RecordID,
+			Actor:         record.Actor,
+			Method:        record.Method,
+			EntityType:    record.EntityType,
+			EntityID:      record.EntityID,
+			Before:        record.Before,
+			After:         record.After,
+			CorrelationID: record.CorrelationID,
+			CreatedAt:     record.CreatedAt.Unix(),
+			PrevHash:      record.PrevHash,
+			Hash:          record.Hash,
+			Valid:         record.Valid,
+		}
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package transport
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"userservice/pkg/user/infrastructure/transport/middlewares"
+)
+
+func parseUUID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, invalidArgumentError(field, err.Error())
+	}
+	return id, nil
+}
+
+func invalidArgumentError(field, description string) error {
+	st, err := status.New(codes.InvalidArgument, "invalid "+field).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: "INVALID_ARGUMENT",
+			Domain: middlewares.ErrorDomain,
+		},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: description},
+			},
+		},
+	)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid "+field)
+	}
+	return st.Err()
+}
@@ -2,28 +2,65 @@ package transport
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 
 	"userservice/api/server/userinternal"
 	appmodel "userservice/pkg/user/application/model"
 	"userservice/pkg/user/application/query"
 	"userservice/pkg/user/application/service"
+	"userservice/pkg/user/domain/model"
+	"userservice/pkg/user/infrastructure/temporal"
+	"userservice/pkg/user/infrastructure/userdata"
 )
 
+const (
+	userDataServiceName = "userservice"
+	auditEntityUser     = "user"
+)
+
+// MethodPermissions - права, нужные для вызова методов UserInternalService.
+// Первого администратора назначает команда assign-role, а не этот API
+var MethodPermissions = map[string]string{
+	"AssignRole": string(model.PermissionManageRoles),
+	"RevokeRole": string(model.PermissionManageRoles),
+}
+
 func NewUserInternalAPI(
 	userQueryService query.UserQueryService,
+	auditLogQueryService query.AuditLogQueryService,
+	userErasureQueryService query.UserErasureQueryService,
 	userService service.UserService,
+	contactVerificationService service.ContactVerificationService,
+	authService service.AuthService,
+	workflowService temporal.WorkflowService,
+	userDataClients userdata.Clients,
 ) userinternal.UserInternalServiceServer {
 	return &userInternalAPI{
-		userQueryService: userQueryService,
-		userService:      userService,
+		userQueryService:           userQueryService,
+		auditLogQueryService:       auditLogQueryService,
+		userErasureQueryService:    userErasureQueryService,
+		userService:                userService,
+		contactVerificationService: contactVerificationService,
+		authService:                authService,
+		workflowService:            workflowService,
+		userDataClients:            userDataClients,
 	}
 }
 
 type userInternalAPI struct {
-	userQueryService query.UserQueryService
-	userService      service.UserService
+	userQueryService           query.UserQueryService
+	auditLogQueryService       query.AuditLogQueryService
+	userErasureQueryService    query.UserErasureQueryService
+	userService                service.UserService
+	contactVerificationService service.ContactVerificationService
+	authService                service.AuthService
+	workflowService            temporal.WorkflowService
+	userDataClients            userdata.Clients
 
 	userinternal.UnimplementedUserInternalServiceServer
 }
@@ -34,7 +71,7 @@ func (u userInternalAPI) StoreUser(ctx context.Context, request *userinternal.St
 		err    error
 	)
 	if request.User.UserID != "" {
-		userID, err = uuid.Parse(request.User.UserID)
+		userID, err = parseUUID("user.userID", request.User.UserID)
 		if err != nil {
 			return nil, err
 		}
@@ -56,8 +93,58 @@ func (u userInternalAPI) StoreUser(ctx context.Context, request *userinternal.St
 	}, nil
 }
 
+func (u userInternalAPI) PatchUser(ctx context.Context, request *userinternal.PatchUserRequest) (*userinternal.PatchUserResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := appmodel.UserPatch{
+		UserID:          userID,
+		ExpectedVersion: request.ExpectedVersion,
+	}
+	for _, path := range request.UpdateMask {
+		switch path {
+		case "status":
+			if request.Status == nil {
+				return nil, invalidArgumentError("status", "status is in updateMask but not set")
+			}
+			patch.SetStatus = true
+			patch.Status = int(*request.Status)
+		case "email":
+			patch.SetEmail = true
+			patch.Email = request.Email
+		case "telegram":
+			patch.SetTelegram = true
+			patch.Telegram = request.Telegram
+		default:
+			return nil, invalidArgumentError("updateMask", "unknown path "+path)
+		}
+	}
+
+	user, err := u.userService.PatchUser(ctx, patch)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.PatchUserResponse{
+		User: toUser(user),
+	}, nil
+}
+
+func (u userInternalAPI) ChangeLogin(ctx context.Context, request *userinternal.ChangeLoginRequest) (*userinternal.ChangeLoginResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.userService.ChangeLogin(ctx, userID, request.Login)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.ChangeLoginResponse{}, nil
+}
+
 func (u userInternalAPI) FindUser(ctx context.Context, request *userinternal.FindUserRequest) (*userinternal.FindUserResponse, error) {
-	userID, err := uuid.Parse(request.UserID)
+	userID, err := parseUUID("userID", request.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +156,330 @@ func (u userInternalAPI) FindUser(ctx context.Context, request *userinternal.Fin
 		return &userinternal.FindUserResponse{}, nil
 	}
 	return &userinternal.FindUserResponse{
-		User: &userinternal.User{
-			UserID:   userID.String(),
-			Status:   userinternal.UserStatus(user.Status), // nolint:gosec
-			Login:    user.Login,
-			Email:    user.Email,
-			Telegram: user.Telegram,
+		User: toUser(*user),
+	}, nil
+}
+
+func (u userInternalAPI) FindUsers(ctx context.Context, request *userinternal.FindUsersRequest) (*userinternal.FindUsersResponse, error) {
+	userIDs := make([]uuid.UUID, 0, len(request.UserIDs))
+	for _, value := range request.UserIDs {
+		userID, err := parseUUID("userIDs", value)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	users, err := u.userQueryService.FindUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &userinternal.FindUsersResponse{
+		Users: make([]*userinternal.User, len(users)),
+	}
+	found := make(map[uuid.UUID]struct{}, len(users))
+	for i, user := range users {
+		response.Users[i] = toUser(user)
+		found[user.UserID] = struct{}{}
+	}
+	for _, userID := range userIDs {
+		if _, ok := found[userID]; !ok {
+			response.MissingUserIDs = append(response.MissingUserIDs, userID.String())
+		}
+	}
+	return response, nil
+}
+
+func (u userInternalAPI) ListUsers(ctx context.Context, request *userinternal.ListUsersRequest) (*userinternal.ListUsersResponse, error) {
+	spec := appmodel.ListUsersSpec{
+		LoginPrefix: request.LoginPrefix,
+		EmailPrefix: request.EmailPrefix,
+		Limit:       listUsersPageSize(request.PageSize),
+	}
+	for _, status := range request.Statuses {
+		spec.Statuses = append(spec.Statuses, int(status))
+	}
+	if request.CreatedFrom != nil {
+		createdFrom := time.Unix(*request.CreatedFrom, 0)
+		spec.CreatedFrom = &createdFrom
+	}
+	if request.CreatedBefore != nil {
+		createdBefore := time.Unix(*request.CreatedBefore, 0)
+		spec.CreatedBefore = &createdBefore
+	}
+	if request.PageToken != "" {
+		cursor, err := decodePageToken(request.PageToken)
+		if err != nil {
+			return nil, invalidArgumentError("pageToken", err.Error())
+		}
+		spec.After = &cursor
+	}
+
+	list, err := u.userQueryService.ListUsers(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*userinternal.User, len(list.Users))
+	for i, user := range list.Users {
+		users[i] = toUser(user)
+	}
+	response := &userinternal.ListUsersResponse{
+		Users: users,
+	}
+	if list.Next != nil {
+		response.NextPageToken = encodePageToken(*list.Next)
+	}
+	return response, nil
+}
+
+func (u userInternalAPI) FindAuditLog(ctx context.Context, request *userinternal.FindAuditLogRequest) (*userinternal.FindAuditLogResponse, error) {
+	records, err := u.auditLogQueryService.FindAuditLog(ctx, request.EntityType, request.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.FindAuditLogResponse{
+		Records: toAuditRecords(records),
+	}, nil
+}
+
+func (u userInternalAPI) ConfirmContact(ctx context.Context, request *userinternal.ConfirmContactRequest) (*userinternal.ConfirmContactResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.contactVerificationService.ConfirmContact(ctx, userID, int(request.ContactType), request.Code)
+	if err != nil {
+		return nil, err
+	}
+	// контакт уже подтвержден, без сигнала workflow просто завершится по истечении кода
+	_ = u.workflowService.SignalContactConfirmed(ctx, userID, int(request.ContactType))
+	return &userinternal.ConfirmContactResponse{}, nil
+}
+
+func (u userInternalAPI) RestoreUser(ctx context.Context, request *userinternal.RestoreUserRequest) (*userinternal.RestoreUserResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.userService.RestoreUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// workflow все равно не удалит восстановленного пользователя, поэтому ошибку отмены не возвращаем
+	_ = u.workflowService.CancelUserDeletedWorkflow(ctx, userID)
+	return &userinternal.RestoreUserResponse{}, nil
+}
+
+func (u userInternalAPI) AssignRole(ctx context.Context, request *userinternal.AssignRoleRequest) (*userinternal.AssignRoleResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.userService.AssignRole(ctx, userID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.AssignRoleResponse{}, nil
+}
+
+func (u userInternalAPI) RevokeRole(ctx context.Context, request *userinternal.RevokeRoleRequest) (*userinternal.RevokeRoleResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.userService.RevokeRole(ctx, userID, request.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.RevokeRoleResponse{}, nil
+}
+
+type exportedUser struct {
+	UserID           string   `json:"user_id"`
+	Status           string   `json:"status"`
+	Login            string   `json:"login"`
+	Email            *string  `json:"email,omitempty"`
+	Telegram         *string  `json:"telegram,omitempty"`
+	EmailVerified    bool     `json:"email_verified"`
+	TelegramVerified bool     `json:"telegram_verified"`
+	Roles            []string `json:"roles,omitempty"`
+}
+
+type exportedUserChange struct {
+	Method    string           `json:"method"`
+	Before    *json.RawMessage `json:"before,omitempty"`
+	After     *json.RawMessage `json:"after,omitempty"`
+	CreatedAt int64            `json:"created_at"`
+}
+
+type exportedUserData struct {
+	User    exportedUser         `json:"user"`
+	History []exportedUserChange `json:"history"`
+}
+
+func (u userInternalAPI) ExportUserData(ctx context.Context, request *userinternal.ExportUserDataRequest) (*userinternal.ExportUserDataResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userQueryService.FindUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := u.auditLogQueryService.FindAuditLog(ctx, auditEntityUser, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	exported := exportedUserData{
+		User: exportedUser{
+			UserID:           user.UserID.String(),
+			Status:           userinternal.UserStatus(user.Status).String(), // nolint:gosec
+			Login:            user.Login,
+			Email:            user.Email,
+			Telegram:         user.Telegram,
+			EmailVerified:    user.EmailVerified,
+			TelegramVerified: user.TelegramVerified,
+			Roles:            user.Roles,
 		},
+		History: make([]exportedUserChange, len(records)),
+	}
+	for i, record := range records {
+		exported.History[i] = exportedUserChange{
+			Method:    record.Method,
+			Before:    toRawJSON(record.Before),
+			After:     toRawJSON(record.After),
+			CreatedAt: record.CreatedAt.Unix(),
+		}
+	}
+	ownData, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	bundle, err := u.userDataClients.Export(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	bundle[userDataServiceName] = ownData
+
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &userinternal.ExportUserDataResponse{
+		Data: string(data),
 	}, nil
 }
+
+func (u userInternalAPI) EraseUser(ctx context.Context, request *userinternal.EraseUserRequest) (*userinternal.EraseUserResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// данные в остальных сервисах удалит workflow по событию окончательного удаления
+	err = u.userService.DeleteUser(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.EraseUserResponse{}, nil
+}
+
+func (u userInternalAPI) FindUserErasure(ctx context.Context, request *userinternal.FindUserErasureRequest) (*userinternal.FindUserErasureResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	erasures, err := u.userErasureQueryService.FindUserErasures(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*userinternal.UserErasure, len(erasures))
+	for i, erasure := range erasures {
+		result[i] = &userinternal.UserErasure{
+			Service:  erasure.Service,
+			ErasedAt: erasure.ErasedAt,
+		}
+	}
+	return &userinternal.FindUserErasureResponse{
+		Erasures: result,
+	}, nil
+}
+
+func (u userInternalAPI) SetPassword(ctx context.Context, request *userinternal.SetPasswordRequest) (*userinternal.SetPasswordResponse, error) {
+	userID, err := parseUUID("userID", request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	err = u.authService.SetPassword(ctx, userID, request.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.SetPasswordResponse{}, nil
+}
+
+func (u userInternalAPI) Authenticate(ctx context.Context, request *userinternal.AuthenticateRequest) (*userinternal.AuthenticateResponse, error) {
+	session, err := u.authService.Authenticate(ctx, request.Login, request.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.AuthenticateResponse{
+		Session: toSession(session),
+	}, nil
+}
+
+func (u userInternalAPI) RefreshSession(ctx context.Context, request *userinternal.RefreshSessionRequest) (*userinternal.RefreshSessionResponse, error) {
+	session, err := u.authService.RefreshSession(ctx, request.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.RefreshSessionResponse{
+		Session: toSession(session),
+	}, nil
+}
+
+func (u userInternalAPI) RevokeSession(ctx context.Context, request *userinternal.RevokeSessionRequest) (*userinternal.RevokeSessionResponse, error) {
+	err := u.authService.RevokeSession(ctx, request.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &userinternal.RevokeSessionResponse{}, nil
+}
+
+func toSession(session appmodel.Session) *userinternal.Session {
+	return &userinternal.Session{
+		UserID:                session.UserID.String(),
+		AccessToken:           session.AccessToken,
+		AccessTokenExpiresAt:  session.AccessTokenExpiresAt,
+		RefreshToken:          session.RefreshToken,
+		RefreshTokenExpiresAt: session.RefreshTokenExpiresAt,
+	}
+}
+
+func toUser(user appmodel.User) *userinternal.User {
+	return &userinternal.User{
+		UserID:           user.UserID.String(),
+		Status:           userinternal.UserStatus(user.Status), // nolint:gosec
+		Login:            user.Login,
+		Email:            user.Email,
+		Telegram:         user.Telegram,
+		EmailVerified:    user.EmailVerified,
+		TelegramVerified: user.TelegramVerified,
+		CreatedAt:        user.CreatedAt,
+		Roles:            user.Roles,
+		Version:          user.Version,
+	}
+}
+
+func toRawJSON(value *string) *json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	raw := json.RawMessage(*value)
+	return &raw
+}
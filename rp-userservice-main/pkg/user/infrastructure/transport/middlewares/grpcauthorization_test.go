@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"userservice/pkg/user/domain/model"
+)
+
+type stubAuthorizer map[uuid.UUID][]string
+
+func (a stubAuthorizer) Authorize(_ context.Context, userID uuid.UUID, permission string) error {
+	for _, granted := range a[userID] {
+		if granted == permission {
+			return nil
+		}
+	}
+	return model.ErrPermissionDenied
+}
+
+func TestGRPCAuthorizationMiddleware(t *testing.T) {
+	manager := uuid.New()
+	customer := uuid.New()
+	authorizer := stubAuthorizer{manager: {"roles.manage"}}
+	middleware := NewGRPCAuthorizationMiddleware(authorizer, map[string]string{"AssignRole": "roles.manage"})
+
+	tests := []struct {
+		name   string
+		method string
+		userID string
+		err    error
+	}{
+		{name: "granted", method: "/User.UserInternalService/AssignRole", userID: manager.String()},
+		{name: "denied", method: "/User.UserInternalService/AssignRole", userID: customer.String(), err: model.ErrPermissionDenied},
+		{name: "no_user", method: "/User.UserInternalService/AssignRole", err: model.ErrPermissionDenied},
+		{name: "invalid_user", method: "/User.UserInternalService/AssignRole", userID: "admin", err: model.ErrPermissionDenied},
+		{name: "unprotected_method", method: "/User.UserInternalService/FindUser"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.userID != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(UserIDMetadataKey, tt.userID))
+			}
+			called := false
+			_, err := middleware(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.False(t, called)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, called)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"userservice/pkg/user/infrastructure/audit"
+)
+
+// NewGRPCCallerMiddleware передает другим сервисам имя вызывающего и correlation id,
+// чтобы их журналы аудита связывались с изменением, из-за которого был вызов
+func NewGRPCCallerMiddleware(callerID string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, CallerIDMetadataKey, callerID)
+		if correlationID := audit.InfoFromContext(ctx).CorrelationID; correlationID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, CorrelationIDMetadataKey, correlationID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"userservice/pkg/user/domain/model"
+)
+
+const ErrorDomain = "userservice"
+
+type domainError struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var domainErrors = []domainError{
+	{err: model.ErrUserNotFound, code: codes.NotFound, reason: "USER_NOT_FOUND"},
+	{err: model.ErrUserLoginAlreadyUsed, code: codes.AlreadyExists, reason: "USER_LOGIN_ALREADY_USED"},
+	{err: model.ErrUserLoginReserved, code: codes.AlreadyExists, reason: "USER_LOGIN_RESERVED"},
+	{err: model.ErrUserEmailAlreadyUsed, code: codes.AlreadyExists, reason: "USER_EMAIL_ALREADY_USED"},
+	{err: model.ErrUserTelegramAlreadyUsed, code: codes.AlreadyExists, reason: "USER_TELEGRAM_ALREADY_USED"},
+	{err: model.ErrUnknownRole, code: codes.InvalidArgument, reason: "UNKNOWN_ROLE"},
+	{err: model.ErrUserVersionMismatch, code: codes.Aborted, reason: "USER_VERSION_MISMATCH"},
+	{err: model.ErrUserNotDeleted, code: codes.FailedPrecondition, reason: "USER_NOT_DELETED"},
+	{err: model.ErrUserDeleted, code: codes.FailedPrecondition, reason: "USER_DELETED"},
+	{err: model.ErrPermissionDenied, code: codes.PermissionDenied, reason: "PERMISSION_DENIED"},
+	{err: model.ErrUserHardDeleted, code: codes.FailedPrecondition, reason: "USER_HARD_DELETED"},
+	{err: model.ErrContactNotFound, code: codes.NotFound, reason: "CONTACT_NOT_FOUND"},
+	{err: model.ErrContactAlreadyVerified, code: codes.FailedPrecondition, reason: "CONTACT_ALREADY_VERIFIED"},
+	{err: model.ErrContactVerificationNotFound, code: codes.NotFound, reason: "CONTACT_VERIFICATION_NOT_FOUND"},
+	{err: model.ErrContactVerificationExpired, code: codes.FailedPrecondition, reason: "CONTACT_VERIFICATION_EXPIRED"},
+	{err: model.ErrInvalidVerificationCode, code: codes.InvalidArgument, reason: "INVALID_VERIFICATION_CODE"},
+	{err: model.ErrTooManyVerificationAttempts, code: codes.ResourceExhausted, reason: "TOO_MANY_VERIFICATION_ATTEMPTS"},
+	{err: model.ErrUnknownContactType, code: codes.InvalidArgument, reason: "UNKNOWN_CONTACT_TYPE"},
+	{err: model.ErrInvalidCredentials, code: codes.Unauthenticated, reason: "INVALID_CREDENTIALS"},
+	{err: model.ErrUserNotActive, code: codes.PermissionDenied, reason: "USER_NOT_ACTIVE"},
+	{err: model.ErrSessionNotFound, code: codes.Unauthenticated, reason: "SESSION_NOT_FOUND"},
+	{err: model.ErrSessionExpired, code: codes.Unauthenticated, reason: "SESSION_EXPIRED"},
+	{err: model.ErrSessionRevoked, code: codes.Unauthenticated, reason: "SESSION_REVOKED"},
+}
+
+func NewGRPCErrorsMiddleware() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toStatusError(err)
+		}
+		return resp, nil
+	}
+}
+
+func toStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, e := range domainErrors {
+		if errors.Is(err, e.err) {
+			return newStatusError(e.code, e.reason, e.err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		// детали внутренних ошибок наружу не отдаем, они есть в логах
+		return newStatusError(codes.Internal, "INTERNAL", "internal error")
+	}
+}
+
+func newStatusError(code codes.Code, reason, message string) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: ErrorDomain,
+	})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -6,8 +6,14 @@ import (
 
 	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"userservice/api/server/userinternal"
 )
 
+const redactedValue = "[REDACTED]"
+
 func NewGRPCLoggingMiddleware(logger logging.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		start := time.Now()
@@ -15,7 +21,7 @@ func NewGRPCLoggingMiddleware(logger logging.Logger) grpc.UnaryServerInterceptor
 		resp, err = handler(ctx, req)
 
 		fields := logging.Fields{
-			"args":     req,
+			"args":     redactSensitive(req),
 			"duration": time.Since(start).String(),
 			"method":   info.FullMethod,
 		}
@@ -29,3 +35,43 @@ func NewGRPCLoggingMiddleware(logger logging.Logger) grpc.UnaryServerInterceptor
 		return resp, err
 	}
 }
+
+// redactSensitive возвращает копию запроса, в которой поля с правилом sensitive заменены заглушкой
+func redactSensitive(req interface{}) interface{} {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return req
+	}
+	clone := proto.Clone(msg)
+	redactMessage(clone.ProtoReflect())
+	return clone
+}
+
+func redactMessage(msg protoreflect.Message) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !msg.Has(fd) {
+			continue
+		}
+		if rules, ok := proto.GetExtension(fd.Options(), userinternal.E_Rules).(*userinternal.FieldRules); ok && rules.GetSensitive() {
+			if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+				msg.Set(fd, protoreflect.ValueOfString(redactedValue))
+			} else {
+				msg.Clear(fd)
+			}
+			continue
+		}
+		if fd.Message() == nil || fd.IsMap() {
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			for j := 0; j < list.Len(); j++ {
+				redactMessage(list.Get(j).Message())
+			}
+			continue
+		}
+		redactMessage(msg.Get(fd).Message())
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"userservice/api/server/userinternal"
+)
+
+func TestRedactSensitive(t *testing.T) {
+	request := &userinternal.SetPasswordRequest{
+		UserID:   "0b8e6f4e-8c3a-4d55-a7a4-4a3e0e3a6b11",
+		Password: "secret-password",
+	}
+
+	redacted := redactSensitive(request).(*userinternal.SetPasswordRequest)
+	assert.Equal(t, redactedValue, redacted.Password)
+	assert.Equal(t, request.UserID, redacted.UserID)
+	// исходный запрос уходит в обработчик, поэтому его менять нельзя
+	assert.Equal(t, "secret-password", request.Password)
+}
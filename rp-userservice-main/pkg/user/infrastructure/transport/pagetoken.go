@@ -0,0 +1,55 @@
+package transport
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	appmodel "userservice/pkg/user/application/model"
+)
+
+const (
+	defaultListUsersPageSize = 50
+	maxListUsersPageSize     = 500
+)
+
+var errInvalidPageToken = errors.New("malformed page token")
+
+func listUsersPageSize(pageSize int32) int {
+	if pageSize <= 0 {
+		return defaultListUsersPageSize
+	}
+	return min(int(pageSize), maxListUsersPageSize)
+}
+
+// page token непрозрачен для клиента: время создания и userID последней записи страницы
+func encodePageToken(cursor appmodel.UserCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(cursor.CreatedAt.Unix(), 10) + ":" + cursor.UserID.String()))
+}
+
+func decodePageToken(token string) (appmodel.UserCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return appmodel.UserCursor{}, errInvalidPageToken
+	}
+	createdAtStr, userIDStr, ok := strings.Cut(string(data), ":")
+	if !ok {
+		return appmodel.UserCursor{}, errInvalidPageToken
+	}
+	createdAt, err := strconv.ParseInt(createdAtStr, 10, 64)
+	if err != nil {
+		return appmodel.UserCursor{}, errInvalidPageToken
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return appmodel.UserCursor{}, errInvalidPageToken
+	}
+	return appmodel.UserCursor{
+		CreatedAt: time.Unix(createdAt, 0),
+		UserID:    userID,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"userservice/api/client/userdatainternal"
+)
+
+const (
+	ProductService      = "productservice"
+	OrderService        = "orderservice"
+	PaymentService      = "paymentservice"
+	NotificationService = "notificationservice"
+)
+
+// Services - сервисы, в которых нужно выгружать и удалять данные пользователя
+var Services = []string{ProductService, OrderService, PaymentService, NotificationService}
+
+var ErrUnknownService = errors.New("unknown user data service")
+
+// Clients - клиенты UserDataInternalService по имени сервиса
+type Clients map[string]userdatainternal.UserDataInternalServiceClient
+
+// Export собирает данные пользователя из всех сервисов параллельно, данные каждого сервиса - JSON как есть
+func (c Clients) Export(ctx context.Context, userID uuid.UUID) (map[string]json.RawMessage, error) {
+	var (
+		mu     sync.Mutex
+		result = make(map[string]json.RawMessage, len(Services))
+	)
+	group, groupCtx := errgroup.WithContext(ctx)
+	for _, service := range Services {
+		client, err := c.client(service)
+		if err != nil {
+			return nil, err
+		}
+		group.Go(func() error {
+			resp, err := client.ExportUserData(groupCtx, &userdatainternal.ExportUserDataRequest{UserID: userID.String()})
+			if err != nil {
+				return errors.Wrapf(err, "export user data from %s", service)
+			}
+			if !json.Valid([]byte(resp.Data)) {
+				return errors.Errorf("%s returned invalid user data", service)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result[service] = json.RawMessage(resp.Data)
+			return nil
+		})
+	}
+	err := group.Wait()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Erase удаляет данные пользователя в сервисе и возвращает время удаления по его часам
+func (c Clients) Erase(ctx context.Context, service string, userID uuid.UUID) (time.Time, error) {
+	client, err := c.client(service)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := client.EraseUserData(ctx, &userdatainternal.EraseUserDataRequest{UserID: userID.String()})
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "erase user data in %s", service)
+	}
+	return time.Unix(resp.ErasedAt, 0), nil
+}
+
+func (c Clients) client(service string) (userdatainternal.UserDataInternalServiceClient, error) {
+	client, ok := c[service]
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownService, service)
+	}
+	return client, nil
+}
@@ -0,0 +1,27 @@
+package verification
+
+import (
+	"context"
+
+	"gitea.xscloud.ru/xscloud/golib/pkg/application/logging"
+
+	"userservice/pkg/user/application/service"
+)
+
+// NewLogSender пишет код в лог вместо доставки, подходит для локального окружения
+func NewLogSender(logger logging.Logger) service.VerificationCodeSender {
+	return &logSender{logger: logger}
+}
+
+type logSender struct {
+	logger logging.Logger
+}
+
+func (s *logSender) Send(_ context.Context, contactType int, contact, code string) error {
+	s.logger.WithFields(logging.Fields{
+		"contact_type": contactType,
+		"contact":      contact,
+		"code":         code,
+	}).Info("verification code sent")
+	return nil
+}
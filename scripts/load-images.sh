@@ -7,10 +7,12 @@ docker build -t productservice:latest -f rp-productservice/Dockerfile rp-product
 docker build -t orderservice:latest -f rp-orderservice/Dockerfile rp-orderservice/
 docker build -t paymentservice:latest -f rp-paymentservice/Dockerfile rp-paymentservice/
 docker build -t notificationservice:latest -f rp-notificationservice/Dockerfile rp-notificationservice/
+docker build -t gatewayservice:latest -f rp-gatewayservice/Dockerfile rp-gatewayservice/
 
 echo "Loading images into Kind..."
 kind load docker-image userservice:latest --name rp-practice
 kind load docker-image productservice:latest --name rp-practice
 kind load docker-image orderservice:latest --name rp-practice
 kind load docker-image paymentservice:latest --name rp-practice
-kind load docker-image notificationservice:latest --name rp-practice
\ No newline at end of file
+kind load docker-image notificationservice:latest --name rp-practice
+kind load docker-image gatewayservice:latest --name rp-practice
\ No newline at end of file